@@ -0,0 +1,24 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !mips && !mipsle && !mips64 && !mips64le && !ppc64 && !ppc64le && !sparc64
+
+package boot
+
+// Direction bits of ioctl numbers in the asm-generic encoding, which has a
+// 14 bit size field.
+const (
+	iocRead  = 0x80000000
+	iocWrite = 0x40000000
+)
@@ -0,0 +1,97 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package boot
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const fsImmutableFlag = 0x10 // FS_IMMUTABLE_FL
+
+// ioctl numbers of FS_IOC_GETFLAGS and FS_IOC_SETFLAGS, which are defined
+// with a long argument, though the kernel uses an int. The direction bits
+// depend on the architecture.
+var (
+	fsIocGetFlags = uintptr(iocRead | unsafe.Sizeof(uintptr(0))<<16 | 'f'<<8 | 1)
+	fsIocSetFlags = uintptr(iocWrite | unsafe.Sizeof(uintptr(0))<<16 | 'f'<<8 | 2)
+)
+
+// clearImmutable removes the immutable attribute from the file at path, and
+// reports whether it was set.
+func clearImmutable(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	var flags int32
+	if err := ioctlFlags(f, fsIocGetFlags, &flags); err != nil {
+		if unsupported(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if flags&fsImmutableFlag == 0 {
+		return false, nil
+	}
+
+	flags &^= fsImmutableFlag
+	return true, ioctlFlags(f, fsIocSetFlags, &flags)
+}
+
+// setImmutable sets the immutable attribute of the file at path.
+func setImmutable(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var flags int32
+	if err := ioctlFlags(f, fsIocGetFlags, &flags); err != nil {
+		return err
+	}
+	flags |= fsImmutableFlag
+	return ioctlFlags(f, fsIocSetFlags, &flags)
+}
+
+func ioctlFlags(f *os.File, req uintptr, flags *int32) error {
+	rc, err := f.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var errno syscall.Errno
+	err = rc.Control(func(fd uintptr) {
+		_, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(unsafe.Pointer(flags)))
+	})
+	if err != nil {
+		return err
+	}
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// unsupported reports whether err indicates that the file system doesn't
+// support attributes.
+func unsupported(err error) bool {
+	return errors.Is(err, syscall.ENOTTY) || errors.Is(err, syscall.EOPNOTSUPP) || errors.Is(err, syscall.EINVAL)
+}
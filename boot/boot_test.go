@@ -0,0 +1,185 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package boot
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testEntry = `# Boot Loader Specification type#1 entry
+title      Fedora Linux 39 (Workstation Edition)
+version    6.5.6-300.fc39.x86_64
+machine-id 6a9857a393724b7a981ebb5b8495b9ea
+sort-key   fedora
+options    root=UUID=6d3376e4-fc93-4509-95ec-a21d68011da2 ro
+linux      /6a9857a393724b7a981ebb5b8495b9ea/6.5.6-300.fc39.x86_64/linux
+initrd     /6a9857a393724b7a981ebb5b8495b9ea/6.5.6-300.fc39.x86_64/initrd
+options	quiet rhgb
+devicetree-overlay /overlays/a.dtbo /overlays/b.dtbo
+grub_users $grub_users
+`
+
+func TestParseEntry(t *testing.T) {
+	e, err := ParseEntry(strings.NewReader(testEntry))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Entry{
+		Title:             "Fedora Linux 39 (Workstation Edition)",
+		Version:           "6.5.6-300.fc39.x86_64",
+		MachineID:         "6a9857a393724b7a981ebb5b8495b9ea",
+		SortKey:           "fedora",
+		Linux:             "/6a9857a393724b7a981ebb5b8495b9ea/6.5.6-300.fc39.x86_64/linux",
+		Initrd:            []string{"/6a9857a393724b7a981ebb5b8495b9ea/6.5.6-300.fc39.x86_64/initrd"},
+		Options:           "root=UUID=6d3376e4-fc93-4509-95ec-a21d68011da2 ro quiet rhgb",
+		DeviceTreeOverlay: []string{"/overlays/a.dtbo", "/overlays/b.dtbo"},
+		TriesLeft:         -1,
+		TriesDone:         -1,
+		Extra:             [][2]string{{"grub_users", "$grub_users"}},
+	}
+	if !reflect.DeepEqual(e, expected) {
+		t.Errorf("got %+v, expected %+v", e, expected)
+	}
+
+	// the serialized entry parses to the same entry
+	again, err := ParseEntry(strings.NewReader(e.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(again, expected) {
+		t.Errorf("got %+v after serializing, expected %+v", again, expected)
+	}
+
+	if _, err := ParseEntry(strings.NewReader("title\n")); err == nil {
+		t.Error("expected error for key without value")
+	}
+}
+
+func TestParseEntryFileName(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		id         string
+		left, done int
+		roundTrips bool
+	}{
+		{"fedora.conf", "fedora.conf", -1, -1, true},
+		{"fedora+3.conf", "fedora.conf", 3, 0, true},
+		{"fedora+1-2.conf", "fedora.conf", 1, 2, true},
+		{"fedora+0-3.conf", "fedora.conf", 0, 3, true},
+		{"a+b+2.conf", "a+b.conf", 2, 0, true},
+		{"fedora+x.conf", "fedora+x.conf", -1, -1, true},
+		{"fedora+1-.conf", "fedora+1-.conf", -1, -1, true},
+		{"fedora+1-0.conf", "fedora.conf", 1, 0, false},
+	} {
+		id, left, done := parseEntryFileName(tt.name)
+		if id != tt.id || left != tt.left || done != tt.done {
+			t.Errorf("parseEntryFileName(%q) = %q, %d, %d", tt.name, id, left, done)
+		}
+		e := &Entry{ID: id, TriesLeft: left, TriesDone: done}
+		if name := e.FileName(); (name == tt.name) != tt.roundTrips {
+			t.Errorf("FileName() of %q = %q", tt.name, name)
+		}
+	}
+}
+
+func TestReadEntries(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"b+2-1.conf": "title B\n",
+		"a.conf":     "title A\n",
+		"README":     "not an entry",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := ReadEntries(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries", len(entries))
+	}
+	if e := entries[0]; e.ID != "a.conf" || e.Title != "A" || e.Path != filepath.Join(dir, "a.conf") {
+		t.Errorf("unexpected first entry %+v", e)
+	}
+	if e := entries[1]; e.ID != "b.conf" || e.Title != "B" || e.TriesLeft != 2 || e.TriesDone != 1 {
+		t.Errorf("unexpected second entry %+v", e)
+	}
+}
+
+func TestLoaderVariables(t *testing.T) {
+	efivarsPath = t.TempDir()
+	defer func() { efivarsPath = "/sys/firmware/efi/efivars" }()
+
+	if _, err := LoaderEntryDefault(); !os.IsNotExist(err) {
+		t.Errorf("expected not exist error, got %v", err)
+	}
+	if err := SetLoaderEntryDefault("fedora.conf"); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(efivarsPath, "LoaderEntryDefault-"+LoaderVendorGUID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []byte("\x07\x00\x00\x00f\x00e\x00d\x00o\x00r\x00a\x00.\x00c\x00o\x00n\x00f\x00\x00\x00")
+	if string(b) != string(expected) {
+		t.Errorf("got variable %q, expected %q", b, expected)
+	}
+
+	id, err := LoaderEntryDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "fedora.conf" {
+		t.Errorf("got default entry %q", id)
+	}
+
+	if err := SetLoaderEntryDefault(""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoaderEntryDefault(); !os.IsNotExist(err) {
+		t.Errorf("expected not exist error after removal, got %v", err)
+	}
+	if err := SetLoaderEntryOneShot(""); err != nil {
+		t.Errorf("removing an unset variable failed: %v", err)
+	}
+
+	// LoaderEntries is a list of NUL-terminated strings
+	list := append([]byte{6, 0, 0, 0}, encodeUTF16("a.conf")...)
+	list = append(list, encodeUTF16("auto-windows")...)
+	if err := os.WriteFile(filepath.Join(efivarsPath, "LoaderEntries-"+LoaderVendorGUID), list, 0644); err != nil {
+		t.Fatal(err)
+	}
+	ids, err := LoaderEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids, []string{"a.conf", "auto-windows"}) {
+		t.Errorf("got entries %q", ids)
+	}
+
+	efivarsPath = filepath.Join(efivarsPath, "missing")
+	if _, err := LoaderEntrySelected(); err != ErrNotEFI {
+		t.Errorf("expected ErrNotEFI, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package boot
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+)
+
+// LoaderVendorGUID is the vendor GUID of the EFI variables of the boot loader
+// interface.
+const LoaderVendorGUID = "4a67b082-0a4c-41cf-b6c7-440b29bb8c4f"
+
+// efivarsPath is a variable so that tests can override it
+var efivarsPath = "/sys/firmware/efi/efivars"
+
+// ErrNotEFI is returned when the system wasn't booted with EFI.
+var ErrNotEFI = errors.New("system not booted with EFI")
+
+const (
+	efiVariableNonVolatile       = 0x1
+	efiVariableBootserviceAccess = 0x2
+	efiVariableRuntimeAccess     = 0x4
+)
+
+func loaderVariablePath(name string) string {
+	return filepath.Join(efivarsPath, name+"-"+LoaderVendorGUID)
+}
+
+// ReadLoaderVariable reads the string EFI variable name of the boot loader
+// interface, e.g. LoaderInfo. If the variable isn't set, an error matching
+// os.ErrNotExist is returned.
+func ReadLoaderVariable(name string) (string, error) {
+	if _, err := os.Stat(efivarsPath); os.IsNotExist(err) {
+		return "", ErrNotEFI
+	}
+
+	b, err := os.ReadFile(loaderVariablePath(name))
+	if err != nil {
+		return "", err
+	}
+	// the data is preceded by the attributes
+	if len(b) < 4 {
+		return "", fmt.Errorf("EFI variable %s too short", name)
+	}
+	return decodeUTF16(b[4:])
+}
+
+// WriteLoaderVariable sets the string EFI variable name of the boot loader
+// interface to value, as a non-volatile variable. If value is empty, the
+// variable is removed.
+func WriteLoaderVariable(name, value string) error {
+	if _, err := os.Stat(efivarsPath); os.IsNotExist(err) {
+		return ErrNotEFI
+	}
+
+	path := loaderVariablePath(name)
+	// efivarfs marks variables immutable, to protect against accidental
+	// removal
+	immutable, err := clearImmutable(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if value == "" {
+		err := os.Remove(path)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil && immutable {
+			setImmutable(path)
+		}
+		return err
+	}
+
+	b := make([]byte, 4, 4+2*len(value)+2)
+	binary.LittleEndian.PutUint32(b, efiVariableNonVolatile|efiVariableBootserviceAccess|efiVariableRuntimeAccess)
+	b = append(b, encodeUTF16(value)...)
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	// efivarfs requires the variable to be written with a single write
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write EFI variable %s: %w", name, err)
+	}
+
+	if immutable {
+		return setImmutable(path)
+	}
+	return nil
+}
+
+// decodeUTF16 decodes a NUL-terminated UTF-16LE string.
+func decodeUTF16(b []byte) (string, error) {
+	if len(b)%2 != 0 {
+		return "", errors.New("invalid UTF-16 string of odd length")
+	}
+	u := make([]uint16, 0, len(b)/2)
+	for i := 0; i < len(b); i += 2 {
+		c := binary.LittleEndian.Uint16(b[i:])
+		if c == 0 {
+			break
+		}
+		u = append(u, c)
+	}
+	return string(utf16.Decode(u)), nil
+}
+
+// encodeUTF16 encodes s as a NUL-terminated UTF-16LE string.
+func encodeUTF16(s string) []byte {
+	u := utf16.Encode([]rune(s))
+	b := make([]byte, 2*len(u)+2)
+	for i, c := range u {
+		binary.LittleEndian.PutUint16(b[2*i:], c)
+	}
+	return b
+}
+
+// LoaderEntryDefault returns the ID of the default boot loader entry set with
+// SetLoaderEntryDefault, or an error matching os.ErrNotExist if none is set.
+func LoaderEntryDefault() (string, error) {
+	return ReadLoaderVariable("LoaderEntryDefault")
+}
+
+// SetLoaderEntryDefault sets the default boot loader entry to the entry id,
+// like bootctl set-default. An empty id removes the setting, so that the boot
+// loader picks the default itself.
+func SetLoaderEntryDefault(id string) error {
+	return WriteLoaderVariable("LoaderEntryDefault", id)
+}
+
+// LoaderEntryOneShot returns the ID of the boot loader entry to boot next,
+// or an error matching os.ErrNotExist if none is set.
+func LoaderEntryOneShot() (string, error) {
+	return ReadLoaderVariable("LoaderEntryOneShot")
+}
+
+// SetLoaderEntryOneShot sets the boot loader entry to boot next, once, to id,
+// like bootctl set-oneshot. An empty id removes the setting.
+func SetLoaderEntryOneShot(id string) error {
+	return WriteLoaderVariable("LoaderEntryOneShot", id)
+}
+
+// LoaderEntrySelected returns the ID of the boot loader entry the system was
+// booted with.
+func LoaderEntrySelected() (string, error) {
+	return ReadLoaderVariable("LoaderEntrySelected")
+}
+
+// LoaderEntries returns the IDs of the boot loader entries the boot loader
+// found when booting the system.
+func LoaderEntries() ([]string, error) {
+	if _, err := os.Stat(efivarsPath); os.IsNotExist(err) {
+		return nil, ErrNotEFI
+	}
+
+	b, err := os.ReadFile(loaderVariablePath("LoaderEntries"))
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < 4 || len(b)%2 != 0 {
+		return nil, errors.New("invalid EFI variable LoaderEntries")
+	}
+
+	// a list of NUL-terminated strings
+	var ids []string
+	u := make([]uint16, 0, (len(b)-4)/2)
+	for i := 4; i < len(b); i += 2 {
+		u = append(u, binary.LittleEndian.Uint16(b[i:]))
+	}
+	for _, id := range strings.Split(string(utf16.Decode(u)), "\x00") {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
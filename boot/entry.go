@@ -0,0 +1,256 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package boot reads and writes boot loader entries as defined by the Boot
+// Loader Specification, and the EFI variables of the boot loader interface
+// implemented by systemd-boot, e.g. to select the entry to boot next. See
+// https://uapi-group.org/specifications/specs/boot_loader_specification/ and
+// https://systemd.io/BOOT_LOADER_INTERFACE/ for details.
+package boot
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EntriesDirs are the directories boot loader entries are looked for in by
+// ListEntries, in the locations the ESP and the XBOOTLDR partition are
+// usually mounted at.
+var EntriesDirs = []string{"/efi/loader/entries", "/boot/loader/entries", "/boot/efi/loader/entries"}
+
+// Entry is a type #1 boot loader entry, i.e. a .conf file in the
+// loader/entries directory.
+type Entry struct {
+	// ID identifies the entry for the boot loader, e.g. in
+	// LoaderEntryDefault. It is the file name, without the boot counter.
+	ID   string
+	Path string // The path the entry was read from, if any
+
+	Title             string
+	Version           string
+	MachineID         string
+	SortKey           string
+	Linux             string
+	Initrd            []string
+	EFI               string
+	Options           string // All options lines, joined with spaces
+	DeviceTree        string
+	DeviceTreeOverlay []string
+	Architecture      string
+
+	// TriesLeft and TriesDone are the boot counter in the file name, or
+	// -1 if the entry doesn't use boot counting.
+	TriesLeft int
+	TriesDone int
+
+	// Extra holds unknown keys, in the order they appeared.
+	Extra [][2]string
+}
+
+// ParseEntry parses the contents of a boot loader entry.
+func ParseEntry(r io.Reader) (*Entry, error) {
+	e := &Entry{TriesLeft: -1, TriesDone: -1}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		s := strings.TrimSpace(scanner.Text())
+		if s == "" || s[0] == '#' {
+			continue
+		}
+
+		key, value := s, ""
+		if i := strings.IndexAny(s, " \t"); i >= 0 {
+			key, value = s[:i], strings.TrimSpace(s[i:])
+		}
+		if value == "" {
+			return nil, fmt.Errorf("line %d: missing value of %q", line, key)
+		}
+
+		switch key {
+		case "title":
+			e.Title = value
+		case "version":
+			e.Version = value
+		case "machine-id":
+			e.MachineID = value
+		case "sort-key":
+			e.SortKey = value
+		case "linux":
+			e.Linux = value
+		case "initrd":
+			e.Initrd = append(e.Initrd, strings.Fields(value)...)
+		case "efi":
+			e.EFI = value
+		case "options":
+			if e.Options != "" {
+				e.Options += " "
+			}
+			e.Options += value
+		case "devicetree":
+			e.DeviceTree = value
+		case "devicetree-overlay":
+			e.DeviceTreeOverlay = append(e.DeviceTreeOverlay, strings.Fields(value)...)
+		case "architecture":
+			e.Architecture = value
+		default:
+			e.Extra = append(e.Extra, [2]string{key, value})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// ReadEntry reads the boot loader entry at path.
+func ReadEntry(path string) (*Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	e, err := ParseEntry(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	e.Path = path
+	e.ID, e.TriesLeft, e.TriesDone = parseEntryFileName(filepath.Base(path))
+	return e, nil
+}
+
+// parseEntryFileName splits the boot counter off a file name of the form
+// ID+LEFT[-DONE].conf, returning -1 for missing counters.
+func parseEntryFileName(name string) (string, int, int) {
+	base, ok := strings.CutSuffix(name, ".conf")
+	if !ok {
+		return name, -1, -1
+	}
+	i := strings.LastIndexByte(base, '+')
+	if i < 0 {
+		return name, -1, -1
+	}
+
+	leftStr, doneStr, hasDone := strings.Cut(base[i+1:], "-")
+	left, err := strconv.ParseUint(leftStr, 10, 16)
+	if err != nil {
+		return name, -1, -1
+	}
+	done := uint64(0)
+	if hasDone {
+		if done, err = strconv.ParseUint(doneStr, 10, 16); err != nil {
+			return name, -1, -1
+		}
+	}
+	return base[:i] + ".conf", int(left), int(done)
+}
+
+// ReadEntries reads the boot loader entries in dir, sorted by ID. Files not
+// ending in .conf are ignored.
+func ReadEntries(dir string) ([]*Entry, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.conf"))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*Entry, 0, len(paths))
+	for _, path := range paths {
+		e, err := ReadEntry(path)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+	return entries, nil
+}
+
+// ListEntries reads the boot loader entries in all existing EntriesDirs.
+func ListEntries() ([]*Entry, error) {
+	var entries []*Entry
+	seen := make(map[string]bool)
+	for _, dir := range EntriesDirs {
+		// the ESP may be mounted at several of the locations
+		real, err := filepath.EvalSymlinks(dir)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if seen[real] {
+			continue
+		}
+		seen[real] = true
+
+		e, err := ReadEntries(dir)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e...)
+	}
+	return entries, nil
+}
+
+// String returns the entry in the format of boot loader entry files. The
+// options are written on a single line.
+func (e *Entry) String() string {
+	var b strings.Builder
+	add := func(key, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%s %s\n", key, value)
+		}
+	}
+
+	add("title", e.Title)
+	add("version", e.Version)
+	add("machine-id", e.MachineID)
+	add("sort-key", e.SortKey)
+	add("linux", e.Linux)
+	for _, initrd := range e.Initrd {
+		add("initrd", initrd)
+	}
+	add("efi", e.EFI)
+	add("options", e.Options)
+	add("devicetree", e.DeviceTree)
+	add("devicetree-overlay", strings.Join(e.DeviceTreeOverlay, " "))
+	add("architecture", e.Architecture)
+	for _, kv := range e.Extra {
+		add(kv[0], kv[1])
+	}
+	return b.String()
+}
+
+// FileName returns the file name of the entry, including the boot counter
+// if TriesLeft is not negative.
+func (e *Entry) FileName() string {
+	if e.TriesLeft < 0 {
+		return e.ID
+	}
+	base := strings.TrimSuffix(e.ID, ".conf")
+	if e.TriesDone > 0 {
+		return fmt.Sprintf("%s+%d-%d.conf", base, e.TriesLeft, e.TriesDone)
+	}
+	return fmt.Sprintf("%s+%d.conf", base, e.TriesLeft)
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cgroups reads the resource usage of the control groups of systemd
+// units from the cgroup file system, for both the unified (v2) and legacy
+// (v1) hierarchies.
+package cgroups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	sd_dbus "github.com/gr-butler/go-systemd/v22/dbus"
+)
+
+// cgroupRoot is a variable for testing
+var cgroupRoot = "/sys/fs/cgroup"
+
+// ErrNoCGroup is returned by UnitCGroup for units without control group,
+// e.g. inactive units or targets.
+var ErrNoCGroup = errors.New("unit has no control group")
+
+// unitTypes maps unit name suffixes to the D-Bus interfaces with the
+// ControlGroup property.
+var unitTypes = map[string]string{
+	".service": "Service",
+	".socket":  "Socket",
+	".mount":   "Mount",
+	".swap":    "Swap",
+	".slice":   "Slice",
+	".scope":   "Scope",
+}
+
+// UnitCGroup returns the control group path of a unit, relative to the
+// root of the cgroup hierarchy, e.g. /system.slice/foo.service.
+func UnitCGroup(ctx context.Context, conn *sd_dbus.Conn, unit string) (string, error) {
+	unitType, ok := unitTypes[filepath.Ext(unit)]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNoCGroup, unit)
+	}
+
+	props, err := conn.GetUnitTypePropertiesContext(ctx, unit, unitType)
+	if err != nil {
+		return "", err
+	}
+	path, _ := props["ControlGroup"].(string)
+	if path == "" {
+		return "", fmt.Errorf("%w: %s", ErrNoCGroup, unit)
+	}
+	return path, nil
+}
+
+// CGroup is a control group in the cgroup file system.
+type CGroup struct {
+	// Path is the path of the control group relative to the root of the
+	// hierarchy.
+	Path string
+	// Unified is whether the unified (v2) hierarchy is used.
+	Unified bool
+}
+
+// Open returns the control group at path, relative to the root of the
+// hierarchy, e.g. as returned by UnitCGroup.
+func Open(path string) (*CGroup, error) {
+	cg := &CGroup{Path: path, Unified: isUnified()}
+	if _, err := os.Stat(cg.dir("")); err != nil {
+		return nil, err
+	}
+	return cg, nil
+}
+
+// OpenUnit returns the control group of a unit.
+func OpenUnit(ctx context.Context, conn *sd_dbus.Conn, unit string) (*CGroup, error) {
+	path, err := UnitCGroup(ctx, conn, unit)
+	if err != nil {
+		return nil, err
+	}
+	return Open(path)
+}
+
+// isUnified reports whether the unified hierarchy is mounted at the root,
+// as opposed to the legacy or hybrid hierarchies.
+func isUnified() bool {
+	_, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers"))
+	return err == nil
+}
+
+// dir returns the directory of the control group in the hierarchy of
+// controller, which is ignored for the unified hierarchy. The systemd
+// hierarchy is used for an empty controller.
+func (cg *CGroup) dir(controller string) string {
+	if cg.Unified {
+		return filepath.Join(cgroupRoot, cg.Path)
+	}
+	if controller == "" {
+		controller = "systemd"
+	}
+	return filepath.Join(cgroupRoot, controller, cg.Path)
+}
+
+func (cg *CGroup) readFile(controller, name string) (string, error) {
+	b, err := os.ReadFile(filepath.Join(cg.dir(controller), name))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// PIDs returns the processes in the control group itself, not including
+// those in its children.
+func (cg *CGroup) PIDs() ([]int, error) {
+	s, err := cg.readFile("", "cgroup.procs")
+	if err != nil {
+		return nil, err
+	}
+	return parsePIDs(s)
+}
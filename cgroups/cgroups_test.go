@@ -0,0 +1,174 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroups
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func setupRoot(t *testing.T, files map[string]string) {
+	t.Helper()
+
+	root := t.TempDir()
+	old := cgroupRoot
+	cgroupRoot = root
+	t.Cleanup(func() { cgroupRoot = old })
+
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestStatsUnified(t *testing.T) {
+	unit := "system.slice/foo.service/"
+	setupRoot(t, map[string]string{
+		"cgroup.controllers":    "cpu memory io pids\n",
+		unit + "cgroup.procs":   "42\n43\n",
+		unit + "cpu.stat":       "usage_usec 1500\nuser_usec 1000\nsystem_usec 500\nnr_periods 10\nnr_throttled 2\nthrottled_usec 300\n",
+		unit + "memory.current": "4096\n",
+		unit + "memory.stat":    "anon 1024\nfile 2048\n",
+		unit + "io.stat":        "8:0 rbytes=100 wbytes=200 rios=1 wios=2 dbytes=0 dios=0\n259:0 rbytes=5 wbytes=0 rios=1 wios=0 dbytes=0 dios=0\n",
+		unit + "pids.current":   "2\n",
+		unit + "pids.max":       "max\n",
+	})
+
+	cg, err := Open("/system.slice/foo.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cg.Unified {
+		t.Fatal("expected the unified hierarchy")
+	}
+
+	s, err := cg.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedCPU := &CPUStat{
+		Usage:         1500 * time.Microsecond,
+		User:          1000 * time.Microsecond,
+		System:        500 * time.Microsecond,
+		Periods:       10,
+		Throttled:     2,
+		ThrottledTime: 300 * time.Microsecond,
+	}
+	if !reflect.DeepEqual(s.CPU, expectedCPU) {
+		t.Errorf("expected CPU %+v, got %+v", expectedCPU, s.CPU)
+	}
+	expectedMemory := &MemoryStat{Current: 4096, Stat: map[string]uint64{"anon": 1024, "file": 2048}}
+	if !reflect.DeepEqual(s.Memory, expectedMemory) {
+		t.Errorf("expected memory %+v, got %+v", expectedMemory, s.Memory)
+	}
+	expectedIO := []IODeviceStat{
+		{Major: 8, Minor: 0, ReadBytes: 100, WriteBytes: 200, ReadIOs: 1, WriteIOs: 2},
+		{Major: 259, Minor: 0, ReadBytes: 5, ReadIOs: 1},
+	}
+	if !reflect.DeepEqual(s.IO, expectedIO) {
+		t.Errorf("expected IO %+v, got %+v", expectedIO, s.IO)
+	}
+	if expected := (&PIDsStat{Current: 2}); !reflect.DeepEqual(s.PIDs, expected) {
+		t.Errorf("expected pids %+v, got %+v", expected, s.PIDs)
+	}
+
+	pids, err := cg.PIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pids, []int{42, 43}) {
+		t.Errorf("unexpected pids %v", pids)
+	}
+}
+
+func TestStatsLegacy(t *testing.T) {
+	unit := "/system.slice/foo.service/"
+	setupRoot(t, map[string]string{
+		"systemd" + unit + "cgroup.procs":                  "42\n",
+		"cpuacct" + unit + "cpuacct.usage":                 "2000000000\n",
+		"cpuacct" + unit + "cpuacct.stat":                  "user 150\nsystem 50\n",
+		"memory" + unit + "memory.usage_in_bytes":          "8192\n",
+		"memory" + unit + "memory.stat":                    "cache 4096\nrss 4096\n",
+		"blkio" + unit + "blkio.throttle.io_service_bytes": "8:0 Read 100\n8:0 Write 200\n8:0 Total 300\nTotal 300\n",
+		"blkio" + unit + "blkio.throttle.io_serviced":      "8:0 Read 1\n8:0 Write 2\n8:0 Total 3\nTotal 3\n",
+	})
+
+	cg, err := Open("/system.slice/foo.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cg.Unified {
+		t.Fatal("expected the legacy hierarchy")
+	}
+
+	s, err := cg.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (&CPUStat{Usage: 2 * time.Second, User: 1500 * time.Millisecond, System: 500 * time.Millisecond}); !reflect.DeepEqual(s.CPU, expected) {
+		t.Errorf("expected CPU %+v, got %+v", expected, s.CPU)
+	}
+	if s.Memory == nil || s.Memory.Current != 8192 || s.Memory.Stat["rss"] != 4096 {
+		t.Errorf("unexpected memory %+v", s.Memory)
+	}
+	expectedIO := []IODeviceStat{{Major: 8, Minor: 0, ReadBytes: 100, WriteBytes: 200, ReadIOs: 1, WriteIOs: 2}}
+	if !reflect.DeepEqual(s.IO, expectedIO) {
+		t.Errorf("expected IO %+v, got %+v", expectedIO, s.IO)
+	}
+	if s.PIDs != nil {
+		t.Errorf("expected no pids stats without pids controller, got %+v", s.PIDs)
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	setupRoot(t, map[string]string{
+		"cgroup.controllers":     "pids\n",
+		"a.service/pids.current": "1\n",
+		"b.service/pids.current": "2\n",
+	})
+
+	w := NewWatcher(time.Millisecond)
+	for _, name := range []string{"b.service", "a.service"} {
+		cg, err := Open("/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Add(name, cg)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	polls := 0
+	err := w.Run(ctx, func(stats []UnitStats) {
+		polls++
+		if len(stats) != 2 || stats[0].Name != "a.service" || stats[1].PIDs.Current != 2 {
+			t.Errorf("unexpected stats %+v", stats)
+		}
+		if polls == 3 {
+			cancel()
+		}
+	})
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
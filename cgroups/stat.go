@@ -0,0 +1,354 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroups
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// userHZ is the unit of cpuacct.stat, which is 100 on all architectures
+// supported by Go.
+const userHZ = 100
+
+// CPUStat is the CPU usage of a control group.
+type CPUStat struct {
+	Usage     time.Duration // Total CPU time
+	User      time.Duration
+	System    time.Duration
+	Periods   uint64 // Elapsed enforcement periods of the CPU quota
+	Throttled uint64 // Periods in which the group was throttled
+	// ThrottledTime is the total time the group was throttled for.
+	ThrottledTime time.Duration
+}
+
+// MemoryStat is the memory usage of a control group.
+type MemoryStat struct {
+	Current uint64 // Bytes currently used
+	// Stat holds the counters of memory.stat, e.g. anon, file or
+	// pgmajfault.
+	Stat map[string]uint64
+}
+
+// IODeviceStat is the IO usage of a control group on a block device.
+type IODeviceStat struct {
+	Major, Minor uint64
+	ReadBytes    uint64
+	WriteBytes   uint64
+	ReadIOs      uint64
+	WriteIOs     uint64
+	DiscardBytes uint64 // Only reported on the unified hierarchy
+	DiscardIOs   uint64 // Only reported on the unified hierarchy
+}
+
+// PIDsStat is the number of tasks in a control group.
+type PIDsStat struct {
+	Current uint64
+	Max     uint64 // 0 if unlimited
+}
+
+// Stats is the resource usage of a control group. Usage of controllers
+// which are not enabled for the group is nil.
+type Stats struct {
+	Time   time.Time // When the stats were read
+	CPU    *CPUStat
+	Memory *MemoryStat
+	IO     []IODeviceStat
+	PIDs   *PIDsStat
+}
+
+// Stats reads the resource usage of the control group, skipping controllers
+// which are not enabled for it.
+func (cg *CGroup) Stats() (*Stats, error) {
+	s := &Stats{Time: time.Now()}
+
+	var err error
+	if s.CPU, err = cg.CPUStat(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if s.Memory, err = cg.MemoryStat(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if s.IO, err = cg.IOStat(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if s.PIDs, err = cg.PIDsStat(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return s, nil
+}
+
+// CPUStat reads the CPU usage of the control group.
+func (cg *CGroup) CPUStat() (*CPUStat, error) {
+	if cg.Unified {
+		s, err := cg.readFile("", "cpu.stat")
+		if err != nil {
+			return nil, err
+		}
+		kv, err := parseKeyValues(s)
+		if err != nil {
+			return nil, err
+		}
+		return &CPUStat{
+			Usage:         time.Duration(kv["usage_usec"]) * time.Microsecond,
+			User:          time.Duration(kv["user_usec"]) * time.Microsecond,
+			System:        time.Duration(kv["system_usec"]) * time.Microsecond,
+			Periods:       kv["nr_periods"],
+			Throttled:     kv["nr_throttled"],
+			ThrottledTime: time.Duration(kv["throttled_usec"]) * time.Microsecond,
+		}, nil
+	}
+
+	stat := &CPUStat{}
+	s, err := cg.readFile("cpuacct", "cpuacct.usage")
+	if err != nil {
+		return nil, err
+	}
+	usage, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cpuacct.usage %q", s)
+	}
+	stat.Usage = time.Duration(usage)
+
+	if s, err = cg.readFile("cpuacct", "cpuacct.stat"); err == nil {
+		kv, err := parseKeyValues(s)
+		if err != nil {
+			return nil, err
+		}
+		stat.User = time.Duration(kv["user"]) * time.Second / userHZ
+		stat.System = time.Duration(kv["system"]) * time.Second / userHZ
+	}
+	if s, err = cg.readFile("cpu", "cpu.stat"); err == nil {
+		kv, err := parseKeyValues(s)
+		if err != nil {
+			return nil, err
+		}
+		stat.Periods = kv["nr_periods"]
+		stat.Throttled = kv["nr_throttled"]
+		stat.ThrottledTime = time.Duration(kv["throttled_time"])
+	}
+	return stat, nil
+}
+
+// MemoryStat reads the memory usage of the control group.
+func (cg *CGroup) MemoryStat() (*MemoryStat, error) {
+	current, stat := "memory.current", "memory.stat"
+	if !cg.Unified {
+		current = "memory.usage_in_bytes"
+	}
+
+	s, err := cg.readFile("memory", current)
+	if err != nil {
+		return nil, err
+	}
+	m := &MemoryStat{}
+	if m.Current, err = strconv.ParseUint(s, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid %s %q", current, s)
+	}
+
+	if s, err = cg.readFile("memory", stat); err != nil {
+		return nil, err
+	}
+	if m.Stat, err = parseKeyValues(s); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// IOStat reads the IO usage of the control group, per block device.
+func (cg *CGroup) IOStat() ([]IODeviceStat, error) {
+	if cg.Unified {
+		s, err := cg.readFile("", "io.stat")
+		if err != nil {
+			return nil, err
+		}
+		return parseIOStat(s)
+	}
+
+	bytes, err := cg.readFile("blkio", "blkio.throttle.io_service_bytes")
+	if err != nil {
+		return nil, err
+	}
+	ios, err := cg.readFile("blkio", "blkio.throttle.io_serviced")
+	if err != nil {
+		return nil, err
+	}
+	return parseBlkioStat(bytes, ios)
+}
+
+// PIDsStat reads the number of tasks in the control group.
+func (cg *CGroup) PIDsStat() (*PIDsStat, error) {
+	s, err := cg.readFile("pids", "pids.current")
+	if err != nil {
+		return nil, err
+	}
+	p := &PIDsStat{}
+	if p.Current, err = strconv.ParseUint(s, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid pids.current %q", s)
+	}
+
+	if s, err = cg.readFile("pids", "pids.max"); err == nil && s != "max" {
+		if p.Max, err = strconv.ParseUint(s, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid pids.max %q", s)
+		}
+	}
+	return p, nil
+}
+
+// parseKeyValues parses the "key value" lines of files like cpu.stat and
+// memory.stat.
+func parseKeyValues(s string) (map[string]uint64, error) {
+	kv := make(map[string]uint64)
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid stat line %q", scanner.Text())
+		}
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stat line %q", scanner.Text())
+		}
+		kv[fields[0]] = v
+	}
+	return kv, nil
+}
+
+func parseDevice(s string) (uint64, uint64, error) {
+	major, minor, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid device %q", s)
+	}
+	ma, err1 := strconv.ParseUint(major, 10, 64)
+	mi, err2 := strconv.ParseUint(minor, 10, 64)
+	if err1 != nil || err2 != nil {
+		return 0, 0, fmt.Errorf("invalid device %q", s)
+	}
+	return ma, mi, nil
+}
+
+// parseIOStat parses the "8:0 rbytes=1 wbytes=2 ..." lines of io.stat.
+func parseIOStat(s string) ([]IODeviceStat, error) {
+	var stats []IODeviceStat
+	for _, line := range strings.Split(s, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		d := IODeviceStat{}
+		var err error
+		if d.Major, d.Minor, err = parseDevice(fields[0]); err != nil {
+			return nil, err
+		}
+		for _, f := range fields[1:] {
+			key, value, ok := strings.Cut(f, "=")
+			if !ok {
+				continue
+			}
+			v, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid io.stat field %q", f)
+			}
+			switch key {
+			case "rbytes":
+				d.ReadBytes = v
+			case "wbytes":
+				d.WriteBytes = v
+			case "rios":
+				d.ReadIOs = v
+			case "wios":
+				d.WriteIOs = v
+			case "dbytes":
+				d.DiscardBytes = v
+			case "dios":
+				d.DiscardIOs = v
+			}
+		}
+		stats = append(stats, d)
+	}
+	return stats, nil
+}
+
+// parseBlkioStat parses the "8:0 Read 1234" lines of the v1 blkio files of
+// bytes and operations.
+func parseBlkioStat(bytes, ios string) ([]IODeviceStat, error) {
+	var stats []IODeviceStat
+	index := make(map[[2]uint64]int)
+
+	parse := func(s string, read, write func(*IODeviceStat, uint64)) error {
+		for _, line := range strings.Split(s, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) != 3 {
+				// skips the Total line
+				continue
+			}
+			major, minor, err := parseDevice(fields[0])
+			if err != nil {
+				return err
+			}
+			v, err := strconv.ParseUint(fields[2], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid blkio line %q", line)
+			}
+
+			key := [2]uint64{major, minor}
+			i, ok := index[key]
+			if !ok {
+				i = len(stats)
+				index[key] = i
+				stats = append(stats, IODeviceStat{Major: major, Minor: minor})
+			}
+			switch fields[1] {
+			case "Read":
+				read(&stats[i], v)
+			case "Write":
+				write(&stats[i], v)
+			}
+		}
+		return nil
+	}
+
+	err := parse(bytes,
+		func(d *IODeviceStat, v uint64) { d.ReadBytes = v },
+		func(d *IODeviceStat, v uint64) { d.WriteBytes = v })
+	if err != nil {
+		return nil, err
+	}
+	err = parse(ios,
+		func(d *IODeviceStat, v uint64) { d.ReadIOs = v },
+		func(d *IODeviceStat, v uint64) { d.WriteIOs = v })
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func parsePIDs(s string) ([]int, error) {
+	var pids []int
+	for _, f := range strings.Fields(s) {
+		pid, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pid %q", f)
+		}
+		pids = append(pids, pid)
+	}
+	return pids, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroups
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Watcher polls the resource usage of control groups.
+type Watcher struct {
+	Interval time.Duration
+	groups   map[string]*CGroup
+}
+
+// UnitStats is the resource usage of a control group watched by a Watcher.
+type UnitStats struct {
+	Name string // The name the group was added with, e.g. the unit name
+	*Stats
+	Err error // Set if reading the stats failed, e.g. as the unit was stopped
+}
+
+// NewWatcher returns a watcher polling every interval.
+func NewWatcher(interval time.Duration) *Watcher {
+	return &Watcher{Interval: interval, groups: make(map[string]*CGroup)}
+}
+
+// Add watches the control group cg, reporting its stats under name. It must
+// not be called while the watcher runs.
+func (w *Watcher) Add(name string, cg *CGroup) {
+	w.groups[name] = cg
+}
+
+// Run reads the stats of the watched groups immediately and then every
+// interval, and calls fn with them sorted by name, until ctx is done.
+func (w *Watcher) Run(ctx context.Context, fn func([]UnitStats)) error {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		stats := make([]UnitStats, 0, len(w.groups))
+		for name, cg := range w.groups {
+			s, err := cg.Stats()
+			stats = append(stats, UnitStats{Name: name, Stats: s, Err: err})
+		}
+		sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+		fn(stats)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,348 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package coredump provides access to the process crashes recorded by
+// systemd-coredump, like coredumpctl. Crashes are read from the journal, and
+// their cores either from the journal or from /var/lib/systemd/coredump.
+// See https://www.freedesktop.org/software/systemd/man/coredumpctl.html
+package coredump
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gr-butler/go-systemd/v22/sdjournal"
+)
+
+// MessageID is the MESSAGE_ID of the journal entries written by
+// systemd-coredump for crashes.
+const MessageID = "fc2e22bc6ee647b6b90729ab34a250b1"
+
+// defaultDataThreshold is the data threshold of sd-journal, which limits
+// the size of the fields returned
+const defaultDataThreshold = 64 * 1024
+
+var (
+	// ErrNoCore is returned by Core when the core of a crash wasn't stored,
+	// or was removed since.
+	ErrNoCore = errors.New("core not available")
+	// ErrUnsupportedCompression is returned by Core for cores compressed
+	// with an unsupported algorithm.
+	ErrUnsupportedCompression = errors.New("unsupported core compression")
+)
+
+// Crash is a process crash recorded by systemd-coredump.
+type Crash struct {
+	PID        int
+	UID        int
+	GID        int
+	Signal     int
+	SignalName string // e.g. SIGSEGV
+	Timestamp  time.Time
+	Hostname   string
+	Comm       string
+	Exe        string
+	Cmdline    string
+	CGroup     string
+	Unit       string // The system unit of the process, if any
+	UserUnit   string // The user unit of the process, if any
+	Slice      string
+	Session    string
+	OwnerUID   int // The owner of the session of the process, -1 if none
+	CWD        string
+	Root       string
+	BootID     string
+
+	PackageName    string
+	PackageVersion string
+
+	// Message is the message of the journal entry, which includes the stack
+	// trace if it was generated.
+	Message string
+	// Filename is the path of the external core, if the core was stored in
+	// a file.
+	Filename string
+	// Truncated reports whether the core was truncated, e.g. because it
+	// exceeded the size limit of systemd-coredump.
+	Truncated bool
+
+	// Cursor is the journal cursor of the entry.
+	Cursor string
+	// Fields holds all fields of the journal entry, except the core.
+	Fields map[string]string
+
+	inline bool // whether the core is stored in the entry
+}
+
+// HasCore reports whether the core of the crash was stored, in the journal or
+// in a file. A stored core may have been removed since.
+func (c *Crash) HasCore() bool {
+	return c.inline || c.Filename != ""
+}
+
+// FromEntry decodes the journal entry of a crash.
+func FromEntry(e *sdjournal.JournalEntry) (*Crash, error) {
+	if id := e.Fields["MESSAGE_ID"]; id != MessageID {
+		return nil, fmt.Errorf("not a coredump entry: MESSAGE_ID=%s", id)
+	}
+
+	fields := make(map[string]string, len(e.Fields))
+	for k, v := range e.Fields {
+		fields[k] = v
+	}
+	_, inline := fields["COREDUMP"]
+	delete(fields, "COREDUMP")
+
+	num := func(name string, def int) int {
+		n, err := strconv.Atoi(fields[name])
+		if err != nil {
+			return def
+		}
+		return n
+	}
+
+	c := &Crash{
+		PID:            num("COREDUMP_PID", 0),
+		UID:            num("COREDUMP_UID", -1),
+		GID:            num("COREDUMP_GID", -1),
+		Signal:         num("COREDUMP_SIGNAL", 0),
+		SignalName:     fields["COREDUMP_SIGNAL_NAME"],
+		Hostname:       fields["COREDUMP_HOSTNAME"],
+		Comm:           fields["COREDUMP_COMM"],
+		Exe:            fields["COREDUMP_EXE"],
+		Cmdline:        fields["COREDUMP_CMDLINE"],
+		CGroup:         fields["COREDUMP_CGROUP"],
+		Unit:           fields["COREDUMP_UNIT"],
+		UserUnit:       fields["COREDUMP_USER_UNIT"],
+		Slice:          fields["COREDUMP_SLICE"],
+		Session:        fields["COREDUMP_SESSION"],
+		OwnerUID:       num("COREDUMP_OWNER_UID", -1),
+		CWD:            fields["COREDUMP_CWD"],
+		Root:           fields["COREDUMP_ROOT"],
+		BootID:         fields["_BOOT_ID"],
+		PackageName:    fields["COREDUMP_PACKAGE_NAME"],
+		PackageVersion: fields["COREDUMP_PACKAGE_VERSION"],
+		Message:        fields["MESSAGE"],
+		Filename:       fields["COREDUMP_FILENAME"],
+		Truncated:      fields["COREDUMP_TRUNCATED"] == "1",
+		Cursor:         e.Cursor,
+		Fields:         fields,
+		inline:         inline,
+	}
+
+	// the time of the crash, rather than of the entry, which is written
+	// after the core was processed
+	if usec, err := strconv.ParseUint(fields["COREDUMP_TIMESTAMP"], 10, 64); err == nil {
+		c.Timestamp = time.UnixMicro(int64(usec))
+	} else {
+		c.Timestamp = time.UnixMicro(int64(e.RealtimeTimestamp))
+	}
+
+	return c, nil
+}
+
+// Reader reads crashes from the journal.
+type Reader struct {
+	j *sdjournal.Journal
+}
+
+// NewReader returns a reader of the crashes in the local journal.
+func NewReader() (*Reader, error) {
+	j, err := sdjournal.NewJournal()
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{j: j}, nil
+}
+
+// NewReaderFromDir returns a reader of the crashes in the journal in dir.
+func NewReaderFromDir(dir string) (*Reader, error) {
+	j, err := sdjournal.NewJournalFromDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{j: j}, nil
+}
+
+// Close closes the journal.
+func (r *Reader) Close() error {
+	return r.j.Close()
+}
+
+// ListOptions configures List. Crashes must match all the non-zero fields.
+type ListOptions struct {
+	PID   int
+	Comm  string
+	Exe   string
+	Unit  string
+	Since time.Time
+	// Limit, if positive, limits the crashes to the latest Limit ones.
+	Limit int
+}
+
+func (opts ListOptions) matches() []sdjournal.Match {
+	matches := []sdjournal.Match{{Field: "MESSAGE_ID", Value: MessageID}}
+	if opts.PID != 0 {
+		matches = append(matches, sdjournal.Match{Field: "COREDUMP_PID", Value: strconv.Itoa(opts.PID)})
+	}
+	if opts.Comm != "" {
+		matches = append(matches, sdjournal.Match{Field: "COREDUMP_COMM", Value: opts.Comm})
+	}
+	if opts.Exe != "" {
+		matches = append(matches, sdjournal.Match{Field: "COREDUMP_EXE", Value: opts.Exe})
+	}
+	if opts.Unit != "" {
+		matches = append(matches, sdjournal.Match{Field: "COREDUMP_UNIT", Value: opts.Unit})
+	}
+	return matches
+}
+
+// List returns the crashes matching opts, oldest first.
+func (r *Reader) List(opts ListOptions) ([]*Crash, error) {
+	r.j.FlushMatches()
+	for _, m := range opts.matches() {
+		if err := r.j.AddMatch(m.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	// walk backwards from the end if limited, so that only the latest
+	// crashes are read
+	var err error
+	next := r.j.Next
+	if opts.Limit > 0 {
+		err = r.j.SeekTail()
+		next = r.j.Previous
+	} else if !opts.Since.IsZero() {
+		err = r.j.SeekRealtimeUsec(uint64(opts.Since.UnixMicro()))
+	} else {
+		err = r.j.SeekHead()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var crashes []*Crash
+	for opts.Limit <= 0 || len(crashes) < opts.Limit {
+		n, err := next()
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			break
+		}
+
+		e, err := r.j.GetEntry()
+		if err != nil {
+			return nil, err
+		}
+		if !opts.Since.IsZero() && e.RealtimeTimestamp < uint64(opts.Since.UnixMicro()) {
+			if opts.Limit > 0 {
+				break
+			}
+			continue
+		}
+
+		c, err := FromEntry(e)
+		if err != nil {
+			return nil, err
+		}
+		crashes = append(crashes, c)
+	}
+
+	if opts.Limit > 0 {
+		for i, j := 0, len(crashes)-1; i < j; i, j = i+1, j-1 {
+			crashes[i], crashes[j] = crashes[j], crashes[i]
+		}
+	}
+	return crashes, nil
+}
+
+// Core returns the core of the crash, decompressed. Cores stored in the
+// journal are read from the journal of r; external cores are read from
+// Filename, which must exist on the local host. If the core isn't available,
+// an error matching ErrNoCore is returned.
+func (r *Reader) Core(c *Crash) (io.ReadCloser, error) {
+	if c.inline {
+		core, err := r.inlineCore(c)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(core)), nil
+	}
+	if c.Filename == "" {
+		return nil, ErrNoCore
+	}
+	return OpenCore(c.Filename)
+}
+
+func (r *Reader) inlineCore(c *Crash) ([]byte, error) {
+	if err := r.j.SeekCursor(c.Cursor); err != nil {
+		return nil, err
+	}
+	if _, err := r.j.Next(); err != nil {
+		return nil, err
+	}
+	if err := r.j.TestCursor(c.Cursor); err != nil {
+		return nil, fmt.Errorf("%w: journal entry not found", ErrNoCore)
+	}
+
+	// the core is usually larger than the data threshold
+	if err := r.j.SetDataThreshold(0); err != nil {
+		return nil, err
+	}
+	defer r.j.SetDataThreshold(defaultDataThreshold)
+
+	core, err := r.j.GetDataValueBytes("COREDUMP")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoCore, err)
+	}
+	return core, nil
+}
+
+// OpenCore opens the external core at path, e.g. in
+// /var/lib/systemd/coredump, decompressing cores compressed with zstd or lz4
+// as indicated by the file name extension. Decompression requires libzstd or
+// liblz4, which are loaded at runtime.
+func OpenCore(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %v", ErrNoCore, err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	var d io.ReadCloser
+	switch {
+	case strings.HasSuffix(path, ".zst"):
+		d, err = newZstdReader(f)
+	case strings.HasSuffix(path, ".lz4"):
+		d, err = newLZ4Reader(f)
+	case strings.HasSuffix(path, ".xz"):
+		err = fmt.Errorf("%w: xz", ErrUnsupportedCompression)
+	default:
+		return f, nil
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return d, nil
+}
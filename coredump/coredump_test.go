@@ -0,0 +1,189 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coredump
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/gr-butler/go-systemd/v22/sdjournal"
+)
+
+func TestFromEntry(t *testing.T) {
+	e := &sdjournal.JournalEntry{
+		Fields: map[string]string{
+			"MESSAGE_ID":           MessageID,
+			"MESSAGE":              "Process 1234 (crasher) of user 1000 dumped core.",
+			"COREDUMP_PID":         "1234",
+			"COREDUMP_UID":         "1000",
+			"COREDUMP_GID":         "1000",
+			"COREDUMP_SIGNAL":      "11",
+			"COREDUMP_SIGNAL_NAME": "SIGSEGV",
+			"COREDUMP_TIMESTAMP":   "1700000000123456",
+			"COREDUMP_COMM":        "crasher",
+			"COREDUMP_EXE":         "/usr/bin/crasher",
+			"COREDUMP_UNIT":        "crasher.service",
+			"COREDUMP_FILENAME":    "/var/lib/systemd/coredump/core.crasher.1000.abc.1234.1700000000000000.zst",
+			"_BOOT_ID":             "0123456789abcdef0123456789abcdef",
+		},
+		Cursor:            "s=abc;i=1",
+		RealtimeTimestamp: 1700000001000000,
+	}
+
+	c, err := FromEntry(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.PID != 1234 || c.UID != 1000 || c.GID != 1000 || c.Signal != 11 || c.SignalName != "SIGSEGV" {
+		t.Errorf("unexpected process fields %+v", c)
+	}
+	if c.Comm != "crasher" || c.Exe != "/usr/bin/crasher" || c.Unit != "crasher.service" || c.BootID != "0123456789abcdef0123456789abcdef" {
+		t.Errorf("unexpected fields %+v", c)
+	}
+	if !c.Timestamp.Equal(time.UnixMicro(1700000000123456)) {
+		t.Errorf("got timestamp %v", c.Timestamp)
+	}
+	if c.OwnerUID != -1 || c.Truncated || c.Cursor != "s=abc;i=1" {
+		t.Errorf("unexpected fields %+v", c)
+	}
+	if !c.HasCore() || c.inline {
+		t.Error("expected an external core")
+	}
+
+	// inline cores are not kept in the fields
+	delete(e.Fields, "COREDUMP_FILENAME")
+	e.Fields["COREDUMP"] = "\x7fELF"
+	delete(e.Fields, "COREDUMP_TIMESTAMP")
+	if c, err = FromEntry(e); err != nil {
+		t.Fatal(err)
+	}
+	if !c.inline || !c.HasCore() {
+		t.Error("expected an inline core")
+	}
+	if _, ok := c.Fields["COREDUMP"]; ok {
+		t.Error("core kept in the fields")
+	}
+	if !c.Timestamp.Equal(time.UnixMicro(1700000001000000)) {
+		t.Errorf("got timestamp %v without COREDUMP_TIMESTAMP", c.Timestamp)
+	}
+
+	e.Fields["MESSAGE_ID"] = "00000000000000000000000000000000"
+	if _, err := FromEntry(e); err == nil {
+		t.Error("expected an error for an entry of another message ID")
+	}
+}
+
+func TestOpenCore(t *testing.T) {
+	const (
+		// "core dump test data\n" repeated 10000 times, compressed with zstd
+		zstdCore = "KLUv/aRADQMA5AAAoGNvcmUgZHVtcCB0ZXN0IGRhdGEKAQDS/y+fTE0AAAhzAQA8DTkQAr76ork="
+		// "core dump test data\n" repeated 1000 times, compressed with lz4
+		lz4Core = "BCJNGGRAp20AAAD/BWNvcmUgZHVtcCB0ZXN0IGRhdGEKFAD///////////////////////////////////////////////////////////////////////////////////////////////////////9CUGRhdGEKAAAAAAJLkAg="
+	)
+
+	dir := t.TempDir()
+	for _, tt := range []struct {
+		name    string
+		content string
+		repeat  int
+	}{
+		{"core.raw", strings.Repeat("core dump test data\n", 10), 10},
+		{"core.zst", zstdCore, 10000},
+		{"core.lz4", lz4Core, 1000},
+	} {
+		content := []byte(tt.content)
+		if tt.name != "core.raw" {
+			var err error
+			if content, err = base64.StdEncoding.DecodeString(tt.content); err != nil {
+				t.Fatal(err)
+			}
+		}
+		path := filepath.Join(dir, tt.name)
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := OpenCore(path)
+		if errors.Is(err, ErrUnsupportedCompression) {
+			t.Logf("skipping %s: %v", tt.name, err)
+			continue
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		// small reads, so that the decompressed data is returned in parts
+		b, err := io.ReadAll(iotest.OneByteReader(r))
+		r.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if string(b) != strings.Repeat("core dump test data\n", tt.repeat) {
+			t.Errorf("%s: unexpected core of %d bytes", tt.name, len(b))
+		}
+
+		// truncated data is detected
+		if tt.name == "core.raw" {
+			continue
+		}
+		if err := os.WriteFile(path, content[:len(content)-6], 0644); err != nil {
+			t.Fatal(err)
+		}
+		if r, err = OpenCore(path); err != nil {
+			t.Fatal(err)
+		}
+		_, err = io.ReadAll(r)
+		r.Close()
+		if err == nil {
+			t.Errorf("%s: expected an error for a truncated core", tt.name)
+		}
+	}
+
+	if _, err := OpenCore(filepath.Join(dir, "missing.zst")); !errors.Is(err, ErrNoCore) {
+		t.Errorf("expected ErrNoCore, got %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "core.xz"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenCore(filepath.Join(dir, "core.xz")); !errors.Is(err, ErrUnsupportedCompression) {
+		t.Errorf("expected ErrUnsupportedCompression, got %v", err)
+	}
+}
+
+func TestList(t *testing.T) {
+	// an empty journal
+	r, err := NewReaderFromDir(t.TempDir())
+	if err != nil {
+		t.Skipf("failed to open journal: %v", err)
+	}
+	defer r.Close()
+
+	crashes, err := r.List(ListOptions{Exe: "/usr/bin/crasher", Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(crashes) != 0 {
+		t.Errorf("got %d crashes from an empty journal", len(crashes))
+	}
+
+	if _, err := r.Core(&Crash{}); !errors.Is(err, ErrNoCore) {
+		t.Errorf("expected ErrNoCore, got %v", err)
+	}
+}
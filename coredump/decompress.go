@@ -0,0 +1,299 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coredump
+
+// #include <stdlib.h>
+//
+// typedef struct {
+//   const void *src;
+//   size_t size;
+//   size_t pos;
+// } my_ZSTD_inBuffer;
+//
+// typedef struct {
+//   void *dst;
+//   size_t size;
+//   size_t pos;
+// } my_ZSTD_outBuffer;
+//
+// void *
+// my_ZSTD_createDStream(void *f)
+// {
+//   void *(*ZSTD_createDStream)(void);
+//
+//   ZSTD_createDStream = f;
+//   return ZSTD_createDStream();
+// }
+//
+// size_t
+// my_ZSTD_freeDStream(void *f, void *zds)
+// {
+//   size_t (*ZSTD_freeDStream)(void *);
+//
+//   ZSTD_freeDStream = f;
+//   return ZSTD_freeDStream(zds);
+// }
+//
+// size_t
+// my_ZSTD_decompressStream(void *f, void *zds, void *dst, size_t dst_size, size_t *dst_pos, const void *src, size_t src_size, size_t *src_pos)
+// {
+//   size_t (*ZSTD_decompressStream)(void *, my_ZSTD_outBuffer *, my_ZSTD_inBuffer *);
+//   my_ZSTD_outBuffer out = { dst, dst_size, 0 };
+//   my_ZSTD_inBuffer in = { src, src_size, 0 };
+//   size_t r;
+//
+//   ZSTD_decompressStream = f;
+//   r = ZSTD_decompressStream(zds, &out, &in);
+//   *dst_pos = out.pos;
+//   *src_pos = in.pos;
+//   return r;
+// }
+//
+// unsigned
+// my_isError(void *f, size_t code)
+// {
+//   unsigned (*isError)(size_t);
+//
+//   isError = f;
+//   return isError(code);
+// }
+//
+// const char *
+// my_getErrorName(void *f, size_t code)
+// {
+//   const char *(*getErrorName)(size_t);
+//
+//   getErrorName = f;
+//   return getErrorName(code);
+// }
+//
+// size_t
+// my_LZ4F_createDecompressionContext(void *f, void **dctx)
+// {
+//   size_t (*LZ4F_createDecompressionContext)(void **, unsigned);
+//
+//   LZ4F_createDecompressionContext = f;
+//   // LZ4F_VERSION
+//   return LZ4F_createDecompressionContext(dctx, 100);
+// }
+//
+// size_t
+// my_LZ4F_freeDecompressionContext(void *f, void *dctx)
+// {
+//   size_t (*LZ4F_freeDecompressionContext)(void *);
+//
+//   LZ4F_freeDecompressionContext = f;
+//   return LZ4F_freeDecompressionContext(dctx);
+// }
+//
+// size_t
+// my_LZ4F_decompress(void *f, void *dctx, void *dst, size_t *dst_size, const void *src, size_t *src_size)
+// {
+//   size_t (*LZ4F_decompress)(void *, void *, size_t *, const void *, size_t *, const void *);
+//
+//   LZ4F_decompress = f;
+//   return LZ4F_decompress(dctx, dst, dst_size, src, src_size, NULL);
+// }
+import "C"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"unsafe"
+
+	"github.com/gr-butler/go-systemd/v22/internal/dlopen"
+)
+
+// library is a compression library loaded on first use.
+type library struct {
+	names []string
+
+	mu        sync.Mutex
+	handle    *dlopen.LibHandle
+	functions map[string]unsafe.Pointer
+}
+
+var (
+	libzstd = &library{names: []string{"libzstd.so.1", "libzstd.so"}}
+	liblz4  = &library{names: []string{"liblz4.so.1", "liblz4.so"}}
+)
+
+func (l *library) getFunction(name string) (unsafe.Pointer, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.handle == nil {
+		h, err := dlopen.GetHandle(l.names)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s not found", ErrUnsupportedCompression, l.names[0])
+		}
+		l.handle = h
+		l.functions = make(map[string]unsafe.Pointer)
+	}
+
+	f, ok := l.functions[name]
+	if !ok {
+		var err error
+		f, err = l.handle.GetSymbolPointer(name)
+		if err != nil {
+			return nil, err
+		}
+		l.functions[name] = f
+	}
+	return f, nil
+}
+
+// getFunctions resolves the functions names into the pointers fs.
+func (l *library) getFunctions(names []string, fs ...*unsafe.Pointer) error {
+	for i, name := range names {
+		f, err := l.getFunction(name)
+		if err != nil {
+			return err
+		}
+		*fs[i] = f
+	}
+	return nil
+}
+
+// decoder is a reader decompressing a stream with step.
+type decoder struct {
+	r   io.ReadCloser
+	buf []byte
+	in  []byte // the unconsumed part of buf
+	eof bool
+
+	// step decompresses from src into dst, returning the number of bytes
+	// written and consumed, and whether the end of the compressed data was
+	// reached
+	step func(dst, src []byte) (int, int, bool, error)
+	free func()
+
+	done bool
+	err  error
+}
+
+func newDecoder(r io.ReadCloser, step func(dst, src []byte) (int, int, bool, error), free func()) *decoder {
+	return &decoder{r: r, buf: make([]byte, 128*1024), step: step, free: free}
+}
+
+func (d *decoder) Read(p []byte) (int, error) {
+	if d.err != nil {
+		return 0, d.err
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for {
+		if len(d.in) == 0 && !d.eof {
+			n, err := d.r.Read(d.buf)
+			d.in = d.buf[:n]
+			if err == io.EOF {
+				d.eof = true
+			} else if err != nil {
+				d.err = err
+				return 0, err
+			}
+		}
+		if len(d.in) == 0 && d.eof && d.done {
+			d.err = io.EOF
+			return 0, io.EOF
+		}
+
+		written, consumed, done, err := d.step(p, d.in)
+		d.in = d.in[consumed:]
+		if err != nil {
+			d.err = err
+			return 0, err
+		}
+		d.done = done
+		if written > 0 {
+			return written, nil
+		}
+		if len(d.in) == 0 && d.eof && !d.done {
+			d.err = io.ErrUnexpectedEOF
+			return 0, d.err
+		}
+	}
+}
+
+func (d *decoder) Close() error {
+	if d.free != nil {
+		d.free()
+		d.free = nil
+	}
+	return d.r.Close()
+}
+
+// ptr returns a pointer to the data of b, nil if b is empty.
+func ptr(b []byte) unsafe.Pointer {
+	if len(b) == 0 {
+		return nil
+	}
+	return unsafe.Pointer(&b[0])
+}
+
+func newZstdReader(r io.ReadCloser) (io.ReadCloser, error) {
+	var create, free, decompress, isError, getErrorName unsafe.Pointer
+	err := libzstd.getFunctions(
+		[]string{"ZSTD_createDStream", "ZSTD_freeDStream", "ZSTD_decompressStream", "ZSTD_isError", "ZSTD_getErrorName"},
+		&create, &free, &decompress, &isError, &getErrorName)
+	if err != nil {
+		return nil, err
+	}
+
+	zds := C.my_ZSTD_createDStream(create)
+	if zds == nil {
+		return nil, errors.New("failed to allocate zstd decompression context")
+	}
+
+	step := func(dst, src []byte) (int, int, bool, error) {
+		var dstPos, srcPos C.size_t
+		ret := C.my_ZSTD_decompressStream(decompress, zds, ptr(dst), C.size_t(len(dst)), &dstPos, ptr(src), C.size_t(len(src)), &srcPos)
+		if C.my_isError(isError, ret) != 0 {
+			return 0, 0, false, fmt.Errorf("zstd: %s", C.GoString(C.my_getErrorName(getErrorName, ret)))
+		}
+		// 0 once a frame is completely decoded and flushed
+		return int(dstPos), int(srcPos), ret == 0, nil
+	}
+	return newDecoder(r, step, func() { C.my_ZSTD_freeDStream(free, zds) }), nil
+}
+
+func newLZ4Reader(r io.ReadCloser) (io.ReadCloser, error) {
+	var create, free, decompress, isError, getErrorName unsafe.Pointer
+	err := liblz4.getFunctions(
+		[]string{"LZ4F_createDecompressionContext", "LZ4F_freeDecompressionContext", "LZ4F_decompress", "LZ4F_isError", "LZ4F_getErrorName"},
+		&create, &free, &decompress, &isError, &getErrorName)
+	if err != nil {
+		return nil, err
+	}
+
+	var dctx unsafe.Pointer
+	if ret := C.my_LZ4F_createDecompressionContext(create, &dctx); C.my_isError(isError, ret) != 0 {
+		return nil, fmt.Errorf("lz4: %s", C.GoString(C.my_getErrorName(getErrorName, ret)))
+	}
+
+	step := func(dst, src []byte) (int, int, bool, error) {
+		dstSize, srcSize := C.size_t(len(dst)), C.size_t(len(src))
+		ret := C.my_LZ4F_decompress(decompress, dctx, ptr(dst), &dstSize, ptr(src), &srcSize)
+		if C.my_isError(isError, ret) != 0 {
+			return 0, 0, false, fmt.Errorf("lz4: %s", C.GoString(C.my_getErrorName(getErrorName, ret)))
+		}
+		// 0 once a frame is completely decoded and flushed
+		return int(dstSize), int(srcSize), ret == 0, nil
+	}
+	return newDecoder(r, step, func() { C.my_LZ4F_freeDecompressionContext(free, dctx) }), nil
+}
@@ -0,0 +1,325 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package creds encrypts and decrypts service credentials in the format of
+// systemd-creds, for use with SetCredentialEncrypted= and
+// LoadCredentialEncrypted=. Only credentials encrypted with the host key,
+// as by systemd-creds encrypt --with-key=host, are supported; TPM2-bound
+// credentials require a TPM.
+package creds
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gr-butler/go-systemd/v22/id128"
+)
+
+// DefaultHostKeyPath is where systemd stores the host key to encrypt
+// credentials with.
+const DefaultHostKeyPath = "/var/lib/systemd/credential.secret"
+
+// hostKeySize is the size of the secret in a host key file, which is
+// preceded by the app-specific machine ID it belongs to.
+const hostKeySize = 4096
+
+// hostKeyAppID is the app ID the machine ID in host key files is derived
+// with, see sd_id128_get_machine_app_specific.
+var hostKeyAppID = id128.ID{0xd3, 0xac, 0xec, 0xba, 0x0d, 0xad, 0x4c, 0xdf, 0xb8, 0xc9, 0x38, 0x15, 0x28, 0x93, 0x6c, 0x58}
+
+// machineIDPath is a variable so that tests can override it
+var machineIDPath = "/etc/machine-id"
+
+// credHostID identifies credentials encrypted with AES256-GCM with the host
+// key, CRED_AES256_GCM_BY_HOST in systemd.
+var credHostID = [16]byte{0x5a, 0x1c, 0x6a, 0x86, 0xdf, 0x9d, 0x40, 0x96, 0xb1, 0xd5, 0xa6, 0x5e, 0x08, 0x62, 0xf1, 0x9a}
+
+const (
+	keySize   = 32
+	blockSize = 1
+	ivSize    = 12
+	tagSize   = 16
+
+	// headerSize is the size of the encrypted_credential_header with the
+	// IV, padded to 8 bytes
+	headerSize = 48
+	// noExpiry is USEC_INFINITY
+	noExpiry = math.MaxUint64
+)
+
+var (
+	// ErrUnsupported is returned when decrypting credentials which are not
+	// encrypted with the host key only, e.g. with a TPM2.
+	ErrUnsupported = errors.New("unsupported credential encryption")
+	// ErrNameMismatch is returned by Decrypt when the credential was
+	// encrypted for another name.
+	ErrNameMismatch = errors.New("credential name mismatch")
+	// ErrExpired is returned by Decrypt for credentials past their
+	// NotAfter time.
+	ErrExpired = errors.New("credential expired")
+)
+
+// EncryptOptions configures Encrypt.
+type EncryptOptions struct {
+	// Timestamp is the creation time recorded in the credential, the
+	// current time if zero.
+	Timestamp time.Time
+	// NotAfter, if set, is the time after which the credential can't be
+	// decrypted anymore.
+	NotAfter time.Time
+}
+
+// Credential is a decrypted credential.
+type Credential struct {
+	Name      string // The name the credential was encrypted for, if any
+	Timestamp time.Time
+	NotAfter  time.Time // Zero if the credential doesn't expire
+	Data      []byte
+}
+
+// ReadHostKey reads the host key at path, DefaultHostKeyPath if empty. The
+// key must belong to the local machine, as identified by its machine ID.
+func ReadHostKey(path string) ([]byte, error) {
+	if path == "" {
+		path = DefaultHostKeyPath
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 16+hostKeySize {
+		return nil, fmt.Errorf("host key %s has invalid size %d", path, len(b))
+	}
+
+	// systemd replaces host keys of other machines when encrypting
+	local, err := hostKeyMachineID()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(b[:16], local[:]) {
+		return nil, fmt.Errorf("host key %s belongs to another machine", path)
+	}
+	return b[16:], nil
+}
+
+// GenerateHostKey writes a new random host key to path, DefaultHostKeyPath if
+// empty, unless the file already exists, like systemd does when encrypting
+// the first credential with the host key. It returns the key in the file.
+func GenerateHostKey(path string) ([]byte, error) {
+	if path == "" {
+		path = DefaultHostKeyPath
+	}
+	if key, err := ReadHostKey(path); err == nil || !errors.Is(err, os.ErrNotExist) {
+		return key, err
+	}
+
+	id, err := hostKeyMachineID()
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, 16+hostKeySize)
+	copy(b, id[:])
+	if _, err := rand.Read(b[16:]); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0400)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+	return b[16:], nil
+}
+
+// hostKeyMachineID returns the app-specific ID of the local machine host keys
+// are bound to.
+func hostKeyMachineID() (id128.ID, error) {
+	b, err := os.ReadFile(machineIDPath)
+	if err != nil {
+		return id128.Null, err
+	}
+	machineID, err := id128.Parse(strings.TrimSpace(string(b)))
+	if err != nil {
+		return id128.Null, fmt.Errorf("invalid machine ID in %s", machineIDPath)
+	}
+	return machineID.AppSpecific(hostKeyAppID), nil
+}
+
+func newAEAD(hostKey []byte) (cipher.AEAD, error) {
+	// systemd hashes the host key together with the TPM2 key, if any
+	key := sha256.Sum256(hostKey)
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func usec(t time.Time) uint64 {
+	if t.IsZero() {
+		return noExpiry
+	}
+	return uint64(t.UnixMicro())
+}
+
+func fromUsec(u uint64) time.Time {
+	if u == noExpiry || u == 0 {
+		return time.Time{}
+	}
+	return time.UnixMicro(int64(u))
+}
+
+func pad8(n int) int {
+	return (n + 7) &^ 7
+}
+
+// Encrypt encrypts data with the host key for the credential name, which
+// may be empty to allow any name, and returns the credential in the binary
+// format of systemd-creds.
+func Encrypt(hostKey []byte, name string, data []byte, opts EncryptOptions) ([]byte, error) {
+	aead, err := newAEAD(hostKey)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, headerSize)
+	copy(header, credHostID[:])
+	binary.LittleEndian.PutUint32(header[16:], keySize)
+	binary.LittleEndian.PutUint32(header[20:], blockSize)
+	binary.LittleEndian.PutUint32(header[24:], ivSize)
+	binary.LittleEndian.PutUint32(header[28:], tagSize)
+	iv := header[32 : 32+ivSize]
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+
+	timestamp := opts.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	plain := make([]byte, pad8(20+len(name)), pad8(20+len(name))+len(data))
+	binary.LittleEndian.PutUint64(plain[0:], usec(timestamp))
+	binary.LittleEndian.PutUint64(plain[8:], usec(opts.NotAfter))
+	binary.LittleEndian.PutUint32(plain[16:], uint32(len(name)))
+	copy(plain[20:], name)
+	plain = append(plain, data...)
+
+	// the header is authenticated as additional data
+	return aead.Seal(header, iv, plain, header), nil
+}
+
+// Decrypt decrypts a credential encrypted with the host key, in the binary
+// format of systemd-creds. If name is not empty, it must match the name the
+// credential was encrypted for, if any.
+func Decrypt(hostKey []byte, name string, credential []byte) (*Credential, error) {
+	if len(credential) < headerSize+tagSize {
+		return nil, fmt.Errorf("credential too short")
+	}
+	if !bytes.Equal(credential[:16], credHostID[:]) {
+		return nil, ErrUnsupported
+	}
+	if binary.LittleEndian.Uint32(credential[16:]) != keySize ||
+		binary.LittleEndian.Uint32(credential[20:]) != blockSize ||
+		binary.LittleEndian.Uint32(credential[24:]) != ivSize ||
+		binary.LittleEndian.Uint32(credential[28:]) != tagSize {
+		return nil, ErrUnsupported
+	}
+
+	aead, err := newAEAD(hostKey)
+	if err != nil {
+		return nil, err
+	}
+	header := credential[:headerSize]
+	plain, err := aead.Open(nil, header[32:32+ivSize], credential[headerSize:], header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt credential: %w", err)
+	}
+
+	if len(plain) < 20 {
+		return nil, fmt.Errorf("credential metadata too short")
+	}
+	nameSize := int(binary.LittleEndian.Uint32(plain[16:]))
+	if nameSize > len(plain)-20 || pad8(20+nameSize) > len(plain) {
+		return nil, fmt.Errorf("invalid credential name size %d", nameSize)
+	}
+
+	c := &Credential{
+		Name:      string(plain[20 : 20+nameSize]),
+		Timestamp: fromUsec(binary.LittleEndian.Uint64(plain[0:])),
+		NotAfter:  fromUsec(binary.LittleEndian.Uint64(plain[8:])),
+		Data:      plain[pad8(20+nameSize):],
+	}
+	if name != "" && c.Name != "" && name != c.Name {
+		return nil, fmt.Errorf("%w: encrypted for %q, not %q", ErrNameMismatch, c.Name, name)
+	}
+	if !c.NotAfter.IsZero() && time.Now().After(c.NotAfter) {
+		return nil, fmt.Errorf("%w at %s", ErrExpired, c.NotAfter)
+	}
+	return c, nil
+}
+
+// EncryptBase64 is like Encrypt, but returns the credential encoded in
+// base64, as written by systemd-creds encrypt and read by
+// LoadCredentialEncrypted=.
+func EncryptBase64(hostKey []byte, name string, data []byte, opts EncryptOptions) (string, error) {
+	c, err := Encrypt(hostKey, name, data, opts)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(c), nil
+}
+
+// DecryptBase64 is like Decrypt, but takes the credential encoded in base64,
+// ignoring whitespace.
+func DecryptBase64(hostKey []byte, name string, credential string) (*Credential, error) {
+	c, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(credential), ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 credential: %w", err)
+	}
+	return Decrypt(hostKey, name, c)
+}
+
+// SetCredentialEncrypted returns a SetCredentialEncrypted= line of a unit
+// file, with the credential encrypted with the host key for name.
+func SetCredentialEncrypted(hostKey []byte, name string, data []byte) (string, error) {
+	c, err := EncryptBase64(hostKey, name, data, EncryptOptions{})
+	if err != nil {
+		return "", err
+	}
+	return "SetCredentialEncrypted=" + name + ": " + c, nil
+}
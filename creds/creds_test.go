@@ -0,0 +1,205 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package creds
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testHostKey() []byte {
+	key := make([]byte, hostKeySize)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	return key
+}
+
+// TestDecryptSystemdCreds tests decrypting a credential encrypted by
+// systemd-creds encrypt --with-key=host --name=password --timestamp=@1700000000
+// with the host key returned by testHostKey.
+func TestDecryptSystemdCreds(t *testing.T) {
+	encrypted := `Whxqht+dQJax1aZeCGLxmiAAAAABAAAADAAAABAAAADZ8+Y0wvnx2pQFcEQAAAAAxkTHUjVJ8tdf8o1
+C3cuZZjprSS9rC8c15hxD1AWdi3dcTSTMLYX8ug6bFhnuKe7bFPPIy3yZXg==`
+
+	c, err := DecryptBase64(testHostKey(), "password", encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != "password" || string(c.Data) != "hunter2" {
+		t.Errorf("got name %q and data %q", c.Name, c.Data)
+	}
+	if !c.Timestamp.Equal(time.Unix(1700000000, 0)) || !c.NotAfter.IsZero() {
+		t.Errorf("got timestamp %v and not after %v", c.Timestamp, c.NotAfter)
+	}
+
+	if _, err := DecryptBase64(testHostKey(), "other", encrypted); !errors.Is(err, ErrNameMismatch) {
+		t.Errorf("expected ErrNameMismatch, got %v", err)
+	}
+	if _, err := DecryptBase64(testHostKey()[1:], "password", encrypted); err == nil {
+		t.Error("expected error decrypting with the wrong key")
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := testHostKey()
+	timestamp := time.UnixMicro(1700000000123456)
+
+	for _, name := range []string{"", "a", "database-password"} {
+		for _, data := range [][]byte{nil, []byte("secret"), bytes.Repeat([]byte{0}, 100)} {
+			encrypted, err := Encrypt(key, name, data, EncryptOptions{Timestamp: timestamp})
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, err := Decrypt(key, name, encrypted)
+			if err != nil {
+				t.Fatalf("name %q: %v", name, err)
+			}
+			if c.Name != name || !bytes.Equal(c.Data, data) || !c.Timestamp.Equal(timestamp) {
+				t.Errorf("got %+v, expected name %q and data %q", c, name, data)
+			}
+
+			// a credential without name can be used for any name
+			if _, err := Decrypt(key, "other", encrypted); (name == "") != (err == nil) {
+				t.Errorf("name %q: unexpected result for other name: %v", name, err)
+			}
+		}
+	}
+
+	encrypted, err := Encrypt(key, "x", []byte("data"), EncryptOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range []int{0, 20, 40, headerSize, len(encrypted) - 1} {
+		tampered := bytes.Clone(encrypted)
+		tampered[i] ^= 1
+		if _, err := Decrypt(key, "x", tampered); err == nil {
+			t.Errorf("expected error for credential modified at %d", i)
+		}
+	}
+	if _, err := Decrypt(key, "x", encrypted[:headerSize]); err == nil {
+		t.Error("expected error for truncated credential")
+	}
+
+	encrypted[0] ^= 1
+	if _, err := Decrypt(key, "x", encrypted); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestNotAfter(t *testing.T) {
+	key := testHostKey()
+
+	encrypted, err := Encrypt(key, "x", []byte("data"), EncryptOptions{NotAfter: time.Now().Add(-time.Second)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decrypt(key, "x", encrypted); !errors.Is(err, ErrExpired) {
+		t.Errorf("expected ErrExpired, got %v", err)
+	}
+
+	notAfter := time.Now().Add(time.Hour).Truncate(time.Microsecond)
+	encrypted, err = Encrypt(key, "x", []byte("data"), EncryptOptions{NotAfter: notAfter})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := Decrypt(key, "x", encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.NotAfter.Equal(notAfter) {
+		t.Errorf("got not after %v, expected %v", c.NotAfter, notAfter)
+	}
+}
+
+func TestSetCredentialEncrypted(t *testing.T) {
+	line, err := SetCredentialEncrypted(testHostKey(), "token", []byte("abc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, ok := strings.CutPrefix(line, "SetCredentialEncrypted=token: ")
+	if !ok {
+		t.Fatalf("unexpected line %q", line)
+	}
+	c, err := DecryptBase64(testHostKey(), "token", encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(c.Data) != "abc" {
+		t.Errorf("got data %q", c.Data)
+	}
+}
+
+func TestHostKey(t *testing.T) {
+	dir := t.TempDir()
+	machineIDPath = filepath.Join(dir, "machine-id")
+	defer func() { machineIDPath = "/etc/machine-id" }()
+	if err := os.WriteFile(machineIDPath, []byte("fed6b2924c424cf1b9a322f606b4de6d\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "credential.secret")
+	key, err := GenerateHostKey(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(key) != hostKeySize {
+		t.Fatalf("got key of size %d", len(key))
+	}
+
+	// the app-specific ID systemd derived from the machine ID above
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := b[:16]; !bytes.Equal(id, []byte{0xd4, 0x43, 0x42, 0x08, 0xbd, 0x7f, 0x4b, 0x1a, 0xab, 0xd8, 0x4e, 0xcc, 0x30, 0x29, 0x9e, 0x2f}) {
+		t.Errorf("unexpected machine ID %x", id)
+	}
+
+	again, err := GenerateHostKey(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(again, key) {
+		t.Error("existing host key was not returned")
+	}
+
+	if err := os.WriteFile(machineIDPath, []byte("00112233445566778899aabbccddeeff\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadHostKey(path); err == nil {
+		t.Error("expected error reading host key of another machine")
+	}
+
+	other := filepath.Join(dir, "other.secret")
+	if err := os.WriteFile(other, append(make([]byte, 16), testHostKey()...), 0400); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadHostKey(other); err == nil {
+		t.Error("expected error reading host key without machine ID")
+	}
+
+	if err := os.WriteFile(other+"2", testHostKey(), 0400); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadHostKey(other + "2"); err == nil {
+		t.Error("expected error for host key of invalid size")
+	}
+}
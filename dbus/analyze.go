@@ -0,0 +1,111 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// UnitActivation holds the activation timestamps of a unit, as used by
+// systemd-analyze. All timestamps are CLOCK_MONOTONIC offsets; zero means
+// the unit never went through the corresponding transition.
+type UnitActivation struct {
+	Name             string        // The primary unit name
+	InactiveExit     time.Duration // When the unit left the inactive state
+	ActiveEnter      time.Duration // When the unit entered the active state
+	ActiveExit       time.Duration // When the unit left the active state
+	InactiveEnter    time.Duration // When the unit entered the inactive state
+	ActivationLength time.Duration // Time spent activating: ActiveEnter - InactiveExit
+}
+
+// activationFromProperties extracts the activation timestamps from the
+// properties of the org.freedesktop.systemd1.Unit interface.
+func activationFromProperties(name string, props map[string]interface{}) UnitActivation {
+	usec := func(key string) time.Duration {
+		v, _ := props[key].(uint64)
+		return time.Duration(v) * time.Microsecond
+	}
+
+	a := UnitActivation{
+		Name:          name,
+		InactiveExit:  usec("InactiveExitTimestampMonotonic"),
+		ActiveEnter:   usec("ActiveEnterTimestampMonotonic"),
+		ActiveExit:    usec("ActiveExitTimestampMonotonic"),
+		InactiveEnter: usec("InactiveEnterTimestampMonotonic"),
+	}
+	if a.InactiveExit > 0 && a.ActiveEnter > a.InactiveExit {
+		a.ActivationLength = a.ActiveEnter - a.InactiveExit
+	}
+
+	return a
+}
+
+// sortBlame drops units that have no activation time and sorts the rest by
+// activation length, longest first.
+func sortBlame(units []UnitActivation) []UnitActivation {
+	out := make([]UnitActivation, 0, len(units))
+	for _, u := range units {
+		if u.ActivationLength > 0 {
+			out = append(out, u)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].ActivationLength != out[j].ActivationLength {
+			return out[i].ActivationLength > out[j].ActivationLength
+		}
+		return out[i].Name < out[j].Name
+	})
+
+	return out
+}
+
+// AnalyzeBlame returns all loaded units that were activated, sorted by the
+// time they took to activate, longest first. This is the programmatic
+// equivalent of systemd-analyze blame.
+func (c *Conn) AnalyzeBlame(ctx context.Context) ([]UnitActivation, error) {
+	units, err := c.ListUnitsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	activations := make([]UnitActivation, 0, len(units))
+	for _, u := range units {
+		props, err := c.getProperties(ctx, u.Path, "org.freedesktop.systemd1.Unit")
+		if isUnitGone(err) {
+			// The unit has been unloaded in the meantime.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		activations = append(activations, activationFromProperties(u.Name, props))
+	}
+
+	return sortBlame(activations), nil
+}
+
+// isUnitGone reports whether err was returned because the unit is no longer
+// loaded.
+func isUnitGone(err error) bool {
+	var dbusErr dbus.Error
+	return errors.Is(err, ErrNoSuchUnit) ||
+		errors.As(err, &dbusErr) && dbusErr.Name == "org.freedesktop.DBus.Error.UnknownObject"
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestActivationFromProperties(t *testing.T) {
+	a := activationFromProperties("foo.service", map[string]interface{}{
+		"InactiveExitTimestampMonotonic": uint64(1000000),
+		"ActiveEnterTimestampMonotonic":  uint64(3500000),
+	})
+
+	if a.Name != "foo.service" {
+		t.Fatalf("unexpected name %s", a.Name)
+	}
+	if a.ActivationLength != 2500*time.Millisecond {
+		t.Fatalf("unexpected activation length %v", a.ActivationLength)
+	}
+
+	a = activationFromProperties("bar.service", map[string]interface{}{
+		"ActiveEnterTimestampMonotonic": uint64(3500000),
+	})
+	if a.ActivationLength != 0 {
+		t.Fatalf("expected no activation length, got %v", a.ActivationLength)
+	}
+}
+
+func TestSortBlame(t *testing.T) {
+	units := sortBlame([]UnitActivation{
+		{Name: "a.service", ActivationLength: time.Second},
+		{Name: "b.service"},
+		{Name: "c.service", ActivationLength: 3 * time.Second},
+		{Name: "d.service", ActivationLength: time.Second},
+	})
+
+	expected := []string{"c.service", "a.service", "d.service"}
+	if len(units) != len(expected) {
+		t.Fatalf("expected %d units, got %d", len(expected), len(units))
+	}
+	for i, name := range expected {
+		if units[i].Name != name {
+			t.Fatalf("position %d: expected %s, got %s", i, name, units[i].Name)
+		}
+	}
+}
+
+func TestIsUnitGone(t *testing.T) {
+	for _, tt := range []struct {
+		err  error
+		gone bool
+	}{
+		{nil, false},
+		{mapError(dbus.Error{Name: "org.freedesktop.systemd1.NoSuchUnit"}), true},
+		{dbus.Error{Name: "org.freedesktop.DBus.Error.UnknownObject"}, true},
+		{dbus.Error{Name: "org.freedesktop.DBus.Error.AccessDenied"}, false},
+		{context.DeadlineExceeded, false},
+	} {
+		if gone := isUnitGone(tt.err); gone != tt.gone {
+			t.Errorf("isUnitGone(%v) = %v, expected %v", tt.err, gone, tt.gone)
+		}
+	}
+}
+
+func TestAnalyzeBlame(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	units, err := conn.AnalyzeBlame(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i < len(units); i++ {
+		if units[i].ActivationLength > units[i-1].ActivationLength {
+			t.Fatalf("units not sorted by activation length: %v", units)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// CoalesceSubStateUpdates batches the updates received from in, e.g. the
+// channel passed to SetSubStateSubscriber, and delivers them at most once per
+// window as a map from unit name to the latest update of that unit. A batch
+// is delivered window after its first update; updates received while the
+// consumer is not ready are merged into the pending batch, so a slow consumer
+// never causes updates to be dropped, only to be consolidated.
+//
+// The returned channel is closed once in is closed, after delivering the
+// pending batch, or when ctx is done.
+func CoalesceSubStateUpdates(ctx context.Context, in <-chan *SubStateUpdate, window time.Duration) <-chan map[string]*SubStateUpdate {
+	return coalesce(ctx, in, window,
+		func(u *SubStateUpdate) string { return u.UnitName },
+		func(_, u *SubStateUpdate) *SubStateUpdate { return u },
+	)
+}
+
+// CoalescePropertiesUpdates is like CoalesceSubStateUpdates, but for the
+// updates of SetPropertiesSubscriber. The changed properties of all updates of
+// a unit within a batch are merged, later values replacing earlier ones.
+func CoalescePropertiesUpdates(ctx context.Context, in <-chan *PropertiesUpdate, window time.Duration) <-chan map[string]*PropertiesUpdate {
+	return coalesce(ctx, in, window,
+		func(u *PropertiesUpdate) string { return u.UnitName },
+		func(old, u *PropertiesUpdate) *PropertiesUpdate {
+			changed := make(map[string]dbus.Variant, len(old.Changed)+len(u.Changed))
+			for k, v := range old.Changed {
+				changed[k] = v
+			}
+			for k, v := range u.Changed {
+				changed[k] = v
+			}
+			return &PropertiesUpdate{UnitName: u.UnitName, Changed: changed}
+		},
+	)
+}
+
+func coalesce[T any](ctx context.Context, in <-chan T, window time.Duration, key func(T) string, merge func(old, new T) T) <-chan map[string]T {
+	out := make(chan map[string]T)
+
+	go func() {
+		defer close(out)
+
+		pending := make(map[string]T)
+		var timer <-chan time.Time
+		// ready is out once the window of the pending batch has elapsed
+		var ready chan<- map[string]T
+
+		for {
+			select {
+			case u, ok := <-in:
+				if !ok {
+					if len(pending) > 0 {
+						select {
+						case out <- pending:
+						case <-ctx.Done():
+						}
+					}
+					return
+				}
+
+				k := key(u)
+				if old, ok := pending[k]; ok {
+					pending[k] = merge(old, u)
+				} else {
+					pending[k] = u
+				}
+
+				if timer == nil && ready == nil {
+					timer = time.After(window)
+				}
+			case <-timer:
+				timer = nil
+				ready = out
+			case ready <- pending:
+				pending = make(map[string]T)
+				ready = nil
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestCoalesceSubStateUpdates(t *testing.T) {
+	in := make(chan *SubStateUpdate)
+	out := CoalesceSubStateUpdates(context.Background(), in, 50*time.Millisecond)
+
+	in <- &SubStateUpdate{"a.service", "start"}
+	in <- &SubStateUpdate{"b.service", "dead"}
+	in <- &SubStateUpdate{"a.service", "running"}
+
+	batch := <-out
+	if len(batch) != 2 {
+		t.Fatalf("expected 2 units in batch, got %d", len(batch))
+	}
+	if batch["a.service"].SubState != "running" {
+		t.Fatalf("expected latest substate running, got %s", batch["a.service"].SubState)
+	}
+
+	in <- &SubStateUpdate{"c.service", "exited"}
+	close(in)
+
+	batch, ok := <-out
+	if !ok || len(batch) != 1 || batch["c.service"] == nil {
+		t.Fatalf("expected pending batch to be flushed on close, got %v", batch)
+	}
+	if _, ok := <-out; ok {
+		t.Fatal("expected output channel to be closed")
+	}
+}
+
+func TestCoalescePropertiesUpdates(t *testing.T) {
+	in := make(chan *PropertiesUpdate)
+	out := CoalescePropertiesUpdates(context.Background(), in, 50*time.Millisecond)
+
+	in <- &PropertiesUpdate{"a.service", map[string]dbus.Variant{
+		"ActiveState": dbus.MakeVariant("activating"),
+		"SubState":    dbus.MakeVariant("start"),
+	}}
+	in <- &PropertiesUpdate{"a.service", map[string]dbus.Variant{
+		"SubState": dbus.MakeVariant("running"),
+	}}
+
+	batch := <-out
+	changed := batch["a.service"].Changed
+	if len(changed) != 2 {
+		t.Fatalf("expected 2 merged properties, got %v", changed)
+	}
+	if changed["ActiveState"].Value() != "activating" || changed["SubState"].Value() != "running" {
+		t.Fatalf("unexpected merged properties %v", changed)
+	}
+}
+
+func TestCoalesceContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan *SubStateUpdate)
+	out := CoalesceSubStateUpdates(ctx, in, time.Hour)
+
+	in <- &SubStateUpdate{"a.service", "running"}
+	cancel()
+
+	if _, ok := <-out; ok {
+		t.Fatal("expected output channel to be closed after cancel")
+	}
+}
@@ -0,0 +1,150 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+package dbus
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/gr-butler/go-systemd/v22/instrument"
+)
+
+// Connection is the interface of Conn, which allows for replacing it in tests,
+// e.g. by the fake of the dbustest package. See Conn for the documentation of
+// the methods.
+type Connection interface {
+	AnalyzeBlame(ctx context.Context) ([]UnitActivation, error)
+	AttachProcessesToUnit(ctx context.Context, unit string, subcgroup string, pids []uint32) error
+	CancelJob(ctx context.Context, id uint32) error
+	CleanUnit(ctx context.Context, name string, mask []string) error
+	ClearJobs(ctx context.Context) error
+	Close()
+	Connected() bool
+	DescribeMount(ctx context.Context, name string) (*MountInfo, error)
+	DescribeService(ctx context.Context, name string) (*ServiceInfo, error)
+	DescribeSocket(ctx context.Context, name string) (*SocketInfo, error)
+	DescribeTimer(ctx context.Context, name string) (*TimerInfo, error)
+	DisableUnitFiles(files []string, runtime bool) ([]DisableUnitFileChange, error)
+	DisableUnitFilesContext(ctx context.Context, files []string, runtime bool) ([]DisableUnitFileChange, error)
+	DumpByFileDescriptor(ctx context.Context) (*os.File, error)
+	DumpUnitsMatchingPatternsByFileDescriptor(ctx context.Context, patterns []string) (*os.File, error)
+	EnableUnitFiles(files []string, runtime bool, force bool) (bool, []EnableUnitFileChange, error)
+	EnableUnitFilesContext(ctx context.Context, files []string, runtime bool, force bool) (bool, []EnableUnitFileChange, error)
+	EnqueueUnitJobContext(ctx context.Context, name string, jobType string, mode JobMode, ch chan<- string) (*Transaction, error)
+	FreezeUnit(ctx context.Context, unit string) error
+	GetAllProperties(unit string) (map[string]interface{}, error)
+	GetAllPropertiesContext(ctx context.Context, unit string) (map[string]interface{}, error)
+	GetArchitecture(ctx context.Context) (string, error)
+	GetControlDropIns(ctx context.Context, name string) ([]DropIn, error)
+	GetFeatures(ctx context.Context) (string, error)
+	GetFinishTimestamp(ctx context.Context) (time.Time, error)
+	GetLogLevel(ctx context.Context) (string, error)
+	GetLogTarget(ctx context.Context) (string, error)
+	GetManagerProperty(prop string) (string, error)
+	GetNNames(ctx context.Context) (uint32, error)
+	GetServiceProperty(service string, propertyName string) (*Property, error)
+	GetServicePropertyContext(ctx context.Context, service string, propertyName string) (*Property, error)
+	GetServiceWatchdogs(ctx context.Context) (bool, error)
+	GetSystemState(ctx context.Context) (string, error)
+	GetUnitByControlGroup(ctx context.Context, cgroup string) (dbus.ObjectPath, error)
+	GetUnitByPID(ctx context.Context, pid uint32) (dbus.ObjectPath, error)
+	GetUnitDependencies(ctx context.Context, unit string, kinds ...DependencyKind) (*DependencyNode, error)
+	GetUnitDependenciesRecursive(ctx context.Context, unit string, kinds ...DependencyKind) (*DependencyNode, error)
+	GetUnitFileStateContext(ctx context.Context, file string) (string, error)
+	GetUnitNameByControlGroup(ctx context.Context, cgroup string) (string, error)
+	GetUnitNameByPID(ctx context.Context, pid uint32) (string, error)
+	GetUnitPathProperties(path dbus.ObjectPath) (map[string]interface{}, error)
+	GetUnitPathPropertiesContext(ctx context.Context, path dbus.ObjectPath) (map[string]interface{}, error)
+	GetUnitProcesses(ctx context.Context, unit string) ([]UnitProcess, error)
+	GetUnitProperties(unit string) (map[string]interface{}, error)
+	GetUnitPropertiesContext(ctx context.Context, unit string) (map[string]interface{}, error)
+	GetUnitProperty(unit string, propertyName string) (*Property, error)
+	GetUnitPropertyContext(ctx context.Context, unit string, propertyName string) (*Property, error)
+	GetUnitTypeProperties(unit string, unitType string) (map[string]interface{}, error)
+	GetUnitTypePropertiesContext(ctx context.Context, unit string, unitType string) (map[string]interface{}, error)
+	GetUnitTypeProperty(unit string, unitType string, propertyName string) (*Property, error)
+	GetUnitTypePropertyContext(ctx context.Context, unit string, unitType string, propertyName string) (*Property, error)
+	GetUnitsProperties(ctx context.Context, names []string, properties []string) ([]UnitProperties, error)
+	GetUserspaceTimestamp(ctx context.Context) (time.Time, error)
+	GetVersion(ctx context.Context) (string, error)
+	GetVirtualization(ctx context.Context) (string, error)
+	KillUnit(name string, signal int32)
+	KillUnitContext(ctx context.Context, name string, signal int32)
+	KillUnitWithSubgroup(ctx context.Context, name string, subcgroup string, signal int32) error
+	KillUnitWithTarget(ctx context.Context, name string, target Who, signal int32) error
+	LinkUnitFiles(files []string, runtime bool, force bool) ([]LinkUnitFileChange, error)
+	LinkUnitFilesContext(ctx context.Context, files []string, runtime bool, force bool) ([]LinkUnitFileChange, error)
+	ListJobs() ([]JobStatus, error)
+	ListJobsContext(ctx context.Context) ([]JobStatus, error)
+	ListUnitFiles() ([]UnitFile, error)
+	ListUnitFilesByPatterns(states []string, patterns []string) ([]UnitFile, error)
+	ListUnitFilesByPatternsContext(ctx context.Context, states []string, patterns []string) ([]UnitFile, error)
+	ListUnitFilesContext(ctx context.Context) ([]UnitFile, error)
+	ListUnits() ([]UnitStatus, error)
+	ListUnitsByNames(units []string) ([]UnitStatus, error)
+	ListUnitsByNamesContext(ctx context.Context, units []string) ([]UnitStatus, error)
+	ListUnitsByPatterns(states []string, patterns []string) ([]UnitStatus, error)
+	ListUnitsByPatternsContext(ctx context.Context, states []string, patterns []string) ([]UnitStatus, error)
+	ListUnitsContext(ctx context.Context) ([]UnitStatus, error)
+	ListUnitsFiltered(states []string) ([]UnitStatus, error)
+	ListUnitsFilteredContext(ctx context.Context, states []string) ([]UnitStatus, error)
+	ListUnitsMatchingContext(ctx context.Context, filter UnitFilter) ([]UnitStatus, error)
+	LoadUnit(ctx context.Context, name string) (*UnitLoadInfo, error)
+	MaskUnitFiles(files []string, runtime bool, force bool) ([]MaskUnitFileChange, error)
+	MaskUnitFilesContext(ctx context.Context, files []string, runtime bool, force bool) ([]MaskUnitFileChange, error)
+	NewSubscriptionSet() *SubscriptionSet
+	ReexecuteContext(ctx context.Context) error
+	Reload() error
+	ReloadContext(ctx context.Context) error
+	ReloadOrRestartUnit(name string, mode JobMode, ch chan<- string) (int, error)
+	ReloadOrRestartUnitContext(ctx context.Context, name string, mode JobMode, ch chan<- string) (int, error)
+	ReloadOrTryRestartUnit(name string, mode JobMode, ch chan<- string) (int, error)
+	ReloadOrTryRestartUnitContext(ctx context.Context, name string, mode JobMode, ch chan<- string) (int, error)
+	ReloadUnit(name string, mode JobMode, ch chan<- string) (int, error)
+	ReloadUnitContext(ctx context.Context, name string, mode JobMode, ch chan<- string) (int, error)
+	ResetFailedAndRestartUnitContext(ctx context.Context, name string, mode JobMode) (string, error)
+	ResetFailedContext(ctx context.Context) error
+	ResetFailedUnit(name string) error
+	ResetFailedUnitContext(ctx context.Context, name string) error
+	RestartUnit(name string, mode JobMode, ch chan<- string) (int, error)
+	RestartUnitContext(ctx context.Context, name string, mode JobMode, ch chan<- string) (int, error)
+	RestartUnits(ctx context.Context, names []string, opts RestartOptions) (*RestartReport, error)
+	RunTransientCommand(ctx context.Context, argv []string, opts RunOptions) (*RunResult, error)
+	Scope() ManagerScope
+	SetHook(hook instrument.Hook)
+	SetLogLevel(ctx context.Context, level string) error
+	SetLogTarget(ctx context.Context, target string) error
+	SetPropertiesSubscriber(updateCh chan<- *PropertiesUpdate, errCh chan<- error)
+	SetServiceWatchdogs(ctx context.Context, enabled bool) error
+	SetSubStateSubscriber(updateCh chan<- *SubStateUpdate, errCh chan<- error)
+	SetTimeout(timeout time.Duration)
+	SetUnitProperties(name string, runtime bool, properties ...Property) error
+	SetUnitPropertiesContext(ctx context.Context, name string, runtime bool, properties ...Property) error
+	SetUnitPropertiesPersistent(ctx context.Context, name string, properties ...Property) ([]DropIn, error)
+	SetUnitResources(ctx context.Context, unit string, spec ResourceSpec) error
+	SnapshotUnits(ctx context.Context, patterns []string, properties []string) (*UnitsSnapshot, error)
+	StartTransientUnit(name string, mode JobMode, properties []Property, ch chan<- string) (int, error)
+	StartTransientUnitContext(ctx context.Context, name string, mode JobMode, properties []Property, ch chan<- string) (int, error)
+	StartUnit(name string, mode JobMode, ch chan<- string) (int, error)
+	StartUnitContext(ctx context.Context, name string, mode JobMode, ch chan<- string) (int, error)
+	StopUnit(name string, mode JobMode, ch chan<- string) (int, error)
+	StopUnitContext(ctx context.Context, name string, mode JobMode, ch chan<- string) (int, error)
+	Subscribe() error
+	SubscribeUnits(interval time.Duration) (<-chan map[string]*UnitStatus, <-chan error)
+	SubscribeUnitsCustom(interval time.Duration, buffer int, isChanged func(*UnitStatus, *UnitStatus) bool, filterUnit func(string) bool) (<-chan map[string]*UnitStatus, <-chan error)
+	SystemState() (*Property, error)
+	SystemStateContext(ctx context.Context) (*Property, error)
+	ThawUnit(ctx context.Context, unit string) error
+	TryRestartUnit(name string, mode JobMode, ch chan<- string) (int, error)
+	TryRestartUnitContext(ctx context.Context, name string, mode JobMode, ch chan<- string) (int, error)
+	UnitExists(ctx context.Context, name string) (bool, error)
+	UnmaskUnitFiles(files []string, runtime bool) ([]UnmaskUnitFileChange, error)
+	UnmaskUnitFilesContext(ctx context.Context, files []string, runtime bool) ([]UnmaskUnitFileChange, error)
+	Unsubscribe() error
+	WaitForIdle(ctx context.Context) error
+	WaitForUnitState(ctx context.Context, unit string, target ActiveState) error
+}
+
+var _ Connection = (*Conn)(nil)
@@ -20,13 +20,20 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/godbus/dbus/v5"
+
+	"github.com/gr-butler/go-systemd/v22/instrument"
+	"github.com/gr-butler/go-systemd/v22/internal/calltimeout"
 )
 
+//go:generate go run github.com/gr-butler/go-systemd/v22/internal/fakegen
+
 const (
 	alpha        = `abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ`
 	num          = `0123456789`
@@ -95,6 +102,8 @@ type Conn struct {
 
 	jobListener struct {
 		jobs map[dbus.ObjectPath]chan<- string
+		// idle is notified whenever any job is removed
+		idle map[chan struct{}]struct{}
 		sync.Mutex
 	}
 	subStateSubscriber struct {
@@ -109,8 +118,24 @@ type Conn struct {
 		errCh    chan<- error
 		sync.Mutex
 	}
+
+	scope   ManagerScope
+	hook    instrument.Hook
+	timeout time.Duration
 }
 
+// ManagerScope tells which systemd manager a Conn talks to.
+type ManagerScope string
+
+const (
+	// UnknownManager is the scope of connections created with NewConnection
+	UnknownManager ManagerScope = ""
+	// SystemManager is the scope of connections to the system manager
+	SystemManager ManagerScope = "system"
+	// UserManager is the scope of connections to a user manager
+	UserManager ManagerScope = "user"
+)
+
 // Deprecated: use NewWithContext instead.
 func New() (*Conn, error) {
 	return NewWithContext(context.Background())
@@ -134,7 +159,7 @@ func NewSystemConnection() (*Conn, error) {
 // NewSystemConnectionContext establishes a connection to the system bus and authenticates.
 // Callers should call Close() when done with the connection.
 func NewSystemConnectionContext(ctx context.Context) (*Conn, error) {
-	return NewConnection(func() (*dbus.Conn, error) {
+	return newScopedConnection(SystemManager, func() (*dbus.Conn, error) {
 		return dbusAuthHelloConnection(ctx, dbus.SystemBusPrivate)
 	})
 }
@@ -148,11 +173,47 @@ func NewUserConnection() (*Conn, error) {
 // authenticates. This can be used to connect to systemd user instances.
 // Callers should call Close() when done with the connection.
 func NewUserConnectionContext(ctx context.Context) (*Conn, error) {
-	return NewConnection(func() (*dbus.Conn, error) {
+	return newScopedConnection(UserManager, func() (*dbus.Conn, error) {
 		return dbusAuthHelloConnection(ctx, dbus.SessionBusPrivate)
 	})
 }
 
+// UserBusAddress returns the address of the session bus of the user with the
+// given UID. For the current user, it is derived from XDG_RUNTIME_DIR if set.
+func UserBusAddress(uid int) string {
+	return "unix:path=" + filepath.Join(userRuntimeDir(uid), "bus")
+}
+
+func userRuntimeDir(uid int) string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" && uid == os.Getuid() {
+		return dir
+	}
+	return "/run/user/" + strconv.Itoa(uid)
+}
+
+// NewUserConnectionForUID establishes a connection to the user manager of the
+// user with the given UID. For the current user, the session bus given by
+// UserBusAddress is used. Connecting to the manager of another user requires
+// root; in that case a private, direct connection to the manager is made,
+// like NewSystemdConnectionContext does for the system manager, as the
+// session bus of another user does not accept the connection.
+// Callers should call Close() when done with the connection.
+func NewUserConnectionForUID(ctx context.Context, uid int) (*Conn, error) {
+	if uid == os.Getuid() {
+		return newScopedConnection(UserManager, func() (*dbus.Conn, error) {
+			return dbusAuthHelloConnection(ctx, func(opts ...dbus.ConnOption) (*dbus.Conn, error) {
+				return dbus.Dial(UserBusAddress(uid), opts...)
+			})
+		})
+	}
+
+	return newScopedConnection(UserManager, func() (*dbus.Conn, error) {
+		return dbusAuthConnection(ctx, func(opts ...dbus.ConnOption) (*dbus.Conn, error) {
+			return dbus.Dial("unix:path="+filepath.Join(userRuntimeDir(uid), "systemd/private"), opts...)
+		})
+	})
+}
+
 // Deprecated: use NewSystemdConnectionContext instead.
 func NewSystemdConnection() (*Conn, error) {
 	return NewSystemdConnectionContext(context.Background())
@@ -162,7 +223,7 @@ func NewSystemdConnection() (*Conn, error) {
 // This can be used for communicating with systemd without a dbus daemon.
 // Callers should call Close() when done with the connection.
 func NewSystemdConnectionContext(ctx context.Context) (*Conn, error) {
-	return NewConnection(func() (*dbus.Conn, error) {
+	return newScopedConnection(SystemManager, func() (*dbus.Conn, error) {
 		// We skip Hello when talking directly to systemd.
 		return dbusAuthConnection(ctx, func(opts ...dbus.ConnOption) (*dbus.Conn, error) {
 			return dbus.Dial("unix:path=/run/systemd/private", opts...)
@@ -176,6 +237,47 @@ func (c *Conn) Close() {
 	c.sigconn.Close()
 }
 
+// Scope returns which manager the connection talks to. It is UnknownManager
+// for connections created with NewConnection.
+func (c *Conn) Scope() ManagerScope {
+	return c.scope
+}
+
+// SetHook sets the hook observing the method calls to systemd made on the
+// connection, e.g. to trace them, see the instrument package. A nil hook
+// removes it. SetHook must not be called concurrently with method calls.
+func (c *Conn) SetHook(hook instrument.Hook) {
+	c.hook = hook
+	c.updateObjects()
+}
+
+// SetTimeout sets the default timeout of the method calls made on the
+// connection without a context deadline, including those made by the
+// methods without context, which otherwise block forever if systemd hangs.
+// It is disabled by a timeout of zero, the default. SetTimeout must not be
+// called concurrently with method calls.
+func (c *Conn) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+	c.updateObjects()
+}
+
+// updateObjects applies the hook and the timeout to the manager objects.
+func (c *Conn) updateObjects() {
+	c.sysobj = c.wrapObject(c.sysconn.Object("org.freedesktop.systemd1", dbus.ObjectPath("/org/freedesktop/systemd1")))
+	c.sigobj = c.wrapObject(c.sigconn.Object("org.freedesktop.systemd1", dbus.ObjectPath("/org/freedesktop/systemd1")))
+}
+
+// wrapObject returns obj with the errors mapped as by systemdObject, and
+// the timeout and the hook of the connection applied.
+func (c *Conn) wrapObject(obj dbus.BusObject) dbus.BusObject {
+	return instrument.Object(errorMappingObject{calltimeout.Object(obj, c.timeout)}, c.hook)
+}
+
+// systemdPathObject returns the object at path on the systemd service.
+func (c *Conn) systemdPathObject(path dbus.ObjectPath) dbus.BusObject {
+	return c.wrapObject(c.sysconn.Object("org.freedesktop.systemd1", path))
+}
+
 // Connected returns whether conn is connected
 func (c *Conn) Connected() bool {
 	return c.sysconn.Connected() && c.sigconn.Connected()
@@ -207,6 +309,7 @@ func NewConnection(dialBus func() (*dbus.Conn, error)) (*Conn, error) {
 
 	c.subStateSubscriber.ignore = make(map[dbus.ObjectPath]int64)
 	c.jobListener.jobs = make(map[dbus.ObjectPath]chan<- string)
+	c.jobListener.idle = make(map[chan struct{}]struct{})
 
 	// Setup the listeners on jobs so that we can get completions
 	c.sigconn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0,
@@ -216,6 +319,15 @@ func NewConnection(dialBus func() (*dbus.Conn, error)) (*Conn, error) {
 	return c, nil
 }
 
+func newScopedConnection(scope ManagerScope, dialBus func() (*dbus.Conn, error)) (*Conn, error) {
+	c, err := NewConnection(dialBus)
+	if err != nil {
+		return nil, err
+	}
+	c.scope = scope
+	return c, nil
+}
+
 // GetManagerProperty returns the value of a property on the org.freedesktop.systemd1.Manager
 // interface. The value is returned in its string representation, as defined at
 // https://developer.gnome.org/glib/unstable/gvariant-text.html.
@@ -262,5 +374,5 @@ func dbusAuthHelloConnection(ctx context.Context, createBus func(opts ...dbus.Co
 }
 
 func systemdObject(conn *dbus.Conn) dbus.BusObject {
-	return conn.Object("org.freedesktop.systemd1", dbus.ObjectPath("/org/freedesktop/systemd1"))
+	return errorMappingObject{conn.Object("org.freedesktop.systemd1", dbus.ObjectPath("/org/freedesktop/systemd1"))}
 }
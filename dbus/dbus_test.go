@@ -15,6 +15,8 @@
 package dbus
 
 import (
+	"os"
+	"strconv"
 	"testing"
 )
 
@@ -94,3 +96,26 @@ func TestNew(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestUserBusAddress(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/tmp/runtime")
+
+	if got := UserBusAddress(os.Getuid()); got != "unix:path=/tmp/runtime/bus" {
+		t.Fatalf("unexpected address for current user: %s", got)
+	}
+
+	other := os.Getuid() + 1
+	expected := "unix:path=/run/user/" + strconv.Itoa(other) + "/bus"
+	if got := UserBusAddress(other); got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestScope(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	if conn.Scope() != SystemManager {
+		t.Fatalf("expected system manager scope, got %q", conn.Scope())
+	}
+}
@@ -0,0 +1,1163 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+// Package dbustest provides a fake of dbus.Conn for unit tests.
+package dbustest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	godbus "github.com/godbus/dbus/v5"
+	"github.com/gr-butler/go-systemd/v22/dbus"
+	"github.com/gr-butler/go-systemd/v22/instrument"
+)
+
+// ErrNotImplemented is returned by the methods whose function field is unset.
+var ErrNotImplemented = errors.New("not implemented")
+
+// Conn is a fake dbus.Connection. Each method calls the function field of
+// the same name with a Func suffix. If it is unset, the method returns zero
+// values and, if it returns an error, ErrNotImplemented.
+type Conn struct {
+	AnalyzeBlameFunc                              func(ctx context.Context) ([]dbus.UnitActivation, error)
+	AttachProcessesToUnitFunc                     func(ctx context.Context, unit string, subcgroup string, pids []uint32) error
+	CancelJobFunc                                 func(ctx context.Context, id uint32) error
+	CleanUnitFunc                                 func(ctx context.Context, name string, mask []string) error
+	ClearJobsFunc                                 func(ctx context.Context) error
+	CloseFunc                                     func()
+	ConnectedFunc                                 func() bool
+	DescribeMountFunc                             func(ctx context.Context, name string) (*dbus.MountInfo, error)
+	DescribeServiceFunc                           func(ctx context.Context, name string) (*dbus.ServiceInfo, error)
+	DescribeSocketFunc                            func(ctx context.Context, name string) (*dbus.SocketInfo, error)
+	DescribeTimerFunc                             func(ctx context.Context, name string) (*dbus.TimerInfo, error)
+	DisableUnitFilesFunc                          func(files []string, runtime bool) ([]dbus.DisableUnitFileChange, error)
+	DisableUnitFilesContextFunc                   func(ctx context.Context, files []string, runtime bool) ([]dbus.DisableUnitFileChange, error)
+	DumpByFileDescriptorFunc                      func(ctx context.Context) (*os.File, error)
+	DumpUnitsMatchingPatternsByFileDescriptorFunc func(ctx context.Context, patterns []string) (*os.File, error)
+	EnableUnitFilesFunc                           func(files []string, runtime bool, force bool) (bool, []dbus.EnableUnitFileChange, error)
+	EnableUnitFilesContextFunc                    func(ctx context.Context, files []string, runtime bool, force bool) (bool, []dbus.EnableUnitFileChange, error)
+	EnqueueUnitJobContextFunc                     func(ctx context.Context, name string, jobType string, mode dbus.JobMode, ch chan<- string) (*dbus.Transaction, error)
+	FreezeUnitFunc                                func(ctx context.Context, unit string) error
+	GetAllPropertiesFunc                          func(unit string) (map[string]interface{}, error)
+	GetAllPropertiesContextFunc                   func(ctx context.Context, unit string) (map[string]interface{}, error)
+	GetArchitectureFunc                           func(ctx context.Context) (string, error)
+	GetControlDropInsFunc                         func(ctx context.Context, name string) ([]dbus.DropIn, error)
+	GetFeaturesFunc                               func(ctx context.Context) (string, error)
+	GetFinishTimestampFunc                        func(ctx context.Context) (time.Time, error)
+	GetLogLevelFunc                               func(ctx context.Context) (string, error)
+	GetLogTargetFunc                              func(ctx context.Context) (string, error)
+	GetManagerPropertyFunc                        func(prop string) (string, error)
+	GetNNamesFunc                                 func(ctx context.Context) (uint32, error)
+	GetServicePropertyFunc                        func(service string, propertyName string) (*dbus.Property, error)
+	GetServicePropertyContextFunc                 func(ctx context.Context, service string, propertyName string) (*dbus.Property, error)
+	GetServiceWatchdogsFunc                       func(ctx context.Context) (bool, error)
+	GetSystemStateFunc                            func(ctx context.Context) (string, error)
+	GetUnitByControlGroupFunc                     func(ctx context.Context, cgroup string) (godbus.ObjectPath, error)
+	GetUnitByPIDFunc                              func(ctx context.Context, pid uint32) (godbus.ObjectPath, error)
+	GetUnitDependenciesFunc                       func(ctx context.Context, unit string, kinds ...dbus.DependencyKind) (*dbus.DependencyNode, error)
+	GetUnitDependenciesRecursiveFunc              func(ctx context.Context, unit string, kinds ...dbus.DependencyKind) (*dbus.DependencyNode, error)
+	GetUnitFileStateContextFunc                   func(ctx context.Context, file string) (string, error)
+	GetUnitNameByControlGroupFunc                 func(ctx context.Context, cgroup string) (string, error)
+	GetUnitNameByPIDFunc                          func(ctx context.Context, pid uint32) (string, error)
+	GetUnitPathPropertiesFunc                     func(path godbus.ObjectPath) (map[string]interface{}, error)
+	GetUnitPathPropertiesContextFunc              func(ctx context.Context, path godbus.ObjectPath) (map[string]interface{}, error)
+	GetUnitProcessesFunc                          func(ctx context.Context, unit string) ([]dbus.UnitProcess, error)
+	GetUnitPropertiesFunc                         func(unit string) (map[string]interface{}, error)
+	GetUnitPropertiesContextFunc                  func(ctx context.Context, unit string) (map[string]interface{}, error)
+	GetUnitPropertyFunc                           func(unit string, propertyName string) (*dbus.Property, error)
+	GetUnitPropertyContextFunc                    func(ctx context.Context, unit string, propertyName string) (*dbus.Property, error)
+	GetUnitTypePropertiesFunc                     func(unit string, unitType string) (map[string]interface{}, error)
+	GetUnitTypePropertiesContextFunc              func(ctx context.Context, unit string, unitType string) (map[string]interface{}, error)
+	GetUnitTypePropertyFunc                       func(unit string, unitType string, propertyName string) (*dbus.Property, error)
+	GetUnitTypePropertyContextFunc                func(ctx context.Context, unit string, unitType string, propertyName string) (*dbus.Property, error)
+	GetUnitsPropertiesFunc                        func(ctx context.Context, names []string, properties []string) ([]dbus.UnitProperties, error)
+	GetUserspaceTimestampFunc                     func(ctx context.Context) (time.Time, error)
+	GetVersionFunc                                func(ctx context.Context) (string, error)
+	GetVirtualizationFunc                         func(ctx context.Context) (string, error)
+	KillUnitFunc                                  func(name string, signal int32)
+	KillUnitContextFunc                           func(ctx context.Context, name string, signal int32)
+	KillUnitWithSubgroupFunc                      func(ctx context.Context, name string, subcgroup string, signal int32) error
+	KillUnitWithTargetFunc                        func(ctx context.Context, name string, target dbus.Who, signal int32) error
+	LinkUnitFilesFunc                             func(files []string, runtime bool, force bool) ([]dbus.LinkUnitFileChange, error)
+	LinkUnitFilesContextFunc                      func(ctx context.Context, files []string, runtime bool, force bool) ([]dbus.LinkUnitFileChange, error)
+	ListJobsFunc                                  func() ([]dbus.JobStatus, error)
+	ListJobsContextFunc                           func(ctx context.Context) ([]dbus.JobStatus, error)
+	ListUnitFilesFunc                             func() ([]dbus.UnitFile, error)
+	ListUnitFilesByPatternsFunc                   func(states []string, patterns []string) ([]dbus.UnitFile, error)
+	ListUnitFilesByPatternsContextFunc            func(ctx context.Context, states []string, patterns []string) ([]dbus.UnitFile, error)
+	ListUnitFilesContextFunc                      func(ctx context.Context) ([]dbus.UnitFile, error)
+	ListUnitsFunc                                 func() ([]dbus.UnitStatus, error)
+	ListUnitsByNamesFunc                          func(units []string) ([]dbus.UnitStatus, error)
+	ListUnitsByNamesContextFunc                   func(ctx context.Context, units []string) ([]dbus.UnitStatus, error)
+	ListUnitsByPatternsFunc                       func(states []string, patterns []string) ([]dbus.UnitStatus, error)
+	ListUnitsByPatternsContextFunc                func(ctx context.Context, states []string, patterns []string) ([]dbus.UnitStatus, error)
+	ListUnitsContextFunc                          func(ctx context.Context) ([]dbus.UnitStatus, error)
+	ListUnitsFilteredFunc                         func(states []string) ([]dbus.UnitStatus, error)
+	ListUnitsFilteredContextFunc                  func(ctx context.Context, states []string) ([]dbus.UnitStatus, error)
+	ListUnitsMatchingContextFunc                  func(ctx context.Context, filter dbus.UnitFilter) ([]dbus.UnitStatus, error)
+	LoadUnitFunc                                  func(ctx context.Context, name string) (*dbus.UnitLoadInfo, error)
+	MaskUnitFilesFunc                             func(files []string, runtime bool, force bool) ([]dbus.MaskUnitFileChange, error)
+	MaskUnitFilesContextFunc                      func(ctx context.Context, files []string, runtime bool, force bool) ([]dbus.MaskUnitFileChange, error)
+	NewSubscriptionSetFunc                        func() *dbus.SubscriptionSet
+	ReexecuteContextFunc                          func(ctx context.Context) error
+	ReloadFunc                                    func() error
+	ReloadContextFunc                             func(ctx context.Context) error
+	ReloadOrRestartUnitFunc                       func(name string, mode dbus.JobMode, ch chan<- string) (int, error)
+	ReloadOrRestartUnitContextFunc                func(ctx context.Context, name string, mode dbus.JobMode, ch chan<- string) (int, error)
+	ReloadOrTryRestartUnitFunc                    func(name string, mode dbus.JobMode, ch chan<- string) (int, error)
+	ReloadOrTryRestartUnitContextFunc             func(ctx context.Context, name string, mode dbus.JobMode, ch chan<- string) (int, error)
+	ReloadUnitFunc                                func(name string, mode dbus.JobMode, ch chan<- string) (int, error)
+	ReloadUnitContextFunc                         func(ctx context.Context, name string, mode dbus.JobMode, ch chan<- string) (int, error)
+	ResetFailedAndRestartUnitContextFunc          func(ctx context.Context, name string, mode dbus.JobMode) (string, error)
+	ResetFailedContextFunc                        func(ctx context.Context) error
+	ResetFailedUnitFunc                           func(name string) error
+	ResetFailedUnitContextFunc                    func(ctx context.Context, name string) error
+	RestartUnitFunc                               func(name string, mode dbus.JobMode, ch chan<- string) (int, error)
+	RestartUnitContextFunc                        func(ctx context.Context, name string, mode dbus.JobMode, ch chan<- string) (int, error)
+	RestartUnitsFunc                              func(ctx context.Context, names []string, opts dbus.RestartOptions) (*dbus.RestartReport, error)
+	RunTransientCommandFunc                       func(ctx context.Context, argv []string, opts dbus.RunOptions) (*dbus.RunResult, error)
+	ScopeFunc                                     func() dbus.ManagerScope
+	SetHookFunc                                   func(hook instrument.Hook)
+	SetLogLevelFunc                               func(ctx context.Context, level string) error
+	SetLogTargetFunc                              func(ctx context.Context, target string) error
+	SetPropertiesSubscriberFunc                   func(updateCh chan<- *dbus.PropertiesUpdate, errCh chan<- error)
+	SetServiceWatchdogsFunc                       func(ctx context.Context, enabled bool) error
+	SetSubStateSubscriberFunc                     func(updateCh chan<- *dbus.SubStateUpdate, errCh chan<- error)
+	SetTimeoutFunc                                func(timeout time.Duration)
+	SetUnitPropertiesFunc                         func(name string, runtime bool, properties ...dbus.Property) error
+	SetUnitPropertiesContextFunc                  func(ctx context.Context, name string, runtime bool, properties ...dbus.Property) error
+	SetUnitPropertiesPersistentFunc               func(ctx context.Context, name string, properties ...dbus.Property) ([]dbus.DropIn, error)
+	SetUnitResourcesFunc                          func(ctx context.Context, unit string, spec dbus.ResourceSpec) error
+	SnapshotUnitsFunc                             func(ctx context.Context, patterns []string, properties []string) (*dbus.UnitsSnapshot, error)
+	StartTransientUnitFunc                        func(name string, mode dbus.JobMode, properties []dbus.Property, ch chan<- string) (int, error)
+	StartTransientUnitContextFunc                 func(ctx context.Context, name string, mode dbus.JobMode, properties []dbus.Property, ch chan<- string) (int, error)
+	StartUnitFunc                                 func(name string, mode dbus.JobMode, ch chan<- string) (int, error)
+	StartUnitContextFunc                          func(ctx context.Context, name string, mode dbus.JobMode, ch chan<- string) (int, error)
+	StopUnitFunc                                  func(name string, mode dbus.JobMode, ch chan<- string) (int, error)
+	StopUnitContextFunc                           func(ctx context.Context, name string, mode dbus.JobMode, ch chan<- string) (int, error)
+	SubscribeFunc                                 func() error
+	SubscribeUnitsFunc                            func(interval time.Duration) (<-chan map[string]*dbus.UnitStatus, <-chan error)
+	SubscribeUnitsCustomFunc                      func(interval time.Duration, buffer int, isChanged func(*dbus.UnitStatus, *dbus.UnitStatus) bool, filterUnit func(string) bool) (<-chan map[string]*dbus.UnitStatus, <-chan error)
+	SystemStateFunc                               func() (*dbus.Property, error)
+	SystemStateContextFunc                        func(ctx context.Context) (*dbus.Property, error)
+	ThawUnitFunc                                  func(ctx context.Context, unit string) error
+	TryRestartUnitFunc                            func(name string, mode dbus.JobMode, ch chan<- string) (int, error)
+	TryRestartUnitContextFunc                     func(ctx context.Context, name string, mode dbus.JobMode, ch chan<- string) (int, error)
+	UnitExistsFunc                                func(ctx context.Context, name string) (bool, error)
+	UnmaskUnitFilesFunc                           func(files []string, runtime bool) ([]dbus.UnmaskUnitFileChange, error)
+	UnmaskUnitFilesContextFunc                    func(ctx context.Context, files []string, runtime bool) ([]dbus.UnmaskUnitFileChange, error)
+	UnsubscribeFunc                               func() error
+	WaitForIdleFunc                               func(ctx context.Context) error
+	WaitForUnitStateFunc                          func(ctx context.Context, unit string, target dbus.ActiveState) error
+}
+
+var _ dbus.Connection = (*Conn)(nil)
+
+func (f *Conn) AnalyzeBlame(ctx context.Context) ([]dbus.UnitActivation, error) {
+	if f.AnalyzeBlameFunc == nil {
+		var r0 []dbus.UnitActivation
+		return r0, fmt.Errorf("%w: AnalyzeBlame", ErrNotImplemented)
+	}
+	return f.AnalyzeBlameFunc(ctx)
+}
+
+func (f *Conn) AttachProcessesToUnit(ctx context.Context, unit string, subcgroup string, pids []uint32) error {
+	if f.AttachProcessesToUnitFunc == nil {
+		return fmt.Errorf("%w: AttachProcessesToUnit", ErrNotImplemented)
+	}
+	return f.AttachProcessesToUnitFunc(ctx, unit, subcgroup, pids)
+}
+
+func (f *Conn) CancelJob(ctx context.Context, id uint32) error {
+	if f.CancelJobFunc == nil {
+		return fmt.Errorf("%w: CancelJob", ErrNotImplemented)
+	}
+	return f.CancelJobFunc(ctx, id)
+}
+
+func (f *Conn) CleanUnit(ctx context.Context, name string, mask []string) error {
+	if f.CleanUnitFunc == nil {
+		return fmt.Errorf("%w: CleanUnit", ErrNotImplemented)
+	}
+	return f.CleanUnitFunc(ctx, name, mask)
+}
+
+func (f *Conn) ClearJobs(ctx context.Context) error {
+	if f.ClearJobsFunc == nil {
+		return fmt.Errorf("%w: ClearJobs", ErrNotImplemented)
+	}
+	return f.ClearJobsFunc(ctx)
+}
+
+func (f *Conn) Close() {
+	if f.CloseFunc != nil {
+		f.CloseFunc()
+	}
+}
+
+func (f *Conn) Connected() bool {
+	if f.ConnectedFunc == nil {
+		var r0 bool
+		return r0
+	}
+	return f.ConnectedFunc()
+}
+
+func (f *Conn) DescribeMount(ctx context.Context, name string) (*dbus.MountInfo, error) {
+	if f.DescribeMountFunc == nil {
+		var r0 *dbus.MountInfo
+		return r0, fmt.Errorf("%w: DescribeMount", ErrNotImplemented)
+	}
+	return f.DescribeMountFunc(ctx, name)
+}
+
+func (f *Conn) DescribeService(ctx context.Context, name string) (*dbus.ServiceInfo, error) {
+	if f.DescribeServiceFunc == nil {
+		var r0 *dbus.ServiceInfo
+		return r0, fmt.Errorf("%w: DescribeService", ErrNotImplemented)
+	}
+	return f.DescribeServiceFunc(ctx, name)
+}
+
+func (f *Conn) DescribeSocket(ctx context.Context, name string) (*dbus.SocketInfo, error) {
+	if f.DescribeSocketFunc == nil {
+		var r0 *dbus.SocketInfo
+		return r0, fmt.Errorf("%w: DescribeSocket", ErrNotImplemented)
+	}
+	return f.DescribeSocketFunc(ctx, name)
+}
+
+func (f *Conn) DescribeTimer(ctx context.Context, name string) (*dbus.TimerInfo, error) {
+	if f.DescribeTimerFunc == nil {
+		var r0 *dbus.TimerInfo
+		return r0, fmt.Errorf("%w: DescribeTimer", ErrNotImplemented)
+	}
+	return f.DescribeTimerFunc(ctx, name)
+}
+
+func (f *Conn) DisableUnitFiles(files []string, runtime bool) ([]dbus.DisableUnitFileChange, error) {
+	if f.DisableUnitFilesFunc == nil {
+		var r0 []dbus.DisableUnitFileChange
+		return r0, fmt.Errorf("%w: DisableUnitFiles", ErrNotImplemented)
+	}
+	return f.DisableUnitFilesFunc(files, runtime)
+}
+
+func (f *Conn) DisableUnitFilesContext(ctx context.Context, files []string, runtime bool) ([]dbus.DisableUnitFileChange, error) {
+	if f.DisableUnitFilesContextFunc == nil {
+		var r0 []dbus.DisableUnitFileChange
+		return r0, fmt.Errorf("%w: DisableUnitFilesContext", ErrNotImplemented)
+	}
+	return f.DisableUnitFilesContextFunc(ctx, files, runtime)
+}
+
+func (f *Conn) DumpByFileDescriptor(ctx context.Context) (*os.File, error) {
+	if f.DumpByFileDescriptorFunc == nil {
+		var r0 *os.File
+		return r0, fmt.Errorf("%w: DumpByFileDescriptor", ErrNotImplemented)
+	}
+	return f.DumpByFileDescriptorFunc(ctx)
+}
+
+func (f *Conn) DumpUnitsMatchingPatternsByFileDescriptor(ctx context.Context, patterns []string) (*os.File, error) {
+	if f.DumpUnitsMatchingPatternsByFileDescriptorFunc == nil {
+		var r0 *os.File
+		return r0, fmt.Errorf("%w: DumpUnitsMatchingPatternsByFileDescriptor", ErrNotImplemented)
+	}
+	return f.DumpUnitsMatchingPatternsByFileDescriptorFunc(ctx, patterns)
+}
+
+func (f *Conn) EnableUnitFiles(files []string, runtime bool, force bool) (bool, []dbus.EnableUnitFileChange, error) {
+	if f.EnableUnitFilesFunc == nil {
+		var r0 bool
+		var r1 []dbus.EnableUnitFileChange
+		return r0, r1, fmt.Errorf("%w: EnableUnitFiles", ErrNotImplemented)
+	}
+	return f.EnableUnitFilesFunc(files, runtime, force)
+}
+
+func (f *Conn) EnableUnitFilesContext(ctx context.Context, files []string, runtime bool, force bool) (bool, []dbus.EnableUnitFileChange, error) {
+	if f.EnableUnitFilesContextFunc == nil {
+		var r0 bool
+		var r1 []dbus.EnableUnitFileChange
+		return r0, r1, fmt.Errorf("%w: EnableUnitFilesContext", ErrNotImplemented)
+	}
+	return f.EnableUnitFilesContextFunc(ctx, files, runtime, force)
+}
+
+func (f *Conn) EnqueueUnitJobContext(ctx context.Context, name string, jobType string, mode dbus.JobMode, ch chan<- string) (*dbus.Transaction, error) {
+	if f.EnqueueUnitJobContextFunc == nil {
+		var r0 *dbus.Transaction
+		return r0, fmt.Errorf("%w: EnqueueUnitJobContext", ErrNotImplemented)
+	}
+	return f.EnqueueUnitJobContextFunc(ctx, name, jobType, mode, ch)
+}
+
+func (f *Conn) FreezeUnit(ctx context.Context, unit string) error {
+	if f.FreezeUnitFunc == nil {
+		return fmt.Errorf("%w: FreezeUnit", ErrNotImplemented)
+	}
+	return f.FreezeUnitFunc(ctx, unit)
+}
+
+func (f *Conn) GetAllProperties(unit string) (map[string]interface{}, error) {
+	if f.GetAllPropertiesFunc == nil {
+		var r0 map[string]interface{}
+		return r0, fmt.Errorf("%w: GetAllProperties", ErrNotImplemented)
+	}
+	return f.GetAllPropertiesFunc(unit)
+}
+
+func (f *Conn) GetAllPropertiesContext(ctx context.Context, unit string) (map[string]interface{}, error) {
+	if f.GetAllPropertiesContextFunc == nil {
+		var r0 map[string]interface{}
+		return r0, fmt.Errorf("%w: GetAllPropertiesContext", ErrNotImplemented)
+	}
+	return f.GetAllPropertiesContextFunc(ctx, unit)
+}
+
+func (f *Conn) GetArchitecture(ctx context.Context) (string, error) {
+	if f.GetArchitectureFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: GetArchitecture", ErrNotImplemented)
+	}
+	return f.GetArchitectureFunc(ctx)
+}
+
+func (f *Conn) GetControlDropIns(ctx context.Context, name string) ([]dbus.DropIn, error) {
+	if f.GetControlDropInsFunc == nil {
+		var r0 []dbus.DropIn
+		return r0, fmt.Errorf("%w: GetControlDropIns", ErrNotImplemented)
+	}
+	return f.GetControlDropInsFunc(ctx, name)
+}
+
+func (f *Conn) GetFeatures(ctx context.Context) (string, error) {
+	if f.GetFeaturesFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: GetFeatures", ErrNotImplemented)
+	}
+	return f.GetFeaturesFunc(ctx)
+}
+
+func (f *Conn) GetFinishTimestamp(ctx context.Context) (time.Time, error) {
+	if f.GetFinishTimestampFunc == nil {
+		var r0 time.Time
+		return r0, fmt.Errorf("%w: GetFinishTimestamp", ErrNotImplemented)
+	}
+	return f.GetFinishTimestampFunc(ctx)
+}
+
+func (f *Conn) GetLogLevel(ctx context.Context) (string, error) {
+	if f.GetLogLevelFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: GetLogLevel", ErrNotImplemented)
+	}
+	return f.GetLogLevelFunc(ctx)
+}
+
+func (f *Conn) GetLogTarget(ctx context.Context) (string, error) {
+	if f.GetLogTargetFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: GetLogTarget", ErrNotImplemented)
+	}
+	return f.GetLogTargetFunc(ctx)
+}
+
+func (f *Conn) GetManagerProperty(prop string) (string, error) {
+	if f.GetManagerPropertyFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: GetManagerProperty", ErrNotImplemented)
+	}
+	return f.GetManagerPropertyFunc(prop)
+}
+
+func (f *Conn) GetNNames(ctx context.Context) (uint32, error) {
+	if f.GetNNamesFunc == nil {
+		var r0 uint32
+		return r0, fmt.Errorf("%w: GetNNames", ErrNotImplemented)
+	}
+	return f.GetNNamesFunc(ctx)
+}
+
+func (f *Conn) GetServiceProperty(service string, propertyName string) (*dbus.Property, error) {
+	if f.GetServicePropertyFunc == nil {
+		var r0 *dbus.Property
+		return r0, fmt.Errorf("%w: GetServiceProperty", ErrNotImplemented)
+	}
+	return f.GetServicePropertyFunc(service, propertyName)
+}
+
+func (f *Conn) GetServicePropertyContext(ctx context.Context, service string, propertyName string) (*dbus.Property, error) {
+	if f.GetServicePropertyContextFunc == nil {
+		var r0 *dbus.Property
+		return r0, fmt.Errorf("%w: GetServicePropertyContext", ErrNotImplemented)
+	}
+	return f.GetServicePropertyContextFunc(ctx, service, propertyName)
+}
+
+func (f *Conn) GetServiceWatchdogs(ctx context.Context) (bool, error) {
+	if f.GetServiceWatchdogsFunc == nil {
+		var r0 bool
+		return r0, fmt.Errorf("%w: GetServiceWatchdogs", ErrNotImplemented)
+	}
+	return f.GetServiceWatchdogsFunc(ctx)
+}
+
+func (f *Conn) GetSystemState(ctx context.Context) (string, error) {
+	if f.GetSystemStateFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: GetSystemState", ErrNotImplemented)
+	}
+	return f.GetSystemStateFunc(ctx)
+}
+
+func (f *Conn) GetUnitByControlGroup(ctx context.Context, cgroup string) (godbus.ObjectPath, error) {
+	if f.GetUnitByControlGroupFunc == nil {
+		var r0 godbus.ObjectPath
+		return r0, fmt.Errorf("%w: GetUnitByControlGroup", ErrNotImplemented)
+	}
+	return f.GetUnitByControlGroupFunc(ctx, cgroup)
+}
+
+func (f *Conn) GetUnitByPID(ctx context.Context, pid uint32) (godbus.ObjectPath, error) {
+	if f.GetUnitByPIDFunc == nil {
+		var r0 godbus.ObjectPath
+		return r0, fmt.Errorf("%w: GetUnitByPID", ErrNotImplemented)
+	}
+	return f.GetUnitByPIDFunc(ctx, pid)
+}
+
+func (f *Conn) GetUnitDependencies(ctx context.Context, unit string, kinds ...dbus.DependencyKind) (*dbus.DependencyNode, error) {
+	if f.GetUnitDependenciesFunc == nil {
+		var r0 *dbus.DependencyNode
+		return r0, fmt.Errorf("%w: GetUnitDependencies", ErrNotImplemented)
+	}
+	return f.GetUnitDependenciesFunc(ctx, unit, kinds...)
+}
+
+func (f *Conn) GetUnitDependenciesRecursive(ctx context.Context, unit string, kinds ...dbus.DependencyKind) (*dbus.DependencyNode, error) {
+	if f.GetUnitDependenciesRecursiveFunc == nil {
+		var r0 *dbus.DependencyNode
+		return r0, fmt.Errorf("%w: GetUnitDependenciesRecursive", ErrNotImplemented)
+	}
+	return f.GetUnitDependenciesRecursiveFunc(ctx, unit, kinds...)
+}
+
+func (f *Conn) GetUnitFileStateContext(ctx context.Context, file string) (string, error) {
+	if f.GetUnitFileStateContextFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: GetUnitFileStateContext", ErrNotImplemented)
+	}
+	return f.GetUnitFileStateContextFunc(ctx, file)
+}
+
+func (f *Conn) GetUnitNameByControlGroup(ctx context.Context, cgroup string) (string, error) {
+	if f.GetUnitNameByControlGroupFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: GetUnitNameByControlGroup", ErrNotImplemented)
+	}
+	return f.GetUnitNameByControlGroupFunc(ctx, cgroup)
+}
+
+func (f *Conn) GetUnitNameByPID(ctx context.Context, pid uint32) (string, error) {
+	if f.GetUnitNameByPIDFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: GetUnitNameByPID", ErrNotImplemented)
+	}
+	return f.GetUnitNameByPIDFunc(ctx, pid)
+}
+
+func (f *Conn) GetUnitPathProperties(path godbus.ObjectPath) (map[string]interface{}, error) {
+	if f.GetUnitPathPropertiesFunc == nil {
+		var r0 map[string]interface{}
+		return r0, fmt.Errorf("%w: GetUnitPathProperties", ErrNotImplemented)
+	}
+	return f.GetUnitPathPropertiesFunc(path)
+}
+
+func (f *Conn) GetUnitPathPropertiesContext(ctx context.Context, path godbus.ObjectPath) (map[string]interface{}, error) {
+	if f.GetUnitPathPropertiesContextFunc == nil {
+		var r0 map[string]interface{}
+		return r0, fmt.Errorf("%w: GetUnitPathPropertiesContext", ErrNotImplemented)
+	}
+	return f.GetUnitPathPropertiesContextFunc(ctx, path)
+}
+
+func (f *Conn) GetUnitProcesses(ctx context.Context, unit string) ([]dbus.UnitProcess, error) {
+	if f.GetUnitProcessesFunc == nil {
+		var r0 []dbus.UnitProcess
+		return r0, fmt.Errorf("%w: GetUnitProcesses", ErrNotImplemented)
+	}
+	return f.GetUnitProcessesFunc(ctx, unit)
+}
+
+func (f *Conn) GetUnitProperties(unit string) (map[string]interface{}, error) {
+	if f.GetUnitPropertiesFunc == nil {
+		var r0 map[string]interface{}
+		return r0, fmt.Errorf("%w: GetUnitProperties", ErrNotImplemented)
+	}
+	return f.GetUnitPropertiesFunc(unit)
+}
+
+func (f *Conn) GetUnitPropertiesContext(ctx context.Context, unit string) (map[string]interface{}, error) {
+	if f.GetUnitPropertiesContextFunc == nil {
+		var r0 map[string]interface{}
+		return r0, fmt.Errorf("%w: GetUnitPropertiesContext", ErrNotImplemented)
+	}
+	return f.GetUnitPropertiesContextFunc(ctx, unit)
+}
+
+func (f *Conn) GetUnitProperty(unit string, propertyName string) (*dbus.Property, error) {
+	if f.GetUnitPropertyFunc == nil {
+		var r0 *dbus.Property
+		return r0, fmt.Errorf("%w: GetUnitProperty", ErrNotImplemented)
+	}
+	return f.GetUnitPropertyFunc(unit, propertyName)
+}
+
+func (f *Conn) GetUnitPropertyContext(ctx context.Context, unit string, propertyName string) (*dbus.Property, error) {
+	if f.GetUnitPropertyContextFunc == nil {
+		var r0 *dbus.Property
+		return r0, fmt.Errorf("%w: GetUnitPropertyContext", ErrNotImplemented)
+	}
+	return f.GetUnitPropertyContextFunc(ctx, unit, propertyName)
+}
+
+func (f *Conn) GetUnitTypeProperties(unit string, unitType string) (map[string]interface{}, error) {
+	if f.GetUnitTypePropertiesFunc == nil {
+		var r0 map[string]interface{}
+		return r0, fmt.Errorf("%w: GetUnitTypeProperties", ErrNotImplemented)
+	}
+	return f.GetUnitTypePropertiesFunc(unit, unitType)
+}
+
+func (f *Conn) GetUnitTypePropertiesContext(ctx context.Context, unit string, unitType string) (map[string]interface{}, error) {
+	if f.GetUnitTypePropertiesContextFunc == nil {
+		var r0 map[string]interface{}
+		return r0, fmt.Errorf("%w: GetUnitTypePropertiesContext", ErrNotImplemented)
+	}
+	return f.GetUnitTypePropertiesContextFunc(ctx, unit, unitType)
+}
+
+func (f *Conn) GetUnitTypeProperty(unit string, unitType string, propertyName string) (*dbus.Property, error) {
+	if f.GetUnitTypePropertyFunc == nil {
+		var r0 *dbus.Property
+		return r0, fmt.Errorf("%w: GetUnitTypeProperty", ErrNotImplemented)
+	}
+	return f.GetUnitTypePropertyFunc(unit, unitType, propertyName)
+}
+
+func (f *Conn) GetUnitTypePropertyContext(ctx context.Context, unit string, unitType string, propertyName string) (*dbus.Property, error) {
+	if f.GetUnitTypePropertyContextFunc == nil {
+		var r0 *dbus.Property
+		return r0, fmt.Errorf("%w: GetUnitTypePropertyContext", ErrNotImplemented)
+	}
+	return f.GetUnitTypePropertyContextFunc(ctx, unit, unitType, propertyName)
+}
+
+func (f *Conn) GetUnitsProperties(ctx context.Context, names []string, properties []string) ([]dbus.UnitProperties, error) {
+	if f.GetUnitsPropertiesFunc == nil {
+		var r0 []dbus.UnitProperties
+		return r0, fmt.Errorf("%w: GetUnitsProperties", ErrNotImplemented)
+	}
+	return f.GetUnitsPropertiesFunc(ctx, names, properties)
+}
+
+func (f *Conn) GetUserspaceTimestamp(ctx context.Context) (time.Time, error) {
+	if f.GetUserspaceTimestampFunc == nil {
+		var r0 time.Time
+		return r0, fmt.Errorf("%w: GetUserspaceTimestamp", ErrNotImplemented)
+	}
+	return f.GetUserspaceTimestampFunc(ctx)
+}
+
+func (f *Conn) GetVersion(ctx context.Context) (string, error) {
+	if f.GetVersionFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: GetVersion", ErrNotImplemented)
+	}
+	return f.GetVersionFunc(ctx)
+}
+
+func (f *Conn) GetVirtualization(ctx context.Context) (string, error) {
+	if f.GetVirtualizationFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: GetVirtualization", ErrNotImplemented)
+	}
+	return f.GetVirtualizationFunc(ctx)
+}
+
+func (f *Conn) KillUnit(name string, signal int32) {
+	if f.KillUnitFunc != nil {
+		f.KillUnitFunc(name, signal)
+	}
+}
+
+func (f *Conn) KillUnitContext(ctx context.Context, name string, signal int32) {
+	if f.KillUnitContextFunc != nil {
+		f.KillUnitContextFunc(ctx, name, signal)
+	}
+}
+
+func (f *Conn) KillUnitWithSubgroup(ctx context.Context, name string, subcgroup string, signal int32) error {
+	if f.KillUnitWithSubgroupFunc == nil {
+		return fmt.Errorf("%w: KillUnitWithSubgroup", ErrNotImplemented)
+	}
+	return f.KillUnitWithSubgroupFunc(ctx, name, subcgroup, signal)
+}
+
+func (f *Conn) KillUnitWithTarget(ctx context.Context, name string, target dbus.Who, signal int32) error {
+	if f.KillUnitWithTargetFunc == nil {
+		return fmt.Errorf("%w: KillUnitWithTarget", ErrNotImplemented)
+	}
+	return f.KillUnitWithTargetFunc(ctx, name, target, signal)
+}
+
+func (f *Conn) LinkUnitFiles(files []string, runtime bool, force bool) ([]dbus.LinkUnitFileChange, error) {
+	if f.LinkUnitFilesFunc == nil {
+		var r0 []dbus.LinkUnitFileChange
+		return r0, fmt.Errorf("%w: LinkUnitFiles", ErrNotImplemented)
+	}
+	return f.LinkUnitFilesFunc(files, runtime, force)
+}
+
+func (f *Conn) LinkUnitFilesContext(ctx context.Context, files []string, runtime bool, force bool) ([]dbus.LinkUnitFileChange, error) {
+	if f.LinkUnitFilesContextFunc == nil {
+		var r0 []dbus.LinkUnitFileChange
+		return r0, fmt.Errorf("%w: LinkUnitFilesContext", ErrNotImplemented)
+	}
+	return f.LinkUnitFilesContextFunc(ctx, files, runtime, force)
+}
+
+func (f *Conn) ListJobs() ([]dbus.JobStatus, error) {
+	if f.ListJobsFunc == nil {
+		var r0 []dbus.JobStatus
+		return r0, fmt.Errorf("%w: ListJobs", ErrNotImplemented)
+	}
+	return f.ListJobsFunc()
+}
+
+func (f *Conn) ListJobsContext(ctx context.Context) ([]dbus.JobStatus, error) {
+	if f.ListJobsContextFunc == nil {
+		var r0 []dbus.JobStatus
+		return r0, fmt.Errorf("%w: ListJobsContext", ErrNotImplemented)
+	}
+	return f.ListJobsContextFunc(ctx)
+}
+
+func (f *Conn) ListUnitFiles() ([]dbus.UnitFile, error) {
+	if f.ListUnitFilesFunc == nil {
+		var r0 []dbus.UnitFile
+		return r0, fmt.Errorf("%w: ListUnitFiles", ErrNotImplemented)
+	}
+	return f.ListUnitFilesFunc()
+}
+
+func (f *Conn) ListUnitFilesByPatterns(states []string, patterns []string) ([]dbus.UnitFile, error) {
+	if f.ListUnitFilesByPatternsFunc == nil {
+		var r0 []dbus.UnitFile
+		return r0, fmt.Errorf("%w: ListUnitFilesByPatterns", ErrNotImplemented)
+	}
+	return f.ListUnitFilesByPatternsFunc(states, patterns)
+}
+
+func (f *Conn) ListUnitFilesByPatternsContext(ctx context.Context, states []string, patterns []string) ([]dbus.UnitFile, error) {
+	if f.ListUnitFilesByPatternsContextFunc == nil {
+		var r0 []dbus.UnitFile
+		return r0, fmt.Errorf("%w: ListUnitFilesByPatternsContext", ErrNotImplemented)
+	}
+	return f.ListUnitFilesByPatternsContextFunc(ctx, states, patterns)
+}
+
+func (f *Conn) ListUnitFilesContext(ctx context.Context) ([]dbus.UnitFile, error) {
+	if f.ListUnitFilesContextFunc == nil {
+		var r0 []dbus.UnitFile
+		return r0, fmt.Errorf("%w: ListUnitFilesContext", ErrNotImplemented)
+	}
+	return f.ListUnitFilesContextFunc(ctx)
+}
+
+func (f *Conn) ListUnits() ([]dbus.UnitStatus, error) {
+	if f.ListUnitsFunc == nil {
+		var r0 []dbus.UnitStatus
+		return r0, fmt.Errorf("%w: ListUnits", ErrNotImplemented)
+	}
+	return f.ListUnitsFunc()
+}
+
+func (f *Conn) ListUnitsByNames(units []string) ([]dbus.UnitStatus, error) {
+	if f.ListUnitsByNamesFunc == nil {
+		var r0 []dbus.UnitStatus
+		return r0, fmt.Errorf("%w: ListUnitsByNames", ErrNotImplemented)
+	}
+	return f.ListUnitsByNamesFunc(units)
+}
+
+func (f *Conn) ListUnitsByNamesContext(ctx context.Context, units []string) ([]dbus.UnitStatus, error) {
+	if f.ListUnitsByNamesContextFunc == nil {
+		var r0 []dbus.UnitStatus
+		return r0, fmt.Errorf("%w: ListUnitsByNamesContext", ErrNotImplemented)
+	}
+	return f.ListUnitsByNamesContextFunc(ctx, units)
+}
+
+func (f *Conn) ListUnitsByPatterns(states []string, patterns []string) ([]dbus.UnitStatus, error) {
+	if f.ListUnitsByPatternsFunc == nil {
+		var r0 []dbus.UnitStatus
+		return r0, fmt.Errorf("%w: ListUnitsByPatterns", ErrNotImplemented)
+	}
+	return f.ListUnitsByPatternsFunc(states, patterns)
+}
+
+func (f *Conn) ListUnitsByPatternsContext(ctx context.Context, states []string, patterns []string) ([]dbus.UnitStatus, error) {
+	if f.ListUnitsByPatternsContextFunc == nil {
+		var r0 []dbus.UnitStatus
+		return r0, fmt.Errorf("%w: ListUnitsByPatternsContext", ErrNotImplemented)
+	}
+	return f.ListUnitsByPatternsContextFunc(ctx, states, patterns)
+}
+
+func (f *Conn) ListUnitsContext(ctx context.Context) ([]dbus.UnitStatus, error) {
+	if f.ListUnitsContextFunc == nil {
+		var r0 []dbus.UnitStatus
+		return r0, fmt.Errorf("%w: ListUnitsContext", ErrNotImplemented)
+	}
+	return f.ListUnitsContextFunc(ctx)
+}
+
+func (f *Conn) ListUnitsFiltered(states []string) ([]dbus.UnitStatus, error) {
+	if f.ListUnitsFilteredFunc == nil {
+		var r0 []dbus.UnitStatus
+		return r0, fmt.Errorf("%w: ListUnitsFiltered", ErrNotImplemented)
+	}
+	return f.ListUnitsFilteredFunc(states)
+}
+
+func (f *Conn) ListUnitsFilteredContext(ctx context.Context, states []string) ([]dbus.UnitStatus, error) {
+	if f.ListUnitsFilteredContextFunc == nil {
+		var r0 []dbus.UnitStatus
+		return r0, fmt.Errorf("%w: ListUnitsFilteredContext", ErrNotImplemented)
+	}
+	return f.ListUnitsFilteredContextFunc(ctx, states)
+}
+
+func (f *Conn) ListUnitsMatchingContext(ctx context.Context, filter dbus.UnitFilter) ([]dbus.UnitStatus, error) {
+	if f.ListUnitsMatchingContextFunc == nil {
+		var r0 []dbus.UnitStatus
+		return r0, fmt.Errorf("%w: ListUnitsMatchingContext", ErrNotImplemented)
+	}
+	return f.ListUnitsMatchingContextFunc(ctx, filter)
+}
+
+func (f *Conn) LoadUnit(ctx context.Context, name string) (*dbus.UnitLoadInfo, error) {
+	if f.LoadUnitFunc == nil {
+		var r0 *dbus.UnitLoadInfo
+		return r0, fmt.Errorf("%w: LoadUnit", ErrNotImplemented)
+	}
+	return f.LoadUnitFunc(ctx, name)
+}
+
+func (f *Conn) MaskUnitFiles(files []string, runtime bool, force bool) ([]dbus.MaskUnitFileChange, error) {
+	if f.MaskUnitFilesFunc == nil {
+		var r0 []dbus.MaskUnitFileChange
+		return r0, fmt.Errorf("%w: MaskUnitFiles", ErrNotImplemented)
+	}
+	return f.MaskUnitFilesFunc(files, runtime, force)
+}
+
+func (f *Conn) MaskUnitFilesContext(ctx context.Context, files []string, runtime bool, force bool) ([]dbus.MaskUnitFileChange, error) {
+	if f.MaskUnitFilesContextFunc == nil {
+		var r0 []dbus.MaskUnitFileChange
+		return r0, fmt.Errorf("%w: MaskUnitFilesContext", ErrNotImplemented)
+	}
+	return f.MaskUnitFilesContextFunc(ctx, files, runtime, force)
+}
+
+func (f *Conn) NewSubscriptionSet() *dbus.SubscriptionSet {
+	if f.NewSubscriptionSetFunc == nil {
+		var r0 *dbus.SubscriptionSet
+		return r0
+	}
+	return f.NewSubscriptionSetFunc()
+}
+
+func (f *Conn) ReexecuteContext(ctx context.Context) error {
+	if f.ReexecuteContextFunc == nil {
+		return fmt.Errorf("%w: ReexecuteContext", ErrNotImplemented)
+	}
+	return f.ReexecuteContextFunc(ctx)
+}
+
+func (f *Conn) Reload() error {
+	if f.ReloadFunc == nil {
+		return fmt.Errorf("%w: Reload", ErrNotImplemented)
+	}
+	return f.ReloadFunc()
+}
+
+func (f *Conn) ReloadContext(ctx context.Context) error {
+	if f.ReloadContextFunc == nil {
+		return fmt.Errorf("%w: ReloadContext", ErrNotImplemented)
+	}
+	return f.ReloadContextFunc(ctx)
+}
+
+func (f *Conn) ReloadOrRestartUnit(name string, mode dbus.JobMode, ch chan<- string) (int, error) {
+	if f.ReloadOrRestartUnitFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: ReloadOrRestartUnit", ErrNotImplemented)
+	}
+	return f.ReloadOrRestartUnitFunc(name, mode, ch)
+}
+
+func (f *Conn) ReloadOrRestartUnitContext(ctx context.Context, name string, mode dbus.JobMode, ch chan<- string) (int, error) {
+	if f.ReloadOrRestartUnitContextFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: ReloadOrRestartUnitContext", ErrNotImplemented)
+	}
+	return f.ReloadOrRestartUnitContextFunc(ctx, name, mode, ch)
+}
+
+func (f *Conn) ReloadOrTryRestartUnit(name string, mode dbus.JobMode, ch chan<- string) (int, error) {
+	if f.ReloadOrTryRestartUnitFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: ReloadOrTryRestartUnit", ErrNotImplemented)
+	}
+	return f.ReloadOrTryRestartUnitFunc(name, mode, ch)
+}
+
+func (f *Conn) ReloadOrTryRestartUnitContext(ctx context.Context, name string, mode dbus.JobMode, ch chan<- string) (int, error) {
+	if f.ReloadOrTryRestartUnitContextFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: ReloadOrTryRestartUnitContext", ErrNotImplemented)
+	}
+	return f.ReloadOrTryRestartUnitContextFunc(ctx, name, mode, ch)
+}
+
+func (f *Conn) ReloadUnit(name string, mode dbus.JobMode, ch chan<- string) (int, error) {
+	if f.ReloadUnitFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: ReloadUnit", ErrNotImplemented)
+	}
+	return f.ReloadUnitFunc(name, mode, ch)
+}
+
+func (f *Conn) ReloadUnitContext(ctx context.Context, name string, mode dbus.JobMode, ch chan<- string) (int, error) {
+	if f.ReloadUnitContextFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: ReloadUnitContext", ErrNotImplemented)
+	}
+	return f.ReloadUnitContextFunc(ctx, name, mode, ch)
+}
+
+func (f *Conn) ResetFailedAndRestartUnitContext(ctx context.Context, name string, mode dbus.JobMode) (string, error) {
+	if f.ResetFailedAndRestartUnitContextFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: ResetFailedAndRestartUnitContext", ErrNotImplemented)
+	}
+	return f.ResetFailedAndRestartUnitContextFunc(ctx, name, mode)
+}
+
+func (f *Conn) ResetFailedContext(ctx context.Context) error {
+	if f.ResetFailedContextFunc == nil {
+		return fmt.Errorf("%w: ResetFailedContext", ErrNotImplemented)
+	}
+	return f.ResetFailedContextFunc(ctx)
+}
+
+func (f *Conn) ResetFailedUnit(name string) error {
+	if f.ResetFailedUnitFunc == nil {
+		return fmt.Errorf("%w: ResetFailedUnit", ErrNotImplemented)
+	}
+	return f.ResetFailedUnitFunc(name)
+}
+
+func (f *Conn) ResetFailedUnitContext(ctx context.Context, name string) error {
+	if f.ResetFailedUnitContextFunc == nil {
+		return fmt.Errorf("%w: ResetFailedUnitContext", ErrNotImplemented)
+	}
+	return f.ResetFailedUnitContextFunc(ctx, name)
+}
+
+func (f *Conn) RestartUnit(name string, mode dbus.JobMode, ch chan<- string) (int, error) {
+	if f.RestartUnitFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: RestartUnit", ErrNotImplemented)
+	}
+	return f.RestartUnitFunc(name, mode, ch)
+}
+
+func (f *Conn) RestartUnitContext(ctx context.Context, name string, mode dbus.JobMode, ch chan<- string) (int, error) {
+	if f.RestartUnitContextFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: RestartUnitContext", ErrNotImplemented)
+	}
+	return f.RestartUnitContextFunc(ctx, name, mode, ch)
+}
+
+func (f *Conn) RestartUnits(ctx context.Context, names []string, opts dbus.RestartOptions) (*dbus.RestartReport, error) {
+	if f.RestartUnitsFunc == nil {
+		var r0 *dbus.RestartReport
+		return r0, fmt.Errorf("%w: RestartUnits", ErrNotImplemented)
+	}
+	return f.RestartUnitsFunc(ctx, names, opts)
+}
+
+func (f *Conn) RunTransientCommand(ctx context.Context, argv []string, opts dbus.RunOptions) (*dbus.RunResult, error) {
+	if f.RunTransientCommandFunc == nil {
+		var r0 *dbus.RunResult
+		return r0, fmt.Errorf("%w: RunTransientCommand", ErrNotImplemented)
+	}
+	return f.RunTransientCommandFunc(ctx, argv, opts)
+}
+
+func (f *Conn) Scope() dbus.ManagerScope {
+	if f.ScopeFunc == nil {
+		var r0 dbus.ManagerScope
+		return r0
+	}
+	return f.ScopeFunc()
+}
+
+func (f *Conn) SetHook(hook instrument.Hook) {
+	if f.SetHookFunc != nil {
+		f.SetHookFunc(hook)
+	}
+}
+
+func (f *Conn) SetLogLevel(ctx context.Context, level string) error {
+	if f.SetLogLevelFunc == nil {
+		return fmt.Errorf("%w: SetLogLevel", ErrNotImplemented)
+	}
+	return f.SetLogLevelFunc(ctx, level)
+}
+
+func (f *Conn) SetLogTarget(ctx context.Context, target string) error {
+	if f.SetLogTargetFunc == nil {
+		return fmt.Errorf("%w: SetLogTarget", ErrNotImplemented)
+	}
+	return f.SetLogTargetFunc(ctx, target)
+}
+
+func (f *Conn) SetPropertiesSubscriber(updateCh chan<- *dbus.PropertiesUpdate, errCh chan<- error) {
+	if f.SetPropertiesSubscriberFunc != nil {
+		f.SetPropertiesSubscriberFunc(updateCh, errCh)
+	}
+}
+
+func (f *Conn) SetServiceWatchdogs(ctx context.Context, enabled bool) error {
+	if f.SetServiceWatchdogsFunc == nil {
+		return fmt.Errorf("%w: SetServiceWatchdogs", ErrNotImplemented)
+	}
+	return f.SetServiceWatchdogsFunc(ctx, enabled)
+}
+
+func (f *Conn) SetSubStateSubscriber(updateCh chan<- *dbus.SubStateUpdate, errCh chan<- error) {
+	if f.SetSubStateSubscriberFunc != nil {
+		f.SetSubStateSubscriberFunc(updateCh, errCh)
+	}
+}
+
+func (f *Conn) SetTimeout(timeout time.Duration) {
+	if f.SetTimeoutFunc != nil {
+		f.SetTimeoutFunc(timeout)
+	}
+}
+
+func (f *Conn) SetUnitProperties(name string, runtime bool, properties ...dbus.Property) error {
+	if f.SetUnitPropertiesFunc == nil {
+		return fmt.Errorf("%w: SetUnitProperties", ErrNotImplemented)
+	}
+	return f.SetUnitPropertiesFunc(name, runtime, properties...)
+}
+
+func (f *Conn) SetUnitPropertiesContext(ctx context.Context, name string, runtime bool, properties ...dbus.Property) error {
+	if f.SetUnitPropertiesContextFunc == nil {
+		return fmt.Errorf("%w: SetUnitPropertiesContext", ErrNotImplemented)
+	}
+	return f.SetUnitPropertiesContextFunc(ctx, name, runtime, properties...)
+}
+
+func (f *Conn) SetUnitPropertiesPersistent(ctx context.Context, name string, properties ...dbus.Property) ([]dbus.DropIn, error) {
+	if f.SetUnitPropertiesPersistentFunc == nil {
+		var r0 []dbus.DropIn
+		return r0, fmt.Errorf("%w: SetUnitPropertiesPersistent", ErrNotImplemented)
+	}
+	return f.SetUnitPropertiesPersistentFunc(ctx, name, properties...)
+}
+
+func (f *Conn) SetUnitResources(ctx context.Context, unit string, spec dbus.ResourceSpec) error {
+	if f.SetUnitResourcesFunc == nil {
+		return fmt.Errorf("%w: SetUnitResources", ErrNotImplemented)
+	}
+	return f.SetUnitResourcesFunc(ctx, unit, spec)
+}
+
+func (f *Conn) SnapshotUnits(ctx context.Context, patterns []string, properties []string) (*dbus.UnitsSnapshot, error) {
+	if f.SnapshotUnitsFunc == nil {
+		var r0 *dbus.UnitsSnapshot
+		return r0, fmt.Errorf("%w: SnapshotUnits", ErrNotImplemented)
+	}
+	return f.SnapshotUnitsFunc(ctx, patterns, properties)
+}
+
+func (f *Conn) StartTransientUnit(name string, mode dbus.JobMode, properties []dbus.Property, ch chan<- string) (int, error) {
+	if f.StartTransientUnitFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: StartTransientUnit", ErrNotImplemented)
+	}
+	return f.StartTransientUnitFunc(name, mode, properties, ch)
+}
+
+func (f *Conn) StartTransientUnitContext(ctx context.Context, name string, mode dbus.JobMode, properties []dbus.Property, ch chan<- string) (int, error) {
+	if f.StartTransientUnitContextFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: StartTransientUnitContext", ErrNotImplemented)
+	}
+	return f.StartTransientUnitContextFunc(ctx, name, mode, properties, ch)
+}
+
+func (f *Conn) StartUnit(name string, mode dbus.JobMode, ch chan<- string) (int, error) {
+	if f.StartUnitFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: StartUnit", ErrNotImplemented)
+	}
+	return f.StartUnitFunc(name, mode, ch)
+}
+
+func (f *Conn) StartUnitContext(ctx context.Context, name string, mode dbus.JobMode, ch chan<- string) (int, error) {
+	if f.StartUnitContextFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: StartUnitContext", ErrNotImplemented)
+	}
+	return f.StartUnitContextFunc(ctx, name, mode, ch)
+}
+
+func (f *Conn) StopUnit(name string, mode dbus.JobMode, ch chan<- string) (int, error) {
+	if f.StopUnitFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: StopUnit", ErrNotImplemented)
+	}
+	return f.StopUnitFunc(name, mode, ch)
+}
+
+func (f *Conn) StopUnitContext(ctx context.Context, name string, mode dbus.JobMode, ch chan<- string) (int, error) {
+	if f.StopUnitContextFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: StopUnitContext", ErrNotImplemented)
+	}
+	return f.StopUnitContextFunc(ctx, name, mode, ch)
+}
+
+func (f *Conn) Subscribe() error {
+	if f.SubscribeFunc == nil {
+		return fmt.Errorf("%w: Subscribe", ErrNotImplemented)
+	}
+	return f.SubscribeFunc()
+}
+
+func (f *Conn) SubscribeUnits(interval time.Duration) (<-chan map[string]*dbus.UnitStatus, <-chan error) {
+	if f.SubscribeUnitsFunc == nil {
+		var r0 <-chan map[string]*dbus.UnitStatus
+		var r1 <-chan error
+		return r0, r1
+	}
+	return f.SubscribeUnitsFunc(interval)
+}
+
+func (f *Conn) SubscribeUnitsCustom(interval time.Duration, buffer int, isChanged func(*dbus.UnitStatus, *dbus.UnitStatus) bool, filterUnit func(string) bool) (<-chan map[string]*dbus.UnitStatus, <-chan error) {
+	if f.SubscribeUnitsCustomFunc == nil {
+		var r0 <-chan map[string]*dbus.UnitStatus
+		var r1 <-chan error
+		return r0, r1
+	}
+	return f.SubscribeUnitsCustomFunc(interval, buffer, isChanged, filterUnit)
+}
+
+func (f *Conn) SystemState() (*dbus.Property, error) {
+	if f.SystemStateFunc == nil {
+		var r0 *dbus.Property
+		return r0, fmt.Errorf("%w: SystemState", ErrNotImplemented)
+	}
+	return f.SystemStateFunc()
+}
+
+func (f *Conn) SystemStateContext(ctx context.Context) (*dbus.Property, error) {
+	if f.SystemStateContextFunc == nil {
+		var r0 *dbus.Property
+		return r0, fmt.Errorf("%w: SystemStateContext", ErrNotImplemented)
+	}
+	return f.SystemStateContextFunc(ctx)
+}
+
+func (f *Conn) ThawUnit(ctx context.Context, unit string) error {
+	if f.ThawUnitFunc == nil {
+		return fmt.Errorf("%w: ThawUnit", ErrNotImplemented)
+	}
+	return f.ThawUnitFunc(ctx, unit)
+}
+
+func (f *Conn) TryRestartUnit(name string, mode dbus.JobMode, ch chan<- string) (int, error) {
+	if f.TryRestartUnitFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: TryRestartUnit", ErrNotImplemented)
+	}
+	return f.TryRestartUnitFunc(name, mode, ch)
+}
+
+func (f *Conn) TryRestartUnitContext(ctx context.Context, name string, mode dbus.JobMode, ch chan<- string) (int, error) {
+	if f.TryRestartUnitContextFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: TryRestartUnitContext", ErrNotImplemented)
+	}
+	return f.TryRestartUnitContextFunc(ctx, name, mode, ch)
+}
+
+func (f *Conn) UnitExists(ctx context.Context, name string) (bool, error) {
+	if f.UnitExistsFunc == nil {
+		var r0 bool
+		return r0, fmt.Errorf("%w: UnitExists", ErrNotImplemented)
+	}
+	return f.UnitExistsFunc(ctx, name)
+}
+
+func (f *Conn) UnmaskUnitFiles(files []string, runtime bool) ([]dbus.UnmaskUnitFileChange, error) {
+	if f.UnmaskUnitFilesFunc == nil {
+		var r0 []dbus.UnmaskUnitFileChange
+		return r0, fmt.Errorf("%w: UnmaskUnitFiles", ErrNotImplemented)
+	}
+	return f.UnmaskUnitFilesFunc(files, runtime)
+}
+
+func (f *Conn) UnmaskUnitFilesContext(ctx context.Context, files []string, runtime bool) ([]dbus.UnmaskUnitFileChange, error) {
+	if f.UnmaskUnitFilesContextFunc == nil {
+		var r0 []dbus.UnmaskUnitFileChange
+		return r0, fmt.Errorf("%w: UnmaskUnitFilesContext", ErrNotImplemented)
+	}
+	return f.UnmaskUnitFilesContextFunc(ctx, files, runtime)
+}
+
+func (f *Conn) Unsubscribe() error {
+	if f.UnsubscribeFunc == nil {
+		return fmt.Errorf("%w: Unsubscribe", ErrNotImplemented)
+	}
+	return f.UnsubscribeFunc()
+}
+
+func (f *Conn) WaitForIdle(ctx context.Context) error {
+	if f.WaitForIdleFunc == nil {
+		return fmt.Errorf("%w: WaitForIdle", ErrNotImplemented)
+	}
+	return f.WaitForIdleFunc(ctx)
+}
+
+func (f *Conn) WaitForUnitState(ctx context.Context, unit string, target dbus.ActiveState) error {
+	if f.WaitForUnitStateFunc == nil {
+		return fmt.Errorf("%w: WaitForUnitState", ErrNotImplemented)
+	}
+	return f.WaitForUnitStateFunc(ctx, unit, target)
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"sort"
+)
+
+// DependencyKind is the name of a unit dependency property.
+type DependencyKind string
+
+const (
+	DependencyRequires DependencyKind = "Requires"
+	DependencyWants    DependencyKind = "Wants"
+	DependencyBindsTo  DependencyKind = "BindsTo"
+	DependencyPartOf   DependencyKind = "PartOf"
+	DependencyAfter    DependencyKind = "After"
+	DependencyBefore   DependencyKind = "Before"
+)
+
+// defaultDependencyKinds mirrors the dependencies shown by
+// systemctl list-dependencies.
+var defaultDependencyKinds = []DependencyKind{DependencyRequires, DependencyWants, DependencyBindsTo}
+
+// DependencyNode is a unit in a dependency graph.
+type DependencyNode struct {
+	Name         string            // The unit name
+	Kind         DependencyKind    // The kind of dependency the parent unit has on this unit, empty for the root
+	Dependencies []*DependencyNode // The dependencies of the unit, ordered by kind and then by name
+}
+
+// GetUnitDependencies returns the direct dependencies of the given kinds of
+// the specified unit. If no kinds are given, Requires, Wants and BindsTo are
+// used, like systemctl list-dependencies.
+func (c *Conn) GetUnitDependencies(ctx context.Context, unit string, kinds ...DependencyKind) (*DependencyNode, error) {
+	return c.getDependencies(ctx, unit, false, kinds)
+}
+
+// GetUnitDependenciesRecursive is like GetUnitDependencies, but also resolves
+// the dependencies of each dependency, building the full dependency tree of
+// the unit. Every unit is expanded only once: subsequent occurrences of a unit
+// in the tree, including those forming a cycle, have no Dependencies.
+func (c *Conn) GetUnitDependenciesRecursive(ctx context.Context, unit string, kinds ...DependencyKind) (*DependencyNode, error) {
+	return c.getDependencies(ctx, unit, true, kinds)
+}
+
+func (c *Conn) getDependencies(ctx context.Context, unit string, recursive bool, kinds []DependencyKind) (*DependencyNode, error) {
+	if len(kinds) == 0 {
+		kinds = defaultDependencyKinds
+	}
+
+	root := &DependencyNode{Name: unit}
+	visited := map[string]bool{unit: true}
+	queue := []*DependencyNode{root}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		props, err := c.GetUnitPropertiesContext(ctx, node.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		node.Dependencies = dependenciesFromProperties(props, kinds)
+		if !recursive {
+			break
+		}
+
+		for _, dep := range node.Dependencies {
+			if visited[dep.Name] {
+				continue
+			}
+			visited[dep.Name] = true
+			queue = append(queue, dep)
+		}
+	}
+
+	return root, nil
+}
+
+// dependenciesFromProperties returns the dependencies of the given kinds
+// listed in the unit properties.
+func dependenciesFromProperties(props map[string]interface{}, kinds []DependencyKind) []*DependencyNode {
+	var deps []*DependencyNode
+	for _, kind := range kinds {
+		names, _ := props[string(kind)].([]string)
+		names = append([]string(nil), names...)
+		sort.Strings(names)
+
+		for _, name := range names {
+			deps = append(deps, &DependencyNode{Name: name, Kind: kind})
+		}
+	}
+
+	return deps
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDependenciesFromProperties(t *testing.T) {
+	props := map[string]interface{}{
+		"Requires": []string{"sysinit.target", "-.mount"},
+		"Wants":    []string{"network.target"},
+		"After":    []string{"basic.target"},
+	}
+
+	deps := dependenciesFromProperties(props, []DependencyKind{DependencyWants, DependencyRequires, DependencyBindsTo})
+
+	expected := []DependencyNode{
+		{Name: "network.target", Kind: DependencyWants},
+		{Name: "-.mount", Kind: DependencyRequires},
+		{Name: "sysinit.target", Kind: DependencyRequires},
+	}
+	if len(deps) != len(expected) {
+		t.Fatalf("expected %d dependencies, got %d", len(expected), len(deps))
+	}
+	for i, e := range expected {
+		if deps[i].Name != e.Name || deps[i].Kind != e.Kind {
+			t.Fatalf("dependency %d: expected %s %s, got %s %s", i, e.Kind, e.Name, deps[i].Kind, deps[i].Name)
+		}
+	}
+}
+
+func TestGetUnitDependencies(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	ctx := context.Background()
+
+	tree, err := conn.GetUnitDependencies(ctx, "multi-user.target")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tree.Dependencies) == 0 {
+		t.Fatal("multi-user.target has no dependencies")
+	}
+	for _, dep := range tree.Dependencies {
+		if dep.Dependencies != nil {
+			t.Fatalf("dependency %s expanded without recursion", dep.Name)
+		}
+	}
+
+	tree, err = conn.GetUnitDependenciesRecursive(ctx, "multi-user.target", DependencyRequires)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	var walk func(n *DependencyNode)
+	walk = func(n *DependencyNode) {
+		if n.Name == "basic.target" {
+			found = true
+		}
+		for _, dep := range n.Dependencies {
+			walk(dep)
+		}
+	}
+	walk(tree)
+	if !found {
+		t.Fatal("basic.target not found in the dependency tree of multi-user.target")
+	}
+}
@@ -0,0 +1,419 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// ExecCommand is an entry of an Exec* property (e.g. ExecStart) together with
+// the status of its last invocation.
+type ExecCommand struct {
+	Path           string    // The binary path to execute
+	Args           []string  // The arguments to pass to the executed command, starting with argument 0
+	IgnoreErrors   bool      // Whether a non-zero exit of the command is ignored
+	StartTimestamp time.Time // When the command was last started, zero if never
+	ExitTimestamp  time.Time // When the command last exited, zero if never or still running
+	PID            uint32    // The PID of the last invocation
+	Code           int32     // The CLD_* exit code of the last invocation
+	Status         int32     // The exit status or signal of the last invocation
+}
+
+// execCommand is the wire format of an Exec* property entry, a(sasbttttuii).
+type execCommand struct {
+	Path           string
+	Args           []string
+	IgnoreErrors   bool
+	StartRealtime  uint64
+	StartMonotonic uint64
+	ExitRealtime   uint64
+	ExitMonotonic  uint64
+	PID            uint32
+	Code           int32
+	Status         int32
+}
+
+// propertyDecoder decodes a property value into a field.
+type propertyDecoder interface {
+	store(v dbus.Variant) error
+}
+
+// plain decodes a property into dst with dbus.Variant.Store.
+type plain struct {
+	dst interface{}
+}
+
+func (p plain) store(v dbus.Variant) error {
+	return v.Store(p.dst)
+}
+
+// execCommands decodes the wire format into dst.
+type execCommands struct {
+	dst *[]ExecCommand
+}
+
+func (e execCommands) store(v dbus.Variant) error {
+	var cmds []execCommand
+	if err := v.Store(&cmds); err != nil {
+		return err
+	}
+
+	*e.dst = make([]ExecCommand, len(cmds))
+	for i, cmd := range cmds {
+		(*e.dst)[i] = ExecCommand{
+			Path:           cmd.Path,
+			Args:           cmd.Args,
+			IgnoreErrors:   cmd.IgnoreErrors,
+			StartTimestamp: usecToTime(cmd.StartRealtime),
+			ExitTimestamp:  usecToTime(cmd.ExitRealtime),
+			PID:            cmd.PID,
+			Code:           cmd.Code,
+			Status:         cmd.Status,
+		}
+	}
+	return nil
+}
+
+// timestamp decodes a CLOCK_REALTIME property in microseconds into dst.
+type timestamp struct {
+	dst *time.Time
+}
+
+func (t timestamp) store(v dbus.Variant) error {
+	var usec uint64
+	if err := v.Store(&usec); err != nil {
+		return err
+	}
+	*t.dst = usecToTime(usec)
+	return nil
+}
+
+// duration decodes a property in microseconds into dst.
+type duration struct {
+	dst *time.Duration
+}
+
+func (d duration) store(v dbus.Variant) error {
+	var usec uint64
+	if err := v.Store(&usec); err != nil {
+		return err
+	}
+	*d.dst = usecToDuration(usec)
+	return nil
+}
+
+// usecToDuration converts a duration in microseconds into a time.Duration.
+// Infinity (the maximum uint64) and other values that overflow are clamped.
+func usecToDuration(usec uint64) time.Duration {
+	if usec > uint64(1<<63-1)/uint64(time.Microsecond) {
+		return time.Duration(1<<63 - 1)
+	}
+	return time.Duration(usec) * time.Microsecond
+}
+
+// decodeProperties decodes the properties listed in fields. Properties which
+// are not present, e.g. because the running systemd is too old, are skipped.
+func decodeProperties(props map[string]dbus.Variant, fields map[string]propertyDecoder) error {
+	for name, d := range fields {
+		v, ok := props[name]
+		if !ok {
+			continue
+		}
+		if err := d.store(v); err != nil {
+			return fmt.Errorf("failed to decode property %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// getPropertyVariants returns all properties of the given interface of the
+// unit.
+func (c *Conn) getPropertyVariants(ctx context.Context, unit string, dbusInterface string) (map[string]dbus.Variant, error) {
+	path := unitPath(unit)
+	if !path.IsValid() {
+		return nil, fmt.Errorf("invalid unit name: %v", unit)
+	}
+
+	var props map[string]dbus.Variant
+	err := c.systemdPathObject(path).CallWithContext(ctx, "org.freedesktop.DBus.Properties.GetAll", 0, dbusInterface).Store(&props)
+	if err != nil {
+		return nil, err
+	}
+	return props, nil
+}
+
+// ServiceInfo holds the properties of a service unit.
+type ServiceInfo struct {
+	Name           string
+	Type           string // e.g. "simple", "exec", "forking", "oneshot", "notify"
+	Restart        string // e.g. "no", "on-failure", "always"
+	RestartDelay   time.Duration
+	TimeoutStart   time.Duration
+	TimeoutStop    time.Duration
+	MainPID        uint32
+	ControlPID     uint32
+	NRestarts      uint32
+	StatusText     string
+	Result         string // e.g. "success", "exit-code", "signal", "timeout"
+	ExecMainStart  time.Time
+	ExecMainExit   time.Time
+	ExecMainCode   int32
+	ExecMainStatus int32
+	ExecStartPre   []ExecCommand
+	ExecStart      []ExecCommand
+	ExecStartPost  []ExecCommand
+	ExecReload     []ExecCommand
+	ExecStop       []ExecCommand
+	ExecStopPost   []ExecCommand
+	MemoryCurrent  uint64 // The memory usage in bytes, the maximum uint64 if unknown
+	TasksCurrent   uint64 // The number of tasks, the maximum uint64 if unknown
+}
+
+func decodeService(name string, props map[string]dbus.Variant) (*ServiceInfo, error) {
+	info := &ServiceInfo{Name: name}
+	err := decodeProperties(props, map[string]propertyDecoder{
+		"Type":                   plain{&info.Type},
+		"Restart":                plain{&info.Restart},
+		"RestartUSec":            duration{&info.RestartDelay},
+		"TimeoutStartUSec":       duration{&info.TimeoutStart},
+		"TimeoutStopUSec":        duration{&info.TimeoutStop},
+		"MainPID":                plain{&info.MainPID},
+		"ControlPID":             plain{&info.ControlPID},
+		"NRestarts":              plain{&info.NRestarts},
+		"StatusText":             plain{&info.StatusText},
+		"Result":                 plain{&info.Result},
+		"ExecMainStartTimestamp": timestamp{&info.ExecMainStart},
+		"ExecMainExitTimestamp":  timestamp{&info.ExecMainExit},
+		"ExecMainCode":           plain{&info.ExecMainCode},
+		"ExecMainStatus":         plain{&info.ExecMainStatus},
+		"ExecStartPre":           execCommands{&info.ExecStartPre},
+		"ExecStart":              execCommands{&info.ExecStart},
+		"ExecStartPost":          execCommands{&info.ExecStartPost},
+		"ExecReload":             execCommands{&info.ExecReload},
+		"ExecStop":               execCommands{&info.ExecStop},
+		"ExecStopPost":           execCommands{&info.ExecStopPost},
+		"MemoryCurrent":          plain{&info.MemoryCurrent},
+		"TasksCurrent":           plain{&info.TasksCurrent},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// DescribeService returns the properties of the specified service unit.
+func (c *Conn) DescribeService(ctx context.Context, name string) (*ServiceInfo, error) {
+	props, err := c.getPropertyVariants(ctx, name, "org.freedesktop.systemd1.Service")
+	if err != nil {
+		return nil, err
+	}
+	return decodeService(name, props)
+}
+
+// SocketListen is an address a socket unit listens on.
+type SocketListen struct {
+	Type    string // e.g. "Stream", "Datagram", "FIFO", "Netlink"
+	Address string // e.g. "/run/foo.sock" or "[::]:80"
+}
+
+// SocketInfo holds the properties of a socket unit.
+type SocketInfo struct {
+	Name         string
+	Listen       []SocketListen
+	Accept       bool
+	NConnections uint32
+	NAccepted    uint32
+	NRefused     uint32
+	ControlPID   uint32
+	Result       string
+	ExecStartPre []ExecCommand
+	ExecStopPost []ExecCommand
+}
+
+func decodeSocket(name string, props map[string]dbus.Variant) (*SocketInfo, error) {
+	info := &SocketInfo{Name: name}
+	err := decodeProperties(props, map[string]propertyDecoder{
+		"Listen":       plain{&info.Listen},
+		"Accept":       plain{&info.Accept},
+		"NConnections": plain{&info.NConnections},
+		"NAccepted":    plain{&info.NAccepted},
+		"NRefused":     plain{&info.NRefused},
+		"ControlPID":   plain{&info.ControlPID},
+		"Result":       plain{&info.Result},
+		"ExecStartPre": execCommands{&info.ExecStartPre},
+		"ExecStopPost": execCommands{&info.ExecStopPost},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// DescribeSocket returns the properties of the specified socket unit.
+func (c *Conn) DescribeSocket(ctx context.Context, name string) (*SocketInfo, error) {
+	props, err := c.getPropertyVariants(ctx, name, "org.freedesktop.systemd1.Socket")
+	if err != nil {
+		return nil, err
+	}
+	return decodeSocket(name, props)
+}
+
+// CalendarTimer is an OnCalendar= setting of a timer unit.
+type CalendarTimer struct {
+	Base       string // Always "OnCalendar"
+	Expression string // The normalized calendar expression
+	NextElapse time.Time
+}
+
+// MonotonicTimer is a monotonic setting of a timer unit, e.g. OnBootSec=.
+type MonotonicTimer struct {
+	Base       string        // e.g. "OnBootUSec" or "OnUnitActiveUSec"
+	Value      time.Duration // The configured offset
+	NextElapse time.Duration // The next elapse relative to CLOCK_MONOTONIC
+}
+
+type calendarTimer struct {
+	Base       string
+	Expression string
+	NextElapse uint64
+}
+
+type monotonicTimer struct {
+	Base       string
+	Value      uint64
+	NextElapse uint64
+}
+
+type calendarTimers struct {
+	dst *[]CalendarTimer
+}
+
+func (t calendarTimers) store(v dbus.Variant) error {
+	var timers []calendarTimer
+	if err := v.Store(&timers); err != nil {
+		return err
+	}
+
+	*t.dst = make([]CalendarTimer, len(timers))
+	for i, timer := range timers {
+		(*t.dst)[i] = CalendarTimer{timer.Base, timer.Expression, usecToTime(timer.NextElapse)}
+	}
+	return nil
+}
+
+type monotonicTimers struct {
+	dst *[]MonotonicTimer
+}
+
+func (t monotonicTimers) store(v dbus.Variant) error {
+	var timers []monotonicTimer
+	if err := v.Store(&timers); err != nil {
+		return err
+	}
+
+	*t.dst = make([]MonotonicTimer, len(timers))
+	for i, timer := range timers {
+		(*t.dst)[i] = MonotonicTimer{timer.Base, usecToDuration(timer.Value), usecToDuration(timer.NextElapse)}
+	}
+	return nil
+}
+
+// TimerInfo holds the properties of a timer unit.
+type TimerInfo struct {
+	Name                string
+	Unit                string    // The unit activated by the timer
+	NextElapse          time.Time // The next elapse of a calendar timer, zero if none
+	NextElapseMonotonic time.Duration
+	LastTrigger         time.Time // When the timer last elapsed, zero if never
+	Persistent          bool
+	Result              string
+	TimersCalendar      []CalendarTimer
+	TimersMonotonic     []MonotonicTimer
+}
+
+func decodeTimer(name string, props map[string]dbus.Variant) (*TimerInfo, error) {
+	info := &TimerInfo{Name: name}
+	err := decodeProperties(props, map[string]propertyDecoder{
+		"Unit":                    plain{&info.Unit},
+		"NextElapseUSecRealtime":  timestamp{&info.NextElapse},
+		"NextElapseUSecMonotonic": duration{&info.NextElapseMonotonic},
+		"LastTriggerUSec":         timestamp{&info.LastTrigger},
+		"Persistent":              plain{&info.Persistent},
+		"Result":                  plain{&info.Result},
+		"TimersCalendar":          calendarTimers{&info.TimersCalendar},
+		"TimersMonotonic":         monotonicTimers{&info.TimersMonotonic},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// DescribeTimer returns the properties of the specified timer unit.
+func (c *Conn) DescribeTimer(ctx context.Context, name string) (*TimerInfo, error) {
+	props, err := c.getPropertyVariants(ctx, name, "org.freedesktop.systemd1.Timer")
+	if err != nil {
+		return nil, err
+	}
+	return decodeTimer(name, props)
+}
+
+// MountInfo holds the properties of a mount unit.
+type MountInfo struct {
+	Name        string
+	Where       string // The mount point
+	What        string // The mounted device or file system
+	Type        string // The file system type
+	Options     string
+	ControlPID  uint32
+	Result      string
+	Timeout     time.Duration
+	ExecMount   []ExecCommand
+	ExecUnmount []ExecCommand
+	ExecRemount []ExecCommand
+}
+
+func decodeMount(name string, props map[string]dbus.Variant) (*MountInfo, error) {
+	info := &MountInfo{Name: name}
+	err := decodeProperties(props, map[string]propertyDecoder{
+		"Where":       plain{&info.Where},
+		"What":        plain{&info.What},
+		"Type":        plain{&info.Type},
+		"Options":     plain{&info.Options},
+		"ControlPID":  plain{&info.ControlPID},
+		"Result":      plain{&info.Result},
+		"TimeoutUSec": duration{&info.Timeout},
+		"ExecMount":   execCommands{&info.ExecMount},
+		"ExecUnmount": execCommands{&info.ExecUnmount},
+		"ExecRemount": execCommands{&info.ExecRemount},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// DescribeMount returns the properties of the specified mount unit.
+func (c *Conn) DescribeMount(ctx context.Context, name string) (*MountInfo, error) {
+	props, err := c.getPropertyVariants(ctx, name, "org.freedesktop.systemd1.Mount")
+	if err != nil {
+		return nil, err
+	}
+	return decodeMount(name, props)
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// wireVariant returns a variant holding v as decoded from the bus, i.e. with
+// structs represented as []interface{}.
+func wireVariant(sig string, v interface{}) dbus.Variant {
+	return dbus.MakeVariantWithSignature(v, dbus.ParseSignatureMust(sig))
+}
+
+func TestDecodeService(t *testing.T) {
+	props := map[string]dbus.Variant{
+		"Type":             dbus.MakeVariant("exec"),
+		"MainPID":          dbus.MakeVariant(uint32(42)),
+		"TimeoutStartUSec": dbus.MakeVariant(uint64(90 * time.Second / time.Microsecond)),
+		"TimeoutStopUSec":  dbus.MakeVariant(uint64(math.MaxUint64)),
+		"ExecStart": wireVariant("a(sasbttttuii)", [][]interface{}{
+			{"/bin/sleep", []string{"sleep", "10"}, false, uint64(1700000000000000), uint64(1), uint64(0), uint64(0), uint32(42), int32(0), int32(0)},
+		}),
+	}
+
+	info, err := decodeService("foo.service", props)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Name != "foo.service" || info.Type != "exec" || info.MainPID != 42 {
+		t.Fatalf("unexpected service info %+v", info)
+	}
+	if info.TimeoutStart != 90*time.Second {
+		t.Fatalf("expected TimeoutStart 90s, got %v", info.TimeoutStart)
+	}
+	if info.TimeoutStop != time.Duration(math.MaxInt64) {
+		t.Fatalf("expected infinite TimeoutStop, got %v", info.TimeoutStop)
+	}
+
+	expected := []ExecCommand{{
+		Path:           "/bin/sleep",
+		Args:           []string{"sleep", "10"},
+		StartTimestamp: time.Unix(1700000000, 0),
+		PID:            42,
+	}}
+	if !reflect.DeepEqual(info.ExecStart, expected) {
+		t.Fatalf("expected ExecStart %+v, got %+v", expected, info.ExecStart)
+	}
+}
+
+func TestDecodeSocketAndTimer(t *testing.T) {
+	socket, err := decodeSocket("foo.socket", map[string]dbus.Variant{
+		"Listen": wireVariant("a(ss)", [][]interface{}{{"Stream", "/run/foo.sock"}}),
+		"Accept": dbus.MakeVariant(true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(socket.Listen, []SocketListen{{"Stream", "/run/foo.sock"}}) || !socket.Accept {
+		t.Fatalf("unexpected socket info %+v", socket)
+	}
+
+	timer, err := decodeTimer("foo.timer", map[string]dbus.Variant{
+		"NextElapseUSecRealtime": dbus.MakeVariant(uint64(1700000000000000)),
+		"TimersCalendar":         wireVariant("a(sst)", [][]interface{}{{"OnCalendar", "*-*-* 00:00:00", uint64(1700000000000000)}}),
+		"TimersMonotonic":        wireVariant("a(stt)", [][]interface{}{{"OnBootUSec", uint64(60000000), uint64(0)}}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !timer.NextElapse.Equal(time.Unix(1700000000, 0)) {
+		t.Fatalf("unexpected NextElapse %v", timer.NextElapse)
+	}
+	if len(timer.TimersCalendar) != 1 || timer.TimersCalendar[0].Expression != "*-*-* 00:00:00" {
+		t.Fatalf("unexpected calendar timers %+v", timer.TimersCalendar)
+	}
+	if len(timer.TimersMonotonic) != 1 || timer.TimersMonotonic[0].Value != time.Minute {
+		t.Fatalf("unexpected monotonic timers %+v", timer.TimersMonotonic)
+	}
+
+	if _, err := decodeTimer("foo.timer", map[string]dbus.Variant{"Persistent": dbus.MakeVariant("yes")}); err == nil {
+		t.Fatal("expected error decoding mistyped property")
+	}
+}
+
+func TestDescribeService(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	info, err := conn.DescribeService(context.Background(), "systemd-journald.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.MainPID == 0 {
+		t.Fatal("expected journald to have a main PID")
+	}
+	if len(info.ExecStart) == 0 || info.ExecStart[0].Path == "" {
+		t.Fatalf("unexpected ExecStart %+v", info.ExecStart)
+	}
+
+	mount, err := conn.DescribeMount(context.Background(), "-.mount")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mount.Where != "/" {
+		t.Fatalf("expected mount point /, got %q", mount.Where)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DropIn is a drop-in file of a unit.
+type DropIn struct {
+	Path    string
+	Content string
+}
+
+// SetUnitPropertiesPersistent is like SetUnitPropertiesContext with runtime
+// set to false, but additionally returns the drop-ins which systemd maintains
+// for properties set over D-Bus, re-read from disk after applying the
+// properties. These are the drop-ins in the *.control directories, e.g.
+// /etc/systemd/system.control/foo.service.d/50-CPUQuota.conf, and include
+// those written by earlier calls as well.
+//
+// As the drop-ins are read from the local file system, the connection must be
+// to a manager running on the same host.
+func (c *Conn) SetUnitPropertiesPersistent(ctx context.Context, name string, properties ...Property) ([]DropIn, error) {
+	if err := c.SetUnitPropertiesContext(ctx, name, false, properties...); err != nil {
+		return nil, err
+	}
+
+	return c.GetControlDropIns(ctx, name)
+}
+
+// GetControlDropIns returns the persistent drop-ins systemd maintains for
+// properties of the unit set over D-Bus, see SetUnitPropertiesPersistent.
+func (c *Conn) GetControlDropIns(ctx context.Context, name string) ([]DropIn, error) {
+	prop, err := c.GetUnitPropertyContext(ctx, name, "DropInPaths")
+	if err != nil {
+		return nil, err
+	}
+
+	paths, ok := prop.Value.Value().([]string)
+	if !ok {
+		return nil, errors.New("failed to typecast DropInPaths to []string")
+	}
+
+	var dropIns []DropIn
+	for _, p := range paths {
+		if !isControlDropIn(p) {
+			continue
+		}
+
+		content, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		dropIns = append(dropIns, DropIn{Path: p, Content: string(content)})
+	}
+
+	return dropIns, nil
+}
+
+// isControlDropIn reports whether path is a persistent drop-in written by
+// systemd for properties set over D-Bus.
+func isControlDropIn(path string) bool {
+	control := filepath.Dir(filepath.Dir(path))
+	return strings.HasSuffix(control, ".control") && !strings.HasPrefix(control, "/run/")
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestIsControlDropIn(t *testing.T) {
+	for path, expected := range map[string]bool{
+		"/etc/systemd/system.control/foo.service.d/50-CPUQuota.conf":          true,
+		"/home/user/.config/systemd/user.control/foo.service.d/50-Slice.conf": true,
+		"/run/systemd/system.control/foo.service.d/50-CPUQuota.conf":          false,
+		"/run/user/1000/systemd/user.control/foo.service.d/50-CPUQuota.conf":  false,
+		"/etc/systemd/system/foo.service.d/override.conf":                     false,
+		"/usr/lib/systemd/system/foo.service.d/10-timeout-abort.conf":         false,
+	} {
+		if got := isControlDropIn(path); got != expected {
+			t.Errorf("isControlDropIn(%q) = %v, expected %v", path, got, expected)
+		}
+	}
+}
+
+func TestSetUnitPropertiesPersistent(t *testing.T) {
+	target := "start-stop.service"
+	conn := setupConn(t)
+	defer conn.Close()
+
+	setupUnit(target, conn, t)
+	linkUnit(target, conn, t)
+
+	ctx := context.Background()
+
+	dropIns, err := conn.SetUnitPropertiesPersistent(ctx, target, PropDescription("persistent description"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		for _, d := range dropIns {
+			os.Remove(d.Path)
+		}
+		conn.ReloadContext(ctx)
+	}()
+
+	found := false
+	for _, d := range dropIns {
+		if strings.Contains(d.Content, "Description=persistent description") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("drop-in with Description not found in %+v", dropIns)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"errors"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Errors returned by the systemd manager. Errors returned by the methods of
+// Conn can be matched against these with errors.Is. Errors with one of these
+// names are no longer dbus.Error values, so a type assertion such as
+// err.(dbus.Error) fails for them; use errors.As to retrieve the underlying
+// dbus.Error instead.
+var (
+	ErrNoSuchUnit                 = errors.New("no such unit")
+	ErrNoUnitForPID               = errors.New("no unit for PID")
+	ErrNoUnitForInvocationID      = errors.New("no unit for invocation ID")
+	ErrUnitExists                 = errors.New("unit exists")
+	ErrLoadFailed                 = errors.New("unit load failed")
+	ErrBadUnitSetting             = errors.New("bad unit setting")
+	ErrJobFailed                  = errors.New("job failed")
+	ErrNoSuchJob                  = errors.New("no such job")
+	ErrNotSubscribed              = errors.New("not subscribed")
+	ErrAlreadySubscribed          = errors.New("already subscribed")
+	ErrOnlyByDependency           = errors.New("operation refused, unit may only be requested by dependency")
+	ErrTransactionJobsConflicting = errors.New("transaction jobs conflicting")
+	ErrTransactionOrderIsCyclic   = errors.New("transaction order is cyclic")
+	ErrTransactionIsDestructive   = errors.New("transaction is destructive")
+	ErrUnitMasked                 = errors.New("unit masked")
+	ErrUnitGenerated              = errors.New("unit generated")
+	ErrUnitLinked                 = errors.New("unit linked")
+	ErrJobTypeNotApplicable       = errors.New("job type not applicable")
+	ErrNoIsolation                = errors.New("unit does not allow isolation")
+	ErrShuttingDown               = errors.New("manager is shutting down")
+	ErrScopeNotRunning            = errors.New("scope not running")
+	ErrUnitBusy                   = errors.New("unit busy")
+	ErrUnitInactive               = errors.New("unit inactive")
+	ErrNothingToClean             = errors.New("nothing to clean")
+)
+
+// systemdErrors maps D-Bus error names to the exported error values.
+var systemdErrors = map[string]error{
+	"org.freedesktop.systemd1.NoSuchUnit":                 ErrNoSuchUnit,
+	"org.freedesktop.systemd1.NoUnitForPID":               ErrNoUnitForPID,
+	"org.freedesktop.systemd1.NoUnitForInvocationID":      ErrNoUnitForInvocationID,
+	"org.freedesktop.systemd1.UnitExists":                 ErrUnitExists,
+	"org.freedesktop.systemd1.LoadFailed":                 ErrLoadFailed,
+	"org.freedesktop.systemd1.BadUnitSetting":             ErrBadUnitSetting,
+	"org.freedesktop.systemd1.JobFailed":                  ErrJobFailed,
+	"org.freedesktop.systemd1.NoSuchJob":                  ErrNoSuchJob,
+	"org.freedesktop.systemd1.NotSubscribed":              ErrNotSubscribed,
+	"org.freedesktop.systemd1.AlreadySubscribed":          ErrAlreadySubscribed,
+	"org.freedesktop.systemd1.OnlyByDependency":           ErrOnlyByDependency,
+	"org.freedesktop.systemd1.TransactionJobsConflicting": ErrTransactionJobsConflicting,
+	"org.freedesktop.systemd1.TransactionOrderIsCyclic":   ErrTransactionOrderIsCyclic,
+	"org.freedesktop.systemd1.TransactionIsDestructive":   ErrTransactionIsDestructive,
+	"org.freedesktop.systemd1.UnitMasked":                 ErrUnitMasked,
+	"org.freedesktop.systemd1.UnitGenerated":              ErrUnitGenerated,
+	"org.freedesktop.systemd1.UnitLinked":                 ErrUnitLinked,
+	"org.freedesktop.systemd1.JobTypeNotApplicable":       ErrJobTypeNotApplicable,
+	"org.freedesktop.systemd1.NoIsolation":                ErrNoIsolation,
+	"org.freedesktop.systemd1.ShuttingDown":               ErrShuttingDown,
+	"org.freedesktop.systemd1.ScopeNotRunning":            ErrScopeNotRunning,
+	"org.freedesktop.systemd1.UnitBusy":                   ErrUnitBusy,
+	"org.freedesktop.systemd1.UnitInactive":               ErrUnitInactive,
+	"org.freedesktop.systemd1.NothingToClean":             ErrNothingToClean,
+}
+
+// systemdError is returned for D-Bus errors which have a known systemd error
+// name. It matches the corresponding exported error with errors.Is and
+// unwraps to the original dbus.Error, so that errors.As still finds it both
+// as a dbus.Error and as a *dbus.Error.
+type systemdError struct {
+	err  dbus.Error
+	kind error
+}
+
+func (e *systemdError) Error() string {
+	return e.err.Error()
+}
+
+func (e *systemdError) Is(target error) bool {
+	return target == e.kind
+}
+
+func (e *systemdError) Unwrap() error {
+	return e.err
+}
+
+func (e *systemdError) As(target interface{}) bool {
+	if p, ok := target.(**dbus.Error); ok {
+		err := e.err
+		*p = &err
+		return true
+	}
+	return false
+}
+
+// mapError wraps D-Bus errors with a known systemd error name into an
+// *systemdError, leaving all other errors untouched.
+func mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var dbusErr dbus.Error
+	if !errors.As(err, &dbusErr) {
+		return err
+	}
+	kind, ok := systemdErrors[dbusErr.Name]
+	if !ok {
+		return err
+	}
+
+	return &systemdError{err: dbusErr, kind: kind}
+}
+
+// errorMappingObject is a dbus.BusObject which applies mapError to the
+// results of method calls.
+type errorMappingObject struct {
+	dbus.BusObject
+}
+
+func (o errorMappingObject) Call(method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+	call := o.BusObject.Call(method, flags, args...)
+	call.Err = mapError(call.Err)
+	return call
+}
+
+func (o errorMappingObject) CallWithContext(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+	call := o.BusObject.CallWithContext(ctx, method, flags, args...)
+	call.Err = mapError(call.Err)
+	return call
+}
+
+func (o errorMappingObject) GetProperty(p string) (dbus.Variant, error) {
+	v, err := o.BusObject.GetProperty(p)
+	return v, mapError(err)
+}
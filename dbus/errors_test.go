@@ -0,0 +1,73 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestMapError(t *testing.T) {
+	dbusErr := dbus.Error{
+		Name: "org.freedesktop.systemd1.NoSuchUnit",
+		Body: []interface{}{"Unit foo.service not loaded."},
+	}
+
+	err := mapError(dbusErr)
+	if !errors.Is(err, ErrNoSuchUnit) {
+		t.Fatalf("expected %v to match ErrNoSuchUnit", err)
+	}
+	if errors.Is(err, ErrUnitExists) {
+		t.Fatalf("expected %v not to match ErrUnitExists", err)
+	}
+	if err.Error() != dbusErr.Error() {
+		t.Fatalf("expected message %q, got %q", dbusErr.Error(), err.Error())
+	}
+
+	var unwrapped dbus.Error
+	if !errors.As(err, &unwrapped) || unwrapped.Name != dbusErr.Name {
+		t.Fatalf("expected %v to unwrap to the dbus.Error", err)
+	}
+	if !errors.As(err, &dbus.Error{}) {
+		t.Fatalf("expected %v to match a dbus.Error target", err)
+	}
+	var ptr *dbus.Error
+	if !errors.As(fmt.Errorf("wrapped: %w", err), &ptr) || ptr.Name != dbusErr.Name {
+		t.Fatalf("expected %v to unwrap to a *dbus.Error", err)
+	}
+
+	other := dbus.Error{Name: "org.freedesktop.DBus.Error.AccessDenied"}
+	if err := mapError(other); err.(dbus.Error).Name != other.Name {
+		t.Fatalf("expected unknown error to be returned unchanged, got %v", err)
+	}
+
+	if mapError(nil) != nil {
+		t.Fatal("expected nil error to stay nil")
+	}
+}
+
+func TestNoSuchUnitError(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	_, err := conn.StartUnitContext(context.Background(), "nonexistent-go-systemd-test.service", JobModeReplace, nil)
+	if !errors.Is(err, ErrNoSuchUnit) {
+		t.Fatalf("expected ErrNoSuchUnit, got %v", err)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// getManagerProperty returns the raw value of a property on the
+// org.freedesktop.systemd1.Manager interface.
+func (c *Conn) getManagerProperty(ctx context.Context, prop string) (dbus.Variant, error) {
+	var value dbus.Variant
+	err := c.sysobj.CallWithContext(ctx, "org.freedesktop.DBus.Properties.Get", 0, "org.freedesktop.systemd1.Manager", prop).Store(&value)
+	return value, err
+}
+
+func (c *Conn) getManagerString(ctx context.Context, prop string) (string, error) {
+	value, err := c.getManagerProperty(ctx, prop)
+	if err != nil {
+		return "", err
+	}
+
+	s, ok := value.Value().(string)
+	if !ok {
+		return "", fmt.Errorf("failed to typecast manager property %s to string", prop)
+	}
+	return s, nil
+}
+
+func (c *Conn) getManagerUint64(ctx context.Context, prop string) (uint64, error) {
+	value, err := c.getManagerProperty(ctx, prop)
+	if err != nil {
+		return 0, err
+	}
+
+	n, ok := value.Value().(uint64)
+	if !ok {
+		return 0, fmt.Errorf("failed to typecast manager property %s to uint64", prop)
+	}
+	return n, nil
+}
+
+func (c *Conn) getManagerTimestamp(ctx context.Context, prop string) (time.Time, error) {
+	usec, err := c.getManagerUint64(ctx, prop)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return usecToTime(usec), nil
+}
+
+// usecToTime converts a CLOCK_REALTIME timestamp in microseconds, as used by
+// systemd, into a time.Time. An unset (zero) timestamp yields the zero time.
+func usecToTime(usec uint64) time.Time {
+	if usec == 0 {
+		return time.Time{}
+	}
+	return time.UnixMicro(int64(usec))
+}
+
+// GetVersion returns the version string of the running systemd manager.
+func (c *Conn) GetVersion(ctx context.Context) (string, error) {
+	return c.getManagerString(ctx, "Version")
+}
+
+// GetFeatures returns the compile-time features of the running systemd
+// manager, e.g. "+PAM +AUDIT -SELINUX ...".
+func (c *Conn) GetFeatures(ctx context.Context) (string, error) {
+	return c.getManagerString(ctx, "Features")
+}
+
+// GetVirtualization returns the detected virtualization technology, or an
+// empty string if none was detected.
+func (c *Conn) GetVirtualization(ctx context.Context) (string, error) {
+	return c.getManagerString(ctx, "Virtualization")
+}
+
+// GetArchitecture returns the architecture of the running kernel as
+// reported by systemd, e.g. "x86-64".
+func (c *Conn) GetArchitecture(ctx context.Context) (string, error) {
+	return c.getManagerString(ctx, "Architecture")
+}
+
+// GetNNames returns the number of unit names currently loaded.
+func (c *Conn) GetNNames(ctx context.Context) (uint32, error) {
+	value, err := c.getManagerProperty(ctx, "NNames")
+	if err != nil {
+		return 0, err
+	}
+
+	n, ok := value.Value().(uint32)
+	if !ok {
+		return 0, fmt.Errorf("failed to typecast manager property NNames to uint32")
+	}
+	return n, nil
+}
+
+// GetUserspaceTimestamp returns the time at which the manager started.
+func (c *Conn) GetUserspaceTimestamp(ctx context.Context) (time.Time, error) {
+	return c.getManagerTimestamp(ctx, "UserspaceTimestamp")
+}
+
+// GetFinishTimestamp returns the time at which startup of the manager
+// finished. The zero time is returned while startup is still in progress.
+func (c *Conn) GetFinishTimestamp(ctx context.Context) (time.Time, error) {
+	return c.getManagerTimestamp(ctx, "FinishTimestamp")
+}
+
+// GetSystemState returns the state of the system as a string, as reported
+// by systemctl is-system-running, e.g. "running" or "degraded".
+func (c *Conn) GetSystemState(ctx context.Context) (string, error) {
+	return c.getManagerString(ctx, "SystemState")
+}
+
+// setManagerProperty sets a writable property on the
+// org.freedesktop.systemd1.Manager interface.
+func (c *Conn) setManagerProperty(ctx context.Context, prop string, value interface{}) error {
+	return c.sysobj.CallWithContext(ctx, "org.freedesktop.DBus.Properties.Set", 0, "org.freedesktop.systemd1.Manager", prop, dbus.MakeVariant(value)).Store()
+}
+
+// GetLogLevel returns the log level of the manager, e.g. "info" or "debug".
+func (c *Conn) GetLogLevel(ctx context.Context) (string, error) {
+	return c.getManagerString(ctx, "LogLevel")
+}
+
+// SetLogLevel sets the log level of the manager, like systemctl log-level.
+// level is one of "emerg", "alert", "crit", "err", "warning", "notice",
+// "info" or "debug". The change does not persist across reboots.
+func (c *Conn) SetLogLevel(ctx context.Context, level string) error {
+	return c.setManagerProperty(ctx, "LogLevel", level)
+}
+
+// GetLogTarget returns the log target of the manager, e.g. "journal-or-kmsg".
+func (c *Conn) GetLogTarget(ctx context.Context) (string, error) {
+	return c.getManagerString(ctx, "LogTarget")
+}
+
+// SetLogTarget sets the log target of the manager, like systemctl
+// log-target. target is one of "console", "kmsg", "journal",
+// "journal-or-kmsg", "syslog", "syslog-or-kmsg", "auto" or "null".
+func (c *Conn) SetLogTarget(ctx context.Context, target string) error {
+	return c.setManagerProperty(ctx, "LogTarget", target)
+}
+
+// GetServiceWatchdogs reports whether service watchdogs are enabled.
+func (c *Conn) GetServiceWatchdogs(ctx context.Context) (bool, error) {
+	value, err := c.getManagerProperty(ctx, "ServiceWatchdogs")
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := value.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("failed to typecast manager property ServiceWatchdogs to bool")
+	}
+	return b, nil
+}
+
+// SetServiceWatchdogs enables or disables the watchdogs of all services,
+// like systemctl service-watchdogs.
+func (c *Conn) SetServiceWatchdogs(ctx context.Context, enabled bool) error {
+	return c.setManagerProperty(ctx, "ServiceWatchdogs", enabled)
+}
+
+// DumpByFileDescriptor returns a file from which the manager state dump, as
+// printed by systemd-analyze dump, can be read. Unlike the Dump method, the
+// dump is streamed rather than sent in a single, potentially huge, D-Bus
+// message. The caller must close the returned file.
+func (c *Conn) DumpByFileDescriptor(ctx context.Context) (*os.File, error) {
+	var fd dbus.UnixFD
+	err := c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.DumpByFileDescriptor", 0).Store(&fd)
+	if err != nil {
+		return nil, err
+	}
+	return os.NewFile(uintptr(fd), "systemd-dump"), nil
+}
+
+// DumpUnitsMatchingPatternsByFileDescriptor is like DumpByFileDescriptor, but
+// only dumps the units matching the given glob patterns.
+//
+// Requires systemd v254 or higher.
+func (c *Conn) DumpUnitsMatchingPatternsByFileDescriptor(ctx context.Context, patterns []string) (*os.File, error) {
+	var fd dbus.UnixFD
+	err := c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.DumpUnitsMatchingPatternsByFileDescriptor", 0, patterns).Store(&fd)
+	if err != nil {
+		return nil, err
+	}
+	return os.NewFile(uintptr(fd), "systemd-dump"), nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestUsecToTime(t *testing.T) {
+	if !usecToTime(0).IsZero() {
+		t.Fatal("expected zero time for unset timestamp")
+	}
+
+	ts := usecToTime(1700000000123456)
+	if ts.Unix() != 1700000000 || ts.Nanosecond() != 123456000 {
+		t.Fatalf("unexpected time %v", ts)
+	}
+}
+
+func TestManagerProperties(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	ctx := context.Background()
+
+	version, err := conn.GetVersion(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version == "" {
+		t.Fatal("empty version")
+	}
+
+	if _, err := conn.GetFeatures(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.GetVirtualization(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	arch, err := conn.GetArchitecture(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if arch == "" {
+		t.Fatal("empty architecture")
+	}
+
+	names, err := conn.GetNNames(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names == 0 {
+		t.Fatal("no unit names loaded")
+	}
+
+	started, err := conn.GetUserspaceTimestamp(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if started.IsZero() || started.After(time.Now()) {
+		t.Fatalf("invalid userspace timestamp %v", started)
+	}
+
+	if _, err := conn.GetFinishTimestamp(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := conn.GetSystemState(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state == "" {
+		t.Fatal("empty system state")
+	}
+}
+
+func TestManagerLogging(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	ctx := context.Background()
+
+	level, err := conn.GetLogLevel(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.SetLogLevel(ctx, "debug"); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.SetLogLevel(ctx, level)
+
+	if got, err := conn.GetLogLevel(ctx); err != nil || got != "debug" {
+		t.Fatalf("expected log level debug, got %q (%v)", got, err)
+	}
+
+	target, err := conn.GetLogTarget(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.SetLogTarget(ctx, target); err != nil {
+		t.Fatal(err)
+	}
+
+	watchdogs, err := conn.GetServiceWatchdogs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.SetServiceWatchdogs(ctx, watchdogs); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDumpByFileDescriptor(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	ctx := context.Background()
+
+	f, err := conn.DumpByFileDescriptor(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dump, err := io.ReadAll(f)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(dump), "-> Unit systemd-journald.service:") {
+		t.Fatal("journald not found in dump")
+	}
+
+	f, err = conn.DumpUnitsMatchingPatternsByFileDescriptor(ctx, []string{"systemd-journald.service"})
+	if err, ok := err.(dbus.Error); ok && err.Name == "org.freedesktop.DBus.Error.UnknownMethod" {
+		t.Skip("DumpUnitsMatchingPatternsByFileDescriptor not supported:", err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	dump, err = io.ReadAll(f)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(dump), "-> Unit systemd-journald.service:") || strings.Contains(string(dump), "-> Unit -.mount:") {
+		t.Fatalf("unexpected units in dump")
+	}
+}
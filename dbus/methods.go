@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"path"
 	"strconv"
+	"time"
 
 	"github.com/godbus/dbus/v5"
 )
@@ -34,6 +35,12 @@ const (
 	Main Who = "main"
 	// Control sends the signal to the control process of the unit
 	Control Who = "control"
+	// AllFail is like All, but fails if no process was signalled
+	AllFail Who = "all-fail"
+	// MainFail is like Main, but fails if the unit has no main process
+	MainFail Who = "main-fail"
+	// ControlFail is like Control, but fails if the unit has no control process
+	ControlFail Who = "control-fail"
 )
 
 func (c *Conn) jobComplete(signal *dbus.Signal) {
@@ -48,6 +55,12 @@ func (c *Conn) jobComplete(signal *dbus.Signal) {
 		out <- result
 		delete(c.jobListener.jobs, job)
 	}
+	for ch := range c.jobListener.idle {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
 	c.jobListener.Unlock()
 }
 
@@ -74,15 +87,16 @@ func (c *Conn) startJob(ctx context.Context, ch chan<- string, job string, args
 }
 
 // Deprecated: use StartUnitContext instead.
-func (c *Conn) StartUnit(name string, mode string, ch chan<- string) (int, error) {
+func (c *Conn) StartUnit(name string, mode JobMode, ch chan<- string) (int, error) {
 	return c.StartUnitContext(context.Background(), name, mode, ch)
 }
 
 // StartUnitContext enqueues a start job and depending jobs, if any (unless otherwise
-// specified by the mode string).
+// specified by the mode).
 //
-// Takes the unit to activate, plus a mode string. The mode needs to be one of
-// replace, fail, isolate, ignore-dependencies, ignore-requirements. If
+// Takes the unit to activate, plus a mode. The mode needs to be one of
+// replace, fail, isolate, ignore-dependencies, ignore-requirements (see the
+// JobMode constants). If
 // "replace" the call will start the unit and its dependencies, possibly
 // replacing already queued jobs that conflict with this. If "fail" the call
 // will start the unit and its dependencies, but will fail if this would change
@@ -108,78 +122,125 @@ func (c *Conn) StartUnit(name string, mode string, ch chan<- string) (int, error
 // should not be considered authoritative.
 //
 // If an error does occur, it will be returned to the user alongside a job ID of 0.
-func (c *Conn) StartUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error) {
-	return c.startJob(ctx, ch, "org.freedesktop.systemd1.Manager.StartUnit", name, mode)
+func (c *Conn) StartUnitContext(ctx context.Context, name string, mode JobMode, ch chan<- string) (int, error) {
+	return c.startJob(ctx, ch, "org.freedesktop.systemd1.Manager.StartUnit", name, string(mode))
 }
 
 // Deprecated: use StopUnitContext instead.
-func (c *Conn) StopUnit(name string, mode string, ch chan<- string) (int, error) {
+func (c *Conn) StopUnit(name string, mode JobMode, ch chan<- string) (int, error) {
 	return c.StopUnitContext(context.Background(), name, mode, ch)
 }
 
 // StopUnitContext is similar to StartUnitContext, but stops the specified unit
 // rather than starting it.
-func (c *Conn) StopUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error) {
-	return c.startJob(ctx, ch, "org.freedesktop.systemd1.Manager.StopUnit", name, mode)
+func (c *Conn) StopUnitContext(ctx context.Context, name string, mode JobMode, ch chan<- string) (int, error) {
+	return c.startJob(ctx, ch, "org.freedesktop.systemd1.Manager.StopUnit", name, string(mode))
 }
 
 // Deprecated: use ReloadUnitContext instead.
-func (c *Conn) ReloadUnit(name string, mode string, ch chan<- string) (int, error) {
+func (c *Conn) ReloadUnit(name string, mode JobMode, ch chan<- string) (int, error) {
 	return c.ReloadUnitContext(context.Background(), name, mode, ch)
 }
 
 // ReloadUnitContext reloads a unit. Reloading is done only if the unit
 // is already running, and fails otherwise.
-func (c *Conn) ReloadUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error) {
-	return c.startJob(ctx, ch, "org.freedesktop.systemd1.Manager.ReloadUnit", name, mode)
+func (c *Conn) ReloadUnitContext(ctx context.Context, name string, mode JobMode, ch chan<- string) (int, error) {
+	return c.startJob(ctx, ch, "org.freedesktop.systemd1.Manager.ReloadUnit", name, string(mode))
 }
 
 // Deprecated: use RestartUnitContext instead.
-func (c *Conn) RestartUnit(name string, mode string, ch chan<- string) (int, error) {
+func (c *Conn) RestartUnit(name string, mode JobMode, ch chan<- string) (int, error) {
 	return c.RestartUnitContext(context.Background(), name, mode, ch)
 }
 
 // RestartUnitContext restarts a service. If a service is restarted that isn't
 // running it will be started.
-func (c *Conn) RestartUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error) {
-	return c.startJob(ctx, ch, "org.freedesktop.systemd1.Manager.RestartUnit", name, mode)
+func (c *Conn) RestartUnitContext(ctx context.Context, name string, mode JobMode, ch chan<- string) (int, error) {
+	return c.startJob(ctx, ch, "org.freedesktop.systemd1.Manager.RestartUnit", name, string(mode))
 }
 
 // Deprecated: use TryRestartUnitContext instead.
-func (c *Conn) TryRestartUnit(name string, mode string, ch chan<- string) (int, error) {
+func (c *Conn) TryRestartUnit(name string, mode JobMode, ch chan<- string) (int, error) {
 	return c.TryRestartUnitContext(context.Background(), name, mode, ch)
 }
 
 // TryRestartUnitContext is like RestartUnitContext, except that a service that
 // isn't running is not affected by the restart.
-func (c *Conn) TryRestartUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error) {
-	return c.startJob(ctx, ch, "org.freedesktop.systemd1.Manager.TryRestartUnit", name, mode)
+func (c *Conn) TryRestartUnitContext(ctx context.Context, name string, mode JobMode, ch chan<- string) (int, error) {
+	return c.startJob(ctx, ch, "org.freedesktop.systemd1.Manager.TryRestartUnit", name, string(mode))
 }
 
 // Deprecated: use ReloadOrRestartUnitContext instead.
-func (c *Conn) ReloadOrRestartUnit(name string, mode string, ch chan<- string) (int, error) {
+func (c *Conn) ReloadOrRestartUnit(name string, mode JobMode, ch chan<- string) (int, error) {
 	return c.ReloadOrRestartUnitContext(context.Background(), name, mode, ch)
 }
 
 // ReloadOrRestartUnitContext attempts a reload if the unit supports it and use
 // a restart otherwise.
-func (c *Conn) ReloadOrRestartUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error) {
-	return c.startJob(ctx, ch, "org.freedesktop.systemd1.Manager.ReloadOrRestartUnit", name, mode)
+func (c *Conn) ReloadOrRestartUnitContext(ctx context.Context, name string, mode JobMode, ch chan<- string) (int, error) {
+	return c.startJob(ctx, ch, "org.freedesktop.systemd1.Manager.ReloadOrRestartUnit", name, string(mode))
 }
 
 // Deprecated: use ReloadOrTryRestartUnitContext instead.
-func (c *Conn) ReloadOrTryRestartUnit(name string, mode string, ch chan<- string) (int, error) {
+func (c *Conn) ReloadOrTryRestartUnit(name string, mode JobMode, ch chan<- string) (int, error) {
 	return c.ReloadOrTryRestartUnitContext(context.Background(), name, mode, ch)
 }
 
 // ReloadOrTryRestartUnitContext attempts a reload if the unit supports it,
 // and use a "Try" flavored restart otherwise.
-func (c *Conn) ReloadOrTryRestartUnitContext(ctx context.Context, name string, mode string, ch chan<- string) (int, error) {
-	return c.startJob(ctx, ch, "org.freedesktop.systemd1.Manager.ReloadOrTryRestartUnit", name, mode)
+func (c *Conn) ReloadOrTryRestartUnitContext(ctx context.Context, name string, mode JobMode, ch chan<- string) (int, error) {
+	return c.startJob(ctx, ch, "org.freedesktop.systemd1.Manager.ReloadOrTryRestartUnit", name, string(mode))
+}
+
+// EnqueuedJob is a job enqueued by EnqueueUnitJobContext.
+type EnqueuedJob struct {
+	Id       uint32          // The numeric job id
+	Path     dbus.ObjectPath // The job object path
+	Unit     string          // The unit the job operates on
+	UnitPath dbus.ObjectPath // The unit object path
+	JobType  string          // The job type, e.g. "start" or "stop"
+}
+
+// Transaction is the result of EnqueueUnitJobContext: the requested job and
+// all jobs which had to be enqueued along with it.
+type Transaction struct {
+	Job      EnqueuedJob
+	Affected []EnqueuedJob
+}
+
+// EnqueueUnitJobContext enqueues a job of the given type for the unit, like
+// the corresponding StartUnitContext, StopUnitContext, ... call, but also
+// returns all other jobs enqueued in the same transaction, similar to
+// systemctl --show-transaction. jobType is one of start, stop, reload,
+// restart, try-restart, reload-or-start, reload-or-try-restart, verify-active
+// or verify-started, and mode is the same as in StartUnitContext.
+//
+// If the provided channel is non-nil, the result of the requested job is sent
+// to it upon completion, as in StartUnitContext.
+//
+// Requires systemd v243 or higher.
+func (c *Conn) EnqueueUnitJobContext(ctx context.Context, name string, jobType string, mode JobMode, ch chan<- string) (*Transaction, error) {
+	if ch != nil {
+		c.jobListener.Lock()
+		defer c.jobListener.Unlock()
+	}
+
+	var t Transaction
+	err := c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.EnqueueUnitJob", 0, name, jobType, string(mode)).
+		Store(&t.Job.Id, &t.Job.Path, &t.Job.Unit, &t.Job.UnitPath, &t.Job.JobType, &t.Affected)
+	if err != nil {
+		return nil, err
+	}
+
+	if ch != nil {
+		c.jobListener.jobs[t.Job.Path] = ch
+	}
+
+	return &t, nil
 }
 
 // Deprecated: use StartTransientUnitContext instead.
-func (c *Conn) StartTransientUnit(name string, mode string, properties []Property, ch chan<- string) (int, error) {
+func (c *Conn) StartTransientUnit(name string, mode JobMode, properties []Property, ch chan<- string) (int, error) {
 	return c.StartTransientUnitContext(context.Background(), name, mode, properties, ch)
 }
 
@@ -188,8 +249,8 @@ func (c *Conn) StartTransientUnit(name string, mode string, properties []Propert
 // system is rebooted. name is the unit name including suffix, and must be
 // unique. mode is the same as in StartUnitContext, properties contains properties
 // of the unit.
-func (c *Conn) StartTransientUnitContext(ctx context.Context, name string, mode string, properties []Property, ch chan<- string) (int, error) {
-	return c.startJob(ctx, ch, "org.freedesktop.systemd1.Manager.StartTransientUnit", name, mode, properties, make([]PropertyCollection, 0))
+func (c *Conn) StartTransientUnitContext(ctx context.Context, name string, mode JobMode, properties []Property, ch chan<- string) (int, error) {
+	return c.startJob(ctx, ch, "org.freedesktop.systemd1.Manager.StartTransientUnit", name, string(mode), properties, make([]PropertyCollection, 0))
 }
 
 // Deprecated: use KillUnitContext instead.
@@ -209,6 +270,15 @@ func (c *Conn) KillUnitWithTarget(ctx context.Context, name string, target Who,
 	return c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.KillUnit", 0, name, string(target), signal).Store()
 }
 
+// KillUnitWithSubgroup sends the signal to all processes in the given
+// subgroup of the unit's control group, e.g. a cgroup created by a service
+// using Delegate=. subcgroup is relative to the unit's control group.
+//
+// Requires systemd v254 or higher.
+func (c *Conn) KillUnitWithSubgroup(ctx context.Context, name string, subcgroup string, signal int32) error {
+	return c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.KillUnitSubgroup", 0, name, subcgroup, signal).Store()
+}
+
 // Deprecated: use ResetFailedUnitContext instead.
 func (c *Conn) ResetFailedUnit(name string) error {
 	return c.ResetFailedUnitContext(context.Background(), name)
@@ -219,6 +289,44 @@ func (c *Conn) ResetFailedUnitContext(ctx context.Context, name string) error {
 	return c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.ResetFailedUnit", 0, name).Store()
 }
 
+// ResetFailedContext resets the "failed" state of all units.
+func (c *Conn) ResetFailedContext(ctx context.Context) error {
+	return c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.ResetFailed", 0).Store()
+}
+
+// ResetFailedAndRestartUnitContext resets the "failed" state of the specified
+// unit, restarts it and waits for the restart job to complete. It returns the
+// result of the job, one of done, canceled, timeout, failed, dependency or
+// skipped (see StartUnitContext). If ctx is done before the job completes,
+// ctx.Err() is returned and the job is left running.
+func (c *Conn) ResetFailedAndRestartUnitContext(ctx context.Context, name string, mode JobMode) (string, error) {
+	if err := c.ResetFailedUnitContext(ctx, name); err != nil {
+		return "", err
+	}
+
+	ch := make(chan string, 1)
+	if _, err := c.RestartUnitContext(ctx, name, mode, ch); err != nil {
+		return "", err
+	}
+
+	select {
+	case result := <-ch:
+		return result, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// CleanUnit removes the configuration, state, cache, logs or runtime data of
+// the specified unit, equivalent to systemctl clean. mask takes one or more
+// of "runtime", "state", "cache", "logs", "configuration", "fdstore" or
+// "all". The unit must not be running.
+//
+// Requires systemd v243 or higher.
+func (c *Conn) CleanUnit(ctx context.Context, name string, mask []string) error {
+	return c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.CleanUnit", 0, name, mask).Store()
+}
+
 // Deprecated: use SystemStateContext instead.
 func (c *Conn) SystemState() (*Property, error) {
 	return c.SystemStateContext(context.Background())
@@ -230,8 +338,7 @@ func (c *Conn) SystemStateContext(ctx context.Context) (*Property, error) {
 	var err error
 	var prop dbus.Variant
 
-	obj := c.sysconn.Object("org.freedesktop.systemd1", "/org/freedesktop/systemd1")
-	err = obj.CallWithContext(ctx, "org.freedesktop.DBus.Properties.Get", 0, "org.freedesktop.systemd1.Manager", "SystemState").Store(&prop)
+	err = c.sysobj.CallWithContext(ctx, "org.freedesktop.DBus.Properties.Get", 0, "org.freedesktop.systemd1.Manager", "SystemState").Store(&prop)
 	if err != nil {
 		return nil, err
 	}
@@ -248,7 +355,7 @@ func (c *Conn) getProperties(ctx context.Context, path dbus.ObjectPath, dbusInte
 		return nil, fmt.Errorf("invalid unit name: %v", path)
 	}
 
-	obj := c.sysconn.Object("org.freedesktop.systemd1", path)
+	obj := c.systemdPathObject(path)
 	err = obj.CallWithContext(ctx, "org.freedesktop.DBus.Properties.GetAll", 0, dbusInterface).Store(&props)
 	if err != nil {
 		return nil, err
@@ -306,7 +413,7 @@ func (c *Conn) getProperty(ctx context.Context, unit string, dbusInterface strin
 		return nil, errors.New("invalid unit name: " + unit)
 	}
 
-	obj := c.sysconn.Object("org.freedesktop.systemd1", path)
+	obj := c.systemdPathObject(path)
 	err = obj.CallWithContext(ctx, "org.freedesktop.DBus.Properties.Get", 0, dbusInterface, propertyName).Store(&prop)
 	if err != nil {
 		return nil, err
@@ -380,7 +487,7 @@ type UnitStatus struct {
 	Name        string          // The primary unit name as string
 	Description string          // The human readable description string
 	LoadState   string          // The load state (i.e. whether the unit file has been loaded successfully)
-	ActiveState string          // The active state (i.e. whether the unit is currently started or not)
+	ActiveState ActiveState     // The active state (i.e. whether the unit is currently started or not)
 	SubState    string          // The sub state (a more fine-grained version of the active state that is specific to the unit type, which the active state is not)
 	Followed    string          // A unit that is being followed in its state by this unit, if there is any, otherwise the empty string.
 	Path        dbus.ObjectPath // The unit object path
@@ -440,6 +547,28 @@ func (c *Conn) GetUnitNameByPID(ctx context.Context, pid uint32) (string, error)
 	return unitName(path), nil
 }
 
+// GetUnitByControlGroup returns the unit object path of the unit owning the
+// given control group. The control group path is relative to the root of the
+// cgroup hierarchy, e.g. "/system.slice/foo.service".
+func (c *Conn) GetUnitByControlGroup(ctx context.Context, cgroup string) (dbus.ObjectPath, error) {
+	var result dbus.ObjectPath
+
+	err := c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.GetUnitByControlGroup", 0, cgroup).Store(&result)
+
+	return result, err
+}
+
+// GetUnitNameByControlGroup returns the name of the unit owning the given
+// control group.
+func (c *Conn) GetUnitNameByControlGroup(ctx context.Context, cgroup string) (string, error) {
+	path, err := c.GetUnitByControlGroup(ctx, cgroup)
+	if err != nil {
+		return "", err
+	}
+
+	return unitName(path), nil
+}
+
 // Deprecated: use ListUnitsContext instead.
 func (c *Conn) ListUnits() ([]UnitStatus, error) {
 	return c.ListUnitsContext(context.Background())
@@ -493,6 +622,62 @@ func (c *Conn) ListUnitsByNamesContext(ctx context.Context, units []string) ([]U
 	return c.listUnitsInternal(c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.ListUnitsByNames", 0, units).Store)
 }
 
+// UnitFilter selects units by state and name; see ListUnitsMatchingContext.
+type UnitFilter struct {
+	States   []string // Load, active or sub states to match, e.g. "failed" or "running"; empty matches all
+	Patterns []string // Glob patterns matched against the unit names, e.g. "*.service"; empty matches all
+}
+
+// ListUnitsMatchingContext returns the loaded units matching the filter,
+// filtered by systemd so that only the matching units are sent over the bus.
+// It issues a single ListUnits, ListUnitsFiltered or ListUnitsByPatterns call
+// depending on which parts of the filter are set. On systemd versions
+// without ListUnitsByPatterns (older than v230), the states are filtered by
+// systemd and the patterns are matched locally.
+func (c *Conn) ListUnitsMatchingContext(ctx context.Context, filter UnitFilter) ([]UnitStatus, error) {
+	if len(filter.Patterns) == 0 {
+		if len(filter.States) == 0 {
+			return c.ListUnitsContext(ctx)
+		}
+		return c.ListUnitsFilteredContext(ctx, filter.States)
+	}
+
+	units, err := c.ListUnitsByPatternsContext(ctx, filter.States, filter.Patterns)
+	if !isUnknownMethod(err) {
+		return units, err
+	}
+
+	units, err = c.ListUnitsFilteredContext(ctx, filter.States)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := units[:0]
+	for _, u := range units {
+		if matchesAny(filter.Patterns, u.Name) {
+			matched = append(matched, u)
+		}
+	}
+
+	return matched, nil
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnknownMethod reports whether err was returned because the method is not
+// implemented by the running systemd.
+func isUnknownMethod(err error) bool {
+	var dbusErr dbus.Error
+	return errors.As(err, &dbusErr) && dbusErr.Name == "org.freedesktop.DBus.Error.UnknownMethod"
+}
+
 type UnitFile struct {
 	Path string
 	Type string
@@ -794,9 +979,119 @@ func (c *Conn) Reload() error {
 }
 
 // ReloadContext instructs systemd to scan for and reload unit files. This is
-// an equivalent to systemctl daemon-reload.
+// an equivalent to systemctl daemon-reload. It returns once the reload is
+// complete, as announced by the Reloading(false) manager signal, so that units
+// can be started with their new definitions right away.
+//
+// systemd only sends the signal to subscribed clients, so the connection is
+// subscribed to systemd signals as by Subscribe while waiting, if it isn't
+// yet.
 func (c *Conn) ReloadContext(ctx context.Context) error {
-	return c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.Reload", 0).Store()
+	ch := make(chan *dbus.Signal, signalBuffer)
+	c.sigconn.Signal(ch)
+	defer c.sigconn.RemoveSignal(ch)
+
+	removeMatch, err := c.addSignalMatch(ctx,
+		dbus.WithMatchObjectPath("/org/freedesktop/systemd1"),
+		dbus.WithMatchInterface("org.freedesktop.systemd1.Manager"),
+		dbus.WithMatchMember("Reloading"),
+	)
+	if err != nil {
+		return err
+	}
+	defer removeMatch()
+
+	unsubscribe, err := c.ensureSubscribed(ctx)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	err = c.sigobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.Reload", 0).Store()
+	if err != nil {
+		return err
+	}
+
+	return waitReloaded(ctx, ch)
+}
+
+// waitReloaded waits for the Reloading(false) signal, which systemd sends
+// once reloading is done. The signals are delivered asynchronously, so it may
+// arrive after the reply to Reload.
+func waitReloaded(ctx context.Context, ch <-chan *dbus.Signal) error {
+	for {
+		select {
+		case signal := <-ch:
+			if signal.Name != "org.freedesktop.systemd1.Manager.Reloading" || len(signal.Body) < 1 {
+				continue
+			}
+			if active, ok := signal.Body[0].(bool); ok && !active {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// ReexecuteContext instructs systemd to serialize its state, re-execute
+// itself and deserialize the state again. This is an equivalent to systemctl
+// daemon-reexec. It returns once the re-executed manager is processing
+// requests again.
+//
+// The connection must go through the bus daemon: direct connections to
+// systemd, as made by NewSystemdConnectionContext, are closed by the
+// re-execution, in which case an error is returned.
+func (c *Conn) ReexecuteContext(ctx context.Context) error {
+	ch := make(chan *dbus.Signal, signalBuffer)
+	c.sigconn.Signal(ch)
+	defer c.sigconn.RemoveSignal(ch)
+
+	removeMatch, err := c.addSignalMatch(ctx,
+		dbus.WithMatchSender("org.freedesktop.DBus"),
+		dbus.WithMatchInterface("org.freedesktop.DBus"),
+		dbus.WithMatchMember("NameOwnerChanged"),
+		dbus.WithMatchArg(0, "org.freedesktop.systemd1"),
+	)
+	if err != nil {
+		return err
+	}
+	defer removeMatch()
+
+	// systemd does not reply to Reexecute
+	err = c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.Reexecute", dbus.FlagNoReplyExpected).Store()
+	if err != nil {
+		return err
+	}
+
+	for restarted := false; !restarted; {
+		select {
+		case signal := <-ch:
+			restarted = isSystemdRestart(signal)
+		case <-c.sysconn.Context().Done():
+			return errors.New("connection closed during re-execution")
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	// Method calls are only processed once the manager finished starting up.
+	_, err = c.GetVersion(ctx)
+	return err
+}
+
+// addSignalMatch adds a match rule for signals to the signal connection and
+// returns the function removing it. Direct connections to systemd have no
+// bus daemon to add the rule to, and receive all signals anyway, so nothing
+// is added on them.
+func (c *Conn) addSignalMatch(ctx context.Context, options ...dbus.MatchOption) (func(), error) {
+	if len(c.sigconn.Names()) == 0 {
+		return func() {}, nil
+	}
+	if err := c.sigconn.AddMatchSignalContext(ctx, options...); err != nil {
+		return nil, err
+	}
+	return func() { c.sigconn.RemoveMatchSignal(options...) }, nil
 }
 
 func unitPath(name string) dbus.ObjectPath {
@@ -852,6 +1147,106 @@ func (c *Conn) listJobsInternal(ctx context.Context) ([]JobStatus, error) {
 	return status, nil
 }
 
+// CancelJob cancels a queued or running job by its numeric ID.
+func (c *Conn) CancelJob(ctx context.Context, id uint32) error {
+	return c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.CancelJob", 0, id).Store()
+}
+
+// ClearJobs cancels all queued and running jobs.
+func (c *Conn) ClearJobs(ctx context.Context) error {
+	return c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.ClearJobs", 0).Store()
+}
+
+// idlePollInterval bounds how long WaitForIdle sleeps between checks of the
+// job queue when no JobRemoved signal arrives. JobRemoved signals are only
+// delivered for jobs created by this connection unless Subscribe has been
+// called, so the queue has to be polled as well.
+const idlePollInterval = time.Second
+
+// WaitForIdle blocks until the manager's job queue is empty, for example to
+// wait until boot has settled. It returns early with the context's error if
+// ctx is done first.
+func (c *Conn) WaitForIdle(ctx context.Context) error {
+	notify := make(chan struct{}, 1)
+
+	c.jobListener.Lock()
+	c.jobListener.idle[notify] = struct{}{}
+	c.jobListener.Unlock()
+
+	defer func() {
+		c.jobListener.Lock()
+		delete(c.jobListener.idle, notify)
+		c.jobListener.Unlock()
+	}()
+
+	for {
+		jobs, err := c.ListJobsContext(ctx)
+		if err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(idlePollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-notify:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// UnitProcess holds a process belonging to a unit.
+type UnitProcess struct {
+	Path    string // The full control group path of the process, e.g. /system.slice/foo.service/sub
+	PID     uint32 // The process ID
+	Command string // The command line of the process
+}
+
+// GetUnitProcesses returns all processes in the control group of the
+// specified unit, including those in sub-cgroups, like systemctl status
+// shows them.
+//
+// Requires systemd v238 or higher.
+func (c *Conn) GetUnitProcesses(ctx context.Context, unit string) ([]UnitProcess, error) {
+	result := make([][]interface{}, 0)
+	if err := c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.GetUnitProcesses", 0, unit).Store(&result); err != nil {
+		return nil, err
+	}
+
+	resultInterface := make([]interface{}, len(result))
+	for i := range result {
+		resultInterface[i] = result[i]
+	}
+
+	processes := make([]UnitProcess, len(result))
+	processesInterface := make([]interface{}, len(processes))
+	for i := range processes {
+		processesInterface[i] = &processes[i]
+	}
+
+	if err := dbus.Store(resultInterface, processesInterface...); err != nil {
+		return nil, err
+	}
+
+	return processes, nil
+}
+
+// AttachProcessesToUnit moves the given processes into the control group of
+// a running unit. subcgroup may be used to place the processes in a
+// sub-cgroup of the unit's own cgroup ("" for the unit cgroup itself), which
+// is only permitted for units with delegation enabled.
+//
+// This lets delegated-cgroup managers migrate processes through systemd
+// instead of writing to cgroup.procs directly. Requires systemd v238 or higher.
+func (c *Conn) AttachProcessesToUnit(ctx context.Context, unit string, subcgroup string, pids []uint32) error {
+	return c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.AttachProcessesToUnit", 0, unit, subcgroup, pids).Store()
+}
+
 // Freeze the cgroup associated with the unit.
 // Note that FreezeUnit and ThawUnit are only supported on systems running with cgroup v2.
 func (c *Conn) FreezeUnit(ctx context.Context, unit string) error {
@@ -482,6 +482,35 @@ func TestGetUnitNameByPID(t *testing.T) {
 	}
 }
 
+func TestGetUnitByControlGroup(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	name, err := conn.GetUnitNameByPID(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prop, err := conn.GetUnitTypePropertyContext(context.Background(), name, "Scope", "ControlGroup")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cgroup, ok := prop.Value.Value().(string)
+	if !ok || cgroup == "" {
+		t.Skip("unit of PID 1 has no control group")
+	}
+
+	found, err := conn.GetUnitNameByControlGroup(context.Background(), cgroup)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found != name {
+		t.Fatalf("expected unit %s for control group %s, got %s", name, cgroup, found)
+	}
+}
+
 // Ensure that ListUnitsByNames works.
 func TestListUnitsByNames(t *testing.T) {
 	target1 := "systemd-journald.service"
@@ -542,6 +571,45 @@ func TestListUnitsByPatterns(t *testing.T) {
 	}
 }
 
+// Ensure that ListUnitsMatchingContext applies states and patterns together.
+func TestListUnitsMatching(t *testing.T) {
+	target := "systemd-journald.service"
+
+	conn := setupConn(t)
+	defer conn.Close()
+
+	units, err := conn.ListUnitsMatchingContext(context.Background(), UnitFilter{
+		States:   []string{"active"},
+		Patterns: []string{"systemd-journald*"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if getUnitStatus(units, target) == nil {
+		t.Fatalf("%s unit not found in list", target)
+	}
+	for _, u := range units {
+		if u.ActiveState != StateActive {
+			t.Fatalf("unit %s with state %s not filtered out", u.Name, u.ActiveState)
+		}
+		if ok, _ := path.Match("systemd-journald*", u.Name); !ok {
+			t.Fatalf("unit %s not matching the pattern returned", u.Name)
+		}
+	}
+
+	units, err = conn.ListUnitsMatchingContext(context.Background(), UnitFilter{
+		States:   []string{"inactive"},
+		Patterns: []string{target},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if getUnitStatus(units, target) != nil {
+		t.Fatalf("active unit %s returned for inactive filter", target)
+	}
+}
+
 // Ensure that ListUnitsFiltered works.
 func TestListUnitsFiltered(t *testing.T) {
 	target := "systemd-journald.service"
@@ -1403,6 +1471,241 @@ func TestStartStopTransientScope(t *testing.T) {
 	//     int sd_pid_get_unit(pid_t pid, char **session)
 }
 
+// startBlockingJob starts a transient oneshot service whose start job does
+// not finish until the service is stopped, and returns the job ID.
+func startBlockingJob(t *testing.T, conn *Conn, target string, reschan chan<- string) uint32 {
+	props := []Property{
+		PropType("oneshot"),
+		PropExecStart([]string{"/bin/sleep", "400"}, false),
+	}
+	id, err := conn.StartTransientUnit(target, "replace", props, reschan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return uint32(id)
+}
+
+// Ensure that a running job can be cancelled and that WaitForIdle returns
+// once the job queue drains.
+func TestCancelJobWaitForIdle(t *testing.T) {
+	target := fmt.Sprintf("testing-cancel-%d.service", os.Getpid())
+
+	conn := setupConn(t)
+	defer conn.Close()
+
+	reschan := make(chan string, 1)
+	id := startBlockingJob(t, conn, target, reschan)
+	defer conn.StopUnit(target, "replace", nil)
+
+	if err := conn.CancelJob(context.Background(), id); err != nil {
+		t.Fatal(err)
+	}
+
+	job := <-reschan
+	if job != "canceled" {
+		t.Fatalf("expected job to be canceled, got %s", job)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := conn.WaitForIdle(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := conn.ListJobsContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found := getJobStatusIfExists(jobs, target); found != nil {
+		t.Fatalf("%s job found in list after WaitForIdle", target)
+	}
+}
+
+// Ensure that ClearJobs cancels running jobs.
+func TestClearJobs(t *testing.T) {
+	target := fmt.Sprintf("testing-clear-%d.service", os.Getpid())
+
+	conn := setupConn(t)
+	defer conn.Close()
+
+	reschan := make(chan string, 1)
+	startBlockingJob(t, conn, target, reschan)
+	defer conn.StopUnit(target, "replace", nil)
+
+	if err := conn.ClearJobs(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	job := <-reschan
+	if job != "canceled" {
+		t.Fatalf("expected job to be canceled, got %s", job)
+	}
+}
+
+// Ensure that transient scopes can be created from pidfds.
+func TestStartTransientScopePIDFDs(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	cmd := exec.Command("/bin/sleep", "400")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer cmd.Process.Kill()
+
+	// pidfd_open(2), available since Linux 5.3.
+	fd, _, errno := syscall.Syscall(434, uintptr(cmd.Process.Pid), 0, 0)
+	if errno != 0 {
+		t.Skipf("pidfd_open not supported: %v", errno)
+	}
+	defer syscall.Close(int(fd))
+
+	target := fmt.Sprintf("testing-pidfd-%d.scope", cmd.Process.Pid)
+
+	reschan := make(chan string)
+	_, err := conn.StartTransientUnit(target, "replace", []Property{PropPIDFDs(int(fd))}, reschan)
+	if err != nil {
+		e, ok := err.(dbus.Error)
+		if ok && e.Name == "org.freedesktop.DBus.Error.PropertyReadOnly" {
+			t.Skip("PIDFDs property not supported by systemd")
+		}
+		t.Fatal(err)
+	}
+
+	job := <-reschan
+	if job != "done" {
+		t.Fatal("Job is not done:", job)
+	}
+
+	name, err := conn.GetUnitNameByPID(context.Background(), uint32(cmd.Process.Pid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != target {
+		t.Fatalf("expected process to be in %s, got %s", target, name)
+	}
+}
+
+// Ensure that GetUnitProcesses lists the processes of a unit.
+func TestGetUnitProcesses(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	name, err := conn.GetUnitNameByPID(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	processes, err := conn.GetUnitProcesses(context.Background(), name)
+	if err != nil {
+		e, ok := err.(dbus.Error)
+		if ok && e.Name == "org.freedesktop.DBus.Error.UnknownMethod" {
+			t.SkipNow()
+		}
+		t.Fatal(err)
+	}
+
+	for _, p := range processes {
+		if p.PID == 1 {
+			if p.Command == "" {
+				t.Fatal("empty command line for PID 1")
+			}
+			return
+		}
+	}
+	t.Fatalf("PID 1 not found in processes of %s: %v", name, processes)
+}
+
+// Ensure that CleanUnit removes the state directory of a unit.
+func TestCleanUnit(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	target := "testing-clean.service"
+	stateDir := "/var/lib/go-systemd-clean-test"
+
+	props := []Property{
+		PropExecStart([]string{"/bin/true"}, false),
+		PropType("oneshot"),
+		{Name: "StateDirectory", Value: dbus.MakeVariant([]string{path.Base(stateDir)})},
+	}
+
+	if err := runStartTrUnit(t, conn, TrUnitProp{target, props}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(stateDir); err != nil {
+		t.Fatalf("state directory not created: %v", err)
+	}
+
+	err := conn.CleanUnit(context.Background(), target, []string{"state"})
+	if err != nil {
+		e, ok := err.(dbus.Error)
+		if ok && e.Name == "org.freedesktop.DBus.Error.UnknownMethod" {
+			t.SkipNow()
+		}
+		t.Fatal(err)
+	}
+
+	// Cleaning is performed asynchronously by the manager.
+	for i := 0; i < 30; i++ {
+		if _, err := os.Stat(stateDir); os.IsNotExist(err) {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("state directory %s still exists after CleanUnit", stateDir)
+}
+
+// Ensure that processes can be attached to a running scope.
+func TestAttachProcessesToUnit(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	cmd := exec.Command("/bin/sleep", "400")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer cmd.Process.Kill()
+
+	target := fmt.Sprintf("testing-attach-%d.scope", cmd.Process.Pid)
+
+	reschan := make(chan string)
+	_, err := conn.StartTransientUnit(target, "replace", []Property{PropPids(uint32(cmd.Process.Pid))}, reschan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job := <-reschan
+	if job != "done" {
+		t.Fatal("Job is not done:", job)
+	}
+
+	other := exec.Command("/bin/sleep", "400")
+	if err := other.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer other.Process.Kill()
+
+	err = conn.AttachProcessesToUnit(context.Background(), target, "", []uint32{uint32(other.Process.Pid)})
+	if err != nil {
+		e, ok := err.(dbus.Error)
+		if ok && e.Name == "org.freedesktop.DBus.Error.UnknownMethod" {
+			t.SkipNow()
+		}
+		t.Fatal(err)
+	}
+
+	name, err := conn.GetUnitNameByPID(context.Background(), uint32(other.Process.Pid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != target {
+		t.Fatalf("expected process to be attached to %s, got %s", target, name)
+	}
+}
+
 // Ensure that basic unit gets killed by SIGTERM
 func TestKillUnit(t *testing.T) {
 	target := "start-stop.service"
@@ -1466,6 +1769,79 @@ waitevent:
 	}
 }
 
+func TestKillUnitTargets(t *testing.T) {
+	target := "start-stop.service"
+	conn := setupConn(t)
+	defer conn.Close()
+
+	setupUnit(target, conn, t)
+	linkUnit(target, conn, t)
+
+	reschan := make(chan string)
+	_, err := conn.StartUnit(target, "replace", reschan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job := <-reschan
+	if job != "done" {
+		t.Fatal("Job is not done:", job)
+	}
+	defer conn.StopUnit(target, "replace", nil)
+
+	ctx := context.Background()
+
+	// SIGCONT is harmless for the running service
+	if err := conn.KillUnitWithTarget(ctx, target, MainFail, int32(syscall.SIGCONT)); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.KillUnitWithTarget(ctx, target, ControlFail, int32(syscall.SIGCONT)); err == nil {
+		t.Fatal("expected error signalling nonexistent control process")
+	}
+
+	err = conn.KillUnitWithSubgroup(ctx, target, "nonexistent", int32(syscall.SIGCONT))
+	if err, ok := err.(dbus.Error); ok && err.Name == "org.freedesktop.DBus.Error.UnknownMethod" {
+		t.Skip("KillUnitSubgroup not supported:", err)
+	}
+	if err == nil {
+		t.Fatal("expected error signalling nonexistent subgroup")
+	}
+}
+
+// Ensure that EnqueueUnitJobContext returns the jobs of the transaction.
+func TestEnqueueUnitJob(t *testing.T) {
+	target := "start-stop.service"
+	conn := setupConn(t)
+	defer conn.Close()
+
+	setupUnit(target, conn, t)
+	linkUnit(target, conn, t)
+
+	reschan := make(chan string)
+	tr, err := conn.EnqueueUnitJobContext(context.Background(), target, "start", JobModeReplace, reschan)
+	if err, ok := err.(dbus.Error); ok && err.Name == "org.freedesktop.DBus.Error.UnknownMethod" {
+		t.Skip("EnqueueUnitJob not supported:", err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.StopUnit(target, "replace", nil)
+
+	if tr.Job.Unit != target || tr.Job.JobType != "start" || tr.Job.Id == 0 {
+		t.Fatalf("unexpected job %+v", tr.Job)
+	}
+	for _, j := range tr.Affected {
+		if j.Id == tr.Job.Id {
+			t.Fatalf("requested job listed as affected job")
+		}
+	}
+
+	job := <-reschan
+	if job != "done" {
+		t.Fatal("Job is not done:", job)
+	}
+}
+
 // Ensure that a failed unit gets reset
 func TestResetFailedUnit(t *testing.T) {
 	target := "start-failed.service"
@@ -1521,6 +1897,38 @@ func TestResetFailedUnit(t *testing.T) {
 	}
 }
 
+// Ensure that ResetFailedContext and ResetFailedAndRestartUnitContext work.
+func TestResetFailedAndRestartUnit(t *testing.T) {
+	target := "start-failed.service"
+	conn := setupConn(t)
+	defer conn.Close()
+
+	setupUnit(target, conn, t)
+	linkUnit(target, conn, t)
+
+	ctx := context.Background()
+
+	result, err := conn.ResetFailedAndRestartUnitContext(ctx, target, JobModeReplace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != "failed" {
+		t.Fatal("Job is not failed:", result)
+	}
+
+	if err := conn.ResetFailedContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	units, err := conn.ListUnitsContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if getUnitStatus(units, target) != nil {
+		t.Fatalf("Test unit still found in list. units = %v", units)
+	}
+}
+
 func TestConnJobListener(t *testing.T) {
 	target := "start-stop.service"
 	conn := setupConn(t)
@@ -1617,6 +2025,60 @@ func TestReload(t *testing.T) {
 	}
 }
 
+// TestReloadUnsubscribed ensures that ReloadContext returns on a connection
+// that isn't subscribed to systemd signals, and leaves it unsubscribed.
+func TestReloadUnsubscribed(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := conn.ReloadContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Subscribe(); err != nil {
+		t.Fatalf("expected to subscribe after reloading: %v", err)
+	}
+	defer conn.Unsubscribe()
+
+	// an existing subscription is kept
+	if err := conn.ReloadContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Unsubscribe(); err != nil {
+		t.Fatalf("expected the subscription to be kept: %v", err)
+	}
+}
+
+func TestWaitReloaded(t *testing.T) {
+	reloading := func(active bool) *dbus.Signal {
+		return &dbus.Signal{Name: "org.freedesktop.systemd1.Manager.Reloading", Body: []interface{}{active}}
+	}
+
+	ch := make(chan *dbus.Signal, 3)
+	ch <- reloading(true)
+	ch <- &dbus.Signal{Name: "org.freedesktop.systemd1.Manager.UnitNew"}
+	ch <- reloading(false)
+	if err := waitReloaded(context.Background(), ch); err != nil {
+		t.Fatal(err)
+	}
+
+	// only Reloading(false) is waited for
+	ch <- reloading(false)
+	if err := waitReloaded(context.Background(), ch); err != nil {
+		t.Fatal(err)
+	}
+
+	// the signal may arrive after the reply to Reload, so it is waited for
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	ch <- reloading(true)
+	if err := waitReloaded(ctx, ch); err != context.DeadlineExceeded {
+		t.Fatalf("expected deadline exceeded while reloading, got %v", err)
+	}
+}
+
 func TestUnitName(t *testing.T) {
 	for _, unit := range []string{
 		"",
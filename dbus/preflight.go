@@ -0,0 +1,93 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// UnitLoadInfo describes the result of loading a unit.
+type UnitLoadInfo struct {
+	Name          string
+	Path          dbus.ObjectPath // The unit object path
+	LoadState     string          // One of loaded, not-found, bad-setting, error or masked
+	LoadError     string          // The reason the unit failed to load, if it did
+	UnitFileState string          // e.g. enabled, disabled, static or masked; empty if the unit has no unit file
+}
+
+// GetUnitFileStateContext returns the enablement state of the unit file,
+// e.g. enabled, disabled, static or masked, like systemctl is-enabled.
+func (c *Conn) GetUnitFileStateContext(ctx context.Context, file string) (string, error) {
+	var state string
+	err := c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.GetUnitFileState", 0, file).Store(&state)
+	return state, err
+}
+
+// LoadUnit loads the unit from disk if it isn't loaded yet and reports its
+// load state. Loading a unit without unit file succeeds; the unit is reported
+// with the not-found load state instead.
+func (c *Conn) LoadUnit(ctx context.Context, name string) (*UnitLoadInfo, error) {
+	info := &UnitLoadInfo{Name: name}
+
+	err := c.sysobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.LoadUnit", 0, name).Store(&info.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := c.getProperties(ctx, info.Path, "org.freedesktop.systemd1.Unit")
+	if err != nil {
+		return nil, err
+	}
+
+	var ok bool
+	if info.LoadState, ok = props["LoadState"].(string); !ok {
+		return nil, fmt.Errorf("failed to typecast LoadState to string")
+	}
+	info.UnitFileState, _ = props["UnitFileState"].(string)
+
+	// LoadError is a (ss) of the D-Bus error name and message
+	if loadErr, ok := props["LoadError"].([]interface{}); ok && len(loadErr) == 2 {
+		info.LoadError, _ = loadErr[1].(string)
+	}
+
+	return info, nil
+}
+
+// UnitExists reports whether the unit can be loaded, so that it can be
+// started. It returns false for units which are not found. For masked units
+// and units which failed to load, an error matching ErrUnitMasked, or
+// ErrLoadFailed or ErrBadUnitSetting respectively, is returned.
+func (c *Conn) UnitExists(ctx context.Context, name string) (bool, error) {
+	info, err := c.LoadUnit(ctx, name)
+	if err != nil {
+		return false, err
+	}
+
+	switch info.LoadState {
+	case "loaded":
+		return true, nil
+	case "not-found":
+		return false, nil
+	case "masked":
+		return false, fmt.Errorf("%w: %s", ErrUnitMasked, name)
+	case "bad-setting":
+		return false, fmt.Errorf("%w: %s: %s", ErrBadUnitSetting, name, info.LoadError)
+	default:
+		return false, fmt.Errorf("%w: %s: %s", ErrLoadFailed, name, info.LoadError)
+	}
+}
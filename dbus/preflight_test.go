@@ -0,0 +1,70 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestUnitExists(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	ctx := context.Background()
+
+	target := "start-stop.service"
+	setupUnit(target, conn, t)
+	linkUnit(target, conn, t)
+
+	exists, err := conn.UnitExists(ctx, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exists {
+		t.Fatalf("%s does not exist", target)
+	}
+
+	exists, err = conn.UnitExists(ctx, "nonexistent-go-systemd-test.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exists {
+		t.Fatal("nonexistent unit exists")
+	}
+
+	masked := "mask-unmask.service"
+	setupUnit(masked, conn, t)
+	if _, err := conn.MaskUnitFiles([]string{masked}, true, true); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.UnmaskUnitFiles([]string{masked}, true)
+	if err := conn.ReloadContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := conn.UnitExists(ctx, masked); !errors.Is(err, ErrUnitMasked) {
+		t.Fatalf("expected ErrUnitMasked, got %v", err)
+	}
+
+	state, err := conn.GetUnitFileStateContext(ctx, masked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state != "masked-runtime" {
+		t.Fatalf("expected unit file state masked-runtime, got %s", state)
+	}
+}
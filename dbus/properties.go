@@ -235,3 +235,20 @@ func PropPids(pids ...uint32) Property {
 		Value: dbus.MakeVariant(pids),
 	}
 }
+
+// PropPIDFDs is like PropPids, but identifies the initial processes of the
+// scope by pidfd (see pidfd_open(2)) rather than by PID, which avoids races
+// with PID reuse. The file descriptors are duplicated by D-Bus when the
+// message is sent and may be closed by the caller afterwards.
+// Requires systemd v253 or higher.
+func PropPIDFDs(fds ...int) Property {
+	unixFDs := make([]dbus.UnixFD, len(fds))
+	for i, fd := range fds {
+		unixFDs[i] = dbus.UnixFD(fd)
+	}
+
+	return Property{
+		Name:  "PIDFDs",
+		Value: dbus.MakeVariant(unixFDs),
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/gr-butler/go-systemd/v22/instrument"
+)
+
+const (
+	defaultMinBackoff = 100 * time.Millisecond
+	defaultMaxBackoff = 30 * time.Second
+)
+
+// ReconnectEvent describes a gap in the stream of events received from
+// systemd, caused either by the loss of the D-Bus connection or by systemd
+// itself being re-executed. Signals emitted between Lost and Restored have
+// not been delivered, so consumers should resynchronise their state, e.g. by
+// calling ListUnitsContext.
+type ReconnectEvent struct {
+	Lost     time.Time // When the connection or subscription was lost
+	Restored time.Time // When the connection and subscription were restored
+	Redialed bool      // Whether a new connection had to be established
+}
+
+// ReconnectOptions configures a ResilientConn.
+type ReconnectOptions struct {
+	// Dial establishes a new connection. It defaults to NewWithContext.
+	Dial func(ctx context.Context) (*Conn, error)
+	// MinBackoff and MaxBackoff bound the exponential delay between
+	// reconnection attempts.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// OnReconnect, if set, is called after every gap in the event stream
+	// has been closed.
+	OnReconnect func(ReconnectEvent)
+	// Hook and Timeout, if set, are set on every connection established,
+	// see Conn.SetHook and Conn.SetTimeout.
+	Hook    instrument.Hook
+	Timeout time.Duration
+}
+
+// configure sets the hook and the timeout of the options on conn.
+func (opts *ReconnectOptions) configure(conn *Conn) {
+	if opts.Hook != nil {
+		conn.SetHook(opts.Hook)
+	}
+	if opts.Timeout > 0 {
+		conn.SetTimeout(opts.Timeout)
+	}
+}
+
+// ResilientConn wraps a Conn and transparently re-establishes it when the
+// connection to the bus is lost or systemd is re-executed (daemon-reexec,
+// soft-reboot). After reconnecting it re-issues Subscribe, replays match rules
+// added through AddMatch and re-registers the subscribers set on it.
+type ResilientConn struct {
+	opts ReconnectOptions
+
+	mu         sync.Mutex
+	conn       *Conn
+	subscribed bool
+	matches    []string
+	subState   struct {
+		updateCh chan<- *SubStateUpdate
+		errCh    chan<- error
+	}
+	properties struct {
+		updateCh chan<- *PropertiesUpdate
+		errCh    chan<- error
+	}
+
+	done chan struct{}
+	once sync.Once
+}
+
+// NewResilientConnection establishes a connection using opts.Dial and keeps
+// it alive until Close is called. Callers should call Close() when done with
+// the connection.
+func NewResilientConnection(ctx context.Context, opts ReconnectOptions) (*ResilientConn, error) {
+	if opts.Dial == nil {
+		opts.Dial = NewWithContext
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = defaultMinBackoff
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = defaultMaxBackoff
+	}
+
+	conn, err := opts.Dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts.configure(conn)
+
+	r := &ResilientConn{
+		opts: opts,
+		conn: conn,
+		done: make(chan struct{}),
+	}
+	go r.monitor(conn)
+
+	return r, nil
+}
+
+// Conn returns the current underlying connection. The returned connection
+// may be replaced after a reconnect, so it should not be cached.
+func (r *ResilientConn) Conn() *Conn {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.conn
+}
+
+// Close closes the underlying connection and stops reconnecting.
+func (r *ResilientConn) Close() {
+	r.once.Do(func() {
+		close(r.done)
+		r.mu.Lock()
+		r.conn.Close()
+		r.mu.Unlock()
+	})
+}
+
+// Subscribe is like Conn.Subscribe, but the subscription is re-established
+// after every reconnect. It is recorded even if the current connection fails
+// to subscribe, e.g. while it is lost, so that the next reconnect retries it.
+func (r *ResilientConn) Subscribe() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscribed = true
+	return r.conn.Subscribe()
+}
+
+// Unsubscribe is like Conn.Unsubscribe, and stops re-subscribing after
+// reconnects.
+func (r *ResilientConn) Unsubscribe() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscribed = false
+	return r.conn.Unsubscribe()
+}
+
+// AddMatch adds a D-Bus match rule to the signal connection. The rule is
+// replayed after every reconnect, even if adding it to the current
+// connection fails.
+func (r *ResilientConn) AddMatch(rule string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.matches = append(r.matches, rule)
+	return r.conn.addMatch(rule)
+}
+
+// SetSubStateSubscriber is like Conn.SetSubStateSubscriber; the channels
+// remain registered across reconnects.
+func (r *ResilientConn) SetSubStateSubscriber(updateCh chan<- *SubStateUpdate, errCh chan<- error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subState.updateCh = updateCh
+	r.subState.errCh = errCh
+	r.conn.SetSubStateSubscriber(updateCh, errCh)
+}
+
+// SetPropertiesSubscriber is like Conn.SetPropertiesSubscriber; the channels
+// remain registered across reconnects.
+func (r *ResilientConn) SetPropertiesSubscriber(updateCh chan<- *PropertiesUpdate, errCh chan<- error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.properties.updateCh = updateCh
+	r.properties.errCh = errCh
+	r.conn.SetPropertiesSubscriber(updateCh, errCh)
+}
+
+// monitor waits for conn to be lost or for systemd to acquire a new bus
+// name owner, and restores the connection state accordingly.
+func (r *ResilientConn) monitor(conn *Conn) {
+	owner := make(chan *dbus.Signal, signalBuffer)
+	conn.sigconn.Signal(owner)
+	// Only effective when connected through a bus daemon; direct connections
+	// to systemd are dropped on re-execution instead.
+	conn.addMatch("type='signal',sender='org.freedesktop.DBus',interface='org.freedesktop.DBus',member='NameOwnerChanged',arg0='org.freedesktop.systemd1'")
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-conn.sysconn.Context().Done():
+		case <-conn.sigconn.Context().Done():
+		case signal, ok := <-owner:
+			if !ok {
+				// The signal channel is closed along with the connection.
+				break
+			}
+			if !isSystemdRestart(signal) {
+				continue
+			}
+			lost := time.Now()
+			r.mu.Lock()
+			r.restore(conn, false)
+			r.mu.Unlock()
+			r.notify(ReconnectEvent{Lost: lost, Restored: time.Now()})
+			continue
+		}
+
+		conn.Close()
+		r.redial(time.Now())
+		return
+	}
+}
+
+// redial establishes a new connection with exponential backoff and hands
+// over monitoring to it.
+func (r *ResilientConn) redial(lost time.Time) {
+	backoff := r.opts.MinBackoff
+
+	for {
+		conn, err := r.opts.Dial(context.Background())
+		if err == nil {
+			r.opts.configure(conn)
+			r.mu.Lock()
+			select {
+			case <-r.done:
+				r.mu.Unlock()
+				conn.Close()
+				return
+			default:
+			}
+			r.conn = conn
+			r.restore(conn, true)
+			r.mu.Unlock()
+
+			go r.monitor(conn)
+			r.notify(ReconnectEvent{Lost: lost, Restored: time.Now(), Redialed: true})
+			return
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-r.done:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if backoff > r.opts.MaxBackoff {
+			backoff = r.opts.MaxBackoff
+		}
+	}
+}
+
+// restore re-applies subscribers, match rules and the subscription on conn.
+// The bus daemon keeps the match rules of a connection across a restart of
+// systemd, and systemd keeps its subscribers across re-execution, so unless
+// conn has been redialed only the subscription is renewed, if systemd lost
+// it. r.mu must be held.
+func (r *ResilientConn) restore(conn *Conn, redialed bool) {
+	if r.subState.updateCh != nil {
+		conn.SetSubStateSubscriber(r.subState.updateCh, r.subState.errCh)
+	}
+	if r.properties.updateCh != nil {
+		conn.SetPropertiesSubscriber(r.properties.updateCh, r.properties.errCh)
+	}
+	if !redialed {
+		if r.subscribed {
+			if _, err := conn.ensureSubscribed(context.Background()); err != nil {
+				r.reportError(err)
+			}
+		}
+		return
+	}
+	for _, rule := range r.matches {
+		if err := conn.addMatch(rule); err != nil {
+			r.reportError(err)
+		}
+	}
+	if r.subscribed {
+		if err := conn.Subscribe(); err != nil {
+			r.reportError(err)
+		}
+	}
+}
+
+// reportError forwards an error encountered while restoring the connection
+// to the registered error channels, without blocking.
+func (r *ResilientConn) reportError(err error) {
+	for _, ch := range []chan<- error{r.subState.errCh, r.properties.errCh} {
+		if ch == nil {
+			continue
+		}
+		select {
+		case ch <- err:
+		default:
+		}
+	}
+}
+
+func (r *ResilientConn) notify(ev ReconnectEvent) {
+	if r.opts.OnReconnect != nil {
+		r.opts.OnReconnect(ev)
+	}
+}
+
+// isSystemdRestart reports whether signal announces a new owner of the
+// org.freedesktop.systemd1 bus name.
+func isSystemdRestart(signal *dbus.Signal) bool {
+	if signal.Name != "org.freedesktop.DBus.NameOwnerChanged" || len(signal.Body) < 3 {
+		return false
+	}
+	name, _ := signal.Body[0].(string)
+	newOwner, _ := signal.Body[2].(string)
+	return name == "org.freedesktop.systemd1" && newOwner != ""
+}
+
+// addMatch adds a match rule on the signal connection.
+func (c *Conn) addMatch(rule string) error {
+	if rule == "" {
+		return errors.New("empty match rule")
+	}
+	return c.sigconn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0, rule).Store()
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestIsSystemdRestart(t *testing.T) {
+	tests := []struct {
+		signal   *dbus.Signal
+		expected bool
+	}{
+		{&dbus.Signal{Name: "org.freedesktop.DBus.NameOwnerChanged", Body: []interface{}{"org.freedesktop.systemd1", ":1.1", ":1.2"}}, true},
+		{&dbus.Signal{Name: "org.freedesktop.DBus.NameOwnerChanged", Body: []interface{}{"org.freedesktop.systemd1", ":1.1", ""}}, false},
+		{&dbus.Signal{Name: "org.freedesktop.DBus.NameOwnerChanged", Body: []interface{}{"org.freedesktop.login1", "", ":1.2"}}, false},
+		{&dbus.Signal{Name: "org.freedesktop.systemd1.Manager.UnitNew", Body: []interface{}{"foo.service", dbus.ObjectPath("/")}}, false},
+	}
+
+	for _, tt := range tests {
+		if got := isSystemdRestart(tt.signal); got != tt.expected {
+			t.Errorf("isSystemdRestart(%v): expected %v, got %v", tt.signal.Body, tt.expected, got)
+		}
+	}
+}
+
+// Ensure that a ResilientConn re-establishes a lost connection.
+func TestResilientConnReconnect(t *testing.T) {
+	events := make(chan ReconnectEvent, 1)
+
+	r, err := NewResilientConnection(context.Background(), ReconnectOptions{
+		OnReconnect: func(ev ReconnectEvent) { events <- ev },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if err := r.Subscribe(); err != nil {
+		t.Fatal(err)
+	}
+
+	old := r.Conn()
+	old.Close()
+
+	select {
+	case ev := <-events:
+		if !ev.Redialed {
+			t.Fatal("expected connection to be redialed")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for reconnect")
+	}
+
+	if r.Conn() == old {
+		t.Fatal("connection was not replaced")
+	}
+	if !r.Conn().Connected() {
+		t.Fatal("new connection is not connected")
+	}
+	if _, err := r.Conn().ListUnitsContext(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Ensure that a subscription and a match rule requested while the connection
+// is lost are established on the next connection.
+func TestResilientConnSubscribeDisconnected(t *testing.T) {
+	events := make(chan ReconnectEvent, 1)
+	redial := make(chan struct{})
+	dials := 0
+
+	r, err := NewResilientConnection(context.Background(), ReconnectOptions{
+		Dial: func(ctx context.Context) (*Conn, error) {
+			dials++
+			if dials > 1 {
+				<-redial
+			}
+			return NewWithContext(ctx)
+		},
+		OnReconnect: func(ev ReconnectEvent) { events <- ev },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	r.Conn().Close()
+
+	rule := "type='signal',interface='org.freedesktop.systemd1.Manager',member='Reloading'"
+	if err := r.Subscribe(); err == nil {
+		t.Fatal("expected an error subscribing on a closed connection")
+	}
+	if err := r.AddMatch(rule); err == nil {
+		t.Fatal("expected an error adding a match rule on a closed connection")
+	}
+	close(redial)
+
+	select {
+	case ev := <-events:
+		if !ev.Redialed {
+			t.Fatal("expected connection to be redialed")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for reconnect")
+	}
+
+	r.mu.Lock()
+	subscribed, matches := r.subscribed, r.matches
+	r.mu.Unlock()
+	if !subscribed {
+		t.Error("expected the subscription to be kept across the reconnect")
+	}
+	if len(matches) != 1 || matches[0] != rule {
+		t.Errorf("expected match rules [%s], got %v", rule, matches)
+	}
+	if err := r.Conn().Unsubscribe(); err != nil {
+		t.Errorf("expected the new connection to be subscribed: %v", err)
+	}
+}
+
+// Ensure that restoring the connection after a restart of systemd does not
+// report an error for the subscription kept by systemd.
+func TestResilientConnRestoreSubscribed(t *testing.T) {
+	r, err := NewResilientConnection(context.Background(), ReconnectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	errCh := make(chan error, 1)
+	r.SetSubStateSubscriber(make(chan *SubStateUpdate, 1), errCh)
+	if err := r.Subscribe(); err != nil {
+		t.Fatal(err)
+	}
+
+	r.mu.Lock()
+	r.restore(r.conn, false)
+	r.mu.Unlock()
+
+	select {
+	case err := <-errCh:
+		t.Fatalf("unexpected error restoring the connection: %v", err)
+	default:
+	}
+}
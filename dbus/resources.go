@@ -0,0 +1,300 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// ResourceSpec holds resource-control settings in the same human readable
+// notation used in unit files, see
+// https://www.freedesktop.org/software/systemd/man/systemd.resource-control.html
+//
+// Empty fields are left untouched.
+type ResourceSpec struct {
+	CPUQuota    string // CPU time quota as a percentage, e.g. "200%", or "infinity" to remove it
+	MemoryMax   string // Hard memory limit, e.g. "2G", "50%" or "infinity"
+	MemoryHigh  string // Memory throttling limit, e.g. "1536M", "40%" or "infinity"
+	TasksMax    string // Maximum number of tasks, e.g. "512", "10%" or "infinity"
+	IOWeight    string // IO weight between 1 and 10000, e.g. "100"
+	AllowedCPUs string // CPU list, e.g. "0-3,6"
+
+	// Runtime controls whether the settings only apply until the next
+	// reboot (true) or are also stored on disk (false).
+	Runtime bool
+}
+
+// Properties translates the specification into the D-Bus properties
+// understood by SetUnitPropertiesContext and StartTransientUnitContext.
+func (r ResourceSpec) Properties() ([]Property, error) {
+	var props []Property
+
+	if r.CPUQuota != "" {
+		p, err := PropCPUQuota(r.CPUQuota)
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, p)
+	}
+	if r.MemoryMax != "" {
+		p, err := propMemory("MemoryMax", r.MemoryMax)
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, p)
+	}
+	if r.MemoryHigh != "" {
+		p, err := propMemory("MemoryHigh", r.MemoryHigh)
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, p)
+	}
+	if r.TasksMax != "" {
+		p, err := PropTasksMax(r.TasksMax)
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, p)
+	}
+	if r.IOWeight != "" {
+		p, err := PropIOWeight(r.IOWeight)
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, p)
+	}
+	if r.AllowedCPUs != "" {
+		p, err := PropAllowedCPUs(r.AllowedCPUs)
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, p)
+	}
+
+	return props, nil
+}
+
+// SetUnitResources applies the resource-control settings in spec to a unit
+// using SetUnitPropertiesContext.
+func (c *Conn) SetUnitResources(ctx context.Context, unit string, spec ResourceSpec) error {
+	props, err := spec.Properties()
+	if err != nil {
+		return err
+	}
+	if len(props) == 0 {
+		return errors.New("no resource settings specified")
+	}
+
+	return c.SetUnitPropertiesContext(ctx, unit, spec.Runtime, props...)
+}
+
+// PropCPUQuota sets the CPUQuotaPerSecUSec property from a percentage such as
+// "20%" or "200%". CPUQuota= has no "infinity" form in unit files, where the
+// quota is removed by assigning the empty string; PropCPUQuota accepts
+// "infinity" for that instead, since ResourceSpec leaves empty fields
+// untouched. See
+// https://www.freedesktop.org/software/systemd/man/systemd.resource-control.html#CPUQuota=
+func PropCPUQuota(quota string) (Property, error) {
+	if quota == "infinity" {
+		return Property{Name: "CPUQuotaPerSecUSec", Value: dbus.MakeVariant(uint64(math.MaxUint64))}, nil
+	}
+	if !strings.HasSuffix(quota, "%") {
+		return Property{}, fmt.Errorf("invalid CPU quota %q: must be a percentage", quota)
+	}
+	pct, err := strconv.ParseFloat(strings.TrimSuffix(quota, "%"), 64)
+	if err != nil || pct <= 0 {
+		return Property{}, fmt.Errorf("invalid CPU quota %q", quota)
+	}
+
+	// systemd stores the quota as CPU time (in µs) granted per second.
+	usec := uint64(pct * 10000)
+
+	return Property{Name: "CPUQuotaPerSecUSec", Value: dbus.MakeVariant(usec)}, nil
+}
+
+// PropMemoryMax sets the MemoryMax (or MemoryMaxScale, for percentages)
+// property. See
+// https://www.freedesktop.org/software/systemd/man/systemd.resource-control.html#MemoryMax=bytes
+func PropMemoryMax(limit string) (Property, error) {
+	return propMemory("MemoryMax", limit)
+}
+
+// PropMemoryHigh sets the MemoryHigh (or MemoryHighScale, for percentages)
+// property. See
+// https://www.freedesktop.org/software/systemd/man/systemd.resource-control.html#MemoryHigh=bytes
+func PropMemoryHigh(limit string) (Property, error) {
+	return propMemory("MemoryHigh", limit)
+}
+
+func propMemory(name, limit string) (Property, error) {
+	if strings.HasSuffix(limit, "%") {
+		scale, err := parseScale(limit)
+		if err != nil {
+			return Property{}, fmt.Errorf("invalid %s %q: %v", name, limit, err)
+		}
+		return Property{Name: name + "Scale", Value: dbus.MakeVariant(scale)}, nil
+	}
+
+	bytes, err := parseSize(limit)
+	if err != nil {
+		return Property{}, fmt.Errorf("invalid %s %q: %v", name, limit, err)
+	}
+
+	return Property{Name: name, Value: dbus.MakeVariant(bytes)}, nil
+}
+
+// PropTasksMax sets the TasksMax (or TasksMaxScale, for percentages)
+// property. See
+// https://www.freedesktop.org/software/systemd/man/systemd.resource-control.html#TasksMax=N
+func PropTasksMax(limit string) (Property, error) {
+	if strings.HasSuffix(limit, "%") {
+		scale, err := parseScale(limit)
+		if err != nil {
+			return Property{}, fmt.Errorf("invalid TasksMax %q: %v", limit, err)
+		}
+		return Property{Name: "TasksMaxScale", Value: dbus.MakeVariant(scale)}, nil
+	}
+
+	n := uint64(math.MaxUint64)
+	if limit != "infinity" {
+		var err error
+		n, err = strconv.ParseUint(limit, 10, 64)
+		if err != nil {
+			return Property{}, fmt.Errorf("invalid TasksMax %q", limit)
+		}
+	}
+
+	return Property{Name: "TasksMax", Value: dbus.MakeVariant(n)}, nil
+}
+
+// PropIOWeight sets the IOWeight property. See
+// https://www.freedesktop.org/software/systemd/man/systemd.resource-control.html#IOWeight=weight
+func PropIOWeight(weight string) (Property, error) {
+	n, err := strconv.ParseUint(weight, 10, 64)
+	if err != nil || n < 1 || n > 10000 {
+		return Property{}, fmt.Errorf("invalid IOWeight %q: must be between 1 and 10000", weight)
+	}
+
+	return Property{Name: "IOWeight", Value: dbus.MakeVariant(n)}, nil
+}
+
+// PropAllowedCPUs sets the AllowedCPUs property from a CPU list such as
+// "0-3,6". See
+// https://www.freedesktop.org/software/systemd/man/systemd.resource-control.html#AllowedCPUs=
+func PropAllowedCPUs(cpus string) (Property, error) {
+	mask, err := parseCPUSet(cpus)
+	if err != nil {
+		return Property{}, fmt.Errorf("invalid AllowedCPUs %q: %v", cpus, err)
+	}
+
+	return Property{Name: "AllowedCPUs", Value: dbus.MakeVariant(mask)}, nil
+}
+
+// parseSize parses a byte size with an optional base-1024 suffix (K, M, G,
+// T, P, E), or the special value "infinity".
+func parseSize(s string) (uint64, error) {
+	if s == "infinity" {
+		return math.MaxUint64, nil
+	}
+
+	num := strings.TrimSpace(s)
+	mult := uint64(1)
+	if num != "" {
+		switch num[len(num)-1] {
+		case 'K':
+			mult = 1 << 10
+		case 'M':
+			mult = 1 << 20
+		case 'G':
+			mult = 1 << 30
+		case 'T':
+			mult = 1 << 40
+		case 'P':
+			mult = 1 << 50
+		case 'E':
+			mult = 1 << 60
+		}
+		if mult != 1 {
+			num = num[:len(num)-1]
+		}
+	}
+
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil || f < 0 {
+		return 0, errors.New("not a valid size")
+	}
+	if f*float64(mult) >= math.MaxUint64 {
+		return 0, errors.New("size out of range")
+	}
+
+	return uint64(f * float64(mult)), nil
+}
+
+// parseScale converts a percentage into the uint32 scale used by the
+// *Scale properties, where UINT32_MAX corresponds to 100%.
+func parseScale(s string) (uint32, error) {
+	pct, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	if err != nil || pct < 0 || pct > 100 {
+		return 0, errors.New("percentage must be between 0 and 100")
+	}
+
+	return uint32(math.Round(pct / 100 * math.MaxUint32)), nil
+}
+
+// parseCPUSet converts a CPU list into the little endian bitmask used by
+// systemd for CPU and NUMA node sets.
+func parseCPUSet(s string) ([]byte, error) {
+	var mask []byte
+
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		lo, hi := part, part
+		if i := strings.IndexByte(part, '-'); i >= 0 {
+			lo, hi = part[:i], part[i+1:]
+		}
+
+		start, err := strconv.ParseUint(lo, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CPU %q", lo)
+		}
+		end, err := strconv.ParseUint(hi, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CPU %q", hi)
+		}
+		if end < start {
+			return nil, fmt.Errorf("invalid CPU range %q", part)
+		}
+
+		for cpu := start; cpu <= end; cpu++ {
+			for uint64(len(mask)) <= cpu/8 {
+				mask = append(mask, 0)
+			}
+			mask[cpu/8] |= 1 << (cpu % 8)
+		}
+	}
+
+	if len(mask) == 0 {
+		return nil, errors.New("empty CPU list")
+	}
+
+	return mask, nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestResourceSpecProperties(t *testing.T) {
+	spec := ResourceSpec{
+		CPUQuota:    "200%",
+		MemoryMax:   "2G",
+		MemoryHigh:  "50%",
+		TasksMax:    "infinity",
+		IOWeight:    "100",
+		AllowedCPUs: "0-3,9",
+	}
+
+	props, err := spec.Properties()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"CPUQuotaPerSecUSec": uint64(2000000),
+		"MemoryMax":          uint64(2 << 30),
+		"MemoryHighScale":    uint32(math.MaxUint32/2 + 1),
+		"TasksMax":           uint64(math.MaxUint64),
+		"IOWeight":           uint64(100),
+		"AllowedCPUs":        []byte{0x0f, 0x02},
+	}
+
+	if len(props) != len(expected) {
+		t.Fatalf("expected %d properties, got %d: %v", len(expected), len(props), props)
+	}
+	for _, p := range props {
+		want, ok := expected[p.Name]
+		if !ok {
+			t.Errorf("unexpected property %s", p.Name)
+			continue
+		}
+		if !reflect.DeepEqual(p.Value.Value(), want) {
+			t.Errorf("property %s: expected %v, got %v", p.Name, want, p.Value.Value())
+		}
+	}
+}
+
+func TestPropCPUQuotaInfinity(t *testing.T) {
+	p, err := PropCPUQuota("infinity")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "CPUQuotaPerSecUSec" || p.Value.Value() != uint64(math.MaxUint64) {
+		t.Fatalf("expected CPUQuotaPerSecUSec to be reset, got %s=%v", p.Name, p.Value.Value())
+	}
+}
+
+func TestResourceSpecInvalid(t *testing.T) {
+	specs := []ResourceSpec{
+		{CPUQuota: "2"},
+		{CPUQuota: "-5%"},
+		{MemoryMax: "lots"},
+		{MemoryHigh: "150%"},
+		{TasksMax: "-1"},
+		{IOWeight: "0"},
+		{IOWeight: "20000"},
+		{AllowedCPUs: "3-1"},
+		{AllowedCPUs: "a"},
+	}
+
+	for _, spec := range specs {
+		if _, err := spec.Properties(); err == nil {
+			t.Errorf("expected error for %+v", spec)
+		}
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in  string
+		out uint64
+	}{
+		{"1024", 1024},
+		{"1K", 1 << 10},
+		{"1.5M", 3 << 19},
+		{"2G", 2 << 30},
+		{"1T", 1 << 40},
+		{"infinity", math.MaxUint64},
+	}
+
+	for _, tt := range tests {
+		out, err := parseSize(tt.in)
+		if err != nil {
+			t.Errorf("parseSize(%q): %v", tt.in, err)
+			continue
+		}
+		if out != tt.out {
+			t.Errorf("parseSize(%q): expected %d, got %d", tt.in, tt.out, out)
+		}
+	}
+}
+
+func TestSetUnitResources(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	unit := "-.mount"
+
+	if err := conn.SetUnitResources(context.Background(), unit, ResourceSpec{TasksMax: "4096", Runtime: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := conn.GetUnitTypePropertyContext(context.Background(), unit, "Mount", "TasksMax")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value.Value.Value() != uint64(4096) {
+		t.Fatalf("expected TasksMax=4096, got %v", value.Value.Value())
+	}
+}
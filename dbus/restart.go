@@ -0,0 +1,233 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RestartOptions configures RestartUnits.
+type RestartOptions struct {
+	// Mode is the job mode of the restart jobs, JobModeReplace if empty.
+	Mode JobMode
+	// SettleTime is how long a unit must stay active after its restart job
+	// completed to be considered successfully restarted. For services with
+	// a watchdog, at least twice the watchdog interval is waited, so that a
+	// service failing to ping the watchdog is detected.
+	SettleTime time.Duration
+	// Rollback enables returning the units restarted so far, including the
+	// one that failed, to their previous state when a restart fails: units
+	// which were active are restarted again and the others are stopped.
+	Rollback bool
+	// OnFailure, if set, is called with the failed unit before rolling back,
+	// e.g. to revert the configuration the units were restarted for. If it
+	// returns an error, no rollback is done.
+	OnFailure func(ctx context.Context, unit string) error
+}
+
+// RestartStep is the outcome of restarting a single unit.
+type RestartStep struct {
+	Unit          string
+	PreviousState ActiveState // The active state before the restart
+	Err           error       // Set if the restart failed
+	RolledBack    bool        // Whether the unit was returned to its previous state
+	RollbackErr   error       // Set if rolling back the unit failed
+}
+
+// RestartReport describes what RestartUnits did.
+type RestartReport struct {
+	Plan   []string      // The units in the order they were to be restarted
+	Steps  []RestartStep // The units restarted, in order, up to and including the failed one
+	Failed string        // The unit whose restart failed, if any
+}
+
+// RestartUnits restarts the given units one after another, in an order
+// respecting the After= and Before= dependencies between them, and waits for
+// each restart to complete before moving on to the next unit. If a restart
+// fails, the remaining units are left alone and, if requested in opts, the
+// already restarted units are rolled back. The returned report is non-nil
+// whenever the plan could be made, even if an error is returned.
+func (c *Conn) RestartUnits(ctx context.Context, names []string, opts RestartOptions) (*RestartReport, error) {
+	if opts.Mode == "" {
+		opts.Mode = JobModeReplace
+	}
+
+	props := make(map[string]map[string]interface{}, len(names))
+	for _, name := range names {
+		p, err := c.GetUnitPropertiesContext(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		props[name] = p
+	}
+
+	plan, err := restartOrder(names, props)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RestartReport{Plan: plan}
+	for _, name := range plan {
+		state, _ := props[name]["ActiveState"].(string)
+		step := RestartStep{Unit: name, PreviousState: ActiveState(state)}
+
+		step.Err = c.restartAndSettle(ctx, name, opts)
+		report.Steps = append(report.Steps, step)
+		if step.Err == nil {
+			continue
+		}
+
+		report.Failed = name
+		if opts.Rollback {
+			c.rollbackRestarts(ctx, report, opts)
+		}
+		return report, fmt.Errorf("failed to restart %s: %w", name, step.Err)
+	}
+
+	return report, nil
+}
+
+// waitJob runs a job started by start and waits for its result.
+func waitJob(ctx context.Context, start func(ch chan<- string) (int, error)) error {
+	ch := make(chan string, 1)
+	if _, err := start(ch); err != nil {
+		return err
+	}
+
+	select {
+	case result := <-ch:
+		if result != "done" {
+			return fmt.Errorf("job finished with result %s", result)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Conn) restartAndSettle(ctx context.Context, name string, opts RestartOptions) error {
+	err := waitJob(ctx, func(ch chan<- string) (int, error) {
+		return c.RestartUnitContext(ctx, name, opts.Mode, ch)
+	})
+	if err != nil {
+		return err
+	}
+
+	settle := opts.SettleTime
+	if strings.HasSuffix(name, ".service") {
+		if p, err := c.GetServicePropertyContext(ctx, name, "WatchdogUSec"); err == nil {
+			if usec, ok := p.Value.Value().(uint64); ok && usec > 0 {
+				if w := 2 * usecToDuration(usec); w > settle {
+					settle = w
+				}
+			}
+		}
+	}
+
+	if settle > 0 {
+		select {
+		case <-time.After(settle):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	p, err := c.GetUnitPropertyContext(ctx, name, "ActiveState")
+	if err != nil {
+		return err
+	}
+	if state, _ := p.Value.Value().(string); ActiveState(state) != StateActive {
+		return fmt.Errorf("unit is %s after restart", state)
+	}
+
+	return nil
+}
+
+func (c *Conn) rollbackRestarts(ctx context.Context, report *RestartReport, opts RestartOptions) {
+	if opts.OnFailure != nil {
+		if err := opts.OnFailure(ctx, report.Failed); err != nil {
+			return
+		}
+	}
+
+	for i := len(report.Steps) - 1; i >= 0; i-- {
+		step := &report.Steps[i]
+		step.RollbackErr = waitJob(ctx, func(ch chan<- string) (int, error) {
+			if step.PreviousState == StateActive || step.PreviousState == StateReloading {
+				return c.RestartUnitContext(ctx, step.Unit, opts.Mode, ch)
+			}
+			return c.StopUnitContext(ctx, step.Unit, opts.Mode, ch)
+		})
+		step.RolledBack = step.RollbackErr == nil
+	}
+}
+
+// restartOrder sorts names topologically according to the After= and
+// Before= dependencies between them found in props. Units without a
+// dependency between them keep their relative order.
+func restartOrder(names []string, props map[string]map[string]interface{}) ([]string, error) {
+	inSet := make(map[string]bool, len(names))
+	for _, name := range names {
+		inSet[name] = true
+	}
+
+	// before[a] lists the units which must be restarted after a
+	before := make(map[string][]string)
+	pending := make(map[string]int)
+	addEdge := func(first, then string) {
+		if first == then || !inSet[first] || !inSet[then] {
+			return
+		}
+		before[first] = append(before[first], then)
+		pending[then]++
+	}
+
+	for _, name := range names {
+		after, _ := props[name]["After"].([]string)
+		for _, dep := range after {
+			addEdge(dep, name)
+		}
+		befores, _ := props[name]["Before"].([]string)
+		for _, dep := range befores {
+			addEdge(name, dep)
+		}
+	}
+
+	var order []string
+	done := make(map[string]bool, len(names))
+	for len(order) < len(inSet) {
+		next := ""
+		for _, name := range names {
+			if !done[name] && pending[name] == 0 {
+				next = name
+				break
+			}
+		}
+		if next == "" {
+			return nil, fmt.Errorf("ordering cycle between units %v", names)
+		}
+
+		done[next] = true
+		order = append(order, next)
+		for _, then := range before[next] {
+			pending[then]--
+		}
+	}
+
+	return order, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestRestartOrder(t *testing.T) {
+	props := map[string]map[string]interface{}{
+		"app.service":   {"After": []string{"db.service", "network.target"}},
+		"db.service":    {"After": []string{"network.target"}},
+		"cache.service": {"Before": []string{"app.service"}},
+		"log.service":   {},
+	}
+
+	order, err := restartOrder([]string{"app.service", "log.service", "cache.service", "db.service"}, props)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"log.service", "cache.service", "db.service", "app.service"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Fatalf("expected order %v, got %v", expected, order)
+	}
+
+	props["db.service"]["After"] = []string{"app.service"}
+	if _, err := restartOrder([]string{"app.service", "db.service"}, props); err == nil {
+		t.Fatal("expected error for ordering cycle")
+	}
+}
+
+func TestRestartUnits(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	for _, unit := range []string{"start-stop.service", "start-failed.service"} {
+		setupUnit(unit, conn, t)
+		linkUnit(unit, conn, t)
+	}
+
+	ctx := context.Background()
+
+	report, err := conn.RestartUnits(ctx, []string{"start-stop.service"}, RestartOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.StopUnit("start-stop.service", "replace", nil)
+	if len(report.Steps) != 1 || report.Steps[0].Err != nil || report.Failed != "" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	report, err = conn.RestartUnits(ctx, []string{"start-stop.service", "start-failed.service"}, RestartOptions{Rollback: true})
+	if err == nil {
+		t.Fatal("expected restart of start-failed.service to fail")
+	}
+	defer conn.ResetFailedUnit("start-failed.service")
+	if report.Failed != "start-failed.service" {
+		t.Fatalf("unexpected failed unit %q", report.Failed)
+	}
+	for _, step := range report.Steps {
+		if step.Unit == "start-stop.service" && !step.RolledBack {
+			t.Fatalf("start-stop.service not rolled back: %v", step.RollbackErr)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// cleanupTimeout bounds the calls of RunTransientCommand cleaning up the
+// service, which are made even if the context of the command is done.
+const cleanupTimeout = 10 * time.Second
+
+// RunOptions configures the transient service created by
+// RunTransientCommand.
+type RunOptions struct {
+	Name             string     // The unit name, generated if empty
+	Description      string     // The unit description, the command line if empty
+	WorkingDirectory string     // The working directory of the command
+	Environment      []string   // Environment variables in the form KEY=VALUE
+	Stdout           *os.File   // Connected to the standard output of the command if set, the output goes to the journal otherwise
+	Stderr           *os.File   // Connected to the standard error of the command if set, the output goes to the journal otherwise
+	Properties       []Property // Additional properties of the service, e.g. resource limits
+}
+
+// RunResult describes how the command started by RunTransientCommand exited.
+type RunResult struct {
+	Unit       string // The name of the transient service
+	Result     string // The Result property of the service, e.g. "success", "exit-code", "signal" or "timeout"
+	ExitCode   int32  // The exit code of the main process as a CLD_* value: 1 for exited, 2 for killed, 3 for dumped
+	ExitStatus int32  // The exit status of the main process, or the signal number if it was killed
+}
+
+// RunTransientCommand runs argv as a transient service, like
+// systemd-run --wait, and returns once the command exited. The absolute path
+// of the binary must be given in argv[0]. A non-zero exit is not reported as an
+// error; it is reflected in the returned RunResult instead.
+//
+// If ctx is done before the command exits, the service is stopped and
+// ctx.Err() is returned. Requires systemd v244 or higher.
+func (c *Conn) RunTransientCommand(ctx context.Context, argv []string, opts RunOptions) (*RunResult, error) {
+	if len(argv) == 0 {
+		return nil, errors.New("no command given")
+	}
+
+	name := opts.Name
+	if name == "" {
+		var b [8]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			return nil, err
+		}
+		name = "run-r" + hex.EncodeToString(b[:]) + ".service"
+	}
+
+	description := opts.Description
+	if description == "" {
+		description = strings.Join(argv, " ")
+	}
+
+	props := []Property{
+		PropDescription(description),
+		PropType("oneshot"),
+		PropExecStart(argv, true),
+		// keep the unit loaded after exiting, until unreferenced, so that the
+		// exit status can be read
+		{Name: "AddRef", Value: dbus.MakeVariant(true)},
+		{Name: "TimeoutStartUSec", Value: dbus.MakeVariant(uint64(math.MaxUint64))},
+	}
+	if opts.WorkingDirectory != "" {
+		props = append(props, Property{Name: "WorkingDirectory", Value: dbus.MakeVariant(opts.WorkingDirectory)})
+	}
+	if len(opts.Environment) > 0 {
+		props = append(props, Property{Name: "Environment", Value: dbus.MakeVariant(opts.Environment)})
+	}
+	props = append(props, propOutput("StandardOutput", opts.Stdout), propOutput("StandardError", opts.Stderr))
+	props = append(props, opts.Properties...)
+
+	ch := make(chan string, 1)
+	if _, err := c.StartTransientUnitContext(ctx, name, JobModeFail, props, ch); err != nil {
+		return nil, err
+	}
+	defer c.releaseTransientService(ctx, name)
+
+	select {
+	case <-ch:
+	case <-ctx.Done():
+		stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+		defer cancel()
+		c.StopUnitContext(stopCtx, name, JobModeReplace, nil)
+		return nil, ctx.Err()
+	}
+
+	info, err := c.GetUnitTypePropertiesContext(ctx, name, "Service")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RunResult{Unit: name}
+	var ok bool
+	if result.Result, ok = info["Result"].(string); !ok {
+		return nil, errors.New("failed to typecast Result to string")
+	}
+	if result.ExitCode, ok = info["ExecMainCode"].(int32); !ok {
+		return nil, errors.New("failed to typecast ExecMainCode to int32")
+	}
+	if result.ExitStatus, ok = info["ExecMainStatus"].(int32); !ok {
+		return nil, errors.New("failed to typecast ExecMainStatus to int32")
+	}
+
+	return result, nil
+}
+
+// propOutput connects the given output of a service to f, or to the journal
+// if f is nil.
+func propOutput(name string, f *os.File) Property {
+	if f == nil {
+		return Property{Name: name, Value: dbus.MakeVariant("journal")}
+	}
+
+	return Property{
+		Name:  name + "FileDescriptor",
+		Value: dbus.MakeVariant(dbus.UnixFD(f.Fd())),
+	}
+}
+
+// releaseTransientService drops the reference taken on the service by
+// RunTransientCommand and resets its failed state, so that it is unloaded.
+func (c *Conn) releaseTransientService(ctx context.Context, name string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+	defer cancel()
+	c.systemdPathObject(unitPath(name)).CallWithContext(ctx, "org.freedesktop.systemd1.Unit.Unref", 0)
+	c.ResetFailedUnitContext(ctx, name)
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRunTransientCommand(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	res, err := conn.RunTransientCommand(ctx, []string{"/bin/sh", "-c", "echo hello; exit 3"}, RunOptions{Stdout: w})
+	w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Result != "exit-code" || res.ExitCode != 1 || res.ExitStatus != 3 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "hello\n" {
+		t.Fatalf("unexpected output %q", out)
+	}
+
+	res, err = conn.RunTransientCommand(ctx, []string{"/bin/true"}, RunOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Result != "success" || res.ExitStatus != 0 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// DefaultSnapshotProperties is the property set fetched by SnapshotUnits
+// when no properties are specified.
+var DefaultSnapshotProperties = []string{
+	"Id",
+	"LoadState",
+	"ActiveState",
+	"SubState",
+	"UnitFileState",
+	"ActiveEnterTimestamp",
+	"InactiveEnterTimestamp",
+	"MainPID",
+	"NRestarts",
+	"Result",
+}
+
+// propertyFetchConcurrency bounds the number of concurrent
+// Properties.GetAll calls issued over a single connection.
+const propertyFetchConcurrency = 16
+
+// UnitSnapshot holds the state of a single unit at the time of a snapshot.
+type UnitSnapshot struct {
+	UnitStatus
+	Properties map[string]interface{} `json:"properties,omitempty"` // The requested properties which the unit has
+	Error      string                 `json:"error,omitempty"`      // Set if the properties of the unit could not be fetched
+}
+
+// UnitsSnapshot is a point-in-time view of a set of units. It can be
+// serialized with encoding/json.
+type UnitsSnapshot struct {
+	Timestamp time.Time      `json:"timestamp"`
+	Units     []UnitSnapshot `json:"units"`
+}
+
+// SnapshotUnits lists the units matching patterns (all loaded units if
+// patterns is empty) and fetches the given properties of each of them
+// concurrently over the connection. If properties is nil,
+// DefaultSnapshotProperties is used. Properties may belong to any interface of
+// the unit object, e.g. "MainPID" for services.
+//
+// Failing to fetch the properties of an individual unit (e.g. because it was
+// unloaded in the meantime) does not fail the snapshot; the error is recorded
+// in the Error field of that unit instead.
+func (c *Conn) SnapshotUnits(ctx context.Context, patterns []string, properties []string) (*UnitsSnapshot, error) {
+	if properties == nil {
+		properties = DefaultSnapshotProperties
+	}
+
+	var units []UnitStatus
+	var err error
+	if len(patterns) == 0 {
+		units, err = c.ListUnitsContext(ctx)
+	} else {
+		units, err = c.ListUnitsByPatternsContext(ctx, nil, patterns)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snap := &UnitsSnapshot{
+		Timestamp: time.Now(),
+		Units:     make([]UnitSnapshot, len(units)),
+	}
+
+	paths := make([]dbus.ObjectPath, len(units))
+	for i, u := range units {
+		snap.Units[i].UnitStatus = u
+		paths[i] = u.Path
+	}
+
+	results := c.fetchProperties(ctx, paths, "", properties, propertyFetchConcurrency)
+	for i, r := range results {
+		if r.err != nil {
+			snap.Units[i].Error = r.err.Error()
+			continue
+		}
+		snap.Units[i].Properties = r.props
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return snap, nil
+}
+
+// UnitProperties holds the properties of a unit fetched by
+// GetUnitsProperties.
+type UnitProperties struct {
+	Name       string
+	Properties map[string]interface{} // The requested properties which the unit has
+	Err        error                  // Set if the properties of the unit could not be fetched
+}
+
+// GetUnitsProperties fetches the given properties (all properties if
+// properties is empty) of each of the named units. Properties may belong to
+// any interface of the unit object, e.g. "MainPID" for services. The
+// Properties.GetAll calls are pipelined over the connection, with at most 16
+// in flight at a time, so that querying many units takes hardly longer than
+// querying one.
+//
+// The results are returned in the order of names. Failing to fetch the
+// properties of an individual unit is reported in its Err field; an error is
+// only returned if ctx is done.
+func (c *Conn) GetUnitsProperties(ctx context.Context, names []string, properties []string) ([]UnitProperties, error) {
+	paths := make([]dbus.ObjectPath, len(names))
+	for i, name := range names {
+		paths[i] = unitPath(name)
+	}
+
+	results := c.fetchProperties(ctx, paths, "", properties, propertyFetchConcurrency)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	units := make([]UnitProperties, len(names))
+	for i, r := range results {
+		units[i] = UnitProperties{Name: names[i], Properties: r.props, Err: r.err}
+	}
+
+	return units, nil
+}
+
+type propertiesResult struct {
+	props map[string]interface{}
+	err   error
+}
+
+// fetchProperties calls Properties.GetAll for each path with at most
+// concurrency calls in flight, and keeps only the requested properties (all
+// of them if properties is empty). Results are returned in the order of paths.
+func (c *Conn) fetchProperties(ctx context.Context, paths []dbus.ObjectPath, dbusInterface string, properties []string, concurrency int) []propertiesResult {
+	results := make([]propertiesResult, len(paths))
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	wanted := make(map[string]bool, len(properties))
+	for _, p := range properties {
+		wanted[p] = true
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, path := range paths {
+		select {
+		case <-ctx.Done():
+			results[i].err = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(i int, path dbus.ObjectPath) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			props, err := c.getProperties(ctx, path, dbusInterface)
+			if err != nil {
+				results[i].err = err
+				return
+			}
+
+			if len(wanted) > 0 {
+				for k := range props {
+					if !wanted[k] {
+						delete(props, k)
+					}
+				}
+			}
+			results[i].props = props
+		}(i, path)
+	}
+
+	wg.Wait()
+	return results
+}
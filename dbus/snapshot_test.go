@@ -0,0 +1,81 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestSnapshotUnits(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	snap, err := conn.SnapshotUnits(context.Background(), []string{"*.mount"}, []string{"Id", "ActiveState", "Where"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(snap.Units) == 0 {
+		t.Fatal("no mount units in snapshot")
+	}
+
+	for _, u := range snap.Units {
+		if u.Error != "" {
+			continue
+		}
+		if u.Properties["Id"] != u.Name {
+			t.Fatalf("expected Id %s, got %v", u.Name, u.Properties["Id"])
+		}
+		if _, ok := u.Properties["Where"]; !ok {
+			t.Fatalf("type-specific property Where missing for %s", u.Name)
+		}
+		if _, ok := u.Properties["SubState"]; ok {
+			t.Fatalf("unrequested property SubState returned for %s", u.Name)
+		}
+	}
+
+	if _, err := json.Marshal(snap); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetUnitsProperties(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	names := []string{"systemd-journald.service", "-.mount", "invalid unit name"}
+	units, err := conn.GetUnitsProperties(context.Background(), names, []string{"Id", "MainPID"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(units) != len(names) {
+		t.Fatalf("expected %d results, got %d", len(names), len(units))
+	}
+	if units[0].Err != nil || units[0].Properties["Id"] != names[0] {
+		t.Fatalf("unexpected result for %s: %+v", names[0], units[0])
+	}
+	if _, ok := units[0].Properties["MainPID"]; !ok {
+		t.Fatalf("MainPID missing for %s", names[0])
+	}
+	if _, ok := units[1].Properties["MainPID"]; ok {
+		t.Fatalf("MainPID returned for %s", names[1])
+	}
+	if units[2].Err == nil {
+		t.Fatal("expected error for invalid unit name")
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+// JobMode specifies how a job interacts with already queued jobs. See
+// StartUnitContext for the meaning of each mode. Modes without a constant
+// here, e.g. those of newer systemd versions, can be converted from a string.
+type JobMode string
+
+const (
+	// JobModeReplace replaces already queued jobs that conflict with the new one.
+	JobModeReplace JobMode = "replace"
+	// JobModeFail fails if the new job would conflict with already queued jobs.
+	JobModeFail JobMode = "fail"
+	// JobModeIsolate stops all units that aren't dependencies of the unit.
+	JobModeIsolate JobMode = "isolate"
+	// JobModeIgnoreDependencies ignores all dependencies of the unit.
+	JobModeIgnoreDependencies JobMode = "ignore-dependencies"
+	// JobModeIgnoreRequirements only ignores the requirement dependencies of the unit.
+	JobModeIgnoreRequirements JobMode = "ignore-requirements"
+	// JobModeFlush cancels all queued jobs when the new job is enqueued.
+	JobModeFlush JobMode = "flush"
+	// JobModeReplaceIrreversibly is like JobModeReplace, but the new job
+	// cannot be replaced by later jobs.
+	JobModeReplaceIrreversibly JobMode = "replace-irreversibly"
+	// JobModeTriggering also stops the units triggered by the unit (stop jobs only).
+	JobModeTriggering JobMode = "triggering"
+)
+
+// ActiveState is the high-level state of a unit, as reported in the
+// ActiveState property and UnitStatus.ActiveState.
+type ActiveState string
+
+const (
+	// StateActive means the unit is started, bound, plugged in, etc.
+	StateActive ActiveState = "active"
+	// StateReloading means the unit is active and reloading its configuration.
+	StateReloading ActiveState = "reloading"
+	// StateInactive means the unit is stopped.
+	StateInactive ActiveState = "inactive"
+	// StateFailed means the unit is stopped after a failure.
+	StateFailed ActiveState = "failed"
+	// StateActivating means the unit is changing from inactive to active.
+	StateActivating ActiveState = "activating"
+	// StateDeactivating means the unit is changing from active to inactive.
+	StateDeactivating ActiveState = "deactivating"
+	// StateMaintenance means the unit is inactive while a maintenance
+	// operation such as CleanUnit is in progress.
+	StateMaintenance ActiveState = "maintenance"
+	// StateRefreshing means the unit is active while new mounts are
+	// propagated to it.
+	StateRefreshing ActiveState = "refreshing"
+)
@@ -0,0 +1,46 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// TestUnitStatusActiveState ensures that the ActiveState of listed units can
+// be compared against the state constants.
+func TestUnitStatusActiveState(t *testing.T) {
+	c := &Conn{}
+
+	store := func(retvalues ...interface{}) error {
+		result := [][]interface{}{
+			{"foo.service", "Foo", "loaded", "failed", "failed", "", dbus.ObjectPath("/org/freedesktop/systemd1/unit/foo_2eservice"), uint32(0), "", dbus.ObjectPath("/")},
+		}
+		return dbus.Store([]interface{}{result}, retvalues...)
+	}
+
+	units, err := c.listUnitsInternal(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(units) != 1 {
+		t.Fatalf("expected 1 unit, got %d", len(units))
+	}
+	if units[0].ActiveState != StateFailed {
+		t.Fatalf("expected ActiveState %q, got %q", StateFailed, units[0].ActiveState)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// ErrUnitFailed is returned by WaitForUnitState when the unit enters the
+// failed state while waiting for another state.
+var ErrUnitFailed = errors.New("unit failed")
+
+// WaitForUnitState waits until the ActiveState of the unit is target. It
+// returns immediately if the unit already is in the target state, and fails
+// with an error matching ErrUnitFailed if the unit enters the failed state
+// while waiting for another state.
+//
+// The state changes are received from the PropertiesChanged signals of the
+// unit, so the connection is subscribed to systemd signals as by Subscribe
+// while waiting, if it isn't yet.
+func (c *Conn) WaitForUnitState(ctx context.Context, unit string, target ActiveState) error {
+	path := unitPath(unit)
+	match := []dbus.MatchOption{
+		dbus.WithMatchObjectPath(path),
+		dbus.WithMatchInterface("org.freedesktop.DBus.Properties"),
+		dbus.WithMatchMember("PropertiesChanged"),
+	}
+
+	ch := make(chan *dbus.Signal, signalBuffer)
+	c.sigconn.Signal(ch)
+	defer c.sigconn.RemoveSignal(ch)
+
+	// fails on direct connections to systemd, which send all signals anyway
+	c.sigconn.AddMatchSignalContext(ctx, match...)
+	defer c.sigconn.RemoveMatchSignal(match...)
+
+	unsubscribe, err := c.ensureSubscribed(ctx)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	// the state may have changed before the match was added
+	p, err := c.GetUnitPropertyContext(ctx, unit, "ActiveState")
+	if err != nil {
+		return err
+	}
+	state, _ := p.Value.Value().(string)
+
+	for {
+		switch ActiveState(state) {
+		case target:
+			return nil
+		case StateFailed:
+			return fmt.Errorf("%w: %s", ErrUnitFailed, unit)
+		}
+
+		select {
+		case signal := <-ch:
+			if s, ok := activeStateChange(signal, path); ok {
+				state = s
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// activeStateChange returns the new ActiveState if signal is a
+// PropertiesChanged signal of the unit at path which changes it.
+func activeStateChange(signal *dbus.Signal, path dbus.ObjectPath) (string, bool) {
+	if signal.Path != path || signal.Name != "org.freedesktop.DBus.Properties.PropertiesChanged" || len(signal.Body) < 2 {
+		return "", false
+	}
+	if iface, _ := signal.Body[0].(string); iface != "org.freedesktop.systemd1.Unit" {
+		return "", false
+	}
+
+	changed, _ := signal.Body[1].(map[string]dbus.Variant)
+	v, ok := changed["ActiveState"]
+	if !ok {
+		return "", false
+	}
+	state, ok := v.Value().(string)
+	return state, ok
+}
+
+// ensureSubscribed subscribes the signal connection to systemd signals,
+// unless it already is. The returned function undoes the subscription if it
+// was made by this call, and does nothing otherwise.
+func (c *Conn) ensureSubscribed(ctx context.Context) (func(), error) {
+	err := c.sigobj.CallWithContext(ctx, "org.freedesktop.systemd1.Manager.Subscribe", 0).Store()
+	if errors.Is(err, ErrAlreadySubscribed) {
+		return func() {}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return func() { c.Unsubscribe() }, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestActiveStateChange(t *testing.T) {
+	path := unitPath("foo.service")
+	signal := &dbus.Signal{
+		Path: path,
+		Name: "org.freedesktop.DBus.Properties.PropertiesChanged",
+		Body: []interface{}{
+			"org.freedesktop.systemd1.Unit",
+			map[string]dbus.Variant{"ActiveState": dbus.MakeVariant("active")},
+			[]string{},
+		},
+	}
+
+	if state, ok := activeStateChange(signal, path); !ok || state != "active" {
+		t.Fatalf("expected active, got %q (%v)", state, ok)
+	}
+	if _, ok := activeStateChange(signal, unitPath("bar.service")); ok {
+		t.Fatal("expected signal of other unit to be ignored")
+	}
+
+	signal.Body[0] = "org.freedesktop.systemd1.Service"
+	if _, ok := activeStateChange(signal, path); ok {
+		t.Fatal("expected signal of other interface to be ignored")
+	}
+}
+
+func TestWaitForUnitState(t *testing.T) {
+	conn := setupConn(t)
+	defer conn.Close()
+
+	for _, unit := range []string{"start-stop.service", "start-failed.service"} {
+		setupUnit(unit, conn, t)
+		linkUnit(unit, conn, t)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := conn.StartUnitContext(ctx, "start-stop.service", JobModeReplace, nil); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.StopUnit("start-stop.service", "replace", nil)
+	if err := conn.WaitForUnitState(ctx, "start-stop.service", StateActive); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := conn.StartUnitContext(ctx, "start-failed.service", JobModeReplace, nil); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.ResetFailedUnit("start-failed.service")
+	if err := conn.WaitForUnitState(ctx, "start-failed.service", StateActive); !errors.Is(err, ErrUnitFailed) {
+		t.Fatalf("expected ErrUnitFailed, got %v", err)
+	}
+
+	// the subscription made while waiting is undone
+	if err := conn.Subscribe(); err != nil {
+		t.Fatalf("expected to subscribe after waiting: %v", err)
+	}
+	conn.Unsubscribe()
+}
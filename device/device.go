@@ -0,0 +1,322 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package device enumerates the devices in /sys and monitors device events
+// of the kernel and systemd-udevd, like sd-device, without cgo.
+package device
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// sysPath and udevDataPath are variables for testing
+	sysPath      = "/sys"
+	udevDataPath = "/run/udev/data"
+)
+
+// Device is a device of the kernel, with the properties and tags added by
+// systemd-udevd.
+type Device struct {
+	Syspath   string // e.g. /sys/devices/pci0000:00/0000:00:1f.2/ata1/host0/target0:0:0/0:0:0:0/block/sda
+	Devpath   string // Syspath without the /sys prefix
+	Sysname   string // e.g. sda
+	Subsystem string // e.g. block, net or usb
+	DevType   string // e.g. disk or partition
+	Driver    string
+	Devnode   string // e.g. /dev/sda, if the device has a device node
+	// Action is the action of a device event, e.g. add, remove, change,
+	// bind or unbind. It is empty for enumerated devices.
+	Action string
+	// Seqnum is the kernel sequence number of a device event.
+	Seqnum uint64
+	// Properties holds the properties of the device, e.g. ID_MODEL or
+	// ID_FS_TYPE, including those of the kernel uevent such as MAJOR and
+	// MINOR.
+	Properties map[string]string
+	Tags       []string
+	Symlinks   []string // The symlinks to the device node created by systemd-udevd
+}
+
+// Property returns the value of the property name, or "" if it isn't set.
+func (d *Device) Property(name string) string {
+	return d.Properties[name]
+}
+
+// HasTag reports whether the device has the udev tag.
+func (d *Device) HasTag(tag string) bool {
+	for _, t := range d.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// id returns the name of the device in the udev database, or "" if it can't
+// be determined.
+func (d *Device) id() string {
+	if major, minor := d.Properties["MAJOR"], d.Properties["MINOR"]; major != "" && minor != "" {
+		if d.Subsystem == "block" {
+			return "b" + major + ":" + minor
+		}
+		return "c" + major + ":" + minor
+	}
+	if ifindex := d.Properties["IFINDEX"]; ifindex != "" && ifindex != "0" {
+		return "n" + ifindex
+	}
+	if d.Subsystem == "" || d.Sysname == "" {
+		return ""
+	}
+	return "+" + d.Subsystem + ":" + d.Sysname
+}
+
+// setProperties fills the fields derived from properties, as found in uevent
+// files and messages.
+func (d *Device) setProperties(props map[string]string) {
+	d.Properties = props
+	if v := props["DEVPATH"]; v != "" {
+		d.Devpath = v
+		d.Syspath = filepath.Join(sysPath, v)
+		d.Sysname = filepath.Base(v)
+	}
+	if v := props["SUBSYSTEM"]; v != "" {
+		d.Subsystem = v
+	}
+	if v := props["DEVTYPE"]; v != "" {
+		d.DevType = v
+	}
+	if v := props["DRIVER"]; v != "" {
+		d.Driver = v
+	}
+	if v := props["DEVNAME"]; v != "" {
+		if !strings.HasPrefix(v, "/") {
+			v = "/dev/" + v
+		}
+		d.Devnode = v
+	}
+	if v := props["ACTION"]; v != "" {
+		d.Action = v
+	}
+	if v := props["SEQNUM"]; v != "" {
+		d.Seqnum, _ = strconv.ParseUint(v, 10, 64)
+	}
+	if v := props["TAGS"]; v != "" {
+		d.Tags = parseTags(v)
+	}
+	if v := props["DEVLINKS"]; v != "" {
+		d.Symlinks = strings.Fields(v)
+	}
+}
+
+// parseTags parses the :tag1:tag2: format of the TAGS property.
+func parseTags(s string) []string {
+	var tags []string
+	for _, t := range strings.Split(s, ":") {
+		if t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// NewFromSyspath returns the device at syspath, e.g. /sys/class/net/eth0,
+// with the properties and tags recorded by systemd-udevd, if any.
+func NewFromSyspath(syspath string) (*Device, error) {
+	real, err := filepath.EvalSymlinks(syspath)
+	if err != nil {
+		return nil, err
+	}
+
+	sys, err := filepath.EvalSymlinks(sysPath)
+	if err != nil {
+		return nil, err
+	}
+	devpath, err := filepath.Rel(sys, real)
+	if err != nil || strings.HasPrefix(devpath, "..") {
+		return nil, fmt.Errorf("%s is not a device in %s", syspath, sysPath)
+	}
+	devpath = "/" + filepath.ToSlash(devpath)
+
+	props, err := readUevent(filepath.Join(real, "uevent"))
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Device{
+		Syspath: filepath.Join(sysPath, devpath),
+		Devpath: devpath,
+		Sysname: filepath.Base(real),
+	}
+	if link, err := os.Readlink(filepath.Join(real, "subsystem")); err == nil {
+		d.Subsystem = filepath.Base(link)
+	}
+	if link, err := os.Readlink(filepath.Join(real, "driver")); err == nil {
+		d.Driver = filepath.Base(link)
+	}
+	props["DEVPATH"] = devpath
+	if d.Subsystem != "" {
+		props["SUBSYSTEM"] = d.Subsystem
+	}
+	d.setProperties(props)
+
+	if err := d.readUdevData(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// readUevent reads the KEY=VALUE lines of a uevent file.
+func readUevent(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	props := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if key, value, ok := strings.Cut(scanner.Text(), "="); ok {
+			props[key] = value
+		}
+	}
+	return props, scanner.Err()
+}
+
+// readUdevData adds the properties, tags and symlinks of the udev database
+// to the device. Devices not handled by systemd-udevd have no entry.
+func (d *Device) readUdevData() error {
+	id := d.id()
+	if id == "" {
+		return nil
+	}
+
+	f, err := os.Open(filepath.Join(udevDataPath, id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) < 2 || line[1] != ':' {
+			continue
+		}
+		value := line[2:]
+		switch line[0] {
+		case 'E':
+			if key, v, ok := strings.Cut(value, "="); ok {
+				d.Properties[key] = v
+			}
+		case 'G':
+			if !d.HasTag(value) {
+				d.Tags = append(d.Tags, value)
+			}
+		case 'S':
+			d.Symlinks = append(d.Symlinks, "/dev/"+value)
+		}
+	}
+	return scanner.Err()
+}
+
+// Filter selects devices. All the non-empty criteria must match.
+type Filter struct {
+	// Subsystems are the subsystems of which devices match.
+	Subsystems []string
+	// DevType, if set, is the device type devices must have.
+	DevType string
+	// Tags are udev tags devices must all have.
+	Tags []string
+	// Properties are property values devices must all have.
+	Properties map[string]string
+}
+
+// Match reports whether the device matches the filter.
+func (f *Filter) Match(d *Device) bool {
+	if len(f.Subsystems) > 0 {
+		found := false
+		for _, s := range f.Subsystems {
+			if s == d.Subsystem {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.DevType != "" && f.DevType != d.DevType {
+		return false
+	}
+	for _, t := range f.Tags {
+		if !d.HasTag(t) {
+			return false
+		}
+	}
+	for name, value := range f.Properties {
+		if d.Properties[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Enumerate returns the devices in /sys matching filter, which may be nil to
+// return all devices, sorted by syspath. Like sd-device, it lists the devices
+// of /sys/bus/*/devices and /sys/class/*.
+func Enumerate(filter *Filter) ([]*Device, error) {
+	var dirs []string
+	for _, pattern := range []string{"bus/*/devices/*", "class/*/*"} {
+		matches, err := filepath.Glob(filepath.Join(sysPath, pattern))
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, matches...)
+	}
+
+	seen := make(map[string]bool)
+	var devices []*Device
+	for _, dir := range dirs {
+		real, err := filepath.EvalSymlinks(dir)
+		if err != nil || seen[real] {
+			continue
+		}
+		seen[real] = true
+
+		d, err := NewFromSyspath(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			// the device was removed, or isn't a device
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if filter == nil || filter.Match(d) {
+			devices = append(devices, d)
+		}
+	}
+
+	sort.Slice(devices, func(i, j int) bool { return devices[i].Syspath < devices[j].Syspath })
+	return devices, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package device
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// setupSys creates a fake /sys with a disk and a network interface, and a
+// udev database with an entry for the disk.
+func setupSys(t *testing.T) {
+	t.Helper()
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldSys, oldData := sysPath, udevDataPath
+	sysPath, udevDataPath = filepath.Join(root, "sys"), filepath.Join(root, "data")
+	t.Cleanup(func() { sysPath, udevDataPath = oldSys, oldData })
+
+	write := func(path, content string) {
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	link := func(target, path string) {
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(filepath.Join(root, target), path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("sys/devices/pci0000:00/block/sda/uevent", "MAJOR=8\nMINOR=0\nDEVNAME=sda\nDEVTYPE=disk\n")
+	link("sys/class/block", "sys/devices/pci0000:00/block/sda/subsystem")
+	link("sys/devices/pci0000:00/block/sda", "sys/class/block/sda")
+
+	write("sys/devices/virtual/net/eth0/uevent", "INTERFACE=eth0\nIFINDEX=2\n")
+	link("sys/class/net", "sys/devices/virtual/net/eth0/subsystem")
+	link("sys/devices/virtual/net/eth0", "sys/class/net/eth0")
+
+	write("data/b8:0", "S:disk/by-id/ata-DISK\nE:ID_MODEL=DISK\nG:systemd\nI:123\n")
+}
+
+func TestNewFromSyspath(t *testing.T) {
+	setupSys(t)
+
+	d, err := NewFromSyspath(filepath.Join(sysPath, "class/block/sda"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Device{
+		Syspath:   filepath.Join(sysPath, "devices/pci0000:00/block/sda"),
+		Devpath:   "/devices/pci0000:00/block/sda",
+		Sysname:   "sda",
+		Subsystem: "block",
+		DevType:   "disk",
+		Devnode:   "/dev/sda",
+		Properties: map[string]string{
+			"MAJOR":     "8",
+			"MINOR":     "0",
+			"DEVNAME":   "sda",
+			"DEVTYPE":   "disk",
+			"DEVPATH":   "/devices/pci0000:00/block/sda",
+			"SUBSYSTEM": "block",
+			"ID_MODEL":  "DISK",
+		},
+		Tags:     []string{"systemd"},
+		Symlinks: []string{"/dev/disk/by-id/ata-DISK"},
+	}
+	if !reflect.DeepEqual(d, expected) {
+		t.Errorf("expected %+v, got %+v", expected, d)
+	}
+
+	if _, err := NewFromSyspath(filepath.Join(sysPath, "class/block/sdb")); !os.IsNotExist(err) {
+		t.Errorf("expected a not exist error, got %v", err)
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	setupSys(t)
+
+	devices, err := Enumerate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 2 || devices[0].Sysname != "sda" || devices[1].Sysname != "eth0" {
+		t.Fatalf("unexpected devices %+v", devices)
+	}
+
+	tests := []struct {
+		filter   Filter
+		expected []string
+	}{
+		{Filter{Subsystems: []string{"net"}}, []string{"eth0"}},
+		{Filter{Tags: []string{"systemd"}}, []string{"sda"}},
+		{Filter{Subsystems: []string{"block"}, DevType: "partition"}, nil},
+		{Filter{Properties: map[string]string{"IFINDEX": "2"}}, []string{"eth0"}},
+	}
+	for i, tt := range tests {
+		devices, err := Enumerate(&tt.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, d := range devices {
+			names = append(names, d.Sysname)
+		}
+		if !reflect.DeepEqual(names, tt.expected) {
+			t.Errorf("#%d: expected %v, got %v", i, tt.expected, names)
+		}
+	}
+}
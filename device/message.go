@@ -0,0 +1,70 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package device
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// udev messages start with a monitor_netlink_header, see
+// src/libsystemd/sd-device/device-monitor.c
+const (
+	udevPrefix     = "libudev\x00"
+	udevMagic      = 0xfeedcafe
+	udevHeaderSize = 40
+)
+
+// parseMessage parses a uevent message of the kernel or systemd-udevd, as
+// received on the netlink socket.
+func parseMessage(buf []byte) (*Device, error) {
+	var props []byte
+	if bytes.HasPrefix(buf, []byte(udevPrefix)) {
+		if len(buf) < udevHeaderSize {
+			return nil, fmt.Errorf("udev message too short: %d bytes", len(buf))
+		}
+		if magic := binary.BigEndian.Uint32(buf[8:12]); magic != udevMagic {
+			return nil, fmt.Errorf("invalid udev message magic %#x", magic)
+		}
+		off := binary.NativeEndian.Uint32(buf[16:20])
+		length := binary.NativeEndian.Uint32(buf[20:24])
+		if off < udevHeaderSize || uint64(off)+uint64(length) > uint64(len(buf)) {
+			return nil, fmt.Errorf("invalid udev message properties at %d+%d of %d bytes", off, length, len(buf))
+		}
+		props = buf[off : off+length]
+	} else {
+		// kernel messages start with action@devpath
+		i := bytes.IndexByte(buf, 0)
+		if i < 0 || !bytes.Contains(buf[:i], []byte("@")) {
+			return nil, fmt.Errorf("invalid kernel uevent message")
+		}
+		props = buf[i+1:]
+	}
+
+	properties := make(map[string]string)
+	for _, p := range bytes.Split(props, []byte{0}) {
+		if key, value, ok := bytes.Cut(p, []byte("=")); ok && len(key) > 0 {
+			properties[string(key)] = string(value)
+		}
+	}
+	if properties["ACTION"] == "" || properties["DEVPATH"] == "" {
+		return nil, fmt.Errorf("uevent message without ACTION or DEVPATH")
+	}
+
+	d := &Device{}
+	d.setProperties(properties)
+	return d, nil
+}
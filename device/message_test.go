@@ -0,0 +1,65 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package device
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func udevMessage(props string) []byte {
+	buf := make([]byte, udevHeaderSize, udevHeaderSize+len(props))
+	copy(buf, udevPrefix)
+	binary.BigEndian.PutUint32(buf[8:], udevMagic)
+	binary.NativeEndian.PutUint32(buf[12:], udevHeaderSize)
+	binary.NativeEndian.PutUint32(buf[16:], udevHeaderSize)
+	binary.NativeEndian.PutUint32(buf[20:], uint32(len(props)))
+	return append(buf, props...)
+}
+
+func TestParseMessage(t *testing.T) {
+	kernel := strings.ReplaceAll("add@/devices/virtual/block/loop0|ACTION=add|DEVPATH=/devices/virtual/block/loop0|SUBSYSTEM=block|DEVNAME=loop0|DEVTYPE=disk|SEQNUM=4242|", "|", "\x00")
+	d, err := parseMessage([]byte(kernel))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Action != "add" || d.Subsystem != "block" || d.Devnode != "/dev/loop0" || d.Seqnum != 4242 || d.Sysname != "loop0" {
+		t.Errorf("unexpected kernel device %+v", d)
+	}
+
+	udev := strings.ReplaceAll("ACTION=change|DEVPATH=/devices/virtual/net/wg0|SUBSYSTEM=net|INTERFACE=wg0|TAGS=:systemd:seat:|DEVLINKS=/dev/a /dev/b|", "|", "\x00")
+	d, err = parseMessage(udevMessage(udev))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Action != "change" || !d.HasTag("seat") || !d.HasTag("systemd") || d.Property("INTERFACE") != "wg0" || len(d.Symlinks) != 2 {
+		t.Errorf("unexpected udev device %+v", d)
+	}
+
+	invalid := [][]byte{
+		[]byte("garbage"),
+		[]byte("add@/devices/foo\x00SUBSYSTEM=block\x00"),
+		udevMessage("")[:20],
+	}
+	bad := udevMessage(udev)
+	binary.BigEndian.PutUint32(bad[8:], 0xdeadbeef)
+	invalid = append(invalid, bad)
+	for i, buf := range invalid {
+		if _, err := parseMessage(buf); err == nil {
+			t.Errorf("#%d: expected an error", i)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package device
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+)
+
+// Source is the source of the events of a Monitor.
+type Source int
+
+const (
+	// SourceUdev receives the events of systemd-udevd, sent once it
+	// processed the devices, with their properties and tags. This is what
+	// most applications want.
+	SourceUdev Source = iota
+	// SourceKernel receives the raw events of the kernel, before
+	// systemd-udevd processed the devices.
+	SourceKernel
+)
+
+// Monitor receives device events from the kernel uevent netlink socket.
+type Monitor struct {
+	sock   socket
+	source Source
+	filter Filter
+}
+
+// socket receives netlink messages, along with whether their sender is
+// trusted: the kernel for SourceKernel, or root for SourceUdev.
+type socket interface {
+	receive() ([]byte, bool, error)
+	setDeadline(t time.Time) error
+	close() error
+}
+
+// NewMonitor opens a monitor for the events of source. Events of devices not
+// matching filter, which may be nil, are skipped.
+func NewMonitor(source Source, filter *Filter) (*Monitor, error) {
+	sock, err := openSocket(source)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Monitor{sock: sock, source: source}
+	if filter != nil {
+		m.filter = *filter
+	}
+	return m, nil
+}
+
+// Receive waits for the next device event matching the filter of the
+// monitor. Messages which are invalid or not sent by a trusted sender are
+// skipped.
+func (m *Monitor) Receive() (*Device, error) {
+	for {
+		buf, trusted, err := m.sock.receive()
+		if err != nil {
+			return nil, err
+		}
+		if !trusted {
+			continue
+		}
+
+		d, err := parseMessage(buf)
+		if err != nil {
+			continue
+		}
+		if m.filter.Match(d) {
+			return d, nil
+		}
+	}
+}
+
+// Start receives the device events matching the filter of the monitor until
+// ctx is done, and sends them to the returned channel, which is closed
+// afterwards. An error receiving events is sent to the error channel, which
+// stops receiving.
+func (m *Monitor) Start(ctx context.Context) (<-chan *Device, <-chan error) {
+	devices := make(chan *Device)
+	errs := make(chan error, 1)
+
+	stop := context.AfterFunc(ctx, func() {
+		// unblocks Receive
+		m.sock.setDeadline(time.Now())
+	})
+
+	go func() {
+		defer close(devices)
+		defer stop()
+
+		for {
+			d, err := m.Receive()
+			if err != nil {
+				if ctx.Err() == nil || !errors.Is(err, os.ErrDeadlineExceeded) {
+					errs <- err
+				}
+				return
+			}
+
+			select {
+			case devices <- d:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return devices, errs
+}
+
+// Close closes the monitor.
+func (m *Monitor) Close() error {
+	return m.sock.close()
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package device
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// The multicast groups of the uevent netlink socket.
+const (
+	groupKernel = 1
+	groupUdev   = 2
+)
+
+type netlinkSocket struct {
+	f      *os.File
+	conn   syscall.RawConn
+	source Source
+	buf    []byte
+	oob    []byte
+}
+
+func openSocket(source Source) (socket, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW|syscall.SOCK_CLOEXEC|syscall.SOCK_NONBLOCK, syscall.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return nil, os.NewSyscallError("socket", err)
+	}
+
+	group := uint32(groupUdev)
+	if source == SourceKernel {
+		group = groupKernel
+	}
+	if err := syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK, Groups: group}); err != nil {
+		syscall.Close(fd)
+		return nil, os.NewSyscallError("bind", err)
+	}
+	// receive the credentials of the senders to only trust messages of root
+	if err := syscall.SetsockoptInt(fd, syscall.SOL_SOCKET, syscall.SO_PASSCRED, 1); err != nil {
+		syscall.Close(fd)
+		return nil, os.NewSyscallError("setsockopt", err)
+	}
+
+	f := os.NewFile(uintptr(fd), "uevent")
+	conn, err := f.SyscallConn()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &netlinkSocket{
+		f:      f,
+		conn:   conn,
+		source: source,
+		buf:    make([]byte, 128*1024),
+		oob:    make([]byte, syscall.CmsgSpace(syscall.SizeofUcred)),
+	}, nil
+}
+
+func (s *netlinkSocket) receive() ([]byte, bool, error) {
+	var n, oobn int
+	var from syscall.Sockaddr
+	var recvErr error
+	err := s.conn.Read(func(fd uintptr) bool {
+		n, oobn, _, from, recvErr = syscall.Recvmsg(int(fd), s.buf, s.oob, 0)
+		return recvErr != syscall.EAGAIN
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if recvErr != nil {
+		return nil, false, os.NewSyscallError("recvmsg", recvErr)
+	}
+
+	nl, ok := from.(*syscall.SockaddrNetlink)
+	if !ok {
+		return nil, false, nil
+	}
+	trusted := false
+	switch s.source {
+	case SourceKernel:
+		// only the kernel sends from port 0
+		trusted = nl.Pid == 0
+	case SourceUdev:
+		trusted = nl.Pid != 0 && senderIsRoot(s.oob[:oobn])
+	}
+
+	msg := make([]byte, n)
+	copy(msg, s.buf[:n])
+	return msg, trusted, nil
+}
+
+func senderIsRoot(oob []byte) bool {
+	msgs, err := syscall.ParseSocketControlMessage(oob)
+	if err != nil {
+		return false
+	}
+	for _, m := range msgs {
+		if cred, err := syscall.ParseUnixCredentials(&m); err == nil {
+			return cred.Uid == 0
+		}
+	}
+	return false
+}
+
+func (s *netlinkSocket) setDeadline(t time.Time) error {
+	return s.f.SetReadDeadline(t)
+}
+
+func (s *netlinkSocket) close() error {
+	return s.f.Close()
+}
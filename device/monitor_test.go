@@ -0,0 +1,108 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package device
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type message struct {
+	buf     []byte
+	trusted bool
+}
+
+// fakeSocket returns queued messages, then blocks until its deadline.
+type fakeSocket struct {
+	messages chan message
+	deadline chan struct{}
+}
+
+func (s *fakeSocket) receive() ([]byte, bool, error) {
+	select {
+	case m := <-s.messages:
+		return m.buf, m.trusted, nil
+	case <-s.deadline:
+		return nil, false, os.ErrDeadlineExceeded
+	}
+}
+
+func (s *fakeSocket) setDeadline(t time.Time) error {
+	close(s.deadline)
+	return nil
+}
+
+func (s *fakeSocket) close() error {
+	return nil
+}
+
+func TestMonitor(t *testing.T) {
+	event := func(action, subsystem string) []byte {
+		return udevMessage(strings.ReplaceAll("ACTION="+action+"|DEVPATH=/devices/foo|SUBSYSTEM="+subsystem+"|", "|", "\x00"))
+	}
+
+	sock := &fakeSocket{messages: make(chan message, 4), deadline: make(chan struct{})}
+	sock.messages <- message{event("add", "usb"), false}
+	sock.messages <- message{event("add", "block"), true}
+	sock.messages <- message{[]byte("garbage"), true}
+	sock.messages <- message{event("add", "usb"), true}
+
+	m := &Monitor{sock: sock, source: SourceUdev, filter: Filter{Subsystems: []string{"usb"}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	devices, errs := m.Start(ctx)
+
+	select {
+	case d := <-devices:
+		if d.Subsystem != "usb" || d.Action != "add" {
+			t.Errorf("unexpected device %+v", d)
+		}
+	case err := <-errs:
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the device event")
+	}
+
+	cancel()
+	for d := range devices {
+		t.Errorf("unexpected device %+v", d)
+	}
+	select {
+	case err := <-errs:
+		t.Errorf("unexpected error %v", err)
+	default:
+	}
+}
+
+func TestNewMonitor(t *testing.T) {
+	m, err := NewMonitor(SourceKernel, nil)
+	if err != nil {
+		t.Skipf("cannot open uevent socket: %v", err)
+	}
+	defer m.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	devices, errs := m.Start(ctx)
+	for range devices {
+	}
+	select {
+	case err := <-errs:
+		t.Errorf("unexpected error %v", err)
+	default:
+	}
+}
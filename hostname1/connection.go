@@ -0,0 +1,40 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+package hostname1
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Connection is the interface of Conn, which allows for replacing it in tests,
+// e.g. by the fake of the hostname1test package. See Conn for the documentation of
+// the methods.
+type Connection interface {
+	Chassis(ctx context.Context) (string, error)
+	Close() error
+	Connected() bool
+	Deployment(ctx context.Context) (string, error)
+	Describe(ctx context.Context) (json.RawMessage, error)
+	FirmwareVersion(ctx context.Context) (string, error)
+	GetHardwareSerial(ctx context.Context) (string, error)
+	GetProductUUID(ctx context.Context, interactive bool) ([]byte, error)
+	GetProperties(ctx context.Context) (*Properties, error)
+	HardwareModel(ctx context.Context) (string, error)
+	HardwareVendor(ctx context.Context) (string, error)
+	Hostname(ctx context.Context) (string, error)
+	Location(ctx context.Context) (string, error)
+	PrettyHostname(ctx context.Context) (string, error)
+	SetChassis(ctx context.Context, chassis string, interactive bool) error
+	SetDeployment(ctx context.Context, deployment string, interactive bool) error
+	SetHostname(ctx context.Context, hostname string, interactive bool) error
+	SetIconName(ctx context.Context, icon string, interactive bool) error
+	SetLocation(ctx context.Context, location string, interactive bool) error
+	SetPrettyHostname(ctx context.Context, hostname string, interactive bool) error
+	SetStaticHostname(ctx context.Context, hostname string, interactive bool) error
+	SetTimeout(timeout time.Duration)
+	StaticHostname(ctx context.Context) (string, error)
+}
+
+var _ Connection = (*Conn)(nil)
@@ -0,0 +1,335 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hostname1 provides integration with the systemd-hostnamed API. See
+// https://www.freedesktop.org/software/systemd/man/org.freedesktop.hostname1.html
+package hostname1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/gr-butler/go-systemd/v22/internal/calltimeout"
+)
+
+//go:generate go run github.com/gr-butler/go-systemd/v22/internal/fakegen
+
+const (
+	dbusDest      = "org.freedesktop.hostname1"
+	dbusInterface = "org.freedesktop.hostname1"
+	dbusPath      = "/org/freedesktop/hostname1"
+)
+
+// Conn is a connection to systemd-hostnamed's dbus endpoint.
+type Conn struct {
+	conn   *dbus.Conn
+	object dbus.BusObject
+
+	timeout time.Duration
+}
+
+// Properties holds the properties of systemd-hostnamed. Properties which are
+// not provided by the running version of systemd-hostnamed, e.g. the hardware
+// and firmware information added in systemd v249 to v253, are left empty.
+type Properties struct {
+	Hostname        string // The transient hostname
+	StaticHostname  string // The hostname configured in /etc/hostname
+	PrettyHostname  string
+	DefaultHostname string // The fallback hostname
+	HostnameSource  string // static, transient or default
+	IconName        string
+	Chassis         string // e.g. desktop, laptop, server, vm or container
+	Deployment      string // e.g. development, staging or production
+	Location        string
+
+	KernelName    string
+	KernelRelease string
+	KernelVersion string
+
+	OperatingSystemPrettyName string
+	OperatingSystemCPEName    string
+	OperatingSystemHomeURL    string
+	OperatingSystemSupportEnd time.Time
+
+	HardwareVendor  string
+	HardwareModel   string
+	FirmwareVersion string
+	FirmwareVendor  string
+	FirmwareDate    time.Time
+
+	MachineID []byte
+	BootID    []byte
+}
+
+// New establishes a connection to the system bus and authenticates.
+func New() (*Conn, error) {
+	c := new(Conn)
+
+	if err := c.initConnection(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Conn) initConnection() error {
+	var err error
+	c.conn, err = dbus.SystemBusPrivate()
+	if err != nil {
+		return err
+	}
+
+	// Only use EXTERNAL method, and hardcode the uid (not username)
+	// to avoid a username lookup (which requires a dynamically linked
+	// libc)
+	methods := []dbus.Auth{dbus.AuthExternal(strconv.Itoa(os.Getuid()))}
+
+	err = c.conn.Auth(methods)
+	if err != nil {
+		c.conn.Close()
+		return err
+	}
+
+	err = c.conn.Hello()
+	if err != nil {
+		c.conn.Close()
+		return err
+	}
+
+	c.object = c.conn.Object(dbusDest, dbus.ObjectPath(dbusPath))
+
+	return nil
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// Connected returns whether conn is connected
+func (c *Conn) Connected() bool {
+	return c.conn.Connected()
+}
+
+// SetTimeout sets the default timeout of the method calls made on the
+// connection without a context deadline, including those made by the
+// methods without context, which otherwise block forever if systemd-hostnamed
+// hangs. It is disabled by a timeout of zero, the default. SetTimeout must
+// not be called concurrently with method calls.
+func (c *Conn) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+	c.object = calltimeout.Object(c.conn.Object(dbusDest, dbus.ObjectPath(dbusPath)), timeout)
+}
+
+func (c *Conn) set(ctx context.Context, method, value string, interactive bool) error {
+	return c.object.CallWithContext(ctx, dbusInterface+"."+method, 0, value, interactive).Err
+}
+
+// SetHostname sets the transient hostname. If interactive is true, the
+// caller may be asked for authorization by polkit.
+func (c *Conn) SetHostname(ctx context.Context, hostname string, interactive bool) error {
+	return c.set(ctx, "SetHostname", hostname, interactive)
+}
+
+// SetStaticHostname sets the static hostname in /etc/hostname. An empty
+// hostname removes it, so that the default hostname is used.
+func (c *Conn) SetStaticHostname(ctx context.Context, hostname string, interactive bool) error {
+	return c.set(ctx, "SetStaticHostname", hostname, interactive)
+}
+
+// SetPrettyHostname sets the free-form pretty hostname.
+func (c *Conn) SetPrettyHostname(ctx context.Context, hostname string, interactive bool) error {
+	return c.set(ctx, "SetPrettyHostname", hostname, interactive)
+}
+
+// SetIconName sets the icon name of the host, following the XDG icon naming
+// specification.
+func (c *Conn) SetIconName(ctx context.Context, icon string, interactive bool) error {
+	return c.set(ctx, "SetIconName", icon, interactive)
+}
+
+// SetChassis sets the chassis type, e.g. desktop, laptop, server, vm or
+// container. An empty chassis restores the detected one.
+func (c *Conn) SetChassis(ctx context.Context, chassis string, interactive bool) error {
+	return c.set(ctx, "SetChassis", chassis, interactive)
+}
+
+// SetDeployment sets the deployment environment, e.g. development, staging or
+// production.
+func (c *Conn) SetDeployment(ctx context.Context, deployment string, interactive bool) error {
+	return c.set(ctx, "SetDeployment", deployment, interactive)
+}
+
+// SetLocation sets the free-form location of the host.
+func (c *Conn) SetLocation(ctx context.Context, location string, interactive bool) error {
+	return c.set(ctx, "SetLocation", location, interactive)
+}
+
+// GetProductUUID returns the product UUID of the hardware, as reported by
+// the firmware. Reading it requires privileges.
+func (c *Conn) GetProductUUID(ctx context.Context, interactive bool) ([]byte, error) {
+	var uuid []byte
+	err := c.object.CallWithContext(ctx, dbusInterface+".GetProductUUID", 0, interactive).Store(&uuid)
+	return uuid, err
+}
+
+// GetHardwareSerial returns the serial number of the hardware, as reported by
+// the firmware. It requires systemd v249 or higher, and privileges.
+func (c *Conn) GetHardwareSerial(ctx context.Context) (string, error) {
+	var serial string
+	err := c.object.CallWithContext(ctx, dbusInterface+".GetHardwareSerial", 0).Store(&serial)
+	return serial, err
+}
+
+// Describe returns the JSON description of the host, as shown by hostnamectl
+// --json. It requires systemd v249 or higher.
+func (c *Conn) Describe(ctx context.Context) (json.RawMessage, error) {
+	var description string
+	if err := c.object.CallWithContext(ctx, dbusInterface+".Describe", 0).Store(&description); err != nil {
+		return nil, err
+	}
+	if !json.Valid([]byte(description)) {
+		return nil, fmt.Errorf("invalid JSON description %q", description)
+	}
+	return json.RawMessage(description), nil
+}
+
+func (c *Conn) getProperty(ctx context.Context, property string) (dbus.Variant, error) {
+	var v dbus.Variant
+	err := c.object.CallWithContext(ctx, "org.freedesktop.DBus.Properties.Get", 0, dbusInterface, property).Store(&v)
+	return v, err
+}
+
+func (c *Conn) getString(ctx context.Context, property string) (string, error) {
+	v, err := c.getProperty(ctx, property)
+	if err != nil {
+		return "", err
+	}
+	s, ok := v.Value().(string)
+	if !ok {
+		return "", fmt.Errorf("failed to typecast %s to string", property)
+	}
+	return s, nil
+}
+
+// Hostname returns the transient hostname.
+func (c *Conn) Hostname(ctx context.Context) (string, error) {
+	return c.getString(ctx, "Hostname")
+}
+
+// StaticHostname returns the static hostname.
+func (c *Conn) StaticHostname(ctx context.Context) (string, error) {
+	return c.getString(ctx, "StaticHostname")
+}
+
+// PrettyHostname returns the pretty hostname.
+func (c *Conn) PrettyHostname(ctx context.Context) (string, error) {
+	return c.getString(ctx, "PrettyHostname")
+}
+
+// Chassis returns the chassis type.
+func (c *Conn) Chassis(ctx context.Context) (string, error) {
+	return c.getString(ctx, "Chassis")
+}
+
+// Deployment returns the deployment environment.
+func (c *Conn) Deployment(ctx context.Context) (string, error) {
+	return c.getString(ctx, "Deployment")
+}
+
+// Location returns the location of the host.
+func (c *Conn) Location(ctx context.Context) (string, error) {
+	return c.getString(ctx, "Location")
+}
+
+// HardwareVendor returns the vendor of the hardware. It requires systemd v249
+// or higher.
+func (c *Conn) HardwareVendor(ctx context.Context) (string, error) {
+	return c.getString(ctx, "HardwareVendor")
+}
+
+// HardwareModel returns the model of the hardware. It requires systemd v249
+// or higher.
+func (c *Conn) HardwareModel(ctx context.Context) (string, error) {
+	return c.getString(ctx, "HardwareModel")
+}
+
+// FirmwareVersion returns the version of the firmware. It requires systemd
+// v250 or higher.
+func (c *Conn) FirmwareVersion(ctx context.Context) (string, error) {
+	return c.getString(ctx, "FirmwareVersion")
+}
+
+// GetProperties returns all the properties of systemd-hostnamed.
+func (c *Conn) GetProperties(ctx context.Context) (*Properties, error) {
+	var props map[string]dbus.Variant
+	err := c.object.CallWithContext(ctx, "org.freedesktop.DBus.Properties.GetAll", 0, dbusInterface).Store(&props)
+	if err != nil {
+		return nil, err
+	}
+	return propertiesFromVariants(props), nil
+}
+
+func propertiesFromVariants(props map[string]dbus.Variant) *Properties {
+	str := func(name string) string {
+		s, _ := props[name].Value().(string)
+		return s
+	}
+	usec := func(name string) time.Time {
+		if t, ok := props[name].Value().(uint64); ok && t > 0 {
+			return time.UnixMicro(int64(t))
+		}
+		return time.Time{}
+	}
+	id := func(name string) []byte {
+		b, _ := props[name].Value().([]byte)
+		return b
+	}
+
+	return &Properties{
+		Hostname:        str("Hostname"),
+		StaticHostname:  str("StaticHostname"),
+		PrettyHostname:  str("PrettyHostname"),
+		DefaultHostname: str("DefaultHostname"),
+		HostnameSource:  str("HostnameSource"),
+		IconName:        str("IconName"),
+		Chassis:         str("Chassis"),
+		Deployment:      str("Deployment"),
+		Location:        str("Location"),
+
+		KernelName:    str("KernelName"),
+		KernelRelease: str("KernelRelease"),
+		KernelVersion: str("KernelVersion"),
+
+		OperatingSystemPrettyName: str("OperatingSystemPrettyName"),
+		OperatingSystemCPEName:    str("OperatingSystemCPEName"),
+		OperatingSystemHomeURL:    str("HomeURL"),
+		OperatingSystemSupportEnd: usec("OperatingSystemSupportEnd"),
+
+		HardwareVendor:  str("HardwareVendor"),
+		HardwareModel:   str("HardwareModel"),
+		FirmwareVersion: str("FirmwareVersion"),
+		FirmwareVendor:  str("FirmwareVendor"),
+		FirmwareDate:    usec("FirmwareDate"),
+
+		MachineID: id("MachineID"),
+		BootID:    id("BootID"),
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hostname1
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestPropertiesFromVariants(t *testing.T) {
+	machineID := []byte{0: 0xab, 15: 0xcd}
+	props := map[string]dbus.Variant{
+		"Hostname":                  dbus.MakeVariant("web-1"),
+		"StaticHostname":            dbus.MakeVariant("web-1"),
+		"Chassis":                   dbus.MakeVariant("server"),
+		"Deployment":                dbus.MakeVariant("production"),
+		"HomeURL":                   dbus.MakeVariant("https://example.com/"),
+		"OperatingSystemSupportEnd": dbus.MakeVariant(uint64(0)),
+		"HardwareVendor":            dbus.MakeVariant("ACME"),
+		"FirmwareDate":              dbus.MakeVariant(uint64(1700000000000000)),
+		"MachineID":                 dbus.MakeVariant(machineID),
+	}
+
+	expected := &Properties{
+		Hostname:               "web-1",
+		StaticHostname:         "web-1",
+		Chassis:                "server",
+		Deployment:             "production",
+		OperatingSystemHomeURL: "https://example.com/",
+		HardwareVendor:         "ACME",
+		FirmwareDate:           time.UnixMicro(1700000000000000),
+		MachineID:              machineID,
+	}
+	if p := propertiesFromVariants(props); !reflect.DeepEqual(p, expected) {
+		t.Errorf("expected %+v, got %+v", expected, p)
+	}
+}
+
+func TestHostname(t *testing.T) {
+	conn, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+	hostname, err := conn.Hostname(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected, _ := os.Hostname(); hostname != expected {
+		t.Errorf("expected hostname %q, got %q", expected, hostname)
+	}
+
+	props, err := conn.GetProperties(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if props.Hostname != hostname {
+		t.Errorf("expected Hostname property %q, got %q", hostname, props.Hostname)
+	}
+}
@@ -0,0 +1,222 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+// Package hostname1test provides a fake of hostname1.Conn for unit tests.
+package hostname1test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gr-butler/go-systemd/v22/hostname1"
+)
+
+// ErrNotImplemented is returned by the methods whose function field is unset.
+var ErrNotImplemented = errors.New("not implemented")
+
+// Conn is a fake hostname1.Connection. Each method calls the function field of
+// the same name with a Func suffix. If it is unset, the method returns zero
+// values and, if it returns an error, ErrNotImplemented.
+type Conn struct {
+	ChassisFunc           func(ctx context.Context) (string, error)
+	CloseFunc             func() error
+	ConnectedFunc         func() bool
+	DeploymentFunc        func(ctx context.Context) (string, error)
+	DescribeFunc          func(ctx context.Context) (json.RawMessage, error)
+	FirmwareVersionFunc   func(ctx context.Context) (string, error)
+	GetHardwareSerialFunc func(ctx context.Context) (string, error)
+	GetProductUUIDFunc    func(ctx context.Context, interactive bool) ([]byte, error)
+	GetPropertiesFunc     func(ctx context.Context) (*hostname1.Properties, error)
+	HardwareModelFunc     func(ctx context.Context) (string, error)
+	HardwareVendorFunc    func(ctx context.Context) (string, error)
+	HostnameFunc          func(ctx context.Context) (string, error)
+	LocationFunc          func(ctx context.Context) (string, error)
+	PrettyHostnameFunc    func(ctx context.Context) (string, error)
+	SetChassisFunc        func(ctx context.Context, chassis string, interactive bool) error
+	SetDeploymentFunc     func(ctx context.Context, deployment string, interactive bool) error
+	SetHostnameFunc       func(ctx context.Context, hostname string, interactive bool) error
+	SetIconNameFunc       func(ctx context.Context, icon string, interactive bool) error
+	SetLocationFunc       func(ctx context.Context, location string, interactive bool) error
+	SetPrettyHostnameFunc func(ctx context.Context, hostname string, interactive bool) error
+	SetStaticHostnameFunc func(ctx context.Context, hostname string, interactive bool) error
+	SetTimeoutFunc        func(timeout time.Duration)
+	StaticHostnameFunc    func(ctx context.Context) (string, error)
+}
+
+var _ hostname1.Connection = (*Conn)(nil)
+
+func (f *Conn) Chassis(ctx context.Context) (string, error) {
+	if f.ChassisFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: Chassis", ErrNotImplemented)
+	}
+	return f.ChassisFunc(ctx)
+}
+
+func (f *Conn) Close() error {
+	if f.CloseFunc == nil {
+		return fmt.Errorf("%w: Close", ErrNotImplemented)
+	}
+	return f.CloseFunc()
+}
+
+func (f *Conn) Connected() bool {
+	if f.ConnectedFunc == nil {
+		var r0 bool
+		return r0
+	}
+	return f.ConnectedFunc()
+}
+
+func (f *Conn) Deployment(ctx context.Context) (string, error) {
+	if f.DeploymentFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: Deployment", ErrNotImplemented)
+	}
+	return f.DeploymentFunc(ctx)
+}
+
+func (f *Conn) Describe(ctx context.Context) (json.RawMessage, error) {
+	if f.DescribeFunc == nil {
+		var r0 json.RawMessage
+		return r0, fmt.Errorf("%w: Describe", ErrNotImplemented)
+	}
+	return f.DescribeFunc(ctx)
+}
+
+func (f *Conn) FirmwareVersion(ctx context.Context) (string, error) {
+	if f.FirmwareVersionFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: FirmwareVersion", ErrNotImplemented)
+	}
+	return f.FirmwareVersionFunc(ctx)
+}
+
+func (f *Conn) GetHardwareSerial(ctx context.Context) (string, error) {
+	if f.GetHardwareSerialFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: GetHardwareSerial", ErrNotImplemented)
+	}
+	return f.GetHardwareSerialFunc(ctx)
+}
+
+func (f *Conn) GetProductUUID(ctx context.Context, interactive bool) ([]byte, error) {
+	if f.GetProductUUIDFunc == nil {
+		var r0 []byte
+		return r0, fmt.Errorf("%w: GetProductUUID", ErrNotImplemented)
+	}
+	return f.GetProductUUIDFunc(ctx, interactive)
+}
+
+func (f *Conn) GetProperties(ctx context.Context) (*hostname1.Properties, error) {
+	if f.GetPropertiesFunc == nil {
+		var r0 *hostname1.Properties
+		return r0, fmt.Errorf("%w: GetProperties", ErrNotImplemented)
+	}
+	return f.GetPropertiesFunc(ctx)
+}
+
+func (f *Conn) HardwareModel(ctx context.Context) (string, error) {
+	if f.HardwareModelFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: HardwareModel", ErrNotImplemented)
+	}
+	return f.HardwareModelFunc(ctx)
+}
+
+func (f *Conn) HardwareVendor(ctx context.Context) (string, error) {
+	if f.HardwareVendorFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: HardwareVendor", ErrNotImplemented)
+	}
+	return f.HardwareVendorFunc(ctx)
+}
+
+func (f *Conn) Hostname(ctx context.Context) (string, error) {
+	if f.HostnameFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: Hostname", ErrNotImplemented)
+	}
+	return f.HostnameFunc(ctx)
+}
+
+func (f *Conn) Location(ctx context.Context) (string, error) {
+	if f.LocationFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: Location", ErrNotImplemented)
+	}
+	return f.LocationFunc(ctx)
+}
+
+func (f *Conn) PrettyHostname(ctx context.Context) (string, error) {
+	if f.PrettyHostnameFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: PrettyHostname", ErrNotImplemented)
+	}
+	return f.PrettyHostnameFunc(ctx)
+}
+
+func (f *Conn) SetChassis(ctx context.Context, chassis string, interactive bool) error {
+	if f.SetChassisFunc == nil {
+		return fmt.Errorf("%w: SetChassis", ErrNotImplemented)
+	}
+	return f.SetChassisFunc(ctx, chassis, interactive)
+}
+
+func (f *Conn) SetDeployment(ctx context.Context, deployment string, interactive bool) error {
+	if f.SetDeploymentFunc == nil {
+		return fmt.Errorf("%w: SetDeployment", ErrNotImplemented)
+	}
+	return f.SetDeploymentFunc(ctx, deployment, interactive)
+}
+
+func (f *Conn) SetHostname(ctx context.Context, hostname string, interactive bool) error {
+	if f.SetHostnameFunc == nil {
+		return fmt.Errorf("%w: SetHostname", ErrNotImplemented)
+	}
+	return f.SetHostnameFunc(ctx, hostname, interactive)
+}
+
+func (f *Conn) SetIconName(ctx context.Context, icon string, interactive bool) error {
+	if f.SetIconNameFunc == nil {
+		return fmt.Errorf("%w: SetIconName", ErrNotImplemented)
+	}
+	return f.SetIconNameFunc(ctx, icon, interactive)
+}
+
+func (f *Conn) SetLocation(ctx context.Context, location string, interactive bool) error {
+	if f.SetLocationFunc == nil {
+		return fmt.Errorf("%w: SetLocation", ErrNotImplemented)
+	}
+	return f.SetLocationFunc(ctx, location, interactive)
+}
+
+func (f *Conn) SetPrettyHostname(ctx context.Context, hostname string, interactive bool) error {
+	if f.SetPrettyHostnameFunc == nil {
+		return fmt.Errorf("%w: SetPrettyHostname", ErrNotImplemented)
+	}
+	return f.SetPrettyHostnameFunc(ctx, hostname, interactive)
+}
+
+func (f *Conn) SetStaticHostname(ctx context.Context, hostname string, interactive bool) error {
+	if f.SetStaticHostnameFunc == nil {
+		return fmt.Errorf("%w: SetStaticHostname", ErrNotImplemented)
+	}
+	return f.SetStaticHostnameFunc(ctx, hostname, interactive)
+}
+
+func (f *Conn) SetTimeout(timeout time.Duration) {
+	if f.SetTimeoutFunc != nil {
+		f.SetTimeoutFunc(timeout)
+	}
+}
+
+func (f *Conn) StaticHostname(ctx context.Context) (string, error) {
+	if f.StaticHostnameFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: StaticHostname", ErrNotImplemented)
+	}
+	return f.StaticHostnameFunc(ctx)
+}
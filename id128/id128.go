@@ -0,0 +1,178 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package id128 provides 128-bit IDs like sd-id128, as used for machine IDs,
+// boot IDs and journal message IDs. See
+// https://www.freedesktop.org/software/systemd/man/sd-id128.html
+package id128
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ID is a 128-bit ID.
+type ID [16]byte
+
+// Null is the ID with all bits zero, SD_ID128_NULL.
+var Null ID
+
+var (
+	// paths are variables so that tests can override them
+	machineIDPath = "/etc/machine-id"
+	bootIDPath    = "/proc/sys/kernel/random/boot_id"
+
+	// ErrNoInvocationID is returned by InvocationID when the process is not
+	// run by systemd.
+	ErrNoInvocationID = errors.New("no invocation ID")
+)
+
+// New returns a random ID, formatted as a v4 UUID like sd_id128_randomize.
+func New() (ID, error) {
+	var id ID
+	if _, err := rand.Read(id[:]); err != nil {
+		return Null, err
+	}
+	return id.makeV4(), nil
+}
+
+// makeV4 sets the version and variant bits of a random v4 UUID.
+func (id ID) makeV4() ID {
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+	return id
+}
+
+// Parse parses an ID formatted as 32 hexadecimal characters or as a UUID.
+func Parse(s string) (ID, error) {
+	var id ID
+	h := s
+	if len(s) == 36 {
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return Null, fmt.Errorf("invalid ID %q", s)
+		}
+		h = s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	}
+	if len(h) != 32 {
+		return Null, fmt.Errorf("invalid ID %q", s)
+	}
+	if _, err := hex.Decode(id[:], []byte(h)); err != nil {
+		return Null, fmt.Errorf("invalid ID %q", s)
+	}
+	return id, nil
+}
+
+// String formats the ID as 32 lowercase hexadecimal characters, like
+// sd_id128_to_string.
+func (id ID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// UUID formats the ID as a UUID, like SD_ID128_UUID_FORMAT_STR.
+func (id ID) UUID() string {
+	s := id.String()
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// IsNull reports whether all bits of the ID are zero.
+func (id ID) IsNull() bool {
+	return id == Null
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (id ID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *ID) UnmarshalText(b []byte) error {
+	parsed, err := Parse(string(b))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// AppSpecific derives an ID specific to the application app from id, like
+// sd_id128_get_machine_app_specific, so that id isn't exposed to the
+// application or network. The derived ID is HMAC-SHA256 of app keyed with id,
+// formatted as a v4 UUID.
+func (id ID) AppSpecific(app ID) ID {
+	h := hmac.New(sha256.New, id[:])
+	h.Write(app[:])
+
+	var derived ID
+	copy(derived[:], h.Sum(nil))
+	return derived.makeV4()
+}
+
+func readID(path string) (ID, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Null, err
+	}
+	id, err := Parse(strings.TrimSpace(string(b)))
+	if err != nil {
+		return Null, fmt.Errorf("%s: %w", path, err)
+	}
+	if id.IsNull() {
+		return Null, fmt.Errorf("%s: null ID", path)
+	}
+	return id, nil
+}
+
+// MachineID returns the ID of the local machine from /etc/machine-id.
+func MachineID() (ID, error) {
+	return readID(machineIDPath)
+}
+
+// BootID returns the ID of the current boot.
+func BootID() (ID, error) {
+	return readID(bootIDPath)
+}
+
+// InvocationID returns the ID of the invocation of the unit the process is
+// run by, from $INVOCATION_ID, or ErrNoInvocationID if it isn't set.
+func InvocationID() (ID, error) {
+	s := os.Getenv("INVOCATION_ID")
+	if s == "" {
+		return Null, ErrNoInvocationID
+	}
+	return Parse(s)
+}
+
+// MachineAppSpecific returns the machine ID specific to app, see AppSpecific.
+func MachineAppSpecific(app ID) (ID, error) {
+	id, err := MachineID()
+	if err != nil {
+		return Null, err
+	}
+	return id.AppSpecific(app), nil
+}
+
+// BootAppSpecific returns the boot ID specific to app, see AppSpecific.
+func BootAppSpecific(app ID) (ID, error) {
+	id, err := BootID()
+	if err != nil {
+		return Null, err
+	}
+	return id.AppSpecific(app), nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package id128
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	for _, s := range []string{
+		"fed6b2924c424cf1b9a322f606b4de6d",
+		"fed6b292-4c42-4cf1-b9a3-22f606b4de6d",
+		"FED6B2924C424CF1B9A322F606B4DE6D",
+	} {
+		id, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", s, err)
+		}
+		if id.String() != "fed6b2924c424cf1b9a322f606b4de6d" || id.UUID() != "fed6b292-4c42-4cf1-b9a3-22f606b4de6d" {
+			t.Errorf("Parse(%q) = %s", s, id.UUID())
+		}
+	}
+
+	for _, s := range []string{
+		"",
+		"fed6b2924c424cf1b9a322f606b4de6",
+		"fed6b2924c424cf1b9a322f606b4de6dd",
+		"fed6b292-4c424-cf1-b9a3-22f606b4de6d",
+		"ged6b2924c424cf1b9a322f606b4de6d",
+	} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("expected error parsing %q", s)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	a, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a == b || a.IsNull() {
+		t.Errorf("got IDs %s and %s", a, b)
+	}
+	if a[6]>>4 != 4 || a[8]>>6 != 2 {
+		t.Errorf("ID %s is not a v4 UUID", a.UUID())
+	}
+}
+
+func TestAppSpecific(t *testing.T) {
+	machine, _ := Parse("fed6b2924c424cf1b9a322f606b4de6d")
+	// the app ID of the credentials host key of systemd, with the ID
+	// systemd-creds derived
+	app, _ := Parse("d3acecba0dad4cdfb8c9381528936c58")
+	if id := machine.AppSpecific(app); id.String() != "d4434208bd7f4b1aabd84ecc30299e2f" {
+		t.Errorf("got app-specific ID %s", id)
+	}
+
+	dir := t.TempDir()
+	machineIDPath = filepath.Join(dir, "machine-id")
+	bootIDPath = filepath.Join(dir, "boot_id")
+	defer func() {
+		machineIDPath = "/etc/machine-id"
+		bootIDPath = "/proc/sys/kernel/random/boot_id"
+	}()
+
+	if err := os.WriteFile(machineIDPath, []byte("fed6b2924c424cf1b9a322f606b4de6d\n"), 0444); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(bootIDPath, []byte("b0a7d4e1-0d3c-4a9e-8f4f-2f3a1c0e9d8b\n"), 0444); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := MachineAppSpecific(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.String() != "d4434208bd7f4b1aabd84ecc30299e2f" {
+		t.Errorf("got machine app-specific ID %s", id)
+	}
+	boot, err := BootID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if boot.String() != "b0a7d4e10d3c4a9e8f4f2f3a1c0e9d8b" {
+		t.Errorf("got boot ID %s", boot)
+	}
+
+	if err := os.WriteFile(machineIDPath, []byte("uninitialized\n"), 0444); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MachineID(); err == nil {
+		t.Error("expected error for uninitialized machine ID")
+	}
+}
+
+func TestInvocationID(t *testing.T) {
+	t.Setenv("INVOCATION_ID", "")
+	if _, err := InvocationID(); err != ErrNoInvocationID {
+		t.Errorf("expected ErrNoInvocationID, got %v", err)
+	}
+
+	t.Setenv("INVOCATION_ID", "fed6b2924c424cf1b9a322f606b4de6d")
+	id, err := InvocationID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.String() != "fed6b2924c424cf1b9a322f606b4de6d" {
+		t.Errorf("got invocation ID %s", id)
+	}
+}
+
+func TestText(t *testing.T) {
+	id, _ := Parse("fed6b2924c424cf1b9a322f606b4de6d")
+	b, err := json.Marshal(map[string]ID{"id": id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"id":"fed6b2924c424cf1b9a322f606b4de6d"}` {
+		t.Errorf("got %s", b)
+	}
+
+	var v struct{ ID ID }
+	if err := json.Unmarshal([]byte(`{"ID":"fed6b292-4c42-4cf1-b9a3-22f606b4de6d"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.ID != id {
+		t.Errorf("got %s", v.ID)
+	}
+}
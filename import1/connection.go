@@ -0,0 +1,41 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+package import1
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// Connection is the interface of Conn, which allows for replacing it in tests,
+// e.g. by the fake of the import1test package. See Conn for the documentation of
+// the methods.
+type Connection interface {
+	CancelTransfer(transfer_id uint32) error
+	Connected() bool
+	ExportRaw(
+		local_name string, f *os.File, format string,
+	) (*Transfer, error)
+	ExportTar(
+		local_name string, f *os.File, format string,
+	) (*Transfer, error)
+	ImportRaw(
+		f *os.File, local_name string, force, read_only bool,
+	) (*Transfer, error)
+	ImportTar(
+		f *os.File, local_name string, force, read_only bool,
+	) (*Transfer, error)
+	ListTransfers() ([]TransferStatus, error)
+	PullRaw(
+		url, local_name, verify_mode string, force bool,
+	) (*Transfer, error)
+	PullTar(
+		url, local_name, verify_mode string, force bool,
+	) (*Transfer, error)
+	RunTransfer(ctx context.Context, start func() (*Transfer, error), h TransferHandler) error
+	SetTimeout(timeout time.Duration)
+	SubscribeTransfers(ctx context.Context, h TransferHandler) error
+}
+
+var _ Connection = (*Conn)(nil)
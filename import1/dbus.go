@@ -21,10 +21,15 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/godbus/dbus/v5"
+
+	"github.com/gr-butler/go-systemd/v22/internal/calltimeout"
 )
 
+//go:generate go run github.com/gr-butler/go-systemd/v22/internal/fakegen
+
 const (
 	dbusInterface = "org.freedesktop.import1.Manager"
 	dbusPath      = "/org/freedesktop/import1"
@@ -34,6 +39,8 @@ const (
 type Conn struct {
 	conn   *dbus.Conn
 	object dbus.BusObject
+
+	timeout time.Duration
 }
 
 // Transfer is an object in dbus for an import, export or download operation.
@@ -69,6 +76,21 @@ func (c *Conn) Connected() bool {
 	return c.conn.Connected()
 }
 
+// SetTimeout sets the default timeout of the method calls made on the
+// connection without a context deadline, including those made by the
+// methods without context, which otherwise block forever if systemd-importd
+// hangs. It is disabled by a timeout of zero, the default. SetTimeout must
+// not be called concurrently with method calls.
+func (c *Conn) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+	c.object = c.pathObject(dbus.ObjectPath(dbusPath))
+}
+
+// pathObject returns the object at path on the import1 service.
+func (c *Conn) pathObject(path dbus.ObjectPath) dbus.BusObject {
+	return calltimeout.Object(c.conn.Object(dbusDest, path), c.timeout)
+}
+
 func (c *Conn) initConnection() error {
 	var err error
 	c.conn, err = dbus.SystemBusPrivate()
@@ -154,14 +176,16 @@ func (c *Conn) ExportRaw(
 	return c.getResult("ExportRaw", local_name, dbus.UnixFD(f.Fd()), format)
 }
 
-// PullTar pulls a tar into systemd-importd.
+// PullTar pulls a tar into systemd-importd. verify_mode is one of VerifyNo,
+// VerifyChecksum or VerifySignature.
 func (c *Conn) PullTar(
 	url, local_name, verify_mode string, force bool,
 ) (*Transfer, error) {
 	return c.getResult("PullTar", url, local_name, verify_mode, force)
 }
 
-// PullRaw pulls a raw image into systemd-importd.
+// PullRaw pulls a raw image into systemd-importd. verify_mode is one of
+// VerifyNo, VerifyChecksum or VerifySignature.
 func (c *Conn) PullRaw(
 	url, local_name, verify_mode string, force bool,
 ) (*Transfer, error) {
@@ -0,0 +1,152 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+// Package import1test provides a fake of import1.Conn for unit tests.
+package import1test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gr-butler/go-systemd/v22/import1"
+)
+
+// ErrNotImplemented is returned by the methods whose function field is unset.
+var ErrNotImplemented = errors.New("not implemented")
+
+// Conn is a fake import1.Connection. Each method calls the function field of
+// the same name with a Func suffix. If it is unset, the method returns zero
+// values and, if it returns an error, ErrNotImplemented.
+type Conn struct {
+	CancelTransferFunc func(transfer_id uint32) error
+	ConnectedFunc      func() bool
+	ExportRawFunc      func(
+		local_name string, f *os.File, format string,
+	) (*import1.Transfer, error)
+	ExportTarFunc func(
+		local_name string, f *os.File, format string,
+	) (*import1.Transfer, error)
+	ImportRawFunc func(
+		f *os.File, local_name string, force, read_only bool,
+	) (*import1.Transfer, error)
+	ImportTarFunc func(
+		f *os.File, local_name string, force, read_only bool,
+	) (*import1.Transfer, error)
+	ListTransfersFunc func() ([]import1.TransferStatus, error)
+	PullRawFunc       func(
+		url, local_name, verify_mode string, force bool,
+	) (*import1.Transfer, error)
+	PullTarFunc func(
+		url, local_name, verify_mode string, force bool,
+	) (*import1.Transfer, error)
+	RunTransferFunc        func(ctx context.Context, start func() (*import1.Transfer, error), h import1.TransferHandler) error
+	SetTimeoutFunc         func(timeout time.Duration)
+	SubscribeTransfersFunc func(ctx context.Context, h import1.TransferHandler) error
+}
+
+var _ import1.Connection = (*Conn)(nil)
+
+func (f *Conn) CancelTransfer(transfer_id uint32) error {
+	if f.CancelTransferFunc == nil {
+		return fmt.Errorf("%w: CancelTransfer", ErrNotImplemented)
+	}
+	return f.CancelTransferFunc(transfer_id)
+}
+
+func (f *Conn) Connected() bool {
+	if f.ConnectedFunc == nil {
+		var r0 bool
+		return r0
+	}
+	return f.ConnectedFunc()
+}
+
+func (f *Conn) ExportRaw(
+	local_name string, arg1 *os.File, format string,
+) (*import1.Transfer, error) {
+	if f.ExportRawFunc == nil {
+		var r0 *import1.Transfer
+		return r0, fmt.Errorf("%w: ExportRaw", ErrNotImplemented)
+	}
+	return f.ExportRawFunc(local_name, arg1, format)
+}
+
+func (f *Conn) ExportTar(
+	local_name string, arg1 *os.File, format string,
+) (*import1.Transfer, error) {
+	if f.ExportTarFunc == nil {
+		var r0 *import1.Transfer
+		return r0, fmt.Errorf("%w: ExportTar", ErrNotImplemented)
+	}
+	return f.ExportTarFunc(local_name, arg1, format)
+}
+
+func (f *Conn) ImportRaw(
+	arg0 *os.File, local_name string, force, read_only bool,
+) (*import1.Transfer, error) {
+	if f.ImportRawFunc == nil {
+		var r0 *import1.Transfer
+		return r0, fmt.Errorf("%w: ImportRaw", ErrNotImplemented)
+	}
+	return f.ImportRawFunc(arg0, local_name, force, read_only)
+}
+
+func (f *Conn) ImportTar(
+	arg0 *os.File, local_name string, force, read_only bool,
+) (*import1.Transfer, error) {
+	if f.ImportTarFunc == nil {
+		var r0 *import1.Transfer
+		return r0, fmt.Errorf("%w: ImportTar", ErrNotImplemented)
+	}
+	return f.ImportTarFunc(arg0, local_name, force, read_only)
+}
+
+func (f *Conn) ListTransfers() ([]import1.TransferStatus, error) {
+	if f.ListTransfersFunc == nil {
+		var r0 []import1.TransferStatus
+		return r0, fmt.Errorf("%w: ListTransfers", ErrNotImplemented)
+	}
+	return f.ListTransfersFunc()
+}
+
+func (f *Conn) PullRaw(
+	url, local_name, verify_mode string, force bool,
+) (*import1.Transfer, error) {
+	if f.PullRawFunc == nil {
+		var r0 *import1.Transfer
+		return r0, fmt.Errorf("%w: PullRaw", ErrNotImplemented)
+	}
+	return f.PullRawFunc(url, local_name, verify_mode, force)
+}
+
+func (f *Conn) PullTar(
+	url, local_name, verify_mode string, force bool,
+) (*import1.Transfer, error) {
+	if f.PullTarFunc == nil {
+		var r0 *import1.Transfer
+		return r0, fmt.Errorf("%w: PullTar", ErrNotImplemented)
+	}
+	return f.PullTarFunc(url, local_name, verify_mode, force)
+}
+
+func (f *Conn) RunTransfer(ctx context.Context, start func() (*import1.Transfer, error), h import1.TransferHandler) error {
+	if f.RunTransferFunc == nil {
+		return fmt.Errorf("%w: RunTransfer", ErrNotImplemented)
+	}
+	return f.RunTransferFunc(ctx, start, h)
+}
+
+func (f *Conn) SetTimeout(timeout time.Duration) {
+	if f.SetTimeoutFunc != nil {
+		f.SetTimeoutFunc(timeout)
+	}
+}
+
+func (f *Conn) SubscribeTransfers(ctx context.Context, h import1.TransferHandler) error {
+	if f.SubscribeTransfersFunc == nil {
+		return fmt.Errorf("%w: SubscribeTransfers", ErrNotImplemented)
+	}
+	return f.SubscribeTransfersFunc(ctx, h)
+}
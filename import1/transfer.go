@@ -0,0 +1,270 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package import1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	dbusDest              = "org.freedesktop.import1"
+	dbusTransferInterface = "org.freedesktop.import1.Transfer"
+)
+
+// Verification modes, as passed to PullTar and PullRaw.
+const (
+	VerifyNo        = "no"        // Don't verify the download
+	VerifyChecksum  = "checksum"  // Verify the SHA256SUMS file of the download
+	VerifySignature = "signature" // Verify the SHA256SUMS file and its GPG signature
+)
+
+// Transfer results, as passed to TransferHandler.OnRemoved.
+const (
+	TransferDone     = "done"
+	TransferFailed   = "failed"
+	TransferCanceled = "canceled"
+)
+
+// ErrTransferFailed is returned by RunTransfer when the transfer doesn't
+// complete successfully.
+var ErrTransferFailed = errors.New("transfer failed")
+
+// progressInterval is how often RunTransfer polls the progress of a transfer.
+const progressInterval = time.Second
+
+// TransferHandler holds callbacks for events on transfers. Nil callbacks are
+// skipped.
+type TransferHandler struct {
+	// OnNew is called when a transfer is started.
+	OnNew func(t Transfer)
+	// OnLog is called for each log message of a transfer, with its syslog
+	// priority.
+	OnLog func(t Transfer, priority int, line string)
+	// OnProgress is called by RunTransfer when the progress of the
+	// transfer, between 0.0 and 1.0, changes.
+	OnProgress func(t Transfer, progress float64)
+	// OnRemoved is called when a transfer completes, with TransferDone,
+	// TransferFailed or TransferCanceled.
+	OnRemoved func(t Transfer, result string)
+}
+
+// transferEvent is a transfer signal of systemd-importd.
+type transferEvent struct {
+	member   string
+	transfer Transfer
+	result   string // for TransferRemoved
+	priority int    // for LogMessage
+	line     string // for LogMessage
+}
+
+// parseTransferSignal parses the TransferNew, TransferRemoved and LogMessage
+// signals of systemd-importd.
+func parseTransferSignal(s *dbus.Signal) (transferEvent, bool) {
+	var ev transferEvent
+	switch s.Name {
+	case dbusInterface + ".TransferNew":
+		ev.member = "TransferNew"
+		if len(s.Body) < 2 {
+			return ev, false
+		}
+		id, ok1 := s.Body[0].(uint32)
+		path, ok2 := s.Body[1].(dbus.ObjectPath)
+		ev.transfer = Transfer{Id: id, Path: path}
+		return ev, ok1 && ok2
+	case dbusInterface + ".TransferRemoved":
+		ev.member = "TransferRemoved"
+		if len(s.Body) < 3 {
+			return ev, false
+		}
+		id, ok1 := s.Body[0].(uint32)
+		path, ok2 := s.Body[1].(dbus.ObjectPath)
+		result, ok3 := s.Body[2].(string)
+		ev.transfer = Transfer{Id: id, Path: path}
+		ev.result = result
+		return ev, ok1 && ok2 && ok3
+	case dbusTransferInterface + ".LogMessage":
+		ev.member = "LogMessage"
+		if len(s.Body) < 2 {
+			return ev, false
+		}
+		priority, ok1 := s.Body[0].(uint32)
+		line, ok2 := s.Body[1].(string)
+		ev.transfer = Transfer{Path: s.Path}
+		ev.priority = int(priority)
+		ev.line = line
+		return ev, ok1 && ok2
+	default:
+		return ev, false
+	}
+}
+
+// dispatch calls the callback of h for ev.
+func (h *TransferHandler) dispatch(ev transferEvent) {
+	switch ev.member {
+	case "TransferNew":
+		if h.OnNew != nil {
+			h.OnNew(ev.transfer)
+		}
+	case "TransferRemoved":
+		if h.OnRemoved != nil {
+			h.OnRemoved(ev.transfer, ev.result)
+		}
+	case "LogMessage":
+		if h.OnLog != nil {
+			h.OnLog(ev.transfer, ev.priority, ev.line)
+		}
+	}
+}
+
+// watchTransfers subscribes to the transfer signals and returns a channel
+// receiving them, and a function to unsubscribe.
+func (c *Conn) watchTransfers(ctx context.Context) (<-chan *dbus.Signal, func(), error) {
+	matches := [][]dbus.MatchOption{
+		{dbus.WithMatchSender(dbusDest), dbus.WithMatchInterface(dbusInterface)},
+		{dbus.WithMatchSender(dbusDest), dbus.WithMatchInterface(dbusTransferInterface), dbus.WithMatchMember("LogMessage")},
+	}
+
+	for i, match := range matches {
+		if err := c.conn.AddMatchSignalContext(ctx, match...); err != nil {
+			for _, m := range matches[:i] {
+				c.conn.RemoveMatchSignal(m...)
+			}
+			return nil, nil, err
+		}
+	}
+
+	ch := make(chan *dbus.Signal, 64)
+	c.conn.Signal(ch)
+
+	return ch, func() {
+		c.conn.RemoveSignal(ch)
+		for _, m := range matches {
+			c.conn.RemoveMatchSignal(m...)
+		}
+	}, nil
+}
+
+// SubscribeTransfers calls h for the events of all transfers until ctx is
+// done. The Id of the transfers passed to OnLog is not set, as LogMessage
+// signals only identify transfers by their path. OnProgress is not called.
+func (c *Conn) SubscribeTransfers(ctx context.Context, h TransferHandler) error {
+	ch, unsubscribe, err := c.watchTransfers(ctx)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	ids := make(map[dbus.ObjectPath]uint32)
+	for {
+		select {
+		case s := <-ch:
+			ev, ok := parseTransferSignal(s)
+			if !ok {
+				continue
+			}
+			switch ev.member {
+			case "TransferNew":
+				ids[ev.transfer.Path] = ev.transfer.Id
+			case "TransferRemoved":
+				delete(ids, ev.transfer.Path)
+			case "LogMessage":
+				ev.transfer.Id = ids[ev.transfer.Path]
+			}
+			h.dispatch(ev)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// RunTransfer starts a transfer with start, e.g. a closure calling PullRaw,
+// and waits for it to complete, calling h for its events. It fails with an
+// error matching ErrTransferFailed if the transfer fails or is canceled. If
+// ctx is done first, the transfer is canceled.
+func (c *Conn) RunTransfer(ctx context.Context, start func() (*Transfer, error), h TransferHandler) error {
+	// subscribe first so that no signal of the transfer is missed
+	ch, unsubscribe, err := c.watchTransfers(ctx)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	t, err := start()
+	if err != nil {
+		return err
+	}
+	if h.OnNew != nil {
+		h.OnNew(*t)
+	}
+
+	var tick <-chan time.Time
+	if h.OnProgress != nil {
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	progress := -1.0
+
+	for {
+		select {
+		case s := <-ch:
+			ev, ok := parseTransferSignal(s)
+			if !ok || ev.transfer.Path != t.Path {
+				continue
+			}
+			ev.transfer.Id = t.Id
+			switch ev.member {
+			case "LogMessage":
+				h.dispatch(ev)
+			case "TransferRemoved":
+				if h.OnProgress != nil && ev.result == TransferDone && progress < 1 {
+					h.OnProgress(*t, 1)
+				}
+				h.dispatch(ev)
+				if ev.result != TransferDone {
+					return fmt.Errorf("%w: %s", ErrTransferFailed, ev.result)
+				}
+				return nil
+			}
+		case <-tick:
+			// the transfer may just have been removed, so errors are ignored
+			if p, err := c.transferProgress(ctx, t.Path); err == nil && p != progress {
+				progress = p
+				h.OnProgress(*t, p)
+			}
+		case <-ctx.Done():
+			c.CancelTransfer(t.Id)
+			return ctx.Err()
+		}
+	}
+}
+
+func (c *Conn) transferProgress(ctx context.Context, path dbus.ObjectPath) (float64, error) {
+	var v dbus.Variant
+	err := c.pathObject(path).CallWithContext(ctx, "org.freedesktop.DBus.Properties.Get", 0, dbusTransferInterface, "Progress").Store(&v)
+	if err != nil {
+		return 0, err
+	}
+	p, ok := v.Value().(float64)
+	if !ok {
+		return 0, fmt.Errorf("failed to typecast Progress to float64")
+	}
+	return p, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package import1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestParseTransferSignal(t *testing.T) {
+	path := dbus.ObjectPath("/org/freedesktop/import1/transfer/_7")
+
+	tests := []struct {
+		signal   *dbus.Signal
+		expected transferEvent
+		ok       bool
+	}{
+		{
+			&dbus.Signal{Name: dbusInterface + ".TransferNew", Body: []interface{}{uint32(7), path}},
+			transferEvent{member: "TransferNew", transfer: Transfer{Id: 7, Path: path}},
+			true,
+		},
+		{
+			&dbus.Signal{Name: dbusInterface + ".TransferRemoved", Body: []interface{}{uint32(7), path, "failed"}},
+			transferEvent{member: "TransferRemoved", transfer: Transfer{Id: 7, Path: path}, result: "failed"},
+			true,
+		},
+		{
+			&dbus.Signal{Path: path, Name: dbusTransferInterface + ".LogMessage", Body: []interface{}{uint32(6), "Downloading 1.2G"}},
+			transferEvent{member: "LogMessage", transfer: Transfer{Path: path}, priority: 6, line: "Downloading 1.2G"},
+			true,
+		},
+		{
+			&dbus.Signal{Name: dbusInterface + ".TransferRemoved", Body: []interface{}{uint32(7), path}},
+			transferEvent{member: "TransferRemoved"},
+			false,
+		},
+		{
+			&dbus.Signal{Name: "org.freedesktop.DBus.NameAcquired", Body: []interface{}{":1.2"}},
+			transferEvent{},
+			false,
+		},
+	}
+
+	for i, tt := range tests {
+		ev, ok := parseTransferSignal(tt.signal)
+		if ok != tt.ok {
+			t.Errorf("#%d: expected ok %v, got %v", i, tt.ok, ok)
+		}
+		if ok && !reflect.DeepEqual(ev, tt.expected) {
+			t.Errorf("#%d: expected %+v, got %+v", i, tt.expected, ev)
+		}
+	}
+}
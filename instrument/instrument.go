@@ -0,0 +1,155 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package instrument provides hooks observing the D-Bus method calls of the
+// dbus, login1 and machine1 packages, e.g. to record tracing spans and
+// latency metrics of the calls to systemd.
+//
+// A Hook is set per connection, with the SetHook method of the connection:
+//
+//	conn, err := login1.New()
+//	...
+//	conn.SetHook(instrument.ObserverFunc(func(call instrument.Call, d time.Duration, err error) {
+//		latency.WithLabelValues(call.Method).Observe(d.Seconds())
+//	}))
+//
+// Asynchronous calls, made with the Go and GoWithContext methods of
+// dbus.BusObject, are not observed.
+package instrument
+
+import (
+	"context"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Call describes a D-Bus method call.
+type Call struct {
+	Destination string
+	Path        dbus.ObjectPath
+	// Method is the interface and member of the method, e.g.
+	// org.freedesktop.systemd1.Manager.StartUnit
+	Method string
+}
+
+// Hook observes D-Bus method calls.
+type Hook interface {
+	// StartCall is called before the method call is sent, with the context
+	// of the call, e.g. to start a span with the span of the caller as
+	// parent. The returned function is called with the error of the call,
+	// if any, after the reply has been received.
+	StartCall(ctx context.Context, call Call) func(err error)
+}
+
+// ObserverFunc is a Hook which calls the function with the duration and the
+// error of each method call, e.g. to record latency metrics.
+type ObserverFunc func(call Call, d time.Duration, err error)
+
+// StartCall implements Hook.
+func (f ObserverFunc) StartCall(ctx context.Context, call Call) func(err error) {
+	start := time.Now()
+	return func(err error) {
+		f(call, time.Since(start), err)
+	}
+}
+
+// Multi returns a Hook calling all the given hooks, e.g. for both tracing and
+// metrics. Nil hooks are skipped.
+func Multi(hooks ...Hook) Hook {
+	var nonNil multiHook
+	for _, h := range hooks {
+		if h != nil {
+			nonNil = append(nonNil, h)
+		}
+	}
+	switch len(nonNil) {
+	case 0:
+		return nil
+	case 1:
+		return nonNil[0]
+	}
+	return nonNil
+}
+
+type multiHook []Hook
+
+func (m multiHook) StartCall(ctx context.Context, call Call) func(err error) {
+	finish := make([]func(error), len(m))
+	for i, h := range m {
+		finish[i] = h.StartCall(ctx, call)
+	}
+	return func(err error) {
+		// in reverse order, like deferred calls
+		for i := len(finish) - 1; i >= 0; i-- {
+			finish[i](err)
+		}
+	}
+}
+
+// Object returns obj with its synchronous method calls, including the Get and
+// Set calls of the property methods, observed by hook. If hook is nil, obj is
+// returned as is.
+func Object(obj dbus.BusObject, hook Hook) dbus.BusObject {
+	if hook == nil {
+		return obj
+	}
+	return &object{BusObject: obj, hook: hook}
+}
+
+// object is a dbus.BusObject which reports method calls to a Hook.
+type object struct {
+	dbus.BusObject
+	hook Hook
+}
+
+func (o *object) start(ctx context.Context, method string) func(error) {
+	return o.hook.StartCall(ctx, Call{
+		Destination: o.Destination(),
+		Path:        o.Path(),
+		Method:      method,
+	})
+}
+
+func (o *object) Call(method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+	return o.CallWithContext(context.Background(), method, flags, args...)
+}
+
+func (o *object) CallWithContext(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+	finish := o.start(ctx, method)
+	call := o.BusObject.CallWithContext(ctx, method, flags, args...)
+	finish(call.Err)
+	return call
+}
+
+func (o *object) GetProperty(p string) (dbus.Variant, error) {
+	finish := o.start(context.Background(), "org.freedesktop.DBus.Properties.Get")
+	v, err := o.BusObject.GetProperty(p)
+	finish(err)
+	return v, err
+}
+
+func (o *object) StoreProperty(p string, value interface{}) error {
+	finish := o.start(context.Background(), "org.freedesktop.DBus.Properties.Get")
+	err := o.BusObject.StoreProperty(p, value)
+	finish(err)
+	return err
+}
+
+func (o *object) SetProperty(p string, v interface{}) error {
+	finish := o.start(context.Background(), "org.freedesktop.DBus.Properties.Set")
+	err := o.BusObject.SetProperty(p, v)
+	finish(err)
+	return err
+}
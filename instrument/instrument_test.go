@@ -0,0 +1,134 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrument
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// fakeObject is a dbus.BusObject failing all method calls with err.
+type fakeObject struct {
+	dbus.BusObject
+	err error
+}
+
+func (o fakeObject) CallWithContext(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+	return &dbus.Call{Method: method, Err: o.err}
+}
+
+func (o fakeObject) GetProperty(p string) (dbus.Variant, error) {
+	return dbus.Variant{}, o.err
+}
+
+func (o fakeObject) StoreProperty(p string, value interface{}) error {
+	return o.err
+}
+
+func (o fakeObject) SetProperty(p string, v interface{}) error {
+	return o.err
+}
+
+func (o fakeObject) Destination() string {
+	return "org.freedesktop.login1"
+}
+
+func (o fakeObject) Path() dbus.ObjectPath {
+	return "/org/freedesktop/login1"
+}
+
+type key struct{}
+
+// recorder is a Hook recording the calls and their errors.
+type recorder struct {
+	name   string
+	events *[]string
+}
+
+func (r recorder) StartCall(ctx context.Context, call Call) func(error) {
+	*r.events = append(*r.events, r.name+" start "+call.Method)
+	if ctx.Value(key{}) == nil {
+		*r.events = append(*r.events, r.name+" missing context")
+	}
+	if call.Destination != "org.freedesktop.login1" || call.Path != "/org/freedesktop/login1" {
+		*r.events = append(*r.events, r.name+" unexpected call "+call.Destination+" "+string(call.Path))
+	}
+	return func(err error) {
+		*r.events = append(*r.events, r.name+" finish "+err.Error())
+	}
+}
+
+func TestObject(t *testing.T) {
+	var events []string
+	failure := errors.New("failure")
+	obj := Object(fakeObject{err: failure}, Multi(recorder{"a", &events}, nil, recorder{"b", &events}))
+
+	ctx := context.WithValue(context.Background(), key{}, true)
+	if err := obj.CallWithContext(ctx, "org.freedesktop.login1.Manager.ListSessions", 0).Err; err != failure {
+		t.Errorf("expected the error of the call, got %v", err)
+	}
+
+	expected := []string{
+		"a start org.freedesktop.login1.Manager.ListSessions",
+		"b start org.freedesktop.login1.Manager.ListSessions",
+		"b finish failure",
+		"a finish failure",
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("expected %q, got %q", expected, events)
+	}
+}
+
+func TestObjectProperties(t *testing.T) {
+	var calls []Call
+	obj := Object(fakeObject{err: errors.New("failure")}, ObserverFunc(func(call Call, d time.Duration, err error) {
+		if err == nil || d < 0 {
+			t.Errorf("unexpected observation %v, %v", d, err)
+		}
+		calls = append(calls, call)
+	}))
+
+	obj.GetProperty("org.freedesktop.login1.Manager.IdleHint")
+	obj.StoreProperty("org.freedesktop.login1.Manager.IdleHint", new(bool))
+	obj.SetProperty("org.freedesktop.login1.Manager.IdleHint", dbus.MakeVariant(true))
+
+	var methods []string
+	for _, call := range calls {
+		methods = append(methods, call.Method)
+	}
+	expected := []string{
+		"org.freedesktop.DBus.Properties.Get",
+		"org.freedesktop.DBus.Properties.Get",
+		"org.freedesktop.DBus.Properties.Set",
+	}
+	if !reflect.DeepEqual(methods, expected) {
+		t.Errorf("expected %q, got %q", expected, methods)
+	}
+}
+
+func TestNilHook(t *testing.T) {
+	obj := fakeObject{}
+	if Object(obj, nil) != dbus.BusObject(obj) {
+		t.Error("expected the object to be returned as is")
+	}
+	if Multi(nil, nil) != nil {
+		t.Error("expected a nil hook")
+	}
+}
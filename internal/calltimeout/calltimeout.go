@@ -0,0 +1,90 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package calltimeout applies a default timeout to the D-Bus method calls made
+// without a context deadline, including those made without context, which
+// otherwise block forever if the service hangs.
+package calltimeout
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Object returns obj with the timeout applied to its synchronous method
+// calls, including the Get and Set calls of the property methods, unless
+// their context has a deadline. If timeout is not positive, obj is returned
+// as is.
+func Object(obj dbus.BusObject, timeout time.Duration) dbus.BusObject {
+	if timeout <= 0 {
+		return obj
+	}
+	return &object{BusObject: obj, timeout: timeout}
+}
+
+type object struct {
+	dbus.BusObject
+	timeout time.Duration
+}
+
+func (o *object) Call(method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+	return o.CallWithContext(context.Background(), method, flags, args...)
+}
+
+func (o *object) CallWithContext(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+	return o.BusObject.CallWithContext(ctx, method, flags, args...)
+}
+
+// The property methods of dbus.Object make their calls on the object itself,
+// so they are reimplemented to go through CallWithContext.
+
+func (o *object) GetProperty(p string) (dbus.Variant, error) {
+	var v dbus.Variant
+	err := o.StoreProperty(p, &v)
+	return v, err
+}
+
+func (o *object) StoreProperty(p string, value interface{}) error {
+	iface, prop, err := splitProperty(p)
+	if err != nil {
+		return err
+	}
+	return o.Call("org.freedesktop.DBus.Properties.Get", 0, iface, prop).Store(value)
+}
+
+func (o *object) SetProperty(p string, v interface{}) error {
+	iface, prop, err := splitProperty(p)
+	if err != nil {
+		return err
+	}
+	return o.Call("org.freedesktop.DBus.Properties.Set", 0, iface, prop, v).Err
+}
+
+// splitProperty splits a property name in interface.member notation.
+func splitProperty(p string) (string, string, error) {
+	i := strings.LastIndex(p, ".")
+	if i == -1 || i+1 == len(p) {
+		return "", "", errors.New("dbus: invalid property " + p)
+	}
+	return p[:i], p[i+1:], nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calltimeout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// fakeObject is a dbus.BusObject recording the deadlines and methods of its
+// calls.
+type fakeObject struct {
+	dbus.BusObject
+	deadlines []time.Time
+	methods   []string
+}
+
+func (o *fakeObject) CallWithContext(ctx context.Context, method string, flags dbus.Flags, args ...interface{}) *dbus.Call {
+	deadline, _ := ctx.Deadline()
+	o.deadlines = append(o.deadlines, deadline)
+	o.methods = append(o.methods, method)
+	return &dbus.Call{Method: method, Body: []interface{}{dbus.MakeVariant("value")}}
+}
+
+func TestObject(t *testing.T) {
+	fake := &fakeObject{}
+	obj := Object(fake, time.Minute)
+
+	start := time.Now()
+	obj.Call("org.freedesktop.login1.Manager.ListSessions", 0)
+	if _, err := obj.GetProperty("org.freedesktop.login1.Manager.IdleHint"); err != nil {
+		t.Fatal(err)
+	}
+	if err := obj.SetProperty("org.freedesktop.login1.Manager.IdleHint", dbus.MakeVariant(true)); err != nil {
+		t.Fatal(err)
+	}
+	for i, deadline := range fake.deadlines {
+		if deadline.Before(start.Add(time.Minute)) || deadline.After(time.Now().Add(time.Minute)) {
+			t.Errorf("%s: unexpected deadline %v", fake.methods[i], deadline)
+		}
+	}
+	if len(fake.methods) != 3 || fake.methods[1] != "org.freedesktop.DBus.Properties.Get" || fake.methods[2] != "org.freedesktop.DBus.Properties.Set" {
+		t.Errorf("unexpected calls %q", fake.methods)
+	}
+
+	// deadlines of the caller are kept, even if they are later
+	expected := time.Now().Add(time.Hour)
+	ctx, cancel := context.WithDeadline(context.Background(), expected)
+	defer cancel()
+	obj.CallWithContext(ctx, "org.freedesktop.login1.Manager.ListSessions", 0)
+	if deadline := fake.deadlines[len(fake.deadlines)-1]; !deadline.Equal(expected) {
+		t.Errorf("expected deadline %v, got %v", expected, deadline)
+	}
+
+	if _, err := obj.GetProperty("IdleHint"); err == nil {
+		t.Error("expected an error for a property without interface")
+	}
+}
+
+func TestNoTimeout(t *testing.T) {
+	fake := &fakeObject{}
+	if Object(fake, 0) != dbus.BusObject(fake) {
+		t.Error("expected the object to be returned as is")
+	}
+}
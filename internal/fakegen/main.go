@@ -0,0 +1,497 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command fakegen generates the interface of the connection type of a D-Bus
+// client package, and a fake implementing it in the testing subpackage named
+// after the package with a "test" suffix, e.g. login1/login1test.
+//
+// It is run by go generate in the directory of the client package:
+//
+//	//go:generate go run github.com/gr-butler/go-systemd/v22/internal/fakegen
+//
+// The interface lists the exported methods of the connection type. The fake
+// has a function field for each method, e.g. ListSessionsFunc for
+// ListSessions, which the method calls. Methods whose function field is unset
+// return zero values and, if they return an error, ErrNotImplemented.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const generatedHeader = "// Code generated by fakegen. DO NOT EDIT.\n\n"
+
+var (
+	typeName  = flag.String("type", "Conn", "name of the connection type")
+	ifaceName = flag.String("interface", "Connection", "name of the generated interface")
+	ifaceFile = flag.String("output", "connection.go", "file name of the generated interface")
+)
+
+// method is an exported method of the connection type.
+type method struct {
+	name    string
+	ftype   *ast.FuncType
+	imports map[string]string // import name to path, of the declaring file
+}
+
+// builtinTypes are the predeclared types that may appear unqualified in
+// method signatures.
+var builtinTypes = map[string]bool{
+	"any": true, "bool": true, "byte": true, "comparable": true,
+	"complex64": true, "complex128": true, "error": true,
+	"float32": true, "float64": true, "int": true, "int8": true,
+	"int16": true, "int32": true, "int64": true, "rune": true,
+	"string": true, "uint": true, "uint8": true, "uint16": true,
+	"uint32": true, "uint64": true, "uintptr": true,
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("fakegen: ")
+	flag.Parse()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		log.Fatal(err)
+	}
+	pkgName, iface, fake, err := generate(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, *ifaceFile), iface, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	fakeDir := filepath.Join(dir, pkgName+"test")
+	if err := os.MkdirAll(fakeDir, 0o755); err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(fakeDir, "fake.go"), fake, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the package name of the client package in dir, and the
+// source of its interface and fake.
+func generate(dir string) (pkgName string, iface, fake []byte, err error) {
+	pkgPath, err := importPath(dir)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	fset := token.NewFileSet()
+	pkgName, methods, err := parseMethods(fset, dir)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if len(methods) == 0 {
+		return "", nil, nil, fmt.Errorf("no exported methods of %s in %s", *typeName, pkgPath)
+	}
+
+	if iface, err = generateInterface(fset, pkgName, methods); err != nil {
+		return "", nil, nil, err
+	}
+	// generateInterface leaves the signatures as they are, so that they can
+	// be rewritten for the fake
+	if fake, err = generateFake(fset, pkgName, pkgPath, methods); err != nil {
+		return "", nil, nil, err
+	}
+	return pkgName, iface, fake, nil
+}
+
+// importPath returns the import path of the package in dir, from the module
+// path in the enclosing go.mod.
+func importPath(dir string) (string, error) {
+	for d := dir; ; d = filepath.Dir(d) {
+		data, err := os.ReadFile(filepath.Join(d, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				if mod, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+					rel, err := filepath.Rel(d, dir)
+					if err != nil {
+						return "", err
+					}
+					return path.Join(strings.TrimSpace(mod), filepath.ToSlash(rel)), nil
+				}
+			}
+			return "", fmt.Errorf("no module path in %s", filepath.Join(d, "go.mod"))
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		if filepath.Dir(d) == d {
+			return "", fmt.Errorf("no go.mod found for %s", dir)
+		}
+	}
+}
+
+// parseMethods returns the name of the package in dir and the exported
+// methods of the connection type, sorted by name. Test files and generated
+// files are ignored. Methods declared in several files for different build
+// constraints are listed once.
+func parseMethods(fset *token.FileSet, dir string) (string, []*method, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var pkgName string
+	seen := make(map[string]bool)
+	var methods []*method
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") || strings.HasSuffix(e.Name(), "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, e.Name()), nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return "", nil, err
+		}
+		if ast.IsGenerated(f) {
+			continue
+		}
+		pkgName = f.Name.Name
+
+		imports := make(map[string]string)
+		for _, spec := range f.Imports {
+			p, _ := strconv.Unquote(spec.Path.Value)
+			name := defaultImportName(p)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			imports[name] = p
+		}
+
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || !fn.Name.IsExported() || !isConnReceiver(fn.Recv) || seen[fn.Name.Name] {
+				continue
+			}
+			seen[fn.Name.Name] = true
+			methods = append(methods, &method{name: fn.Name.Name, ftype: fn.Type, imports: imports})
+		}
+	}
+
+	sort.Slice(methods, func(i, j int) bool { return methods[i].name < methods[j].name })
+	return pkgName, methods, nil
+}
+
+func isConnReceiver(recv *ast.FieldList) bool {
+	if len(recv.List) != 1 {
+		return false
+	}
+	t := recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	id, ok := t.(*ast.Ident)
+	return ok && id.Name == *typeName
+}
+
+// defaultImportName returns the package name assumed for an import path
+// without explicit name, i.e. its last element, skipping a major version
+// suffix.
+func defaultImportName(p string) string {
+	name := path.Base(p)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = path.Base(path.Dir(p))
+	}
+	return name
+}
+
+// importSet collects the imports used by the generated code.
+type importSet map[string]string // import name to path
+
+func (s importSet) add(name, p string) error {
+	if old, ok := s[name]; ok && old != p {
+		return fmt.Errorf("import name %s used for both %s and %s", name, old, p)
+	}
+	s[name] = p
+	return nil
+}
+
+func (s importSet) format(buf *bytes.Buffer) {
+	if len(s) == 0 {
+		return
+	}
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		pi, pj := s[names[i]], s[names[j]]
+		if isStd(pi) != isStd(pj) {
+			return isStd(pi)
+		}
+		return pi < pj
+	})
+
+	buf.WriteString("import (\n")
+	for i, name := range names {
+		p := s[name]
+		// the standard library first, as goimports does
+		if i > 0 && isStd(s[names[i-1]]) && !isStd(p) {
+			buf.WriteString("\n")
+		}
+		if name == defaultImportName(p) {
+			fmt.Fprintf(buf, "\t%q\n", p)
+		} else {
+			fmt.Fprintf(buf, "\t%s %q\n", name, p)
+		}
+	}
+	buf.WriteString(")\n\n")
+}
+
+func isStd(p string) bool {
+	return !strings.Contains(strings.SplitN(p, "/", 2)[0], ".")
+}
+
+// rewriteTypes walks the types in the signature of m, calling local for the
+// (exported) identifiers declared in the package and qualified for the
+// qualified identifiers. It fails on unexported identifiers of the package,
+// which can't be used outside of it.
+func rewriteTypes(m *method, local func(*ast.Ident) ast.Expr, qualified func(*ast.SelectorExpr) error) error {
+	var err error
+	var walk func(ast.Expr) ast.Expr
+	walkFields := func(fl *ast.FieldList) {
+		if fl == nil {
+			return
+		}
+		for _, field := range fl.List {
+			field.Type = walk(field.Type)
+		}
+	}
+	walk = func(e ast.Expr) ast.Expr {
+		switch t := e.(type) {
+		case *ast.Ident:
+			if builtinTypes[t.Name] {
+				return t
+			}
+			if !t.IsExported() {
+				if err == nil {
+					err = fmt.Errorf("%s: unexported type %s in signature", m.name, t.Name)
+				}
+				return t
+			}
+			return local(t)
+		case *ast.SelectorExpr:
+			if e := qualified(t); e != nil && err == nil {
+				err = fmt.Errorf("%s: %w", m.name, e)
+			}
+		case *ast.StarExpr:
+			t.X = walk(t.X)
+		case *ast.ArrayType:
+			t.Elt = walk(t.Elt)
+		case *ast.MapType:
+			t.Key = walk(t.Key)
+			t.Value = walk(t.Value)
+		case *ast.ChanType:
+			t.Value = walk(t.Value)
+		case *ast.Ellipsis:
+			t.Elt = walk(t.Elt)
+		case *ast.FuncType:
+			walkFields(t.Params)
+			walkFields(t.Results)
+		case *ast.InterfaceType:
+			if len(t.Methods.List) > 0 && err == nil {
+				err = fmt.Errorf("%s: unsupported interface literal in signature", m.name)
+			}
+		case *ast.StructType:
+			walkFields(t.Fields)
+		default:
+			if err == nil {
+				err = fmt.Errorf("%s: unsupported type %T in signature", m.name, e)
+			}
+		}
+		return e
+	}
+	walk(m.ftype)
+	return err
+}
+
+// importOf returns the import path of the package qualifying sel.
+func importOf(m *method, sel *ast.SelectorExpr) (string, string, error) {
+	x, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", "", fmt.Errorf("unsupported type %T in signature", sel.X)
+	}
+	p, ok := m.imports[x.Name]
+	if !ok {
+		return "", "", fmt.Errorf("unknown package %s in signature", x.Name)
+	}
+	return x.Name, p, nil
+}
+
+func generateInterface(fset *token.FileSet, pkgName string, methods []*method) ([]byte, error) {
+	imports := make(importSet)
+	for _, m := range methods {
+		err := rewriteTypes(m, func(id *ast.Ident) ast.Expr { return id }, func(sel *ast.SelectorExpr) error {
+			name, p, err := importOf(m, sel)
+			if err != nil {
+				return err
+			}
+			return imports.add(name, p)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(generatedHeader)
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+	imports.format(&buf)
+	fmt.Fprintf(&buf, "// %s is the interface of %s, which allows for replacing it in tests,\n", *ifaceName, *typeName)
+	fmt.Fprintf(&buf, "// e.g. by the fake of the %stest package. See %s for the documentation of\n", pkgName, *typeName)
+	buf.WriteString("// the methods.\n")
+	fmt.Fprintf(&buf, "type %s interface {\n", *ifaceName)
+	for _, m := range methods {
+		fmt.Fprintf(&buf, "\t%s%s\n", m.name, strings.TrimPrefix(nodeString(fset, m.ftype), "func"))
+	}
+	buf.WriteString("}\n\n")
+	fmt.Fprintf(&buf, "var _ %s = (*%s)(nil)\n", *ifaceName, *typeName)
+
+	return formatSource(buf.Bytes())
+}
+
+func generateFake(fset *token.FileSet, pkgName, pkgPath string, methods []*method) ([]byte, error) {
+	imports := importSet{pkgName: pkgPath, "errors": "errors", "fmt": "fmt"}
+	for _, m := range methods {
+		err := rewriteTypes(m, func(id *ast.Ident) ast.Expr {
+			return &ast.SelectorExpr{X: &ast.Ident{NamePos: id.NamePos, Name: pkgName}, Sel: id}
+		}, func(sel *ast.SelectorExpr) error {
+			name, p, err := importOf(m, sel)
+			if err != nil {
+				return err
+			}
+			if _, taken := imports[name]; taken && imports[name] != p {
+				// e.g. github.com/godbus/dbus/v5 in the dbus package
+				name = "go" + name
+				sel.X = &ast.Ident{NamePos: sel.X.Pos(), Name: name}
+			}
+			return imports.add(name, p)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(generatedHeader)
+	fmt.Fprintf(&buf, "// Package %stest provides a fake of %s.%s for unit tests.\n", pkgName, pkgName, *typeName)
+	fmt.Fprintf(&buf, "package %stest\n\n", pkgName)
+	imports.format(&buf)
+
+	buf.WriteString("// ErrNotImplemented is returned by the methods whose function field is unset.\n")
+	buf.WriteString("var ErrNotImplemented = errors.New(\"not implemented\")\n\n")
+
+	fmt.Fprintf(&buf, "// %s is a fake %s.%s. Each method calls the function field of\n", *typeName, pkgName, *ifaceName)
+	buf.WriteString("// the same name with a Func suffix. If it is unset, the method returns zero\n")
+	buf.WriteString("// values and, if it returns an error, ErrNotImplemented.\n")
+	fmt.Fprintf(&buf, "type %s struct {\n", *typeName)
+	for _, m := range methods {
+		fmt.Fprintf(&buf, "\t%sFunc %s\n", m.name, nodeString(fset, m.ftype))
+	}
+	buf.WriteString("}\n\n")
+	fmt.Fprintf(&buf, "var _ %s.%s = (*%s)(nil)\n\n", pkgName, *ifaceName, *typeName)
+
+	for _, m := range methods {
+		writeFakeMethod(&buf, fset, m, imports)
+	}
+
+	return formatSource(buf.Bytes())
+}
+
+// writeFakeMethod writes the method m of the fake, naming its parameters
+// where needed so that they can be passed on to the function field.
+func writeFakeMethod(buf *bytes.Buffer, fset *token.FileSet, m *method, imports importSet) {
+	var args []string
+	if m.ftype.Params != nil {
+		for _, field := range m.ftype.Params.List {
+			if len(field.Names) == 0 {
+				field.Names = []*ast.Ident{ast.NewIdent("")}
+			}
+			for _, id := range field.Names {
+				if _, taken := imports[id.Name]; taken || id.Name == "" || id.Name == "_" || id.Name == "f" {
+					id.Name = fmt.Sprintf("arg%d", len(args))
+				}
+				arg := id.Name
+				if _, ok := field.Type.(*ast.Ellipsis); ok {
+					arg += "..."
+				}
+				args = append(args, arg)
+			}
+		}
+	}
+	call := fmt.Sprintf("f.%sFunc(%s)", m.name, strings.Join(args, ", "))
+
+	sig := nodeString(fset, &ast.FuncType{Params: m.ftype.Params, Results: m.ftype.Results})
+	fmt.Fprintf(buf, "func (f *%s) %s%s {\n", *typeName, m.name, strings.TrimPrefix(sig, "func"))
+
+	var results []ast.Expr
+	if m.ftype.Results != nil {
+		for _, field := range m.ftype.Results.List {
+			n := len(field.Names)
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				results = append(results, field.Type)
+			}
+		}
+	}
+	if len(results) == 0 {
+		fmt.Fprintf(buf, "\tif f.%sFunc != nil {\n\t\t%s\n\t}\n}\n\n", m.name, call)
+		return
+	}
+
+	fmt.Fprintf(buf, "\tif f.%sFunc == nil {\n", m.name)
+	zeros := make([]string, len(results))
+	for i, t := range results {
+		if id, ok := t.(*ast.Ident); ok && id.Name == "error" && i == len(results)-1 {
+			zeros[i] = fmt.Sprintf("fmt.Errorf(\"%%w: %s\", ErrNotImplemented)", m.name)
+			continue
+		}
+		zeros[i] = fmt.Sprintf("r%d", i)
+		fmt.Fprintf(buf, "\t\tvar r%d %s\n", i, nodeString(fset, t))
+	}
+	fmt.Fprintf(buf, "\t\treturn %s\n\t}\n", strings.Join(zeros, ", "))
+	fmt.Fprintf(buf, "\treturn %s\n}\n\n", call)
+}
+
+func nodeString(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, node)
+	return buf.String()
+}
+
+func formatSource(src []byte) ([]byte, error) {
+	out, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, src)
+	}
+	return out, nil
+}
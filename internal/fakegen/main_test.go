@@ -0,0 +1,69 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// clients are the packages with go:generate directives running fakegen.
+var clients = []string{
+	"dbus", "hostname1", "import1", "locale1", "login1", "machine1",
+	"network1", "oomd1", "resolve1", "timesync1",
+}
+
+// TestGenerated checks that the generated interfaces and fakes are up to
+// date.
+func TestGenerated(t *testing.T) {
+	for _, client := range clients {
+		t.Run(client, func(t *testing.T) {
+			dir := filepath.Join("..", "..", client)
+			pkgName, iface, fake, err := generate(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkFile(t, filepath.Join(dir, *ifaceFile), iface)
+			checkFile(t, filepath.Join(dir, pkgName+"test", "fake.go"), fake)
+		})
+	}
+}
+
+func checkFile(t *testing.T, path string, expected []byte) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the checkout may have CRLF line endings
+	if !bytes.Equal(bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n")), expected) {
+		t.Errorf("%s is out of date, run go generate", path)
+	}
+}
+
+func TestDefaultImportName(t *testing.T) {
+	for p, expected := range map[string]string{
+		"context":                    "context",
+		"os/exec":                    "exec",
+		"github.com/godbus/dbus/v5":  "dbus",
+		"github.com/example/v2board": "v2board",
+	} {
+		if name := defaultImportName(p); name != expected {
+			t.Errorf("%s: expected %s, got %s", p, expected, name)
+		}
+	}
+}
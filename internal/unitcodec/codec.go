@@ -0,0 +1,289 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package unitcodec maps typed representations of configuration files in the
+// unit file format, e.g. .network or .nspawn files, to and from the files.
+package unitcodec
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gr-butler/go-systemd/v22/unit"
+)
+
+// The file types map to files with struct tags:
+//
+//   - fields of the file types tagged section:"Name" hold the section Name,
+//     as a struct, a pointer to a struct for optional sections, or a slice of
+//     structs for sections which can be repeated
+//   - fields of the section types tagged unit:"Name" hold the option Name;
+//     untagged fields use the field name. Options are strings, integers,
+//     *bool for booleans which may be unset, or string slices for lists
+//   - the ",join" option writes slices as a single space-separated assignment
+//     instead of one assignment per element. The ",line" option makes each
+//     assignment a single element, instead of splitting it at whitespace
+//   - the Extra field of the file types, an []Option, holds the options which
+//     are not mapped to a field, so that they are preserved when a file is
+//     parsed and serialized again
+
+// Option is an option which is not mapped to a field of a file type.
+type Option struct {
+	Section string
+	// Occurrence is the index of the section among the sections of the same
+	// name, e.g. 1 for the options of the second [Route] section.
+	Occurrence int
+	Name       string
+	Value      string
+}
+
+// Serialize encodes the file type pointed to by file.
+func Serialize(file interface{}) io.Reader {
+	v := reflect.ValueOf(file).Elem()
+	t := v.Type()
+
+	var sections []*unit.UnitSection
+	var extra []Option
+	for i := 0; i < t.NumField(); i++ {
+		f, fv := t.Field(i), v.Field(i)
+		name := f.Tag.Get("section")
+		if name == "" {
+			if f.Name == "Extra" {
+				extra = fv.Interface().([]Option)
+			}
+			continue
+		}
+
+		switch fv.Kind() {
+		case reflect.Struct:
+			if s := serializeSection(name, fv); len(s.Entries) > 0 {
+				sections = append(sections, s)
+			}
+		case reflect.Ptr:
+			if !fv.IsNil() {
+				sections = append(sections, serializeSection(name, fv.Elem()))
+			}
+		case reflect.Slice:
+			for j := 0; j < fv.Len(); j++ {
+				sections = append(sections, serializeSection(name, fv.Index(j)))
+			}
+		}
+	}
+
+	// the occurrences of the sections of each name, which the extra options
+	// are added to
+	occurrences := make(map[string][]*unit.UnitSection)
+	for _, s := range sections {
+		occurrences[s.Section] = append(occurrences[s.Section], s)
+	}
+	for _, opt := range extra {
+		for len(occurrences[opt.Section]) <= opt.Occurrence {
+			s := &unit.UnitSection{Section: opt.Section}
+			sections = append(sections, s)
+			occurrences[opt.Section] = append(occurrences[opt.Section], s)
+		}
+		section := occurrences[opt.Section][opt.Occurrence]
+		section.Entries = append(section.Entries, &unit.UnitEntry{Name: opt.Name, Value: opt.Value})
+	}
+
+	return unit.SerializeSections(sections)
+}
+
+func serializeSection(name string, v reflect.Value) *unit.UnitSection {
+	section := &unit.UnitSection{Section: name}
+	add := func(key, value string) {
+		section.Entries = append(section.Entries, &unit.UnitEntry{Name: key, Value: value})
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key, opt := optionName(t.Field(i))
+		fv := v.Field(i)
+
+		switch fv.Kind() {
+		case reflect.String:
+			if s := fv.String(); s != "" {
+				add(key, s)
+			}
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			if n := fv.Int(); n != 0 {
+				add(key, strconv.FormatInt(n, 10))
+			}
+		case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if n := fv.Uint(); n != 0 {
+				add(key, strconv.FormatUint(n, 10))
+			}
+		case reflect.Ptr:
+			if !fv.IsNil() {
+				add(key, formatBool(fv.Elem().Bool()))
+			}
+		case reflect.Slice:
+			values := fv.Interface().([]string)
+			if opt == "join" && len(values) > 0 {
+				add(key, strings.Join(values, " "))
+			} else {
+				for _, s := range values {
+					add(key, s)
+				}
+			}
+		}
+	}
+
+	return section
+}
+
+// optionName returns the option name of the field f and the option of its
+// tag, if any.
+func optionName(f reflect.StructField) (string, string) {
+	tag := f.Tag.Get("unit")
+	name, opt, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = f.Name
+	}
+	return name, opt
+}
+
+// Deserialize decodes the file read from r into the file type pointed to by
+// file.
+func Deserialize(r io.Reader, file interface{}) error {
+	sections, err := unit.DeserializeSections(r)
+	if err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(file).Elem()
+	t := v.Type()
+	extra := v.FieldByName("Extra")
+	occurrences := make(map[string]int)
+
+	for _, s := range sections {
+		occurrence := occurrences[s.Section]
+		occurrences[s.Section]++
+
+		var target reflect.Value
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).Tag.Get("section") != s.Section {
+				continue
+			}
+
+			switch fv := v.Field(i); fv.Kind() {
+			case reflect.Struct:
+				target = fv
+			case reflect.Ptr:
+				if fv.IsNil() {
+					fv.Set(reflect.New(fv.Type().Elem()))
+				}
+				target = fv.Elem()
+			case reflect.Slice:
+				fv.Set(reflect.Append(fv, reflect.New(fv.Type().Elem()).Elem()))
+				target = fv.Index(fv.Len() - 1)
+			}
+		}
+
+		for _, e := range s.Entries {
+			ok, err := setOption(target, e)
+			if err != nil {
+				return fmt.Errorf("[%s] %s: %w", s.Section, e.Name, err)
+			}
+			if !ok {
+				opt := Option{Section: s.Section, Occurrence: occurrence, Name: e.Name, Value: e.Value}
+				extra.Set(reflect.Append(extra, reflect.ValueOf(opt)))
+			}
+		}
+	}
+
+	return nil
+}
+
+// setOption sets the field of the section v for the entry e, and reports
+// whether v has such a field.
+func setOption(v reflect.Value, e *unit.UnitEntry) (bool, error) {
+	if !v.IsValid() {
+		return false, nil
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key, opt := optionName(t.Field(i))
+		if key != e.Name {
+			continue
+		}
+
+		fv := v.Field(i)
+		switch fv.Kind() {
+		case reflect.String:
+			fv.SetString(e.Value)
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			n, err := strconv.ParseInt(e.Value, 10, fv.Type().Bits())
+			if err != nil {
+				return true, fmt.Errorf("invalid value %q", e.Value)
+			}
+			fv.SetInt(n)
+		case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			n, err := strconv.ParseUint(e.Value, 10, fv.Type().Bits())
+			if err != nil {
+				return true, fmt.Errorf("invalid value %q", e.Value)
+			}
+			fv.SetUint(n)
+		case reflect.Ptr:
+			// an empty assignment resets the option to its default
+			if e.Value == "" {
+				fv.Set(reflect.Zero(fv.Type()))
+				break
+			}
+			b, err := parseBool(e.Value)
+			if err != nil {
+				return true, err
+			}
+			fv.Set(reflect.ValueOf(&b))
+		case reflect.Slice:
+			// like systemd, an empty assignment resets the list
+			if e.Value == "" {
+				fv.Set(reflect.Zero(fv.Type()))
+				break
+			}
+			values := fv.Interface().([]string)
+			if opt == "line" {
+				values = append(values, e.Value)
+			} else {
+				values = append(values, strings.Fields(e.Value)...)
+			}
+			fv.Set(reflect.ValueOf(values))
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// parseBool parses a boolean like systemd's parse_boolean.
+func parseBool(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "1", "yes", "y", "true", "t", "on":
+		return true, nil
+	case "0", "no", "n", "false", "f", "off":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid boolean %q", s)
+}
+
+func formatBool(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package words splits and quotes the whitespace-separated words of systemd
+// configuration lines, like extract_first_word() with EXTRACT_UNQUOTE and
+// EXTRACT_CUNESCAPE in systemd.
+package words
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const whitespace = " \t\n\r"
+
+// Split extracts up to n words from line, removing their quotes and
+// resolving C escapes, and returns them with the rest of the line after the
+// whitespace following the last word.
+func Split(line string, n int) ([]string, string, error) {
+	var words []string
+	rest := strings.TrimLeft(line, whitespace)
+	for len(words) < n && rest != "" {
+		word, r, err := next(rest)
+		if err != nil {
+			return nil, "", err
+		}
+		words = append(words, word)
+		rest = strings.TrimLeft(r, whitespace)
+	}
+	return words, rest, nil
+}
+
+// next extracts the word at the start of s.
+func next(s string) (string, string, error) {
+	var b strings.Builder
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == 0 && strings.IndexByte(whitespace, c) >= 0:
+			return b.String(), s[i:], nil
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case c == quote:
+			quote = 0
+		case c == '\\':
+			r, n, err := unescapeAt(s[i+1:])
+			if err != nil {
+				return "", "", err
+			}
+			b.WriteString(r)
+			i += n
+		default:
+			b.WriteByte(c)
+		}
+	}
+	if quote != 0 {
+		return "", "", fmt.Errorf("unterminated quote in %q", s)
+	}
+	return b.String(), "", nil
+}
+
+// Unescape resolves the C escapes of s, e.g. in the argument of tmpfiles.d
+// lines.
+func Unescape(s string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' {
+			b.WriteByte(s[i])
+			continue
+		}
+		r, n, err := unescapeAt(s[i+1:])
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(r)
+		i += n
+	}
+	return b.String(), nil
+}
+
+// unescapeAt resolves the escape after a backslash at the start of s, and
+// returns the number of bytes of s it consumed.
+func unescapeAt(s string) (string, int, error) {
+	if s == "" {
+		return "", 0, fmt.Errorf("trailing backslash")
+	}
+
+	simple := map[byte]string{
+		'a': "\a", 'b': "\b", 'f': "\f", 'n': "\n", 'r': "\r", 't': "\t", 'v': "\v",
+		'\\': "\\", '"': "\"", '\'': "'", ' ': " ", 's': " ",
+	}
+	if r, ok := simple[s[0]]; ok {
+		return r, 1, nil
+	}
+
+	switch s[0] {
+	case 'x':
+		if len(s) < 3 {
+			return "", 0, fmt.Errorf("invalid escape \\%s", s)
+		}
+		v, err := strconv.ParseUint(s[1:3], 16, 8)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid escape \\%s", s[:3])
+		}
+		return string([]byte{byte(v)}), 3, nil
+	case 'u', 'U':
+		n := 4
+		if s[0] == 'U' {
+			n = 8
+		}
+		if len(s) < n+1 {
+			return "", 0, fmt.Errorf("invalid escape \\%s", s)
+		}
+		v, err := strconv.ParseUint(s[1:n+1], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(v)) {
+			return "", 0, fmt.Errorf("invalid escape \\%s", s[:n+1])
+		}
+		return string(rune(v)), n + 1, nil
+	case '0', '1', '2', '3':
+		if len(s) < 3 {
+			return "", 0, fmt.Errorf("invalid escape \\%s", s)
+		}
+		v, err := strconv.ParseUint(s[:3], 8, 8)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid escape \\%s", s[:3])
+		}
+		return string([]byte{byte(v)}), 3, nil
+	}
+	return "", 0, fmt.Errorf("invalid escape \\%c", s[0])
+}
+
+// Quote returns word as written in a configuration line: as is if it can be
+// split unambiguously, or else double-quoted with C escapes.
+func Quote(word string) string {
+	if word != "" && !strings.ContainsAny(word, whitespace+"\"'\\") && isPrintable(word) {
+		return word
+	}
+	return `"` + Escape(word) + `"`
+}
+
+// Escape returns s with backslashes, double quotes and non-printable
+// characters escaped, so that Unescape returns s.
+func Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == '\\' || r == '"':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == utf8.RuneError && size == 1, r < ' ', r == 0x7f:
+			fmt.Fprintf(&b, `\x%02x`, s[i])
+		default:
+			b.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+func isPrintable(s string) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+	for _, r := range s {
+		if r < ' ' || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package words
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		line     string
+		n        int
+		expected []string
+		rest     string
+	}{
+		{"u  foo 42", 6, []string{"u", "foo", "42"}, ""},
+		{`u foo - "Foo \"Bar\" User" /home/foo`, 6, []string{"u", "foo", "-", `Foo "Bar" User`, "/home/foo"}, ""},
+		{`f /tmp/a - - - - hello world`, 6, []string{"f", "/tmp/a", "-", "-", "-", "-"}, "hello world"},
+		{`d '/tmp/with space' 0755`, 6, []string{"d", "/tmp/with space", "0755"}, ""},
+		{`w /a\x20b`, 6, []string{"w", "/a b"}, ""},
+		{"", 6, nil, ""},
+	}
+
+	for i, tt := range tests {
+		words, rest, err := Split(tt.line, tt.n)
+		if err != nil {
+			t.Errorf("#%d: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(words, tt.expected) || rest != tt.rest {
+			t.Errorf("#%d: expected %q, %q, got %q, %q", i, tt.expected, tt.rest, words, rest)
+		}
+	}
+
+	for _, line := range []string{`u "foo`, `u foo\`, `u foo\q`, `u \x4`} {
+		if _, _, err := Split(line, 6); err == nil {
+			t.Errorf("expected an error for %q", line)
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	for _, word := range []string{"foo", "", "with space", `quote"s`, "back\\slash", "tab\there", "new\nline", "\x01", "ünïcödé", "it's"} {
+		quoted := Quote(word)
+		words, rest, err := Split(quoted+" next", 1)
+		if err != nil {
+			t.Errorf("%q: %v", quoted, err)
+			continue
+		}
+		if len(words) != 1 || words[0] != word || rest != "next" {
+			t.Errorf("%q: expected %q, got %q, %q", quoted, word, words, rest)
+		}
+	}
+
+	if q := Quote("plain"); q != "plain" {
+		t.Errorf("expected plain words not to be quoted, got %q", q)
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrQueueFull is returned by AsyncSender.Send when the queue is full
+	// and the overflow policy is DropNewest.
+	ErrQueueFull = errors.New("journal queue is full")
+	// ErrSenderClosed is returned by AsyncSender.Send after Close.
+	ErrSenderClosed = errors.New("journal sender is closed")
+)
+
+// OverflowPolicy determines what AsyncSender.Send does when the queue is
+// full.
+type OverflowPolicy int
+
+const (
+	// Block waits until there is room in the queue.
+	Block OverflowPolicy = iota
+	// DropNewest drops the message being sent, returning ErrQueueFull.
+	DropNewest
+	// DropOldest drops the oldest queued message to make room.
+	DropOldest
+)
+
+// AsyncOptions configures an AsyncSender.
+type AsyncOptions struct {
+	// QueueSize is the maximum number of queued messages, 1024 if zero.
+	QueueSize int
+	// Overflow is what to do when the queue is full.
+	Overflow OverflowPolicy
+	// OnError, if set, is called from the background goroutine with the
+	// errors sending queued messages.
+	OnError func(err error)
+}
+
+// AsyncSender sends messages to the journal from a background goroutine, so
+// that logging doesn't block on the journal socket. Messages are encoded when
+// queued, and queued messages are sent in batches.
+type AsyncSender struct {
+	send     func(data []byte) error
+	onError  func(err error)
+	overflow OverflowPolicy
+
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	ring     [][]byte
+	head     int // index of the oldest queued message
+	n        int // number of queued messages
+	queued   uint64
+	done     uint64        // number of messages sent or dropped
+	progress chan struct{} // closed and replaced whenever done grows
+	dropped  uint64
+	closed   bool
+	exited   chan struct{}
+}
+
+// NewAsyncSender starts an AsyncSender. opts may be nil to use the default
+// options. Close must be called to stop it.
+func NewAsyncSender(opts *AsyncOptions) *AsyncSender {
+	return newAsyncSender(opts, sendData)
+}
+
+func newAsyncSender(opts *AsyncOptions, send func(data []byte) error) *AsyncSender {
+	if opts == nil {
+		opts = &AsyncOptions{}
+	}
+	size := opts.QueueSize
+	if size <= 0 {
+		size = 1024
+	}
+
+	s := &AsyncSender{
+		send:     send,
+		onError:  opts.OnError,
+		overflow: opts.Overflow,
+		ring:     make([][]byte, size),
+		progress: make(chan struct{}),
+		exited:   make(chan struct{}),
+	}
+	s.notEmpty = sync.NewCond(&s.mu)
+	s.notFull = sync.NewCond(&s.mu)
+
+	go s.run()
+	return s
+}
+
+// Send queues a message to be sent to the journal, in the same format as
+// Send.
+func (s *AsyncSender) Send(message string, priority Priority, vars map[string]string) error {
+	data := encodeMessage(message, priority, vars)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for !s.closed && s.n == len(s.ring) {
+		switch s.overflow {
+		case DropNewest:
+			s.dropped++
+			return ErrQueueFull
+		case DropOldest:
+			s.ring[s.head] = nil
+			s.head = (s.head + 1) % len(s.ring)
+			s.n--
+			s.dropped++
+			s.advance(1)
+		default:
+			s.notFull.Wait()
+		}
+	}
+	if s.closed {
+		return ErrSenderClosed
+	}
+
+	s.ring[(s.head+s.n)%len(s.ring)] = data
+	s.n++
+	s.queued++
+	s.notEmpty.Signal()
+	return nil
+}
+
+// Dropped returns the number of messages dropped because the queue was full.
+func (s *AsyncSender) Dropped() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Flush waits until the messages queued so far have been sent, or ctx is
+// done.
+func (s *AsyncSender) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	target := s.queued
+	for s.done < target {
+		progress := s.progress
+		s.mu.Unlock()
+
+		select {
+		case <-progress:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		s.mu.Lock()
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// Close stops accepting messages, sends the queued ones and stops the
+// background goroutine.
+func (s *AsyncSender) Close() error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		s.notEmpty.Broadcast()
+		s.notFull.Broadcast()
+	}
+	s.mu.Unlock()
+
+	<-s.exited
+	return nil
+}
+
+// advance records that n more messages were sent or dropped. It must be
+// called with s.mu held.
+func (s *AsyncSender) advance(n int) {
+	s.done += uint64(n)
+	close(s.progress)
+	s.progress = make(chan struct{})
+}
+
+func (s *AsyncSender) run() {
+	defer close(s.exited)
+
+	var batch [][]byte
+	for {
+		s.mu.Lock()
+		for s.n == 0 && !s.closed {
+			s.notEmpty.Wait()
+		}
+		if s.n == 0 {
+			s.mu.Unlock()
+			return
+		}
+
+		batch = batch[:0]
+		for ; s.n > 0; s.n-- {
+			batch = append(batch, s.ring[s.head])
+			s.ring[s.head] = nil
+			s.head = (s.head + 1) % len(s.ring)
+		}
+		s.notFull.Broadcast()
+		s.mu.Unlock()
+
+		for _, data := range batch {
+			if err := s.send(data); err != nil && s.onError != nil {
+				s.onError(err)
+			}
+		}
+
+		s.mu.Lock()
+		s.advance(len(batch))
+		s.mu.Unlock()
+	}
+}
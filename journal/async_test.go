@@ -0,0 +1,112 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingSender records the messages sent while allowing the test to hold
+// up sending.
+type blockingSender struct {
+	mu      sync.Mutex
+	sent    []string
+	release chan struct{}
+}
+
+func (b *blockingSender) send(data []byte) error {
+	<-b.release
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, line := range strings.Split(string(data), "\n") {
+		if msg, ok := strings.CutPrefix(line, "MESSAGE="); ok {
+			b.sent = append(b.sent, msg)
+		}
+	}
+	return nil
+}
+
+func TestAsyncSenderFlush(t *testing.T) {
+	b := &blockingSender{release: make(chan struct{})}
+	close(b.release)
+	s := newAsyncSender(nil, b.send)
+	defer s.Close()
+
+	for _, msg := range []string{"one", "two", "three"} {
+		if err := s.Send(msg, PriInfo, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if strings.Join(b.sent, ",") != "one,two,three" {
+		t.Errorf("unexpected messages sent: %v", b.sent)
+	}
+}
+
+func TestAsyncSenderOverflow(t *testing.T) {
+	for _, tt := range []struct {
+		policy   OverflowPolicy
+		expected string
+	}{
+		{DropNewest, "first,m0,m1"},
+		{DropOldest, "first,m2,m3"},
+	} {
+		b := &blockingSender{release: make(chan struct{})}
+		s := newAsyncSender(&AsyncOptions{QueueSize: 2, Overflow: tt.policy}, b.send)
+
+		// the first message is taken off the queue and held up in send
+		s.Send("first", PriInfo, nil)
+		for {
+			s.mu.Lock()
+			n := s.n
+			s.mu.Unlock()
+			if n == 0 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		for i := 0; i < 4; i++ {
+			err := s.Send("m"+string(rune('0'+i)), PriInfo, nil)
+			if tt.policy == DropNewest && i >= 2 && err != ErrQueueFull {
+				t.Errorf("expected ErrQueueFull, got %v", err)
+			}
+		}
+		if s.Dropped() != 2 {
+			t.Errorf("expected 2 dropped messages, got %d", s.Dropped())
+		}
+
+		close(b.release)
+		s.Close()
+		if got := strings.Join(b.sent, ","); got != tt.expected {
+			t.Errorf("policy %d: expected %s, got %s", tt.policy, tt.expected, got)
+		}
+		if err := s.Send("late", PriInfo, nil); err != ErrSenderClosed {
+			t.Errorf("expected ErrSenderClosed, got %v", err)
+		}
+	}
+}
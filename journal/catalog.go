@@ -0,0 +1,344 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultCatalogDir is the directory of the message catalog files installed
+// on the system.
+const DefaultCatalogDir = "/usr/lib/systemd/catalog"
+
+// CatalogHeader is a header of a catalog entry, e.g. Subject or
+// Documentation.
+type CatalogHeader struct {
+	Name  string
+	Value string
+}
+
+// CatalogEntry is an entry of the message catalog, explaining the messages
+// with its MESSAGE_ID:
+// https://www.freedesktop.org/wiki/Software/systemd/catalog/
+type CatalogEntry struct {
+	MessageID string // 32 lowercase hexadecimal characters
+	Language  string // e.g. de or pt_BR, empty for the default language
+	Headers   []CatalogHeader
+	Body      string
+}
+
+// Header returns the value of the named header, or "" if the entry has no
+// such header.
+func (e *CatalogEntry) Header(name string) string {
+	for _, h := range e.Headers {
+		if h.Name == name {
+			return h.Value
+		}
+	}
+	return ""
+}
+
+// Render returns the text of the entry, its headers followed by an empty line
+// and the body, with @FIELD@ references replaced by the values of the fields
+// of the message being explained, as shown by journalctl -x. References to
+// fields missing from fields are left as they are.
+func (e *CatalogEntry) Render(fields map[string]string) string {
+	var b strings.Builder
+	for _, h := range e.Headers {
+		fmt.Fprintf(&b, "%s: %s\n", h.Name, h.Value)
+	}
+	if e.Body != "" {
+		b.WriteString("\n")
+		b.WriteString(e.Body)
+		b.WriteString("\n")
+	}
+	return replaceCatalogFields(b.String(), fields)
+}
+
+func replaceCatalogFields(text string, fields map[string]string) string {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(text, '@')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(text[start+1:], '@')
+		if end < 0 {
+			break
+		}
+		end += start + 1
+
+		value, ok := fields[text[start+1:end]]
+		if !ok {
+			// the closing @ may start the next reference
+			b.WriteString(text[:end])
+			text = text[end:]
+			continue
+		}
+		b.WriteString(text[:start])
+		b.WriteString(value)
+		text = text[end+1:]
+	}
+	b.WriteString(text)
+	return b.String()
+}
+
+// SendWithMessageID sends a message with the given MESSAGE_ID, which links it
+// to its catalog entry. id is a 128-bit ID, see Fields.MessageID.
+func SendWithMessageID(id, message string, priority Priority, vars map[string]string) error {
+	v, err := messageID(id).format()
+	if err != nil {
+		return err
+	}
+
+	fields := make(map[string]string, len(vars)+1)
+	for name, value := range vars {
+		fields[name] = value
+	}
+	fields["MESSAGE_ID"] = v
+	return Send(message, priority, fields)
+}
+
+// WriteCatalog writes entries in the catalog file format, to be installed in
+// DefaultCatalogDir and compiled with journalctl --update-catalog.
+func WriteCatalog(w io.Writer, entries []CatalogEntry) error {
+	b := bufio.NewWriter(w)
+	for i, e := range entries {
+		id, err := messageID(e.MessageID).format()
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("-- " + id)
+		if e.Language != "" {
+			b.WriteString(" " + e.Language)
+		}
+		b.WriteString("\n")
+		for _, h := range e.Headers {
+			if strings.ContainsAny(h.Name, ":\n") || strings.Contains(h.Value, "\n") {
+				return fmt.Errorf("invalid header %q of catalog entry %s", h.Name, id)
+			}
+			fmt.Fprintf(b, "%s: %s\n", h.Name, h.Value)
+		}
+		b.WriteString("\n")
+		if body := strings.TrimRight(e.Body, "\n"); body != "" {
+			b.WriteString(body + "\n")
+		}
+	}
+	return b.Flush()
+}
+
+// ParseCatalog parses a catalog file. lang is the language of the entries
+// which don't specify one, as given by the name of catalog files, e.g. de
+// for systemd.de.catalog.
+func ParseCatalog(r io.Reader, lang string) ([]CatalogEntry, error) {
+	var entries []CatalogEntry
+	var e *CatalogEntry
+	var body []string
+	inHeaders := false
+
+	finish := func() {
+		if e != nil {
+			// trim the empty lines separating entries
+			for len(body) > 0 && body[len(body)-1] == "" {
+				body = body[:len(body)-1]
+			}
+			e.Body = strings.Join(body, "\n")
+			entries = append(entries, *e)
+		}
+		e, body = nil, nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if header, ok := strings.CutPrefix(line, "-- "); ok {
+			finish()
+			parts := strings.Fields(header)
+			if len(parts) == 0 || len(parts) > 2 {
+				return nil, fmt.Errorf("line %d: invalid entry header %q", n, line)
+			}
+			id, err := messageID(parts[0]).format()
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			e = &CatalogEntry{MessageID: id, Language: lang}
+			if len(parts) == 2 {
+				e.Language = parts[1]
+			}
+			inHeaders = true
+			continue
+		}
+
+		if e == nil {
+			if strings.TrimSpace(line) != "" {
+				return nil, fmt.Errorf("line %d: text outside of an entry", n)
+			}
+			continue
+		}
+
+		if inHeaders {
+			if line == "" {
+				inHeaders = false
+				continue
+			}
+			name, value, ok := strings.Cut(line, ":")
+			if !ok {
+				return nil, fmt.Errorf("line %d: invalid header %q", n, line)
+			}
+			e.Headers = append(e.Headers, CatalogHeader{Name: name, Value: strings.TrimSpace(value)})
+			continue
+		}
+
+		body = append(body, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	finish()
+
+	return entries, nil
+}
+
+// Catalog is a message catalog, mapping message IDs to their entries.
+type Catalog struct {
+	entries map[string][]CatalogEntry
+}
+
+// LoadCatalog loads the *.catalog files in the given directories, or in
+// DefaultCatalogDir if none are given.
+func LoadCatalog(dirs ...string) (*Catalog, error) {
+	if len(dirs) == 0 {
+		dirs = []string{DefaultCatalogDir}
+	}
+
+	c := &Catalog{entries: make(map[string][]CatalogEntry)}
+	for _, dir := range dirs {
+		paths, err := filepath.Glob(filepath.Join(dir, "*.catalog"))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, path := range paths {
+			if err := c.loadFile(path); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return c, nil
+}
+
+func (c *Catalog) loadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// systemd.de.catalog holds the entries in German
+	var lang string
+	if name := strings.TrimSuffix(filepath.Base(path), ".catalog"); strings.Contains(name, ".") {
+		lang = name[strings.LastIndexByte(name, '.')+1:]
+	}
+
+	entries, err := ParseCatalog(f, lang)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	c.Add(entries...)
+	return nil
+}
+
+// Add adds entries to the catalog, replacing those with the same message ID
+// and language.
+func (c *Catalog) Add(entries ...CatalogEntry) {
+	if c.entries == nil {
+		c.entries = make(map[string][]CatalogEntry)
+	}
+
+outer:
+	for _, e := range entries {
+		list := c.entries[e.MessageID]
+		for i := range list {
+			if list[i].Language == e.Language {
+				list[i] = e
+				continue outer
+			}
+		}
+		c.entries[e.MessageID] = append(list, e)
+	}
+}
+
+// Lookup returns the entry for the message ID in the language lang, falling
+// back to the language without territory, e.g. de for de_AT, and to the
+// default language. If lang is empty, the language of the locale set in the
+// environment is used. It returns nil if there is no entry.
+func (c *Catalog) Lookup(id, lang string) *CatalogEntry {
+	id, err := messageID(id).format()
+	if err != nil {
+		return nil
+	}
+	if lang == "" {
+		lang = localeLanguage()
+	}
+
+	list := c.entries[id]
+	candidates := []string{lang}
+	if i := strings.IndexAny(lang, "_@"); i > 0 {
+		candidates = append(candidates, lang[:i])
+	}
+	candidates = append(candidates, "")
+
+	for _, l := range candidates {
+		for i := range list {
+			if list[i].Language == l {
+				return &list[i]
+			}
+		}
+	}
+	return nil
+}
+
+// localeLanguage returns the language of the messages locale, e.g. de_AT for
+// de_AT.UTF-8, or "" for the C and POSIX locales.
+func localeLanguage() string {
+	var locale string
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if locale = os.Getenv(name); locale != "" {
+			break
+		}
+	}
+
+	if i := strings.IndexByte(locale, '.'); i >= 0 {
+		locale = locale[:i]
+	}
+	if locale == "C" || locale == "POSIX" {
+		return ""
+	}
+	return locale
+}
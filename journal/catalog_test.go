@@ -0,0 +1,109 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testCatalog = `# SPDX-License-Identifier: LGPL-2.1-or-later
+
+-- fc2e22bc6ee647b6b90729ab34a250b1
+Subject: Process @COREDUMP_PID@ (@COREDUMP_COMM@) dumped core
+Defined-By: systemd
+Documentation: man:core(5)
+
+Process @COREDUMP_PID@ (@COREDUMP_COMM@) crashed and dumped core.
+
+This usually indicates a programming error.
+
+-- FC2E22BC-6EE6-47B6-B907-29AB34A250B1 de
+Subject: Speicherabbild für Prozess @COREDUMP_PID@ generiert
+`
+
+func TestCatalogRoundtrip(t *testing.T) {
+	entries, err := ParseCatalog(bytes.NewBufferString(testCatalog), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+
+	e := entries[0]
+	if e.MessageID != "fc2e22bc6ee647b6b90729ab34a250b1" || e.Language != "" || e.Header("Documentation") != "man:core(5)" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	if e.Body != "Process @COREDUMP_PID@ (@COREDUMP_COMM@) crashed and dumped core.\n\nThis usually indicates a programming error." {
+		t.Errorf("unexpected body %q", e.Body)
+	}
+	if entries[1].Language != "de" || entries[1].MessageID != e.MessageID {
+		t.Errorf("unexpected entry %+v", entries[1])
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCatalog(&buf, entries); err != nil {
+		t.Fatal(err)
+	}
+	reparsed, err := ParseCatalog(&buf, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(reparsed, entries) {
+		t.Errorf("expected %+v, got %+v", entries, reparsed)
+	}
+}
+
+func TestCatalogLookup(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "test.catalog"), []byte(testCatalog), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fr := "-- fc2e22bc6ee647b6b90729ab34a250b1\nSubject: Le processus @COREDUMP_PID@ a généré un fichier « core »\n"
+	if err := os.WriteFile(filepath.Join(dir, "test.fr.catalog"), []byte(fr), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := LoadCatalog(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := "fc2e22bc6ee647b6b90729ab34a250b1"
+	for lang, subject := range map[string]string{
+		"de_AT": "Speicherabbild für Prozess @COREDUMP_PID@ generiert",
+		"fr":    "Le processus @COREDUMP_PID@ a généré un fichier « core »",
+		"C":     "Process @COREDUMP_PID@ (@COREDUMP_COMM@) dumped core",
+	} {
+		e := c.Lookup(id, lang)
+		if e == nil || e.Header("Subject") != subject {
+			t.Errorf("%s: unexpected entry %+v", lang, e)
+		}
+	}
+	if c.Lookup("00000000000000000000000000000000", "C") != nil {
+		t.Error("expected no entry for an unknown message ID")
+	}
+
+	text := c.Lookup(id, "C").Render(map[string]string{"COREDUMP_PID": "42"})
+	expected := "Subject: Process 42 (@COREDUMP_COMM@) dumped core\nDefined-By: systemd\nDocumentation: man:core(5)\n\n" +
+		"Process 42 (@COREDUMP_COMM@) crashed and dumped core.\n\nThis usually indicates a programming error.\n"
+	if text != expected {
+		t.Errorf("expected %q, got %q", expected, text)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// maxExportFieldSize is the maximum size of a binary field value accepted by
+// ExportDecoder, the maximum size of journal data objects in systemd.
+const maxExportFieldSize = 768 * 1024 * 1024
+
+// ExportEncoder writes entries in the journal export format, as produced by
+// journalctl -o export and consumed by systemd-journal-remote:
+// https://systemd.io/JOURNAL_EXPORT_FORMATS/
+type ExportEncoder struct {
+	w *bufio.Writer
+}
+
+// NewExportEncoder returns an ExportEncoder writing to w.
+func NewExportEncoder(w io.Writer) *ExportEncoder {
+	return &ExportEncoder{w: bufio.NewWriter(w)}
+}
+
+// Encode writes e, followed by the empty line terminating an entry. The
+// cursor and timestamps are written first, if set, followed by the fields
+// sorted by name. Values which aren't printable text on a single line are
+// written with the binary framing.
+func (enc *ExportEncoder) Encode(e *Entry) error {
+	if e.Cursor != "" {
+		enc.writeField("__CURSOR", e.Cursor)
+	}
+	if e.RealtimeTimestamp != 0 {
+		enc.writeField("__REALTIME_TIMESTAMP", strconv.FormatUint(e.RealtimeTimestamp, 10))
+	}
+	if e.MonotonicTimestamp != 0 {
+		enc.writeField("__MONOTONIC_TIMESTAMP", strconv.FormatUint(e.MonotonicTimestamp, 10))
+	}
+
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range e.Fields[name] {
+			enc.writeField(name, value)
+		}
+	}
+
+	enc.w.WriteByte('\n')
+	return enc.w.Flush()
+}
+
+func (enc *ExportEncoder) writeField(name, value string) {
+	enc.w.WriteString(name)
+
+	if isExportText(value) {
+		enc.w.WriteByte('=')
+		enc.w.WriteString(value)
+	} else {
+		enc.w.WriteByte('\n')
+		binary.Write(enc.w, binary.LittleEndian, uint64(len(value)))
+		enc.w.WriteString(value)
+	}
+	enc.w.WriteByte('\n')
+}
+
+// isExportText reports whether value can be written as text, i.e. it is valid
+// UTF-8 without control characters other than tabs.
+func isExportText(value string) bool {
+	if !utf8.ValidString(value) {
+		return false
+	}
+	for _, c := range value {
+		if (c < ' ' && c != '\t') || (0x7f <= c && c <= 0x9f) {
+			return false
+		}
+	}
+	return true
+}
+
+// ExportDecoder reads entries in the journal export format.
+type ExportDecoder struct {
+	r *bufio.Reader
+}
+
+// NewExportDecoder returns an ExportDecoder reading from r.
+func NewExportDecoder(r io.Reader) *ExportDecoder {
+	return &ExportDecoder{r: bufio.NewReader(r)}
+}
+
+// Decode reads the next entry. The __CURSOR, __REALTIME_TIMESTAMP and
+// __MONOTONIC_TIMESTAMP fields are stored in the corresponding fields of the
+// Entry, all other fields in Fields. It returns io.EOF when there are no more
+// entries.
+func (dec *ExportDecoder) Decode() (*Entry, error) {
+	var e *Entry
+
+	for {
+		line, err := dec.r.ReadBytes('\n')
+		if err == io.EOF && len(line) == 0 {
+			if e == nil {
+				return nil, io.EOF
+			}
+			// tolerate a missing empty line after the last entry
+			return e, nil
+		}
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		line = line[:len(line)-1]
+
+		if len(line) == 0 {
+			if e == nil {
+				// skip empty lines between entries
+				continue
+			}
+			return e, nil
+		}
+
+		if e == nil {
+			e = &Entry{Fields: make(map[string][]string)}
+		}
+
+		var name, value string
+		if i := bytes.IndexByte(line, '='); i >= 0 {
+			name, value = string(line[:i]), string(line[i+1:])
+		} else {
+			name = string(line)
+			if value, err = dec.readBinary(); err != nil {
+				return nil, fmt.Errorf("failed to read field %s: %w", name, err)
+			}
+		}
+
+		if err := e.setExportField(name, value); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readBinary reads a value with the binary framing, after the field name.
+func (dec *ExportDecoder) readBinary() (string, error) {
+	var size uint64
+	if err := binary.Read(dec.r, binary.LittleEndian, &size); err != nil {
+		return "", unexpectedEOF(err)
+	}
+	if size > maxExportFieldSize {
+		return "", fmt.Errorf("field size %d too large", size)
+	}
+
+	value := make([]byte, size+1)
+	if _, err := io.ReadFull(dec.r, value); err != nil {
+		return "", unexpectedEOF(err)
+	}
+	if value[size] != '\n' {
+		return "", fmt.Errorf("missing newline after binary field")
+	}
+	return string(value[:size]), nil
+}
+
+func (e *Entry) setExportField(name, value string) error {
+	var err error
+	switch name {
+	case "__CURSOR":
+		e.Cursor = value
+	case "__REALTIME_TIMESTAMP":
+		e.RealtimeTimestamp, err = strconv.ParseUint(value, 10, 64)
+	case "__MONOTONIC_TIMESTAMP":
+		e.MonotonicTimestamp, err = strconv.ParseUint(value, 10, 64)
+	default:
+		e.Fields[name] = append(e.Fields[name], value)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
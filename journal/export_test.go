@@ -0,0 +1,80 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExportRoundtrip(t *testing.T) {
+	entries := []Entry{
+		{
+			Cursor:             "s=abc;i=1",
+			RealtimeTimestamp:  1700000000000000,
+			MonotonicTimestamp: 42,
+			Fields: map[string][]string{
+				"MESSAGE":  {"panic: boom\n\ngoroutine 1 [running]:"},
+				"PRIORITY": {"3"},
+				"TAGS":     {"a", "b\tc"},
+				"BLOB":     {"\x00\x01\xff"},
+			},
+		},
+		{
+			Fields: map[string][]string{"MESSAGE": {"second"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	enc := NewExportEncoder(&buf)
+	for i := range entries {
+		if err := enc.Encode(&entries[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dec := NewExportDecoder(&buf)
+	for i := range entries {
+		e, err := dec.Decode()
+		if err != nil {
+			t.Fatalf("entry %d: %v", i, err)
+		}
+		if !reflect.DeepEqual(*e, entries[i]) {
+			t.Errorf("entry %d: expected %+v, got %+v", i, entries[i], *e)
+		}
+	}
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestExportDecodeInvalid(t *testing.T) {
+	testCases := []string{
+		// truncated binary value
+		"MESSAGE\n\x10\x00\x00\x00\x00\x00\x00\x00short\n",
+		// missing newline after binary value
+		"MESSAGE\n\x02\x00\x00\x00\x00\x00\x00\x00abc\n\n",
+		"__REALTIME_TIMESTAMP=yesterday\n\n",
+	}
+
+	for _, in := range testCases {
+		if _, err := NewExportDecoder(strings.NewReader(in)).Decode(); err == nil || err == io.EOF {
+			t.Errorf("expected an error decoding %q, got %v", in, err)
+		}
+	}
+}
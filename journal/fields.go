@@ -0,0 +1,183 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fields holds journal fields with typed values, to be sent with
+// SendFields. Values are formatted as follows:
+//
+//   - strings, []byte and fmt.Stringer values are used as is
+//   - integers and floats are formatted in decimal notation
+//   - bools are formatted as "true" or "false"
+//   - time.Duration values are formatted as microseconds, like the *_USEC
+//     fields of systemd
+//   - time.Time values are formatted as microseconds since the epoch, like
+//     _SOURCE_REALTIME_TIMESTAMP
+//   - errors are formatted as their message
+//
+// Other values are formatted with fmt.Sprint.
+type Fields map[string]interface{}
+
+// Add sets the field name to value and returns f, so that calls can be
+// chained.
+func (f Fields) Add(name string, value interface{}) Fields {
+	f[name] = value
+	return f
+}
+
+// MessageID sets the MESSAGE_ID field, which identifies the kind of message
+// for use with message catalogs and filtering. id is a 128-bit ID as
+// generated by journalctl --new-id128, either as 32 hexadecimal characters or
+// in UUID format.
+func (f Fields) MessageID(id string) Fields {
+	f["MESSAGE_ID"] = messageID(id)
+	return f
+}
+
+// messageID is a MESSAGE_ID value, validated when formatted.
+type messageID string
+
+func (id messageID) format() (string, error) {
+	s := strings.ToLower(strings.ReplaceAll(string(id), "-", ""))
+	if b, err := hex.DecodeString(s); err != nil || len(b) != 16 {
+		return "", fmt.Errorf("invalid MESSAGE_ID %q: must be a 128-bit ID", string(id))
+	}
+	return s, nil
+}
+
+// SendFields is like Send, but takes fields with typed values. Unlike Send,
+// which skips invalid field names with a warning, it fails if a field name or
+// value is invalid.
+func SendFields(message string, priority Priority, fields Fields) error {
+	vars, err := fields.format()
+	if err != nil {
+		return err
+	}
+	return Send(message, priority, vars)
+}
+
+// format converts the fields to the string values accepted by Send.
+func (f Fields) format() (map[string]string, error) {
+	vars := make(map[string]string, len(f))
+	for name, value := range f {
+		if err := validVarName(name); err != nil {
+			return nil, fmt.Errorf("invalid field name %q: %w", name, err)
+		}
+
+		s, err := formatValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of field %s: %w", name, err)
+		}
+		vars[name] = s
+	}
+	return vars, nil
+}
+
+func formatValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", fmt.Errorf("nil value")
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case messageID:
+		return v.format()
+	case objectPID:
+		return v.format()
+	case time.Duration:
+		return strconv.FormatInt(v.Microseconds(), 10), nil
+	case time.Time:
+		return strconv.FormatInt(v.UnixMicro(), 10), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int8:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int16:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case error:
+		return v.Error(), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// ObjectPID sets the OBJECT_PID field, so that the message is about the given
+// process rather than the sender. When the sender is privileged, journald
+// adds the trusted OBJECT_UID, OBJECT_COMM, OBJECT_SYSTEMD_UNIT and related
+// fields describing the process, which makes the message show up for
+// journalctl --unit of the process' unit, for example. This allows
+// supervisors to log on behalf of the processes they manage.
+func (f Fields) ObjectPID(pid int) Fields {
+	f["OBJECT_PID"] = objectPID(pid)
+	return f
+}
+
+// ObjectUnit sets the OBJECT_SYSTEMD_UNIT field, e.g. for messages about a
+// process which already exited, so that ObjectPID can't be used. journalctl
+// --unit only shows such messages if they are sent by root.
+func (f Fields) ObjectUnit(unit string) Fields {
+	f["OBJECT_SYSTEMD_UNIT"] = unit
+	return f
+}
+
+// objectPID is an OBJECT_PID value, validated when formatted.
+type objectPID int
+
+func (pid objectPID) format() (string, error) {
+	if pid <= 0 {
+		return "", fmt.Errorf("invalid OBJECT_PID %d", int(pid))
+	}
+	return strconv.Itoa(int(pid)), nil
+}
+
+// SendForPID sends a message on behalf of the process pid, see
+// Fields.ObjectPID.
+func SendForPID(pid int, message string, priority Priority, vars map[string]string) error {
+	fields := make(Fields, len(vars)+1)
+	for name, value := range vars {
+		fields[name] = value
+	}
+	return SendFields(message, priority, fields.ObjectPID(pid))
+}
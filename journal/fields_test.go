@@ -0,0 +1,91 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFieldsFormat(t *testing.T) {
+	fields := Fields{}.
+		Add("STRING", "value").
+		Add("BYTES", []byte("bytes")).
+		Add("INT", -42).
+		Add("UINT64", uint64(18446744073709551615)).
+		Add("FLOAT", 1.5).
+		Add("BOOL", true).
+		Add("DURATION", 1500*time.Millisecond).
+		Add("TIME", time.Unix(1, 2000)).
+		Add("ERROR", errors.New("boom")).
+		MessageID("FC2E22BC-6EE6-47B6-B907-29AB34A250B1")
+
+	vars, err := fields.format()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"STRING":     "value",
+		"BYTES":      "bytes",
+		"INT":        "-42",
+		"UINT64":     "18446744073709551615",
+		"FLOAT":      "1.5",
+		"BOOL":       "true",
+		"DURATION":   "1500000",
+		"TIME":       "1000002",
+		"ERROR":      "boom",
+		"MESSAGE_ID": "fc2e22bc6ee647b6b90729ab34a250b1",
+	}
+	if len(vars) != len(expected) {
+		t.Fatalf("expected %d fields, got %v", len(expected), vars)
+	}
+	for name, value := range expected {
+		if vars[name] != value {
+			t.Errorf("field %s: expected %q, got %q", name, value, vars[name])
+		}
+	}
+}
+
+func TestFieldsFormatInvalid(t *testing.T) {
+	testCases := []Fields{
+		{"lowercase": "value"},
+		{"_TRUSTED": "value"},
+		{"NIL": nil},
+		Fields{}.MessageID("not-an-id"),
+		Fields{}.MessageID("fc2e22bc6ee647b6b90729ab34a250"),
+	}
+
+	for _, fields := range testCases {
+		if _, err := fields.format(); err == nil {
+			t.Errorf("expected an error formatting %v", fields)
+		}
+	}
+}
+
+func TestFieldsObject(t *testing.T) {
+	vars, err := Fields{}.ObjectPID(1234).ObjectUnit("nginx.service").format()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vars["OBJECT_PID"] != "1234" || vars["OBJECT_SYSTEMD_UNIT"] != "nginx.service" {
+		t.Errorf("unexpected fields %v", vars)
+	}
+
+	if _, err := (Fields{}).ObjectPID(0).format(); err == nil {
+		t.Error("expected an error for an invalid OBJECT_PID")
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GatewayClient reads the journal of a remote host served by
+// systemd-journal-gatewayd.
+type GatewayClient struct {
+	// URL is the URL of the gateway, e.g. http://host:19531.
+	URL string
+	// Client is used for the requests, http.DefaultClient if nil.
+	Client *http.Client
+}
+
+// GatewayQuery selects the entries returned by GatewayClient.Entries.
+type GatewayQuery struct {
+	// Cursor is the entry to start at, the first entry of the journal if
+	// empty.
+	Cursor string
+	// SkipEntries is the number of entries to skip from the start, and may
+	// be negative to start before it, e.g. to get the last entries of the
+	// journal. It is only used if NumEntries is set.
+	SkipEntries int64
+	// NumEntries is the maximum number of entries to return, unlimited if
+	// zero.
+	NumEntries uint64
+	// Follow keeps the request open to return new entries as they are
+	// written, until the context is done.
+	Follow bool
+	// Boot restricts the entries to those of the current boot.
+	Boot bool
+	// Matches restricts the entries to those matching all of the given
+	// FIELD=value pairs, except that matches for the same field are
+	// combined with OR.
+	Matches []string
+}
+
+// GatewayError is returned when the gateway fails a request.
+type GatewayError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *GatewayError) Error() string {
+	return fmt.Sprintf("gateway request failed with status %d: %s", e.StatusCode, e.Message)
+}
+
+// Entries calls fn for each entry selected by q, until there are no more
+// entries, fn returns an error or ctx is done. When following, it only
+// returns when fn fails or ctx is done, with ctx.Err() in the latter case.
+func (c *GatewayClient) Entries(ctx context.Context, q GatewayQuery, fn func(entry *Entry) error) error {
+	var query []string
+	if q.Follow {
+		query = append(query, "follow")
+	}
+	if q.Boot {
+		query = append(query, "boot")
+	}
+	for _, m := range q.Matches {
+		field, value, ok := strings.Cut(m, "=")
+		if !ok {
+			return fmt.Errorf("invalid match %q", m)
+		}
+		query = append(query, url.QueryEscape(field)+"="+url.QueryEscape(value))
+	}
+
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.fdo.journal")
+	if q.NumEntries > 0 {
+		header.Set("Range", fmt.Sprintf("entries=%s:%d:%d", q.Cursor, q.SkipEntries, q.NumEntries))
+	} else if q.Cursor != "" {
+		header.Set("Range", "entries="+q.Cursor)
+	}
+
+	body, err := c.get(ctx, "/entries", query, header)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	dec := NewExportDecoder(body)
+	for {
+		entry, err := dec.Decode()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+}
+
+// Fields returns the unique values of the field in the journal.
+func (c *GatewayClient) Fields(ctx context.Context, field string) ([]string, error) {
+	body, err := c.get(ctx, "/fields/"+url.PathEscape(field), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var values []string
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(nil, maxExportFieldSize)
+	for scanner.Scan() {
+		values = append(values, scanner.Text())
+	}
+	return values, scanner.Err()
+}
+
+func (c *GatewayClient) get(ctx context.Context, path string, query []string, header http.Header) (io.ReadCloser, error) {
+	u := strings.TrimSuffix(c.URL, "/") + path
+	if len(query) > 0 {
+		u += "?" + strings.Join(query, "&")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, &GatewayError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(msg))}
+	}
+	return resp.Body, nil
+}
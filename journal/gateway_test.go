@@ -0,0 +1,86 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestGatewayEntries(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/entries" || r.Header.Get("Accept") != "application/vnd.fdo.journal" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		if r.URL.RawQuery != "boot&_SYSTEMD_UNIT=nginx.service" || r.Header.Get("Range") != "entries=s=1:-2:2" {
+			http.Error(w, fmt.Sprintf("unexpected request %s %s", r.URL.RawQuery, r.Header.Get("Range")), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, "__CURSOR=s=2\nMESSAGE=first\n\n__CURSOR=s=3\nMESSAGE=second\n\n")
+	}))
+	defer srv.Close()
+
+	c := &GatewayClient{URL: srv.URL}
+	q := GatewayQuery{
+		Cursor:      "s=1",
+		SkipEntries: -2,
+		NumEntries:  2,
+		Boot:        true,
+		Matches:     []string{"_SYSTEMD_UNIT=nginx.service"},
+	}
+
+	var cursors []string
+	err := c.Entries(context.Background(), q, func(e *Entry) error {
+		cursors = append(cursors, e.Cursor)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cursors, []string{"s=2", "s=3"}) {
+		t.Errorf("unexpected entries %v", cursors)
+	}
+}
+
+func TestGatewayFields(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fields/_SYSTEMD_UNIT" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "a.service\nb.service\n")
+	}))
+	defer srv.Close()
+
+	c := &GatewayClient{URL: srv.URL}
+	values, err := c.Fields(context.Background(), "_SYSTEMD_UNIT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(values, []string{"a.service", "b.service"}) {
+		t.Errorf("unexpected values %v", values)
+	}
+
+	var gatewayErr *GatewayError
+	if _, err := c.Fields(context.Background(), "MESSAGE"); !errors.As(err, &gatewayErr) || gatewayErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected a GatewayError, got %v", err)
+	}
+}
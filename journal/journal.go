@@ -23,7 +23,17 @@
 package journal
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 )
 
 // Priority of a journal message
@@ -40,7 +50,107 @@ const (
 	PriDebug
 )
 
+var (
+	errNoSocket = errors.New("could not initialize socket to journald")
+
+	// stderrFallback enables writing messages to fallbackOutput when the
+	// journal is unavailable.
+	stderrFallback atomic.Bool
+	fallbackOutput io.Writer = os.Stderr
+	fallbackMu     sync.Mutex
+)
+
+// EnableStderrFallback sets whether Send writes messages to stderr when the
+// journal is unavailable, e.g. in containers or CI, rather than failing. The
+// lines are prefixed with the priority as in "<3>", the sd-daemon convention
+// understood by systemd for services logging to stderr, and the fields other
+// than the message are dropped.
+func EnableStderrFallback(enable bool) {
+	stderrFallback.Store(enable)
+}
+
 // Print prints a message to the local systemd journal using Send().
 func Print(priority Priority, format string, a ...interface{}) error {
 	return Send(fmt.Sprintf(format, a...), priority, nil)
 }
+
+// validVarName validates a variable name to make sure journald will accept it.
+// The variable name must be in uppercase and consist only of characters,
+// numbers and underscores, and may not begin with an underscore:
+// https://www.freedesktop.org/software/systemd/man/sd_journal_print.html
+func validVarName(name string) error {
+	if name == "" {
+		return errors.New("Empty variable name")
+	} else if name[0] == '_' {
+		return errors.New("Variable name begins with an underscore")
+	}
+
+	for _, c := range name {
+		if !(('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') || c == '_') {
+			return errors.New("Variable name contains invalid characters")
+		}
+	}
+	return nil
+}
+
+// send sends a message to the journal, falling back to stderr if enabled.
+func send(message string, priority Priority, vars map[string]string) error {
+	err := sendData(encodeMessage(message, priority, vars))
+	if err != nil && stderrFallback.Load() && journalUnavailable(err) {
+		return writeFallback(message, priority)
+	}
+	return err
+}
+
+// journalUnavailable reports whether err means that journald isn't running.
+func journalUnavailable(err error) bool {
+	return errors.Is(err, errNoSocket) || errors.Is(err, syscall.ENOENT) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// writeFallback writes each line of the message to fallbackOutput, prefixed
+// with the priority.
+func writeFallback(message string, priority Priority) error {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(message, "\n"), "\n") {
+		fmt.Fprintf(&b, "<%d>%s\n", priority, line)
+	}
+
+	fallbackMu.Lock()
+	defer fallbackMu.Unlock()
+	_, err := io.WriteString(fallbackOutput, b.String())
+	return err
+}
+
+// encodeMessage encodes a message in the native journal protocol.
+func encodeMessage(message string, priority Priority, vars map[string]string) []byte {
+	data := new(bytes.Buffer)
+	appendVariable(data, "PRIORITY", strconv.Itoa(int(priority)))
+	appendVariable(data, "MESSAGE", message)
+	for k, v := range vars {
+		appendVariable(data, k, v)
+	}
+	return data.Bytes()
+}
+
+// appendVariable writes a field in the native journal protocol. Fields with
+// invalid names are skipped with a warning, as journald would drop them.
+func appendVariable(w io.Writer, name, value string) {
+	if err := validVarName(name); err != nil {
+		fmt.Fprintf(os.Stderr, "variable name %s contains invalid character, ignoring\n", name)
+		return
+	}
+	if !isExportText(value) {
+		/* When the value contains a newline or binary data, we write:
+		 * - the variable name, followed by a newline
+		 * - the size (in 64bit little endian format)
+		 * - the data, followed by a newline
+		 */
+		fmt.Fprintln(w, name)
+		binary.Write(w, binary.LittleEndian, uint64(len(value)))
+		io.WriteString(w, value)
+		io.WriteString(w, "\n")
+	} else {
+		/* just write the variable and value all on one line */
+		fmt.Fprintf(w, "%s=%s\n", name, value)
+	}
+}
@@ -15,11 +15,15 @@
 package journal
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
+	"net"
+	"os"
 	"runtime"
 	"strconv"
 	"strings"
+	"syscall"
 	"testing"
 )
 
@@ -119,3 +123,49 @@ func SendAlloc(run int, label string, len int) error {
 	msg := fmt.Sprintf("go-systemd test #%v - %s", run, label)
 	return Send(msg, PriCrit, largeVars)
 }
+
+func TestAppendVariable(t *testing.T) {
+	testCases := []struct {
+		name, value, expected string
+	}{
+		{"MESSAGE", "hello\tworld", "MESSAGE=hello\tworld\n"},
+		{"MESSAGE", "line 1\nline 2", "MESSAGE\n\x0d\x00\x00\x00\x00\x00\x00\x00line 1\nline 2\n"},
+		{"BLOB", "\x00\xff", "BLOB\n\x02\x00\x00\x00\x00\x00\x00\x00\x00\xff\n"},
+		{"lowercase", "value", ""},
+	}
+
+	for _, tt := range testCases {
+		var buf bytes.Buffer
+		appendVariable(&buf, tt.name, tt.value)
+		if buf.String() != tt.expected {
+			t.Errorf("%s=%q: expected %q, got %q", tt.name, tt.value, tt.expected, buf.String())
+		}
+	}
+}
+
+func TestStderrFallback(t *testing.T) {
+	var buf bytes.Buffer
+	fallbackOutput = &buf
+	defer func() { fallbackOutput = os.Stderr }()
+
+	if err := writeFallback("panic: boom\ngoroutine 1\n", PriCrit); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "<2>panic: boom\n<2>goroutine 1\n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	unavailable := []error{
+		errNoSocket,
+		&net.OpError{Op: "write", Err: os.NewSyscallError("sendmsg", syscall.ENOENT)},
+		&net.OpError{Op: "write", Err: os.NewSyscallError("sendmsg", syscall.ECONNREFUSED)},
+	}
+	for _, err := range unavailable {
+		if !journalUnavailable(err) {
+			t.Errorf("expected %v to mean the journal is unavailable", err)
+		}
+	}
+	if journalUnavailable(&net.OpError{Op: "write", Err: os.NewSyscallError("sendmsg", syscall.EMSGSIZE)}) {
+		t.Error("expected EMSGSIZE not to mean the journal is unavailable")
+	}
+}
@@ -26,16 +26,10 @@
 package journal
 
 import (
-	"bytes"
-	"encoding/binary"
-	"errors"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"net"
 	"os"
-	"strconv"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"syscall"
@@ -127,11 +121,20 @@ func fdIsJournalStream(fd int) (bool, error) {
 // restrictions, any arbitrary field name may be used.  Some names have special
 // significance: see the journalctl documentation
 // (http://www.freedesktop.org/software/systemd/man/systemd.journal-fields.html)
-// for more details.  vars may be nil.
+// for more details.  vars may be nil. Values may span several lines and may
+// contain binary data.
+//
+// If the journal is unavailable and the stderr fallback is enabled with
+// EnableStderrFallback, the message is written to stderr instead.
 func Send(message string, priority Priority, vars map[string]string) error {
+	return send(message, priority, vars)
+}
+
+// sendData sends a datagram encoded by encodeMessage to the journal.
+func sendData(data []byte) error {
 	conn := getOrInitConn()
 	if conn == nil {
-		return errors.New("could not initialize socket to journald")
+		return errNoSocket
 	}
 
 	socketAddr := &net.UnixAddr{
@@ -139,14 +142,7 @@ func Send(message string, priority Priority, vars map[string]string) error {
 		Net:  "unixgram",
 	}
 
-	data := new(bytes.Buffer)
-	appendVariable(data, "PRIORITY", strconv.Itoa(int(priority)))
-	appendVariable(data, "MESSAGE", message)
-	for k, v := range vars {
-		appendVariable(data, k, v)
-	}
-
-	_, _, err := conn.WriteMsgUnix(data.Bytes(), nil, socketAddr)
+	_, _, err := conn.WriteMsgUnix(data, nil, socketAddr)
 	if err == nil {
 		return nil
 	}
@@ -154,16 +150,19 @@ func Send(message string, priority Priority, vars map[string]string) error {
 		return err
 	}
 
-	// Large log entry, send it via tempfile and ancillary-fd.
-	file, err := tempFd()
+	// Large log entry, send it via a sealed memfd or a tempfile and
+	// ancillary-fd.
+	file, err := memfd(data)
 	if err != nil {
-		return err
+		if file, err = tempFd(); err != nil {
+			return err
+		}
+		if _, err = file.Write(data); err != nil {
+			file.Close()
+			return err
+		}
 	}
 	defer file.Close()
-	_, err = io.Copy(file, data)
-	if err != nil {
-		return err
-	}
 	rights := syscall.UnixRights(int(file.Fd()))
 	_, _, err = conn.WriteMsgUnix([]byte{}, rights, socketAddr)
 	if err != nil {
@@ -183,44 +182,6 @@ func getOrInitConn() *net.UnixConn {
 	return (*net.UnixConn)(atomic.LoadPointer(&unixConnPtr))
 }
 
-func appendVariable(w io.Writer, name, value string) {
-	if err := validVarName(name); err != nil {
-		fmt.Fprintf(os.Stderr, "variable name %s contains invalid character, ignoring\n", name)
-	}
-	if strings.ContainsRune(value, '\n') {
-		/* When the value contains a newline, we write:
-		 * - the variable name, followed by a newline
-		 * - the size (in 64bit little endian format)
-		 * - the data, followed by a newline
-		 */
-		fmt.Fprintln(w, name)
-		binary.Write(w, binary.LittleEndian, uint64(len(value)))
-		fmt.Fprintln(w, value)
-	} else {
-		/* just write the variable and value all on one line */
-		fmt.Fprintf(w, "%s=%s\n", name, value)
-	}
-}
-
-// validVarName validates a variable name to make sure journald will accept it.
-// The variable name must be in uppercase and consist only of characters,
-// numbers and underscores, and may not begin with an underscore:
-// https://www.freedesktop.org/software/systemd/man/sd_journal_print.html
-func validVarName(name string) error {
-	if name == "" {
-		return errors.New("Empty variable name")
-	} else if name[0] == '_' {
-		return errors.New("Variable name begins with an underscore")
-	}
-
-	for _, c := range name {
-		if !(('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') || c == '_') {
-			return errors.New("Variable name contains invalid characters")
-		}
-	}
-	return nil
-}
-
 // isSocketSpaceError checks whether the error is signaling
 // an "overlarge message" condition.
 func isSocketSpaceError(err error) bool {
@@ -0,0 +1,40 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"io"
+	"testing"
+)
+
+func TestMemfd(t *testing.T) {
+	file, err := memfd([]byte("MESSAGE=test\n"))
+	if err != nil {
+		t.Skipf("memfd not supported: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write([]byte("more")); err == nil {
+		t.Error("expected writing to the sealed memfd to fail")
+	}
+
+	data, err := io.ReadAll(io.NewSectionReader(file, 0, 1024))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "MESSAGE=test\n" {
+		t.Errorf("unexpected memfd content %q", data)
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// HandlerOptions configures a Handler.
+type HandlerOptions struct {
+	// Level is the minimum level of the records logged, slog.LevelInfo if
+	// nil.
+	Level slog.Leveler
+	// Fallback is where records are written in the slog text format when the
+	// journal is unavailable, os.Stderr if nil.
+	Fallback io.Writer
+}
+
+// Handler is a slog.Handler sending records to the local systemd journal.
+//
+// The record message is sent as MESSAGE and its level is mapped to PRIORITY:
+// levels from slog.LevelError up map to PriErr, from slog.LevelWarn up to
+// PriWarning, above slog.LevelInfo to PriNotice, slog.LevelInfo to PriInfo
+// and the levels below to PriDebug. The source of the record is sent as
+// CODE_FILE, CODE_LINE and CODE_FUNC.
+//
+// Attributes are sent as fields named by the uppercased attribute key,
+// prefixed with the uppercased names of the groups they are in, joined by
+// underscores: the attribute "id" in the group "request" is sent as
+// REQUEST_ID. Characters not allowed in field names are replaced by
+// underscores, and leading underscores are dropped. Attributes which would
+// be sent as one of the fields set by the Handler itself, e.g. "message", are
+// prefixed with ATTR_ instead. Values are formatted as in Fields.
+//
+// If the journal is unavailable when the Handler is created, or sending a
+// record fails, records are written to the fallback writer instead.
+type Handler struct {
+	level   slog.Leveler
+	prefix  string
+	fields  map[string]string
+	journal bool
+	send    func(message string, priority Priority, vars map[string]string) error
+
+	fallback slog.Handler
+}
+
+// NewHandler returns a Handler sending records to the journal. opts may be
+// nil to use the default options.
+func NewHandler(opts *HandlerOptions) *Handler {
+	if opts == nil {
+		opts = &HandlerOptions{}
+	}
+
+	level := opts.Level
+	if level == nil {
+		level = slog.LevelInfo
+	}
+	fallback := opts.Fallback
+	if fallback == nil {
+		fallback = os.Stderr
+	}
+
+	return &Handler{
+		level:    level,
+		journal:  Enabled(),
+		send:     Send,
+		fallback: slog.NewTextHandler(fallback, &slog.HandlerOptions{Level: level, AddSource: true}),
+	}
+}
+
+// Enabled reports whether records of the given level are logged.
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle sends the record to the journal.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.journal {
+		return h.fallback.Handle(ctx, r)
+	}
+
+	vars := make(map[string]string, len(h.fields)+r.NumAttrs()+3)
+	for name, value := range h.fields {
+		vars[name] = value
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		addAttr(vars, h.prefix, a)
+		return true
+	})
+
+	if r.PC != 0 {
+		frames := runtime.CallersFrames([]uintptr{r.PC})
+		frame, _ := frames.Next()
+		vars["CODE_FILE"] = frame.File
+		vars["CODE_LINE"] = strconv.Itoa(frame.Line)
+		vars["CODE_FUNC"] = frame.Function
+	}
+
+	if err := h.send(r.Message, levelPriority(r.Level), vars); err != nil {
+		return h.fallback.Handle(ctx, r)
+	}
+	return nil
+}
+
+// WithAttrs returns a Handler adding attrs to all records.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	h2 := *h
+	h2.fields = make(map[string]string, len(h.fields)+len(attrs))
+	for name, value := range h.fields {
+		h2.fields[name] = value
+	}
+	for _, a := range attrs {
+		addAttr(h2.fields, h.prefix, a)
+	}
+	h2.fallback = h.fallback.WithAttrs(attrs)
+	return &h2
+}
+
+// WithGroup returns a Handler qualifying the attributes of all records with
+// the group name.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.prefix = h.prefix + fieldName(name) + "_"
+	h2.fallback = h.fallback.WithGroup(name)
+	return &h2
+}
+
+// levelPriority maps a slog level to a journal priority.
+func levelPriority(level slog.Level) Priority {
+	switch {
+	case level >= slog.LevelError:
+		return PriErr
+	case level >= slog.LevelWarn:
+		return PriWarning
+	case level > slog.LevelInfo:
+		return PriNotice
+	case level == slog.LevelInfo:
+		return PriInfo
+	default:
+		return PriDebug
+	}
+}
+
+// reservedFields are the fields set by Handle itself.
+var reservedFields = map[string]bool{
+	"MESSAGE":   true,
+	"PRIORITY":  true,
+	"CODE_FILE": true,
+	"CODE_LINE": true,
+	"CODE_FUNC": true,
+}
+
+// addAttr adds the field for a to vars, or the fields of its members if it
+// is a group.
+func addAttr(vars map[string]string, prefix string, a slog.Attr) {
+	v := a.Value.Resolve()
+
+	if v.Kind() == slog.KindGroup {
+		group := v.Group()
+		if len(group) == 0 {
+			return
+		}
+		if a.Key != "" {
+			prefix += fieldName(a.Key) + "_"
+		}
+		for _, member := range group {
+			addAttr(vars, prefix, member)
+		}
+		return
+	}
+
+	if a.Key == "" {
+		return
+	}
+	name := strings.TrimLeft(prefix+fieldName(a.Key), "_")
+	if name == "" {
+		return
+	}
+	if reservedFields[name] {
+		name = "ATTR_" + name
+	}
+
+	value, err := formatValue(v.Any())
+	if err != nil {
+		value = fmt.Sprint(v.Any())
+	}
+	vars[name] = value
+}
+
+// fieldName converts key to a valid journal field name, except for leading
+// underscores.
+func fieldName(key string) string {
+	return strings.Map(func(c rune) rune {
+		switch {
+		case 'a' <= c && c <= 'z':
+			return c - 'a' + 'A'
+		case ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9'):
+			return c
+		default:
+			return '_'
+		}
+	}, key)
+}
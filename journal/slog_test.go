@@ -0,0 +1,127 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+type sentMessage struct {
+	message  string
+	priority Priority
+	vars     map[string]string
+}
+
+func newTestHandler(sent *[]sentMessage, err error) (*Handler, *bytes.Buffer) {
+	var buf bytes.Buffer
+	h := NewHandler(&HandlerOptions{Level: slog.LevelDebug, Fallback: &buf})
+	h.journal = true
+	h.send = func(message string, priority Priority, vars map[string]string) error {
+		*sent = append(*sent, sentMessage{message, priority, vars})
+		return err
+	}
+	return h, &buf
+}
+
+func TestHandler(t *testing.T) {
+	var sent []sentMessage
+	h, _ := newTestHandler(&sent, nil)
+
+	logger := slog.New(h).With("service", "web").WithGroup("request")
+	logger.Warn("slow request", "id", 42, slog.Group("client", "addr", "127.0.0.1"), "took", time.Second, "_x-y", true)
+
+	if len(sent) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(sent))
+	}
+	m := sent[0]
+	if m.message != "slow request" || m.priority != PriWarning {
+		t.Errorf("unexpected message %q with priority %d", m.message, m.priority)
+	}
+
+	expected := map[string]string{
+		"SERVICE":             "web",
+		"REQUEST_ID":          "42",
+		"REQUEST_CLIENT_ADDR": "127.0.0.1",
+		"REQUEST_TOOK":        "1000000",
+		"REQUEST__X_Y":        "true",
+	}
+	for name, value := range expected {
+		if m.vars[name] != value {
+			t.Errorf("field %s: expected %q, got %q", name, value, m.vars[name])
+		}
+	}
+	if !strings.HasSuffix(m.vars["CODE_FILE"], "slog_test.go") || m.vars["CODE_LINE"] == "" {
+		t.Errorf("unexpected source %s:%s", m.vars["CODE_FILE"], m.vars["CODE_LINE"])
+	}
+}
+
+func TestHandlerReservedFields(t *testing.T) {
+	var sent []sentMessage
+	h, _ := newTestHandler(&sent, nil)
+
+	slog.New(h).With("priority", "high").Info("hello", "message", "other", slog.Group("_", "priority", "low"))
+
+	if len(sent) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(sent))
+	}
+	m := sent[0]
+	if m.message != "hello" || m.priority != PriInfo {
+		t.Errorf("unexpected message %q with priority %d", m.message, m.priority)
+	}
+	for _, name := range []string{"MESSAGE", "PRIORITY"} {
+		if value, ok := m.vars[name]; ok {
+			t.Errorf("unexpected field %s=%q", name, value)
+		}
+	}
+	if m.vars["ATTR_MESSAGE"] != "other" || m.vars["ATTR_PRIORITY"] != "low" {
+		t.Errorf("expected reserved attributes to be prefixed, got %v", m.vars)
+	}
+}
+
+func TestHandlerFallback(t *testing.T) {
+	var sent []sentMessage
+	h, buf := newTestHandler(&sent, errors.New("journal unavailable"))
+
+	slog.New(h).Info("hello", "key", "value")
+
+	if len(sent) != 1 {
+		t.Fatalf("expected a send attempt, got %d", len(sent))
+	}
+	if out := buf.String(); !strings.Contains(out, "msg=hello") || !strings.Contains(out, "key=value") {
+		t.Errorf("unexpected fallback output %q", out)
+	}
+}
+
+func TestLevelPriority(t *testing.T) {
+	testCases := map[slog.Level]Priority{
+		slog.LevelDebug:     PriDebug,
+		slog.LevelInfo:      PriInfo,
+		slog.LevelInfo + 2:  PriNotice,
+		slog.LevelWarn:      PriWarning,
+		slog.LevelError:     PriErr,
+		slog.LevelError + 4: PriErr,
+	}
+
+	for level, expected := range testCases {
+		if p := levelPriority(level); p != expected {
+			t.Errorf("level %v: expected priority %d, got %d", level, expected, p)
+		}
+	}
+}
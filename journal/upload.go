@@ -0,0 +1,187 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Entry is a journal entry as read from a journal, e.g. with the sdjournal
+// package, to be uploaded or exported, or as decoded by ExportDecoder.
+type Entry struct {
+	Cursor             string
+	RealtimeTimestamp  uint64 // microseconds since the epoch
+	MonotonicTimestamp uint64 // microseconds since boot
+	// Fields maps the field names to their values. A field may have several
+	// values, and values may contain binary data.
+	Fields map[string][]string
+}
+
+// Uploader uploads journal entries to a systemd-journal-remote collector,
+// like systemd-journal-upload.
+type Uploader struct {
+	// URL is the URL of the collector, e.g. https://collector:19532. The
+	// entries are uploaded to its /upload endpoint.
+	URL string
+	// Client is used for the uploads, http.DefaultClient if nil. Configure
+	// its transport with the client certificate and trusted CAs to use
+	// HTTPS with authentication.
+	Client *http.Client
+	// StateFile, if set, is where the cursor of the last uploaded entry is
+	// saved, in the format of systemd-journal-upload, e.g.
+	// /var/lib/systemd/journal-upload/state.
+	StateFile string
+	// Retries is how many times a failed upload is retried. Uploads are
+	// retried on connection errors and server errors, but not if the
+	// collector rejects the entries.
+	Retries int
+	// RetryInterval is the time to wait before the first retry, doubled for
+	// every further retry, one second if zero.
+	RetryInterval time.Duration
+}
+
+// UploadError is returned by Upload when the collector rejects an upload.
+type UploadError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *UploadError) Error() string {
+	return fmt.Sprintf("upload failed with status %d: %s", e.StatusCode, e.Message)
+}
+
+// Upload uploads the entries to the collector in a single request, retrying
+// as configured, and saves the cursor of the last entry to the state file on
+// success.
+func (u *Uploader) Upload(ctx context.Context, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	enc := NewExportEncoder(&body)
+	for i := range entries {
+		if err := enc.Encode(&entries[i]); err != nil {
+			return err
+		}
+	}
+
+	interval := u.RetryInterval
+	if interval == 0 {
+		interval = time.Second
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = u.post(ctx, body.Bytes())
+
+		var uploadErr *UploadError
+		retryable := !errors.As(err, &uploadErr) || uploadErr.StatusCode >= 500 || uploadErr.StatusCode == http.StatusTooManyRequests
+		if err == nil || !retryable || attempt >= u.Retries || ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		interval *= 2
+	}
+	if err != nil {
+		return err
+	}
+
+	if u.StateFile != "" {
+		return u.saveCursor(entries[len(entries)-1].Cursor)
+	}
+	return nil
+}
+
+func (u *Uploader) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(u.URL, "/")+"/upload", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.fdo.journal")
+	req.Header.Set("Accept", "text/plain")
+
+	client := u.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &UploadError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(msg))}
+	}
+	return nil
+}
+
+// LastCursor returns the cursor of the last uploaded entry saved in the state
+// file, or "" if there is none yet.
+func (u *Uploader) LastCursor() (string, error) {
+	f, err := os.Open(u.StateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if cursor, ok := strings.CutPrefix(scanner.Text(), "LAST_CURSOR="); ok {
+			return cursor, nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+func (u *Uploader) saveCursor(cursor string) error {
+	if cursor == "" {
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(u.StateFile), "."+filepath.Base(u.StateFile))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = fmt.Fprintf(tmp, "# This is private data. Do not parse.\nLAST_CURSOR=%s\n", cursor)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), u.StateFile)
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package journal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUploader(t *testing.T) {
+	var requests int
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path != "/upload" || r.Header.Get("Content-Type") != "application/vnd.fdo.journal" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	u := &Uploader{
+		URL:           srv.URL,
+		StateFile:     filepath.Join(t.TempDir(), "state"),
+		Retries:       1,
+		RetryInterval: time.Millisecond,
+	}
+	entries := []Entry{{
+		Cursor:             "s=1;i=1",
+		RealtimeTimestamp:  1000,
+		MonotonicTimestamp: 2000,
+		Fields: map[string][]string{
+			"MESSAGE":  {"line 1\nline 2"},
+			"PRIORITY": {"6"},
+		},
+	}}
+
+	if err := u.Upload(context.Background(), entries); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("expected the upload to be retried once, got %d requests", requests)
+	}
+
+	expected := "__CURSOR=s=1;i=1\n__REALTIME_TIMESTAMP=1000\n__MONOTONIC_TIMESTAMP=2000\n" +
+		"MESSAGE\n\x0d\x00\x00\x00\x00\x00\x00\x00line 1\nline 2\nPRIORITY=6\n\n"
+	if body != expected {
+		t.Errorf("expected body %q, got %q", expected, body)
+	}
+
+	cursor, err := u.LastCursor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cursor != "s=1;i=1" {
+		t.Errorf("expected the last cursor to be saved, got %q", cursor)
+	}
+}
+
+func TestUploaderRejected(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Error(w, "Unsupported media type", http.StatusUnsupportedMediaType)
+	}))
+	defer srv.Close()
+
+	u := &Uploader{URL: srv.URL, Retries: 3, RetryInterval: time.Millisecond}
+	err := u.Upload(context.Background(), []Entry{{Fields: map[string][]string{"MESSAGE": {"test"}}}})
+
+	var uploadErr *UploadError
+	if !errors.As(err, &uploadErr) || uploadErr.StatusCode != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected an UploadError, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected a rejected upload not to be retried, got %d requests", requests)
+	}
+}
@@ -0,0 +1,26 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+package locale1
+
+import (
+	"context"
+	"time"
+)
+
+// Connection is the interface of Conn, which allows for replacing it in tests,
+// e.g. by the fake of the locale1test package. See Conn for the documentation of
+// the methods.
+type Connection interface {
+	Close() error
+	Connected() bool
+	GetLocale(ctx context.Context) (map[string]string, error)
+	GetState(ctx context.Context) (*State, error)
+	GetVConsoleKeyboard(ctx context.Context) (*VConsoleKeyboard, error)
+	GetX11Keyboard(ctx context.Context) (*X11Keyboard, error)
+	SetLocale(ctx context.Context, locale map[string]string, interactive bool) error
+	SetTimeout(timeout time.Duration)
+	SetVConsoleKeyboard(ctx context.Context, kbd VConsoleKeyboard, convert, interactive bool) error
+	SetX11Keyboard(ctx context.Context, kbd X11Keyboard, convert, interactive bool) error
+}
+
+var _ Connection = (*Conn)(nil)
@@ -0,0 +1,248 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package locale1 provides integration with the systemd-localed API. See
+// https://www.freedesktop.org/software/systemd/man/org.freedesktop.locale1.html
+package locale1
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/gr-butler/go-systemd/v22/internal/calltimeout"
+)
+
+//go:generate go run github.com/gr-butler/go-systemd/v22/internal/fakegen
+
+const (
+	dbusDest      = "org.freedesktop.locale1"
+	dbusInterface = "org.freedesktop.locale1"
+	dbusPath      = "/org/freedesktop/locale1"
+)
+
+// Conn is a connection to systemd-localed's dbus endpoint.
+type Conn struct {
+	conn   *dbus.Conn
+	object dbus.BusObject
+
+	timeout time.Duration
+}
+
+// VConsoleKeyboard is the keyboard mapping of the virtual console.
+type VConsoleKeyboard struct {
+	Keymap       string
+	KeymapToggle string
+}
+
+// X11Keyboard is the keyboard mapping of X11 and Wayland.
+type X11Keyboard struct {
+	Layout  string
+	Model   string
+	Variant string
+	Options string
+}
+
+// State is the system locale and keyboard configuration.
+type State struct {
+	// Locale maps locale variables, e.g. LANG and LC_MESSAGES, to their
+	// values.
+	Locale   map[string]string
+	VConsole VConsoleKeyboard
+	X11      X11Keyboard
+}
+
+// New establishes a connection to the system bus and authenticates.
+func New() (*Conn, error) {
+	c := new(Conn)
+
+	if err := c.initConnection(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Conn) initConnection() error {
+	var err error
+	c.conn, err = dbus.SystemBusPrivate()
+	if err != nil {
+		return err
+	}
+
+	// Only use EXTERNAL method, and hardcode the uid (not username)
+	// to avoid a username lookup (which requires a dynamically linked
+	// libc)
+	methods := []dbus.Auth{dbus.AuthExternal(strconv.Itoa(os.Getuid()))}
+
+	err = c.conn.Auth(methods)
+	if err != nil {
+		c.conn.Close()
+		return err
+	}
+
+	err = c.conn.Hello()
+	if err != nil {
+		c.conn.Close()
+		return err
+	}
+
+	c.object = c.conn.Object(dbusDest, dbus.ObjectPath(dbusPath))
+
+	return nil
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// Connected returns whether conn is connected
+func (c *Conn) Connected() bool {
+	return c.conn.Connected()
+}
+
+// SetTimeout sets the default timeout of the method calls made on the
+// connection without a context deadline, including those made by the
+// methods without context, which otherwise block forever if systemd-localed
+// hangs. It is disabled by a timeout of zero, the default. SetTimeout must
+// not be called concurrently with method calls.
+func (c *Conn) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+	c.object = calltimeout.Object(c.conn.Object(dbusDest, dbus.ObjectPath(dbusPath)), timeout)
+}
+
+// SetLocale sets the system locale, replacing all the locale variables. If
+// interactive is true, the caller may be asked for authorization by polkit.
+func (c *Conn) SetLocale(ctx context.Context, locale map[string]string, interactive bool) error {
+	assignments, err := localeAssignments(locale)
+	if err != nil {
+		return err
+	}
+	return c.object.CallWithContext(ctx, dbusInterface+".SetLocale", 0, assignments, interactive).Err
+}
+
+// SetVConsoleKeyboard sets the keyboard mapping of the virtual console. If
+// convert is true, the X11 keyboard mapping is set to the closest match as
+// well.
+func (c *Conn) SetVConsoleKeyboard(ctx context.Context, kbd VConsoleKeyboard, convert, interactive bool) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".SetVConsoleKeyboard", 0, kbd.Keymap, kbd.KeymapToggle, convert, interactive).Err
+}
+
+// SetX11Keyboard sets the keyboard mapping of X11 and Wayland. If convert is
+// true, the virtual console keyboard mapping is set to the closest match as
+// well.
+func (c *Conn) SetX11Keyboard(ctx context.Context, kbd X11Keyboard, convert, interactive bool) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".SetX11Keyboard", 0, kbd.Layout, kbd.Model, kbd.Variant, kbd.Options, convert, interactive).Err
+}
+
+// GetState returns the current locale and keyboard configuration.
+func (c *Conn) GetState(ctx context.Context) (*State, error) {
+	var props map[string]dbus.Variant
+	err := c.object.CallWithContext(ctx, "org.freedesktop.DBus.Properties.GetAll", 0, dbusInterface).Store(&props)
+	if err != nil {
+		return nil, err
+	}
+	return stateFromVariants(props)
+}
+
+// GetLocale returns the locale variables of the system locale.
+func (c *Conn) GetLocale(ctx context.Context) (map[string]string, error) {
+	state, err := c.GetState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return state.Locale, nil
+}
+
+// GetVConsoleKeyboard returns the keyboard mapping of the virtual console.
+func (c *Conn) GetVConsoleKeyboard(ctx context.Context) (*VConsoleKeyboard, error) {
+	state, err := c.GetState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &state.VConsole, nil
+}
+
+// GetX11Keyboard returns the keyboard mapping of X11 and Wayland.
+func (c *Conn) GetX11Keyboard(ctx context.Context) (*X11Keyboard, error) {
+	state, err := c.GetState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &state.X11, nil
+}
+
+func stateFromVariants(props map[string]dbus.Variant) (*State, error) {
+	str := func(name string) string {
+		s, _ := props[name].Value().(string)
+		return s
+	}
+
+	assignments, ok := props["Locale"].Value().([]string)
+	if !ok {
+		return nil, fmt.Errorf("failed to typecast Locale to []string")
+	}
+	locale, err := parseLocale(assignments)
+	if err != nil {
+		return nil, err
+	}
+
+	return &State{
+		Locale: locale,
+		VConsole: VConsoleKeyboard{
+			Keymap:       str("VConsoleKeymap"),
+			KeymapToggle: str("VConsoleKeymapToggle"),
+		},
+		X11: X11Keyboard{
+			Layout:  str("X11Layout"),
+			Model:   str("X11Model"),
+			Variant: str("X11Variant"),
+			Options: str("X11Options"),
+		},
+	}, nil
+}
+
+// parseLocale parses the VAR=value assignments of the Locale property.
+func parseLocale(assignments []string) (map[string]string, error) {
+	locale := make(map[string]string, len(assignments))
+	for _, a := range assignments {
+		name, value, ok := strings.Cut(a, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid locale assignment %q", a)
+		}
+		locale[name] = value
+	}
+	return locale, nil
+}
+
+// localeAssignments formats locale as the sorted VAR=value assignments taken
+// by SetLocale.
+func localeAssignments(locale map[string]string) ([]string, error) {
+	assignments := make([]string, 0, len(locale))
+	for name, value := range locale {
+		if name == "" || strings.ContainsAny(name, "= ") {
+			return nil, fmt.Errorf("invalid locale variable %q", name)
+		}
+		assignments = append(assignments, name+"="+value)
+	}
+	sort.Strings(assignments)
+	return assignments, nil
+}
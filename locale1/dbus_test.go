@@ -0,0 +1,76 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package locale1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestStateFromVariants(t *testing.T) {
+	props := map[string]dbus.Variant{
+		"Locale":         dbus.MakeVariant([]string{"LANG=en_US.UTF-8", "LC_TIME=de_DE.UTF-8"}),
+		"VConsoleKeymap": dbus.MakeVariant("us"),
+		"X11Layout":      dbus.MakeVariant("us,de"),
+		"X11Options":     dbus.MakeVariant("grp:alt_shift_toggle"),
+	}
+
+	expected := &State{
+		Locale:   map[string]string{"LANG": "en_US.UTF-8", "LC_TIME": "de_DE.UTF-8"},
+		VConsole: VConsoleKeyboard{Keymap: "us"},
+		X11:      X11Keyboard{Layout: "us,de", Options: "grp:alt_shift_toggle"},
+	}
+	state, err := stateFromVariants(props)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(state, expected) {
+		t.Errorf("expected %+v, got %+v", expected, state)
+	}
+
+	props["Locale"] = dbus.MakeVariant([]string{"LANG"})
+	if _, err := stateFromVariants(props); err == nil {
+		t.Error("expected an error for an invalid locale assignment")
+	}
+}
+
+func TestLocaleAssignments(t *testing.T) {
+	assignments, err := localeAssignments(map[string]string{"LC_TIME": "C", "LANG": "en_US.UTF-8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"LANG=en_US.UTF-8", "LC_TIME=C"}; !reflect.DeepEqual(assignments, expected) {
+		t.Errorf("expected %v, got %v", expected, assignments)
+	}
+
+	if _, err := localeAssignments(map[string]string{"LANG=": "C"}); err == nil {
+		t.Error("expected an error for an invalid variable name")
+	}
+}
+
+func TestGetState(t *testing.T) {
+	conn, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.GetState(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
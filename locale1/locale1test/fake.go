@@ -0,0 +1,108 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+// Package locale1test provides a fake of locale1.Conn for unit tests.
+package locale1test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gr-butler/go-systemd/v22/locale1"
+)
+
+// ErrNotImplemented is returned by the methods whose function field is unset.
+var ErrNotImplemented = errors.New("not implemented")
+
+// Conn is a fake locale1.Connection. Each method calls the function field of
+// the same name with a Func suffix. If it is unset, the method returns zero
+// values and, if it returns an error, ErrNotImplemented.
+type Conn struct {
+	CloseFunc               func() error
+	ConnectedFunc           func() bool
+	GetLocaleFunc           func(ctx context.Context) (map[string]string, error)
+	GetStateFunc            func(ctx context.Context) (*locale1.State, error)
+	GetVConsoleKeyboardFunc func(ctx context.Context) (*locale1.VConsoleKeyboard, error)
+	GetX11KeyboardFunc      func(ctx context.Context) (*locale1.X11Keyboard, error)
+	SetLocaleFunc           func(ctx context.Context, locale map[string]string, interactive bool) error
+	SetTimeoutFunc          func(timeout time.Duration)
+	SetVConsoleKeyboardFunc func(ctx context.Context, kbd locale1.VConsoleKeyboard, convert, interactive bool) error
+	SetX11KeyboardFunc      func(ctx context.Context, kbd locale1.X11Keyboard, convert, interactive bool) error
+}
+
+var _ locale1.Connection = (*Conn)(nil)
+
+func (f *Conn) Close() error {
+	if f.CloseFunc == nil {
+		return fmt.Errorf("%w: Close", ErrNotImplemented)
+	}
+	return f.CloseFunc()
+}
+
+func (f *Conn) Connected() bool {
+	if f.ConnectedFunc == nil {
+		var r0 bool
+		return r0
+	}
+	return f.ConnectedFunc()
+}
+
+func (f *Conn) GetLocale(ctx context.Context) (map[string]string, error) {
+	if f.GetLocaleFunc == nil {
+		var r0 map[string]string
+		return r0, fmt.Errorf("%w: GetLocale", ErrNotImplemented)
+	}
+	return f.GetLocaleFunc(ctx)
+}
+
+func (f *Conn) GetState(ctx context.Context) (*locale1.State, error) {
+	if f.GetStateFunc == nil {
+		var r0 *locale1.State
+		return r0, fmt.Errorf("%w: GetState", ErrNotImplemented)
+	}
+	return f.GetStateFunc(ctx)
+}
+
+func (f *Conn) GetVConsoleKeyboard(ctx context.Context) (*locale1.VConsoleKeyboard, error) {
+	if f.GetVConsoleKeyboardFunc == nil {
+		var r0 *locale1.VConsoleKeyboard
+		return r0, fmt.Errorf("%w: GetVConsoleKeyboard", ErrNotImplemented)
+	}
+	return f.GetVConsoleKeyboardFunc(ctx)
+}
+
+func (f *Conn) GetX11Keyboard(ctx context.Context) (*locale1.X11Keyboard, error) {
+	if f.GetX11KeyboardFunc == nil {
+		var r0 *locale1.X11Keyboard
+		return r0, fmt.Errorf("%w: GetX11Keyboard", ErrNotImplemented)
+	}
+	return f.GetX11KeyboardFunc(ctx)
+}
+
+func (f *Conn) SetLocale(ctx context.Context, locale map[string]string, interactive bool) error {
+	if f.SetLocaleFunc == nil {
+		return fmt.Errorf("%w: SetLocale", ErrNotImplemented)
+	}
+	return f.SetLocaleFunc(ctx, locale, interactive)
+}
+
+func (f *Conn) SetTimeout(timeout time.Duration) {
+	if f.SetTimeoutFunc != nil {
+		f.SetTimeoutFunc(timeout)
+	}
+}
+
+func (f *Conn) SetVConsoleKeyboard(ctx context.Context, kbd locale1.VConsoleKeyboard, convert, interactive bool) error {
+	if f.SetVConsoleKeyboardFunc == nil {
+		return fmt.Errorf("%w: SetVConsoleKeyboard", ErrNotImplemented)
+	}
+	return f.SetVConsoleKeyboardFunc(ctx, kbd, convert, interactive)
+}
+
+func (f *Conn) SetX11Keyboard(ctx context.Context, kbd locale1.X11Keyboard, convert, interactive bool) error {
+	if f.SetX11KeyboardFunc == nil {
+		return fmt.Errorf("%w: SetX11Keyboard", ErrNotImplemented)
+	}
+	return f.SetX11KeyboardFunc(ctx, kbd, convert, interactive)
+}
@@ -0,0 +1,107 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+package login1
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/gr-butler/go-systemd/v22/instrument"
+)
+
+// Connection is the interface of Conn, which allows for replacing it in tests,
+// e.g. by the fake of the login1test package. See Conn for the documentation of
+// the methods.
+type Connection interface {
+	ActivateSession(ctx context.Context, seat, session string) error
+	CanGraphical(ctx context.Context, seat string) (bool, error)
+	CanHibernate(ctx context.Context) (Capability, error)
+	CanHybridSleep(ctx context.Context) (Capability, error)
+	CanMultiSession(ctx context.Context, seat string) (bool, error)
+	CanPowerOff(ctx context.Context) (Capability, error)
+	CanReboot(ctx context.Context) (Capability, error)
+	CanRebootParameter(ctx context.Context) (Capability, error)
+	CanRebootToBootLoaderEntry(ctx context.Context) (Capability, error)
+	CanRebootToFirmwareSetup(ctx context.Context) (Capability, error)
+	CanSleep(ctx context.Context) (Capability, error)
+	CanSuspend(ctx context.Context) (Capability, error)
+	CanSuspendThenHibernate(ctx context.Context) (Capability, error)
+	CancelScheduledShutdown(ctx context.Context) (bool, error)
+	Close()
+	Connected() bool
+	CreateSession(ctx context.Context, opts CreateSessionOptions) (*CreatedSession, error)
+	GetActiveSession() (dbus.ObjectPath, error)
+	GetActiveSessionForSeat(ctx context.Context, seatID string) (dbus.ObjectPath, error)
+	GetIdleHint(ctx context.Context, sessionPath dbus.ObjectPath) (bool, error)
+	GetIdleSinceHint(ctx context.Context, sessionPath dbus.ObjectPath) (time.Time, error)
+	GetIdleSinceHintMonotonic(ctx context.Context, sessionPath dbus.ObjectPath) (time.Duration, error)
+	GetLockedHint(ctx context.Context, sessionPath dbus.ObjectPath) (bool, error)
+	GetScheduledShutdown(ctx context.Context) (string, uint64, error)
+	GetSeatContext(ctx context.Context, id string) (dbus.ObjectPath, error)
+	GetSession(id string) (dbus.ObjectPath, error)
+	GetSessionDisplay(sessionPath dbus.ObjectPath) (string, error)
+	GetSessionInfo(ctx context.Context, sessionPath dbus.ObjectPath) (*SessionInfo, error)
+	GetSessionPropertiesContext(ctx context.Context, sessionPath dbus.ObjectPath) (map[string]dbus.Variant, error)
+	GetSessionPropertyContext(ctx context.Context, sessionPath dbus.ObjectPath, property string) (*dbus.Variant, error)
+	GetSessionTyped(ctx context.Context, sessionPath dbus.ObjectPath) (*SessionProperties, error)
+	GetSessionUser(sessionPath dbus.ObjectPath) (*User, error)
+	GetSleepOperation(ctx context.Context) ([]string, error)
+	GetUserContext(ctx context.Context, uid uint32) (dbus.ObjectPath, error)
+	GetUserLingerContext(ctx context.Context, uid uint32) (bool, error)
+	GetUserPropertiesContext(ctx context.Context, userPath dbus.ObjectPath) (map[string]dbus.Variant, error)
+	GetUserPropertyContext(ctx context.Context, userPath dbus.ObjectPath, property string) (*dbus.Variant, error)
+	Hibernate(ctx context.Context, askForAuth bool) error
+	HybridSleep(ctx context.Context, askForAuth bool) error
+	Inhibit(what, who, why, mode string) (*os.File, error)
+	InhibitContext(ctx context.Context, what, who, why, mode string) (*InhibitorLock, error)
+	InhibitWithOptions(ctx context.Context, opts InhibitOptions) (*InhibitorLock, error)
+	ListInhibitorsContext(ctx context.Context) ([]Inhibitor, error)
+	ListSeatsContext(ctx context.Context) ([]Seat, error)
+	ListSessions() ([]Session, error)
+	ListSessionsContext(ctx context.Context) ([]Session, error)
+	ListSessionsFiltered(ctx context.Context, filter SessionFilter) ([]Session, error)
+	ListUsers() ([]User, error)
+	ListUsersContext(ctx context.Context) ([]User, error)
+	Lock(ctx context.Context, sessionPath dbus.ObjectPath) error
+	LockSession(id string)
+	LockSessionContext(ctx context.Context, id string) error
+	LockSessions()
+	LockSessionsContext(ctx context.Context) error
+	OnPrepareForShutdown(ctx context.Context, opts PrepareOptions, prepare func(context.Context)) error
+	OnPrepareForSleep(ctx context.Context, opts PrepareOptions, prepare func(context.Context)) error
+	PowerOff(askForAuth bool)
+	PowerOffContext(ctx context.Context, askForAuth bool) error
+	Reboot(askForAuth bool)
+	RebootContext(ctx context.Context, askForAuth bool) error
+	RebootWithFlagsContext(ctx context.Context, flags uint64) error
+	ReleaseSession(ctx context.Context, id string) error
+	ScheduleShutdown(ctx context.Context, kind string, usec uint64) error
+	SetHook(hook instrument.Hook)
+	SetIdleHint(ctx context.Context, sessionPath dbus.ObjectPath, idle bool) error
+	SetLockedHint(ctx context.Context, sessionPath dbus.ObjectPath, locked bool) error
+	SetRebootParameterContext(ctx context.Context, param string) error
+	SetRebootToBootLoaderEntry(ctx context.Context, entry string) error
+	SetRebootToFirmwareSetup(ctx context.Context, enable bool) error
+	SetTimeout(timeout time.Duration)
+	SetUserLingerContext(ctx context.Context, uid uint32, enable, interactive bool) error
+	SetWallMessage(ctx context.Context, message string, enable bool) error
+	SleepWithFlags(ctx context.Context, flags uint64) error
+	Subscribe(members ...string) chan *dbus.Signal
+	SubscribeContext(ctx context.Context, members ...string) (signals <-chan *dbus.Signal, cancel func(), err error)
+	Suspend(ctx context.Context, askForAuth bool) error
+	SuspendThenHibernate(ctx context.Context, askForAuth bool) error
+	SwitchTo(ctx context.Context, seat string, vtnr uint32) error
+	TerminateSession(id string)
+	TerminateSessionContext(ctx context.Context, id string) error
+	TerminateUser(uid uint32)
+	TerminateUserContext(ctx context.Context, uid uint32) error
+	Unlock(ctx context.Context, sessionPath dbus.ObjectPath) error
+	WatchSessionProperties(ctx context.Context, sessionPath dbus.ObjectPath, names ...string) (<-chan PropertiesUpdate, error)
+	WatchSessions(ctx context.Context) (<-chan SessionEvent, error)
+	WatchUserProperties(ctx context.Context, userPath dbus.ObjectPath, names ...string) (<-chan PropertiesUpdate, error)
+	WatchUsers(ctx context.Context) (<-chan UserEvent, error)
+}
+
+var _ Connection = (*Conn)(nil)
@@ -0,0 +1,106 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"context"
+	"os"
+	"sort"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// CreateSessionOptions are the arguments of CreateSession.
+type CreateSessionOptions struct {
+	UID     uint32
+	PID     uint32 // The leader process of the session, 0 for the caller
+	Service string // The PAM service name, e.g. login
+	Type    string // e.g. tty, x11, wayland or unspecified
+	Class   string // e.g. user or greeter
+	Desktop string
+	Seat    string // Empty if the session is not attached to a seat
+	VTNr    uint32 // The virtual terminal number, 0 if none
+	TTY     string
+	Display string // The X11 display, if any
+
+	Remote     bool
+	RemoteUser string
+	RemoteHost string
+
+	// Properties are additional properties of the scope unit of the
+	// session, e.g. CPUWeight.
+	Properties map[string]dbus.Variant
+}
+
+// CreatedSession is a session created by CreateSession.
+type CreatedSession struct {
+	ID          string
+	Path        dbus.ObjectPath
+	RuntimePath string // The runtime directory of the user, e.g. /run/user/1000
+	// FIFO is the session FIFO. The session is closed when it is closed,
+	// so it has to be kept open for the lifetime of the session.
+	FIFO     *os.File
+	UID      uint32
+	Seat     string
+	VTNr     uint32
+	Existing bool // Whether the caller already was part of a session, which is returned then
+}
+
+// CreateSession registers a new session, like pam_systemd does. It is meant
+// for session managers with login paths not going through PAM, and requires
+// root. The session lasts until the returned FIFO is closed or
+// ReleaseSession is called.
+func (c *Conn) CreateSession(ctx context.Context, opts CreateSessionOptions) (*CreatedSession, error) {
+	var (
+		s    CreatedSession
+		fifo dbus.UnixFD
+	)
+	err := c.object.CallWithContext(ctx, dbusManagerInterface+".CreateSession", 0, createSessionArgs(opts)...).
+		Store(&s.ID, &s.Path, &s.RuntimePath, &fifo, &s.UID, &s.Seat, &s.VTNr, &s.Existing)
+	if err != nil {
+		return nil, err
+	}
+
+	s.FIFO = os.NewFile(uintptr(fifo), "session")
+	return &s, nil
+}
+
+// ReleaseSession releases the session with the given ID, so that it is
+// closed once its processes exited.
+func (c *Conn) ReleaseSession(ctx context.Context, id string) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".ReleaseSession", 0, id).Err
+}
+
+// scopeProperty is an (sv) property of the scope unit of a session.
+type scopeProperty struct {
+	Name  string
+	Value dbus.Variant
+}
+
+// createSessionArgs returns the arguments of the CreateSession method, with
+// the properties sorted by name.
+func createSessionArgs(opts CreateSessionOptions) []interface{} {
+	properties := make([]scopeProperty, 0, len(opts.Properties))
+	for name, value := range opts.Properties {
+		properties = append(properties, scopeProperty{name, value})
+	}
+	sort.Slice(properties, func(i, j int) bool { return properties[i].Name < properties[j].Name })
+
+	return []interface{}{
+		opts.UID, opts.PID, opts.Service, opts.Type, opts.Class, opts.Desktop, opts.Seat,
+		opts.VTNr, opts.TTY, opts.Display, opts.Remote, opts.RemoteUser, opts.RemoteHost,
+		properties,
+	}
+}
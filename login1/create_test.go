@@ -0,0 +1,55 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestCreateSessionArgs(t *testing.T) {
+	args := createSessionArgs(CreateSessionOptions{
+		UID:     1000,
+		PID:     812,
+		Service: "login",
+		Type:    "tty",
+		Class:   "user",
+		Seat:    "seat0",
+		VTNr:    2,
+		TTY:     "tty2",
+		Properties: map[string]dbus.Variant{
+			"TasksMax":  dbus.MakeVariant(uint64(100)),
+			"CPUWeight": dbus.MakeVariant(uint64(50)),
+		},
+	})
+
+	expected := []interface{}{
+		uint32(1000), uint32(812), "login", "tty", "user", "", "seat0",
+		uint32(2), "tty2", "", false, "", "",
+		[]scopeProperty{
+			{"CPUWeight", dbus.MakeVariant(uint64(50))},
+			{"TasksMax", dbus.MakeVariant(uint64(100))},
+		},
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("expected %v, got %v", expected, args)
+	}
+
+	if sig := dbus.SignatureOf(args...).String(); sig != "uusssssussbssa(sv)" {
+		t.Errorf("expected the CreateSession signature, got %s", sig)
+	}
+}
@@ -21,10 +21,16 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/godbus/dbus/v5"
+
+	"github.com/gr-butler/go-systemd/v22/instrument"
+	"github.com/gr-butler/go-systemd/v22/internal/calltimeout"
 )
 
+//go:generate go run github.com/gr-butler/go-systemd/v22/internal/fakegen
+
 const (
 	dbusDest             = "org.freedesktop.login1"
 	dbusManagerInterface = "org.freedesktop.login1.Manager"
@@ -37,26 +43,63 @@ const (
 type Conn struct {
 	conn   *dbus.Conn
 	object dbus.BusObject
+
+	hook    instrument.Hook
+	timeout time.Duration
+
+	// shared is true if conn was passed to NewWithConnection, so that it
+	// isn't closed by Close.
+	shared bool
 }
 
 // New establishes a connection to the system bus and authenticates.
 func New() (*Conn, error) {
 	c := new(Conn)
 
-	if err := c.initConnection(); err != nil {
+	if err := c.initConnection(dbus.SystemBusPrivate); err != nil {
 		return nil, err
 	}
 
 	return c, nil
 }
 
-// Close closes the dbus connection
+// NewUserConnection establishes a connection to the session bus and
+// authenticates. logind is only available on the system bus, but the session
+// bus may provide it in sandboxes and tests, e.g. through a D-Bus proxy or a
+// mock service.
+func NewUserConnection() (*Conn, error) {
+	c := new(Conn)
+
+	if err := c.initConnection(dbus.SessionBusPrivate); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// NewWithConnection returns a Conn using the existing connection conn, e.g.
+// the shared connection returned by dbus.SystemBus. conn must be
+// authenticated, and the Hello call must have succeeded. Close does not close
+// conn, which remains owned by the caller.
+func NewWithConnection(conn *dbus.Conn) (*Conn, error) {
+	if conn == nil {
+		return nil, fmt.Errorf("nil connection")
+	}
+
+	c := &Conn{conn: conn, shared: true}
+	c.object = c.pathObject(dbus.ObjectPath(dbusPath))
+
+	return c, nil
+}
+
+// Close closes the dbus connection, unless it was passed to
+// NewWithConnection.
 func (c *Conn) Close() {
 	if c == nil {
 		return
 	}
 
-	if c.conn != nil {
+	if c.conn != nil && !c.shared {
 		c.conn.Close()
 	}
 }
@@ -66,9 +109,32 @@ func (c *Conn) Connected() bool {
 	return c.conn.Connected()
 }
 
-func (c *Conn) initConnection() error {
+// SetHook sets the hook observing the method calls made on the connection,
+// e.g. to trace them, see the instrument package. A nil hook removes it.
+// SetHook must not be called concurrently with method calls.
+func (c *Conn) SetHook(hook instrument.Hook) {
+	c.hook = hook
+	c.object = c.pathObject(dbus.ObjectPath(dbusPath))
+}
+
+// SetTimeout sets the default timeout of the method calls made on the
+// connection without a context deadline, including those made by the
+// methods without context, which otherwise block forever if logind hangs.
+// It is disabled by a timeout of zero, the default. SetTimeout must not be
+// called concurrently with method calls.
+func (c *Conn) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+	c.object = c.pathObject(dbus.ObjectPath(dbusPath))
+}
+
+// pathObject returns the object at path on the login1 service.
+func (c *Conn) pathObject(path dbus.ObjectPath) dbus.BusObject {
+	return instrument.Object(calltimeout.Object(c.conn.Object(dbusDest, path), c.timeout), c.hook)
+}
+
+func (c *Conn) initConnection(createBus func(opts ...dbus.ConnOption) (*dbus.Conn, error)) error {
 	var err error
-	c.conn, err = dbus.SystemBusPrivate()
+	c.conn, err = createBus()
 	if err != nil {
 		return err
 	}
@@ -90,7 +156,7 @@ func (c *Conn) initConnection() error {
 		return err
 	}
 
-	c.object = c.conn.Object("org.freedesktop.login1", dbus.ObjectPath(dbusPath))
+	c.object = c.pathObject(dbus.ObjectPath(dbusPath))
 
 	return nil
 }
@@ -166,14 +232,20 @@ func userFromInterfaces(user []interface{}) (*User, error) {
 }
 
 // GetActiveSession may be used to get the session object path for the current active session
+// of seat0, see GetActiveSessionForSeat.
 func (c *Conn) GetActiveSession() (dbus.ObjectPath, error) {
-	var seat0Path dbus.ObjectPath
-	if err := c.object.Call(dbusManagerInterface+".GetSeat", 0, "seat0").Store(&seat0Path); err != nil {
+	return c.GetActiveSessionForSeat(context.Background(), "seat0")
+}
+
+// GetActiveSessionForSeat returns the object path of the active session of
+// the seat with the given ID, e.g. seat0.
+func (c *Conn) GetActiveSessionForSeat(ctx context.Context, seatID string) (dbus.ObjectPath, error) {
+	seatPath, err := c.GetSeatContext(ctx, seatID)
+	if err != nil {
 		return "", err
 	}
 
-	seat0Obj := c.conn.Object(dbusDest, seat0Path)
-	activeSession, err := seat0Obj.GetProperty(dbusDest + ".Seat.ActiveSession")
+	activeSession, err := c.getProperty(ctx, seatPath, dbusSeatInterface, "ActiveSession")
 	if err != nil {
 		return "", err
 	}
@@ -195,7 +267,7 @@ func (c *Conn) GetSessionUser(sessionPath dbus.ObjectPath) (*User, error) {
 		return nil, fmt.Errorf("empty sessionPath")
 	}
 
-	activeSessionObj := c.conn.Object(dbusDest, sessionPath)
+	activeSessionObj := c.pathObject(sessionPath)
 	sessionUserName, err := activeSessionObj.GetProperty(dbusDest + ".Session.Name")
 	if err != nil {
 		return nil, err
@@ -232,7 +304,7 @@ func (c *Conn) GetSessionDisplay(sessionPath dbus.ObjectPath) (string, error) {
 	if len(sessionPath) == 0 {
 		return "", fmt.Errorf("empty sessionPath")
 	}
-	sessionObj := c.conn.Object(dbusDest, sessionPath)
+	sessionObj := c.pathObject(sessionPath)
 	display, err := sessionObj.GetProperty(dbusDest + ".Session.Display")
 	if err != nil {
 		return "", err
@@ -322,36 +394,65 @@ func (c *Conn) GetUserPropertyContext(ctx context.Context, userPath dbus.ObjectP
 	return c.getProperty(ctx, userPath, dbusUserInterface, property)
 }
 
-// LockSession asks the session with the specified ID to activate the screen lock.
+// Deprecated: use LockSessionContext instead.
 func (c *Conn) LockSession(id string) {
-	c.object.Call(dbusManagerInterface+".LockSession", 0, id)
+	c.LockSessionContext(context.Background(), id)
 }
 
-// LockSessions asks all sessions to activate the screen locks. This may be used to lock any access to the machine in one action.
+// LockSessionContext asks the session with the specified ID to activate the screen lock.
+func (c *Conn) LockSessionContext(ctx context.Context, id string) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".LockSession", 0, id).Err
+}
+
+// Deprecated: use LockSessionsContext instead.
 func (c *Conn) LockSessions() {
-	c.object.Call(dbusManagerInterface+".LockSessions", 0)
+	c.LockSessionsContext(context.Background())
 }
 
-// TerminateSession forcibly terminate one specific session.
+// LockSessionsContext asks all sessions to activate the screen locks. This may be used to lock any access to the machine in one action.
+func (c *Conn) LockSessionsContext(ctx context.Context) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".LockSessions", 0).Err
+}
+
+// Deprecated: use TerminateSessionContext instead.
 func (c *Conn) TerminateSession(id string) {
-	c.object.Call(dbusManagerInterface+".TerminateSession", 0, id)
+	c.TerminateSessionContext(context.Background(), id)
 }
 
-// TerminateUser forcibly terminates all processes of a user.
+// TerminateSessionContext forcibly terminate one specific session.
+func (c *Conn) TerminateSessionContext(ctx context.Context, id string) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".TerminateSession", 0, id).Err
+}
+
+// Deprecated: use TerminateUserContext instead.
 func (c *Conn) TerminateUser(uid uint32) {
-	c.object.Call(dbusManagerInterface+".TerminateUser", 0, uid)
+	c.TerminateUserContext(context.Background(), uid)
+}
+
+// TerminateUserContext forcibly terminates all processes of a user.
+func (c *Conn) TerminateUserContext(ctx context.Context, uid uint32) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".TerminateUser", 0, uid).Err
 }
 
-// Reboot asks logind for a reboot optionally asking for auth.
+// Deprecated: use RebootContext instead.
 func (c *Conn) Reboot(askForAuth bool) {
-	c.object.Call(dbusManagerInterface+".Reboot", 0, askForAuth)
+	c.RebootContext(context.Background(), askForAuth)
+}
+
+// RebootContext asks logind for a reboot optionally asking for auth.
+func (c *Conn) RebootContext(ctx context.Context, askForAuth bool) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".Reboot", 0, askForAuth).Err
 }
 
 // Inhibit takes inhibition lock in logind.
 func (c *Conn) Inhibit(what, who, why, mode string) (*os.File, error) {
+	return c.inhibit(context.Background(), what, who, why, mode)
+}
+
+func (c *Conn) inhibit(ctx context.Context, what, who, why, mode string) (*os.File, error) {
 	var fd dbus.UnixFD
 
-	err := c.object.Call(dbusManagerInterface+".Inhibit", 0, what, who, why, mode).Store(&fd)
+	err := c.object.CallWithContext(ctx, dbusManagerInterface+".Inhibit", 0, what, who, why, mode).Store(&fd)
 	if err != nil {
 		return nil, err
 	}
@@ -360,6 +461,9 @@ func (c *Conn) Inhibit(what, who, why, mode string) (*os.File, error) {
 }
 
 // Subscribe to signals on the logind dbus
+//
+// Deprecated: use SubscribeContext instead, which reports errors and removes
+// the match rules when cancelled.
 func (c *Conn) Subscribe(members ...string) chan *dbus.Signal {
 	for _, member := range members {
 		c.conn.BusObject().Call("org.freedesktop.DBus.AddMatch", 0,
@@ -370,9 +474,14 @@ func (c *Conn) Subscribe(members ...string) chan *dbus.Signal {
 	return ch
 }
 
-// PowerOff asks logind for a power off optionally asking for auth.
+// Deprecated: use PowerOffContext instead.
 func (c *Conn) PowerOff(askForAuth bool) {
-	c.object.Call(dbusManagerInterface+".PowerOff", 0, askForAuth)
+	c.PowerOffContext(context.Background(), askForAuth)
+}
+
+// PowerOffContext asks logind for a power off optionally asking for auth.
+func (c *Conn) PowerOffContext(ctx context.Context, askForAuth bool) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".PowerOff", 0, askForAuth).Err
 }
 
 func (c *Conn) getProperties(ctx context.Context, path dbus.ObjectPath, dbusInterface string) (map[string]dbus.Variant, error) {
@@ -380,7 +489,7 @@ func (c *Conn) getProperties(ctx context.Context, path dbus.ObjectPath, dbusInte
 		return nil, fmt.Errorf("invalid object path (%s)", path)
 	}
 
-	obj := c.conn.Object(dbusDest, path)
+	obj := c.pathObject(path)
 
 	var props map[string]dbus.Variant
 	err := obj.CallWithContext(ctx, "org.freedesktop.DBus.Properties.GetAll", 0, dbusInterface).Store(&props)
@@ -396,7 +505,7 @@ func (c *Conn) getProperty(ctx context.Context, path dbus.ObjectPath, dbusInterf
 		return nil, fmt.Errorf("invalid object path (%s)", path)
 	}
 
-	obj := c.conn.Object(dbusDest, path)
+	obj := c.pathObject(path)
 
 	var prop dbus.Variant
 	err := obj.CallWithContext(ctx, "org.freedesktop.DBus.Properties.Get", 0, dbusInterface, property).Store(&prop)
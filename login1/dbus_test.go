@@ -21,6 +21,8 @@ import (
 	"regexp"
 	"testing"
 	"time"
+
+	"github.com/godbus/dbus/v5"
 )
 
 // TestNew ensures that New() works without errors.
@@ -32,6 +34,30 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewWithConnection(t *testing.T) {
+	if _, err := NewWithConnection(nil); err == nil {
+		t.Error("expected an error for a nil connection")
+	}
+
+	conn, err := dbus.SystemBus()
+	if err != nil {
+		t.Skip(err)
+	}
+
+	c, err := NewWithConnection(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListSessions(); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Close()
+	if !conn.Connected() {
+		t.Error("Close closed the shared connection")
+	}
+}
+
 func TestListSessions(t *testing.T) {
 	c, err := New()
 	if err != nil {
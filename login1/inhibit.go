@@ -0,0 +1,198 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Inhibitor is an inhibitor lock taken in logind.
+type Inhibitor struct {
+	What string // Colon-separated list of what is inhibited, e.g. shutdown:sleep
+	Who  string // Who took the lock, e.g. the name of the application
+	Why  string // Why the lock was taken
+	Mode string // block or delay
+	UID  uint32
+	PID  uint32
+}
+
+// ListInhibitorsContext returns the inhibitor locks currently taken.
+func (c *Conn) ListInhibitorsContext(ctx context.Context) ([]Inhibitor, error) {
+	var out [][]interface{}
+	if err := c.object.CallWithContext(ctx, dbusManagerInterface+".ListInhibitors", 0).Store(&out); err != nil {
+		return nil, err
+	}
+
+	ret := []Inhibitor{}
+	for _, el := range out {
+		inhibitor, err := inhibitorFromInterfaces(el)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, *inhibitor)
+	}
+	return ret, nil
+}
+
+func inhibitorFromInterfaces(inhibitor []interface{}) (*Inhibitor, error) {
+	var ret Inhibitor
+	if err := dbus.Store(inhibitor, &ret.What, &ret.Who, &ret.Why, &ret.Mode, &ret.UID, &ret.PID); err != nil {
+		return nil, fmt.Errorf("failed to convert inhibitor: %w", err)
+	}
+	return &ret, nil
+}
+
+// InhibitOptions configures an inhibitor lock taken with InhibitWithOptions.
+type InhibitOptions struct {
+	What string // Colon-separated list of what to inhibit, e.g. shutdown:sleep
+	Who  string
+	Why  string
+	Mode string // block or delay
+
+	// Reacquire makes the lock be taken again when logind restarts, so that
+	// it is not lost if logind fails to restore it.
+	Reacquire bool
+	// OnReacquireError, if set, is called with the errors of taking the
+	// lock again. The previous lock is kept then.
+	OnReacquireError func(error)
+}
+
+// InhibitorLock is an inhibitor lock held until it is released, either by
+// Release or by the cancellation of the context it was taken with.
+type InhibitorLock struct {
+	c    *Conn
+	opts InhibitOptions
+
+	mu   sync.Mutex
+	file *os.File
+
+	release chan struct{}
+	once    sync.Once
+	err     error
+}
+
+// InhibitContext takes an inhibitor lock in logind, which is released when
+// ctx is done, or by Release.
+func (c *Conn) InhibitContext(ctx context.Context, what, who, why, mode string) (*InhibitorLock, error) {
+	return c.InhibitWithOptions(ctx, InhibitOptions{What: what, Who: who, Why: why, Mode: mode})
+}
+
+// InhibitWithOptions is like InhibitContext, but takes options.
+func (c *Conn) InhibitWithOptions(ctx context.Context, opts InhibitOptions) (*InhibitorLock, error) {
+	file, err := c.inhibit(ctx, opts.What, opts.Who, opts.Why, opts.Mode)
+	if err != nil {
+		return nil, err
+	}
+	l := &InhibitorLock{c: c, opts: opts, file: file, release: make(chan struct{})}
+
+	var signals chan *dbus.Signal
+	match := []dbus.MatchOption{
+		dbus.WithMatchSender("org.freedesktop.DBus"),
+		dbus.WithMatchObjectPath("/org/freedesktop/DBus"),
+		dbus.WithMatchInterface("org.freedesktop.DBus"),
+		dbus.WithMatchMember("NameOwnerChanged"),
+		dbus.WithMatchArg(0, dbusDest),
+	}
+	if opts.Reacquire {
+		if err := c.conn.AddMatchSignalContext(ctx, match...); err != nil {
+			file.Close()
+			return nil, err
+		}
+		signals = make(chan *dbus.Signal, signalBuffer)
+		c.conn.Signal(signals)
+	}
+
+	go func() {
+		if signals != nil {
+			defer func() {
+				c.conn.RemoveSignal(signals)
+				c.conn.RemoveMatchSignal(match...)
+			}()
+		}
+
+		for {
+			select {
+			case signal, ok := <-signals:
+				if !ok {
+					signals = nil
+					continue
+				}
+				if logindStarted(signal) {
+					l.reacquire()
+				}
+			case <-ctx.Done():
+				l.Release()
+				return
+			case <-l.release:
+				return
+			}
+		}
+	}()
+
+	return l, nil
+}
+
+// logindStarted reports whether signal is a NameOwnerChanged signal for a
+// new owner of the logind bus name.
+func logindStarted(signal *dbus.Signal) bool {
+	if signal.Name != "org.freedesktop.DBus.NameOwnerChanged" {
+		return false
+	}
+	var name, oldOwner, newOwner string
+	if dbus.Store(signal.Body, &name, &oldOwner, &newOwner) != nil {
+		return false
+	}
+	return name == dbusDest && newOwner != ""
+}
+
+// reacquire takes the lock again and releases the previous one.
+func (l *InhibitorLock) reacquire() {
+	file, err := l.c.inhibit(context.Background(), l.opts.What, l.opts.Who, l.opts.Why, l.opts.Mode)
+	if err != nil {
+		if l.opts.OnReacquireError != nil {
+			l.opts.OnReacquireError(err)
+		}
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		// released meanwhile
+		file.Close()
+		return
+	}
+	l.file.Close()
+	l.file = file
+}
+
+// Release releases the lock. It may be called more than once, returning the
+// result of the first call.
+func (l *InhibitorLock) Release() error {
+	l.once.Do(func() {
+		close(l.release)
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.err = l.file.Close()
+		l.file = nil
+	})
+	return l.err
+}
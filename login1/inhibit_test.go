@@ -0,0 +1,80 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"os"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestInhibitorFromInterfaces(t *testing.T) {
+	inhibitor, err := inhibitorFromInterfaces([]interface{}{"shutdown:sleep", "NetworkManager", "NetworkManager needs to turn off networks", "delay", uint32(0), uint32(731)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Inhibitor{
+		What: "shutdown:sleep",
+		Who:  "NetworkManager",
+		Why:  "NetworkManager needs to turn off networks",
+		Mode: "delay",
+		UID:  0,
+		PID:  731,
+	}
+	if *inhibitor != expected {
+		t.Errorf("expected %+v, got %+v", expected, *inhibitor)
+	}
+
+	if _, err := inhibitorFromInterfaces([]interface{}{"shutdown", "who"}); err == nil {
+		t.Error("expected an error for missing fields")
+	}
+}
+
+func TestLogindStarted(t *testing.T) {
+	signal := &dbus.Signal{
+		Name: "org.freedesktop.DBus.NameOwnerChanged",
+		Body: []interface{}{"org.freedesktop.login1", "", ":1.42"},
+	}
+	if !logindStarted(signal) {
+		t.Error("expected a new owner of logind")
+	}
+
+	signal.Body = []interface{}{"org.freedesktop.login1", ":1.42", ""}
+	if logindStarted(signal) {
+		t.Error("expected no new owner for a lost name")
+	}
+}
+
+func TestInhibitorLockRelease(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	l := &InhibitorLock{file: w, release: make(chan struct{})}
+	if err := l.Release(); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Release(); err != nil {
+		t.Errorf("expected the result of the first release, got %v", err)
+	}
+
+	// the write end is closed, so reads return EOF
+	if n, err := r.Read(make([]byte, 1)); n != 0 || err == nil {
+		t.Errorf("expected EOF, got %d, %v", n, err)
+	}
+}
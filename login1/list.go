@@ -0,0 +1,133 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// SessionFilter selects sessions in ListSessionsFiltered. Empty fields match
+// all sessions.
+type SessionFilter struct {
+	Seat    string   // The seat of the sessions, e.g. seat0
+	User    string   // The name of the user of the sessions
+	Classes []string // The classes of the sessions, e.g. user or greeter
+}
+
+func (f SessionFilter) matches(s Session) bool {
+	return (f.Seat == "" || s.Seat == f.Seat) && (f.User == "" || s.User == f.User)
+}
+
+func (f SessionFilter) matchesClass(class string) bool {
+	if len(f.Classes) == 0 {
+		return true
+	}
+	for _, c := range f.Classes {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// ListSessionsFiltered returns the sessions matching the filter. logind
+// doesn't filter sessions itself, but on systemd versions with
+// ListSessionsEx (256 and newer) the classes of the sessions are listed
+// along with them, so that a single call is made. On older versions, the
+// Class property of the sessions matching the seat and user is fetched if
+// the filter has classes.
+func (c *Conn) ListSessionsFiltered(ctx context.Context, filter SessionFilter) ([]Session, error) {
+	if len(filter.Classes) == 0 {
+		sessions, err := c.ListSessionsContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return filterSessions(sessions, filter.matches), nil
+	}
+
+	var out [][]interface{}
+	err := c.object.CallWithContext(ctx, dbusManagerInterface+".ListSessionsEx", 0).Store(&out)
+	if isUnknownMethod(err) {
+		return c.listSessionsByClass(ctx, filter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []Session{}
+	for _, el := range out {
+		session, class, err := sessionExFromInterfaces(el)
+		if err != nil {
+			return nil, err
+		}
+		if filter.matches(*session) && filter.matchesClass(class) {
+			ret = append(ret, *session)
+		}
+	}
+	return ret, nil
+}
+
+// listSessionsByClass filters the sessions by class on systemd versions
+// without ListSessionsEx.
+func (c *Conn) listSessionsByClass(ctx context.Context, filter SessionFilter) ([]Session, error) {
+	sessions, err := c.ListSessionsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []Session{}
+	for _, s := range filterSessions(sessions, filter.matches) {
+		p, err := c.GetSessionPropertyContext(ctx, s.Path, "Class")
+		if err != nil {
+			return nil, err
+		}
+		if class, _ := p.Value().(string); filter.matchesClass(class) {
+			ret = append(ret, s)
+		}
+	}
+	return ret, nil
+}
+
+func filterSessions(sessions []Session, match func(Session) bool) []Session {
+	ret := sessions[:0]
+	for _, s := range sessions {
+		if match(s) {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+// sessionExFromInterfaces converts a (sussussbto) session returned by
+// ListSessionsEx: the ID, UID, user name, seat, leader PID, class, TTY, idle
+// hint, idle since hint and path of the session. It returns the session and
+// its class.
+func sessionExFromInterfaces(session []interface{}) (*Session, string, error) {
+	var (
+		ret         Session
+		leader      uint32
+		class, tty  string
+		idle        bool
+		idleSinceUs uint64
+	)
+	err := dbus.Store(session, &ret.ID, &ret.UID, &ret.User, &ret.Seat, &leader, &class, &tty, &idle, &idleSinceUs, &ret.Path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to convert session: %w", err)
+	}
+	return &ret, class, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestSessionExFromInterfaces(t *testing.T) {
+	session, class, err := sessionExFromInterfaces([]interface{}{
+		"c1", uint32(0), "root", "seat0", uint32(812), "greeter", "tty1", false, uint64(0), dbus.ObjectPath("/org/freedesktop/login1/session/c1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &Session{ID: "c1", UID: 0, User: "root", Seat: "seat0", Path: "/org/freedesktop/login1/session/c1"}
+	if !reflect.DeepEqual(session, expected) {
+		t.Errorf("expected %+v, got %+v", expected, session)
+	}
+	if class != "greeter" {
+		t.Errorf("expected class greeter, got %s", class)
+	}
+
+	if _, _, err := sessionExFromInterfaces([]interface{}{"c1", uint32(0)}); err == nil {
+		t.Error("expected an error for a truncated session")
+	}
+}
+
+func TestSessionFilter(t *testing.T) {
+	sessions := []Session{
+		{ID: "1", User: "core", Seat: "seat0"},
+		{ID: "2", User: "core"},
+		{ID: "c1", User: "gdm", Seat: "seat0"},
+	}
+
+	filter := SessionFilter{Seat: "seat0", User: "core", Classes: []string{"user", "user-early"}}
+	got := filterSessions(append([]Session(nil), sessions...), filter.matches)
+	if len(got) != 1 || got[0].ID != "1" {
+		t.Errorf("expected only session 1 to match, got %v", got)
+	}
+	if !filter.matchesClass("user-early") || filter.matchesClass("greeter") {
+		t.Error("expected only the classes of the filter to match")
+	}
+
+	if got := filterSessions(append([]Session(nil), sessions...), SessionFilter{}.matches); len(got) != len(sessions) {
+		t.Errorf("expected the empty filter to match all sessions, got %v", got)
+	}
+	if !(SessionFilter{}).matchesClass("greeter") {
+		t.Error("expected the empty filter to match all classes")
+	}
+}
@@ -0,0 +1,767 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+// Package login1test provides a fake of login1.Conn for unit tests.
+package login1test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/gr-butler/go-systemd/v22/instrument"
+	"github.com/gr-butler/go-systemd/v22/login1"
+)
+
+// ErrNotImplemented is returned by the methods whose function field is unset.
+var ErrNotImplemented = errors.New("not implemented")
+
+// Conn is a fake login1.Connection. Each method calls the function field of
+// the same name with a Func suffix. If it is unset, the method returns zero
+// values and, if it returns an error, ErrNotImplemented.
+type Conn struct {
+	ActivateSessionFunc             func(ctx context.Context, seat, session string) error
+	CanGraphicalFunc                func(ctx context.Context, seat string) (bool, error)
+	CanHibernateFunc                func(ctx context.Context) (login1.Capability, error)
+	CanHybridSleepFunc              func(ctx context.Context) (login1.Capability, error)
+	CanMultiSessionFunc             func(ctx context.Context, seat string) (bool, error)
+	CanPowerOffFunc                 func(ctx context.Context) (login1.Capability, error)
+	CanRebootFunc                   func(ctx context.Context) (login1.Capability, error)
+	CanRebootParameterFunc          func(ctx context.Context) (login1.Capability, error)
+	CanRebootToBootLoaderEntryFunc  func(ctx context.Context) (login1.Capability, error)
+	CanRebootToFirmwareSetupFunc    func(ctx context.Context) (login1.Capability, error)
+	CanSleepFunc                    func(ctx context.Context) (login1.Capability, error)
+	CanSuspendFunc                  func(ctx context.Context) (login1.Capability, error)
+	CanSuspendThenHibernateFunc     func(ctx context.Context) (login1.Capability, error)
+	CancelScheduledShutdownFunc     func(ctx context.Context) (bool, error)
+	CloseFunc                       func()
+	ConnectedFunc                   func() bool
+	CreateSessionFunc               func(ctx context.Context, opts login1.CreateSessionOptions) (*login1.CreatedSession, error)
+	GetActiveSessionFunc            func() (dbus.ObjectPath, error)
+	GetActiveSessionForSeatFunc     func(ctx context.Context, seatID string) (dbus.ObjectPath, error)
+	GetIdleHintFunc                 func(ctx context.Context, sessionPath dbus.ObjectPath) (bool, error)
+	GetIdleSinceHintFunc            func(ctx context.Context, sessionPath dbus.ObjectPath) (time.Time, error)
+	GetIdleSinceHintMonotonicFunc   func(ctx context.Context, sessionPath dbus.ObjectPath) (time.Duration, error)
+	GetLockedHintFunc               func(ctx context.Context, sessionPath dbus.ObjectPath) (bool, error)
+	GetScheduledShutdownFunc        func(ctx context.Context) (string, uint64, error)
+	GetSeatContextFunc              func(ctx context.Context, id string) (dbus.ObjectPath, error)
+	GetSessionFunc                  func(id string) (dbus.ObjectPath, error)
+	GetSessionDisplayFunc           func(sessionPath dbus.ObjectPath) (string, error)
+	GetSessionInfoFunc              func(ctx context.Context, sessionPath dbus.ObjectPath) (*login1.SessionInfo, error)
+	GetSessionPropertiesContextFunc func(ctx context.Context, sessionPath dbus.ObjectPath) (map[string]dbus.Variant, error)
+	GetSessionPropertyContextFunc   func(ctx context.Context, sessionPath dbus.ObjectPath, property string) (*dbus.Variant, error)
+	GetSessionTypedFunc             func(ctx context.Context, sessionPath dbus.ObjectPath) (*login1.SessionProperties, error)
+	GetSessionUserFunc              func(sessionPath dbus.ObjectPath) (*login1.User, error)
+	GetSleepOperationFunc           func(ctx context.Context) ([]string, error)
+	GetUserContextFunc              func(ctx context.Context, uid uint32) (dbus.ObjectPath, error)
+	GetUserLingerContextFunc        func(ctx context.Context, uid uint32) (bool, error)
+	GetUserPropertiesContextFunc    func(ctx context.Context, userPath dbus.ObjectPath) (map[string]dbus.Variant, error)
+	GetUserPropertyContextFunc      func(ctx context.Context, userPath dbus.ObjectPath, property string) (*dbus.Variant, error)
+	HibernateFunc                   func(ctx context.Context, askForAuth bool) error
+	HybridSleepFunc                 func(ctx context.Context, askForAuth bool) error
+	InhibitFunc                     func(what, who, why, mode string) (*os.File, error)
+	InhibitContextFunc              func(ctx context.Context, what, who, why, mode string) (*login1.InhibitorLock, error)
+	InhibitWithOptionsFunc          func(ctx context.Context, opts login1.InhibitOptions) (*login1.InhibitorLock, error)
+	ListInhibitorsContextFunc       func(ctx context.Context) ([]login1.Inhibitor, error)
+	ListSeatsContextFunc            func(ctx context.Context) ([]login1.Seat, error)
+	ListSessionsFunc                func() ([]login1.Session, error)
+	ListSessionsContextFunc         func(ctx context.Context) ([]login1.Session, error)
+	ListSessionsFilteredFunc        func(ctx context.Context, filter login1.SessionFilter) ([]login1.Session, error)
+	ListUsersFunc                   func() ([]login1.User, error)
+	ListUsersContextFunc            func(ctx context.Context) ([]login1.User, error)
+	LockFunc                        func(ctx context.Context, sessionPath dbus.ObjectPath) error
+	LockSessionFunc                 func(id string)
+	LockSessionContextFunc          func(ctx context.Context, id string) error
+	LockSessionsFunc                func()
+	LockSessionsContextFunc         func(ctx context.Context) error
+	OnPrepareForShutdownFunc        func(ctx context.Context, opts login1.PrepareOptions, prepare func(context.Context)) error
+	OnPrepareForSleepFunc           func(ctx context.Context, opts login1.PrepareOptions, prepare func(context.Context)) error
+	PowerOffFunc                    func(askForAuth bool)
+	PowerOffContextFunc             func(ctx context.Context, askForAuth bool) error
+	RebootFunc                      func(askForAuth bool)
+	RebootContextFunc               func(ctx context.Context, askForAuth bool) error
+	RebootWithFlagsContextFunc      func(ctx context.Context, flags uint64) error
+	ReleaseSessionFunc              func(ctx context.Context, id string) error
+	ScheduleShutdownFunc            func(ctx context.Context, kind string, usec uint64) error
+	SetHookFunc                     func(hook instrument.Hook)
+	SetIdleHintFunc                 func(ctx context.Context, sessionPath dbus.ObjectPath, idle bool) error
+	SetLockedHintFunc               func(ctx context.Context, sessionPath dbus.ObjectPath, locked bool) error
+	SetRebootParameterContextFunc   func(ctx context.Context, param string) error
+	SetRebootToBootLoaderEntryFunc  func(ctx context.Context, entry string) error
+	SetRebootToFirmwareSetupFunc    func(ctx context.Context, enable bool) error
+	SetTimeoutFunc                  func(timeout time.Duration)
+	SetUserLingerContextFunc        func(ctx context.Context, uid uint32, enable, interactive bool) error
+	SetWallMessageFunc              func(ctx context.Context, message string, enable bool) error
+	SleepWithFlagsFunc              func(ctx context.Context, flags uint64) error
+	SubscribeFunc                   func(members ...string) chan *dbus.Signal
+	SubscribeContextFunc            func(ctx context.Context, members ...string) (signals <-chan *dbus.Signal, cancel func(), err error)
+	SuspendFunc                     func(ctx context.Context, askForAuth bool) error
+	SuspendThenHibernateFunc        func(ctx context.Context, askForAuth bool) error
+	SwitchToFunc                    func(ctx context.Context, seat string, vtnr uint32) error
+	TerminateSessionFunc            func(id string)
+	TerminateSessionContextFunc     func(ctx context.Context, id string) error
+	TerminateUserFunc               func(uid uint32)
+	TerminateUserContextFunc        func(ctx context.Context, uid uint32) error
+	UnlockFunc                      func(ctx context.Context, sessionPath dbus.ObjectPath) error
+	WatchSessionPropertiesFunc      func(ctx context.Context, sessionPath dbus.ObjectPath, names ...string) (<-chan login1.PropertiesUpdate, error)
+	WatchSessionsFunc               func(ctx context.Context) (<-chan login1.SessionEvent, error)
+	WatchUserPropertiesFunc         func(ctx context.Context, userPath dbus.ObjectPath, names ...string) (<-chan login1.PropertiesUpdate, error)
+	WatchUsersFunc                  func(ctx context.Context) (<-chan login1.UserEvent, error)
+}
+
+var _ login1.Connection = (*Conn)(nil)
+
+func (f *Conn) ActivateSession(ctx context.Context, seat, session string) error {
+	if f.ActivateSessionFunc == nil {
+		return fmt.Errorf("%w: ActivateSession", ErrNotImplemented)
+	}
+	return f.ActivateSessionFunc(ctx, seat, session)
+}
+
+func (f *Conn) CanGraphical(ctx context.Context, seat string) (bool, error) {
+	if f.CanGraphicalFunc == nil {
+		var r0 bool
+		return r0, fmt.Errorf("%w: CanGraphical", ErrNotImplemented)
+	}
+	return f.CanGraphicalFunc(ctx, seat)
+}
+
+func (f *Conn) CanHibernate(ctx context.Context) (login1.Capability, error) {
+	if f.CanHibernateFunc == nil {
+		var r0 login1.Capability
+		return r0, fmt.Errorf("%w: CanHibernate", ErrNotImplemented)
+	}
+	return f.CanHibernateFunc(ctx)
+}
+
+func (f *Conn) CanHybridSleep(ctx context.Context) (login1.Capability, error) {
+	if f.CanHybridSleepFunc == nil {
+		var r0 login1.Capability
+		return r0, fmt.Errorf("%w: CanHybridSleep", ErrNotImplemented)
+	}
+	return f.CanHybridSleepFunc(ctx)
+}
+
+func (f *Conn) CanMultiSession(ctx context.Context, seat string) (bool, error) {
+	if f.CanMultiSessionFunc == nil {
+		var r0 bool
+		return r0, fmt.Errorf("%w: CanMultiSession", ErrNotImplemented)
+	}
+	return f.CanMultiSessionFunc(ctx, seat)
+}
+
+func (f *Conn) CanPowerOff(ctx context.Context) (login1.Capability, error) {
+	if f.CanPowerOffFunc == nil {
+		var r0 login1.Capability
+		return r0, fmt.Errorf("%w: CanPowerOff", ErrNotImplemented)
+	}
+	return f.CanPowerOffFunc(ctx)
+}
+
+func (f *Conn) CanReboot(ctx context.Context) (login1.Capability, error) {
+	if f.CanRebootFunc == nil {
+		var r0 login1.Capability
+		return r0, fmt.Errorf("%w: CanReboot", ErrNotImplemented)
+	}
+	return f.CanRebootFunc(ctx)
+}
+
+func (f *Conn) CanRebootParameter(ctx context.Context) (login1.Capability, error) {
+	if f.CanRebootParameterFunc == nil {
+		var r0 login1.Capability
+		return r0, fmt.Errorf("%w: CanRebootParameter", ErrNotImplemented)
+	}
+	return f.CanRebootParameterFunc(ctx)
+}
+
+func (f *Conn) CanRebootToBootLoaderEntry(ctx context.Context) (login1.Capability, error) {
+	if f.CanRebootToBootLoaderEntryFunc == nil {
+		var r0 login1.Capability
+		return r0, fmt.Errorf("%w: CanRebootToBootLoaderEntry", ErrNotImplemented)
+	}
+	return f.CanRebootToBootLoaderEntryFunc(ctx)
+}
+
+func (f *Conn) CanRebootToFirmwareSetup(ctx context.Context) (login1.Capability, error) {
+	if f.CanRebootToFirmwareSetupFunc == nil {
+		var r0 login1.Capability
+		return r0, fmt.Errorf("%w: CanRebootToFirmwareSetup", ErrNotImplemented)
+	}
+	return f.CanRebootToFirmwareSetupFunc(ctx)
+}
+
+func (f *Conn) CanSleep(ctx context.Context) (login1.Capability, error) {
+	if f.CanSleepFunc == nil {
+		var r0 login1.Capability
+		return r0, fmt.Errorf("%w: CanSleep", ErrNotImplemented)
+	}
+	return f.CanSleepFunc(ctx)
+}
+
+func (f *Conn) CanSuspend(ctx context.Context) (login1.Capability, error) {
+	if f.CanSuspendFunc == nil {
+		var r0 login1.Capability
+		return r0, fmt.Errorf("%w: CanSuspend", ErrNotImplemented)
+	}
+	return f.CanSuspendFunc(ctx)
+}
+
+func (f *Conn) CanSuspendThenHibernate(ctx context.Context) (login1.Capability, error) {
+	if f.CanSuspendThenHibernateFunc == nil {
+		var r0 login1.Capability
+		return r0, fmt.Errorf("%w: CanSuspendThenHibernate", ErrNotImplemented)
+	}
+	return f.CanSuspendThenHibernateFunc(ctx)
+}
+
+func (f *Conn) CancelScheduledShutdown(ctx context.Context) (bool, error) {
+	if f.CancelScheduledShutdownFunc == nil {
+		var r0 bool
+		return r0, fmt.Errorf("%w: CancelScheduledShutdown", ErrNotImplemented)
+	}
+	return f.CancelScheduledShutdownFunc(ctx)
+}
+
+func (f *Conn) Close() {
+	if f.CloseFunc != nil {
+		f.CloseFunc()
+	}
+}
+
+func (f *Conn) Connected() bool {
+	if f.ConnectedFunc == nil {
+		var r0 bool
+		return r0
+	}
+	return f.ConnectedFunc()
+}
+
+func (f *Conn) CreateSession(ctx context.Context, opts login1.CreateSessionOptions) (*login1.CreatedSession, error) {
+	if f.CreateSessionFunc == nil {
+		var r0 *login1.CreatedSession
+		return r0, fmt.Errorf("%w: CreateSession", ErrNotImplemented)
+	}
+	return f.CreateSessionFunc(ctx, opts)
+}
+
+func (f *Conn) GetActiveSession() (dbus.ObjectPath, error) {
+	if f.GetActiveSessionFunc == nil {
+		var r0 dbus.ObjectPath
+		return r0, fmt.Errorf("%w: GetActiveSession", ErrNotImplemented)
+	}
+	return f.GetActiveSessionFunc()
+}
+
+func (f *Conn) GetActiveSessionForSeat(ctx context.Context, seatID string) (dbus.ObjectPath, error) {
+	if f.GetActiveSessionForSeatFunc == nil {
+		var r0 dbus.ObjectPath
+		return r0, fmt.Errorf("%w: GetActiveSessionForSeat", ErrNotImplemented)
+	}
+	return f.GetActiveSessionForSeatFunc(ctx, seatID)
+}
+
+func (f *Conn) GetIdleHint(ctx context.Context, sessionPath dbus.ObjectPath) (bool, error) {
+	if f.GetIdleHintFunc == nil {
+		var r0 bool
+		return r0, fmt.Errorf("%w: GetIdleHint", ErrNotImplemented)
+	}
+	return f.GetIdleHintFunc(ctx, sessionPath)
+}
+
+func (f *Conn) GetIdleSinceHint(ctx context.Context, sessionPath dbus.ObjectPath) (time.Time, error) {
+	if f.GetIdleSinceHintFunc == nil {
+		var r0 time.Time
+		return r0, fmt.Errorf("%w: GetIdleSinceHint", ErrNotImplemented)
+	}
+	return f.GetIdleSinceHintFunc(ctx, sessionPath)
+}
+
+func (f *Conn) GetIdleSinceHintMonotonic(ctx context.Context, sessionPath dbus.ObjectPath) (time.Duration, error) {
+	if f.GetIdleSinceHintMonotonicFunc == nil {
+		var r0 time.Duration
+		return r0, fmt.Errorf("%w: GetIdleSinceHintMonotonic", ErrNotImplemented)
+	}
+	return f.GetIdleSinceHintMonotonicFunc(ctx, sessionPath)
+}
+
+func (f *Conn) GetLockedHint(ctx context.Context, sessionPath dbus.ObjectPath) (bool, error) {
+	if f.GetLockedHintFunc == nil {
+		var r0 bool
+		return r0, fmt.Errorf("%w: GetLockedHint", ErrNotImplemented)
+	}
+	return f.GetLockedHintFunc(ctx, sessionPath)
+}
+
+func (f *Conn) GetScheduledShutdown(ctx context.Context) (string, uint64, error) {
+	if f.GetScheduledShutdownFunc == nil {
+		var r0 string
+		var r1 uint64
+		return r0, r1, fmt.Errorf("%w: GetScheduledShutdown", ErrNotImplemented)
+	}
+	return f.GetScheduledShutdownFunc(ctx)
+}
+
+func (f *Conn) GetSeatContext(ctx context.Context, id string) (dbus.ObjectPath, error) {
+	if f.GetSeatContextFunc == nil {
+		var r0 dbus.ObjectPath
+		return r0, fmt.Errorf("%w: GetSeatContext", ErrNotImplemented)
+	}
+	return f.GetSeatContextFunc(ctx, id)
+}
+
+func (f *Conn) GetSession(id string) (dbus.ObjectPath, error) {
+	if f.GetSessionFunc == nil {
+		var r0 dbus.ObjectPath
+		return r0, fmt.Errorf("%w: GetSession", ErrNotImplemented)
+	}
+	return f.GetSessionFunc(id)
+}
+
+func (f *Conn) GetSessionDisplay(sessionPath dbus.ObjectPath) (string, error) {
+	if f.GetSessionDisplayFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: GetSessionDisplay", ErrNotImplemented)
+	}
+	return f.GetSessionDisplayFunc(sessionPath)
+}
+
+func (f *Conn) GetSessionInfo(ctx context.Context, sessionPath dbus.ObjectPath) (*login1.SessionInfo, error) {
+	if f.GetSessionInfoFunc == nil {
+		var r0 *login1.SessionInfo
+		return r0, fmt.Errorf("%w: GetSessionInfo", ErrNotImplemented)
+	}
+	return f.GetSessionInfoFunc(ctx, sessionPath)
+}
+
+func (f *Conn) GetSessionPropertiesContext(ctx context.Context, sessionPath dbus.ObjectPath) (map[string]dbus.Variant, error) {
+	if f.GetSessionPropertiesContextFunc == nil {
+		var r0 map[string]dbus.Variant
+		return r0, fmt.Errorf("%w: GetSessionPropertiesContext", ErrNotImplemented)
+	}
+	return f.GetSessionPropertiesContextFunc(ctx, sessionPath)
+}
+
+func (f *Conn) GetSessionPropertyContext(ctx context.Context, sessionPath dbus.ObjectPath, property string) (*dbus.Variant, error) {
+	if f.GetSessionPropertyContextFunc == nil {
+		var r0 *dbus.Variant
+		return r0, fmt.Errorf("%w: GetSessionPropertyContext", ErrNotImplemented)
+	}
+	return f.GetSessionPropertyContextFunc(ctx, sessionPath, property)
+}
+
+func (f *Conn) GetSessionTyped(ctx context.Context, sessionPath dbus.ObjectPath) (*login1.SessionProperties, error) {
+	if f.GetSessionTypedFunc == nil {
+		var r0 *login1.SessionProperties
+		return r0, fmt.Errorf("%w: GetSessionTyped", ErrNotImplemented)
+	}
+	return f.GetSessionTypedFunc(ctx, sessionPath)
+}
+
+func (f *Conn) GetSessionUser(sessionPath dbus.ObjectPath) (*login1.User, error) {
+	if f.GetSessionUserFunc == nil {
+		var r0 *login1.User
+		return r0, fmt.Errorf("%w: GetSessionUser", ErrNotImplemented)
+	}
+	return f.GetSessionUserFunc(sessionPath)
+}
+
+func (f *Conn) GetSleepOperation(ctx context.Context) ([]string, error) {
+	if f.GetSleepOperationFunc == nil {
+		var r0 []string
+		return r0, fmt.Errorf("%w: GetSleepOperation", ErrNotImplemented)
+	}
+	return f.GetSleepOperationFunc(ctx)
+}
+
+func (f *Conn) GetUserContext(ctx context.Context, uid uint32) (dbus.ObjectPath, error) {
+	if f.GetUserContextFunc == nil {
+		var r0 dbus.ObjectPath
+		return r0, fmt.Errorf("%w: GetUserContext", ErrNotImplemented)
+	}
+	return f.GetUserContextFunc(ctx, uid)
+}
+
+func (f *Conn) GetUserLingerContext(ctx context.Context, uid uint32) (bool, error) {
+	if f.GetUserLingerContextFunc == nil {
+		var r0 bool
+		return r0, fmt.Errorf("%w: GetUserLingerContext", ErrNotImplemented)
+	}
+	return f.GetUserLingerContextFunc(ctx, uid)
+}
+
+func (f *Conn) GetUserPropertiesContext(ctx context.Context, userPath dbus.ObjectPath) (map[string]dbus.Variant, error) {
+	if f.GetUserPropertiesContextFunc == nil {
+		var r0 map[string]dbus.Variant
+		return r0, fmt.Errorf("%w: GetUserPropertiesContext", ErrNotImplemented)
+	}
+	return f.GetUserPropertiesContextFunc(ctx, userPath)
+}
+
+func (f *Conn) GetUserPropertyContext(ctx context.Context, userPath dbus.ObjectPath, property string) (*dbus.Variant, error) {
+	if f.GetUserPropertyContextFunc == nil {
+		var r0 *dbus.Variant
+		return r0, fmt.Errorf("%w: GetUserPropertyContext", ErrNotImplemented)
+	}
+	return f.GetUserPropertyContextFunc(ctx, userPath, property)
+}
+
+func (f *Conn) Hibernate(ctx context.Context, askForAuth bool) error {
+	if f.HibernateFunc == nil {
+		return fmt.Errorf("%w: Hibernate", ErrNotImplemented)
+	}
+	return f.HibernateFunc(ctx, askForAuth)
+}
+
+func (f *Conn) HybridSleep(ctx context.Context, askForAuth bool) error {
+	if f.HybridSleepFunc == nil {
+		return fmt.Errorf("%w: HybridSleep", ErrNotImplemented)
+	}
+	return f.HybridSleepFunc(ctx, askForAuth)
+}
+
+func (f *Conn) Inhibit(what, who, why, mode string) (*os.File, error) {
+	if f.InhibitFunc == nil {
+		var r0 *os.File
+		return r0, fmt.Errorf("%w: Inhibit", ErrNotImplemented)
+	}
+	return f.InhibitFunc(what, who, why, mode)
+}
+
+func (f *Conn) InhibitContext(ctx context.Context, what, who, why, mode string) (*login1.InhibitorLock, error) {
+	if f.InhibitContextFunc == nil {
+		var r0 *login1.InhibitorLock
+		return r0, fmt.Errorf("%w: InhibitContext", ErrNotImplemented)
+	}
+	return f.InhibitContextFunc(ctx, what, who, why, mode)
+}
+
+func (f *Conn) InhibitWithOptions(ctx context.Context, opts login1.InhibitOptions) (*login1.InhibitorLock, error) {
+	if f.InhibitWithOptionsFunc == nil {
+		var r0 *login1.InhibitorLock
+		return r0, fmt.Errorf("%w: InhibitWithOptions", ErrNotImplemented)
+	}
+	return f.InhibitWithOptionsFunc(ctx, opts)
+}
+
+func (f *Conn) ListInhibitorsContext(ctx context.Context) ([]login1.Inhibitor, error) {
+	if f.ListInhibitorsContextFunc == nil {
+		var r0 []login1.Inhibitor
+		return r0, fmt.Errorf("%w: ListInhibitorsContext", ErrNotImplemented)
+	}
+	return f.ListInhibitorsContextFunc(ctx)
+}
+
+func (f *Conn) ListSeatsContext(ctx context.Context) ([]login1.Seat, error) {
+	if f.ListSeatsContextFunc == nil {
+		var r0 []login1.Seat
+		return r0, fmt.Errorf("%w: ListSeatsContext", ErrNotImplemented)
+	}
+	return f.ListSeatsContextFunc(ctx)
+}
+
+func (f *Conn) ListSessions() ([]login1.Session, error) {
+	if f.ListSessionsFunc == nil {
+		var r0 []login1.Session
+		return r0, fmt.Errorf("%w: ListSessions", ErrNotImplemented)
+	}
+	return f.ListSessionsFunc()
+}
+
+func (f *Conn) ListSessionsContext(ctx context.Context) ([]login1.Session, error) {
+	if f.ListSessionsContextFunc == nil {
+		var r0 []login1.Session
+		return r0, fmt.Errorf("%w: ListSessionsContext", ErrNotImplemented)
+	}
+	return f.ListSessionsContextFunc(ctx)
+}
+
+func (f *Conn) ListSessionsFiltered(ctx context.Context, filter login1.SessionFilter) ([]login1.Session, error) {
+	if f.ListSessionsFilteredFunc == nil {
+		var r0 []login1.Session
+		return r0, fmt.Errorf("%w: ListSessionsFiltered", ErrNotImplemented)
+	}
+	return f.ListSessionsFilteredFunc(ctx, filter)
+}
+
+func (f *Conn) ListUsers() ([]login1.User, error) {
+	if f.ListUsersFunc == nil {
+		var r0 []login1.User
+		return r0, fmt.Errorf("%w: ListUsers", ErrNotImplemented)
+	}
+	return f.ListUsersFunc()
+}
+
+func (f *Conn) ListUsersContext(ctx context.Context) ([]login1.User, error) {
+	if f.ListUsersContextFunc == nil {
+		var r0 []login1.User
+		return r0, fmt.Errorf("%w: ListUsersContext", ErrNotImplemented)
+	}
+	return f.ListUsersContextFunc(ctx)
+}
+
+func (f *Conn) Lock(ctx context.Context, sessionPath dbus.ObjectPath) error {
+	if f.LockFunc == nil {
+		return fmt.Errorf("%w: Lock", ErrNotImplemented)
+	}
+	return f.LockFunc(ctx, sessionPath)
+}
+
+func (f *Conn) LockSession(id string) {
+	if f.LockSessionFunc != nil {
+		f.LockSessionFunc(id)
+	}
+}
+
+func (f *Conn) LockSessionContext(ctx context.Context, id string) error {
+	if f.LockSessionContextFunc == nil {
+		return fmt.Errorf("%w: LockSessionContext", ErrNotImplemented)
+	}
+	return f.LockSessionContextFunc(ctx, id)
+}
+
+func (f *Conn) LockSessions() {
+	if f.LockSessionsFunc != nil {
+		f.LockSessionsFunc()
+	}
+}
+
+func (f *Conn) LockSessionsContext(ctx context.Context) error {
+	if f.LockSessionsContextFunc == nil {
+		return fmt.Errorf("%w: LockSessionsContext", ErrNotImplemented)
+	}
+	return f.LockSessionsContextFunc(ctx)
+}
+
+func (f *Conn) OnPrepareForShutdown(ctx context.Context, opts login1.PrepareOptions, prepare func(context.Context)) error {
+	if f.OnPrepareForShutdownFunc == nil {
+		return fmt.Errorf("%w: OnPrepareForShutdown", ErrNotImplemented)
+	}
+	return f.OnPrepareForShutdownFunc(ctx, opts, prepare)
+}
+
+func (f *Conn) OnPrepareForSleep(ctx context.Context, opts login1.PrepareOptions, prepare func(context.Context)) error {
+	if f.OnPrepareForSleepFunc == nil {
+		return fmt.Errorf("%w: OnPrepareForSleep", ErrNotImplemented)
+	}
+	return f.OnPrepareForSleepFunc(ctx, opts, prepare)
+}
+
+func (f *Conn) PowerOff(askForAuth bool) {
+	if f.PowerOffFunc != nil {
+		f.PowerOffFunc(askForAuth)
+	}
+}
+
+func (f *Conn) PowerOffContext(ctx context.Context, askForAuth bool) error {
+	if f.PowerOffContextFunc == nil {
+		return fmt.Errorf("%w: PowerOffContext", ErrNotImplemented)
+	}
+	return f.PowerOffContextFunc(ctx, askForAuth)
+}
+
+func (f *Conn) Reboot(askForAuth bool) {
+	if f.RebootFunc != nil {
+		f.RebootFunc(askForAuth)
+	}
+}
+
+func (f *Conn) RebootContext(ctx context.Context, askForAuth bool) error {
+	if f.RebootContextFunc == nil {
+		return fmt.Errorf("%w: RebootContext", ErrNotImplemented)
+	}
+	return f.RebootContextFunc(ctx, askForAuth)
+}
+
+func (f *Conn) RebootWithFlagsContext(ctx context.Context, flags uint64) error {
+	if f.RebootWithFlagsContextFunc == nil {
+		return fmt.Errorf("%w: RebootWithFlagsContext", ErrNotImplemented)
+	}
+	return f.RebootWithFlagsContextFunc(ctx, flags)
+}
+
+func (f *Conn) ReleaseSession(ctx context.Context, id string) error {
+	if f.ReleaseSessionFunc == nil {
+		return fmt.Errorf("%w: ReleaseSession", ErrNotImplemented)
+	}
+	return f.ReleaseSessionFunc(ctx, id)
+}
+
+func (f *Conn) ScheduleShutdown(ctx context.Context, kind string, usec uint64) error {
+	if f.ScheduleShutdownFunc == nil {
+		return fmt.Errorf("%w: ScheduleShutdown", ErrNotImplemented)
+	}
+	return f.ScheduleShutdownFunc(ctx, kind, usec)
+}
+
+func (f *Conn) SetHook(hook instrument.Hook) {
+	if f.SetHookFunc != nil {
+		f.SetHookFunc(hook)
+	}
+}
+
+func (f *Conn) SetIdleHint(ctx context.Context, sessionPath dbus.ObjectPath, idle bool) error {
+	if f.SetIdleHintFunc == nil {
+		return fmt.Errorf("%w: SetIdleHint", ErrNotImplemented)
+	}
+	return f.SetIdleHintFunc(ctx, sessionPath, idle)
+}
+
+func (f *Conn) SetLockedHint(ctx context.Context, sessionPath dbus.ObjectPath, locked bool) error {
+	if f.SetLockedHintFunc == nil {
+		return fmt.Errorf("%w: SetLockedHint", ErrNotImplemented)
+	}
+	return f.SetLockedHintFunc(ctx, sessionPath, locked)
+}
+
+func (f *Conn) SetRebootParameterContext(ctx context.Context, param string) error {
+	if f.SetRebootParameterContextFunc == nil {
+		return fmt.Errorf("%w: SetRebootParameterContext", ErrNotImplemented)
+	}
+	return f.SetRebootParameterContextFunc(ctx, param)
+}
+
+func (f *Conn) SetRebootToBootLoaderEntry(ctx context.Context, entry string) error {
+	if f.SetRebootToBootLoaderEntryFunc == nil {
+		return fmt.Errorf("%w: SetRebootToBootLoaderEntry", ErrNotImplemented)
+	}
+	return f.SetRebootToBootLoaderEntryFunc(ctx, entry)
+}
+
+func (f *Conn) SetRebootToFirmwareSetup(ctx context.Context, enable bool) error {
+	if f.SetRebootToFirmwareSetupFunc == nil {
+		return fmt.Errorf("%w: SetRebootToFirmwareSetup", ErrNotImplemented)
+	}
+	return f.SetRebootToFirmwareSetupFunc(ctx, enable)
+}
+
+func (f *Conn) SetTimeout(timeout time.Duration) {
+	if f.SetTimeoutFunc != nil {
+		f.SetTimeoutFunc(timeout)
+	}
+}
+
+func (f *Conn) SetUserLingerContext(ctx context.Context, uid uint32, enable, interactive bool) error {
+	if f.SetUserLingerContextFunc == nil {
+		return fmt.Errorf("%w: SetUserLingerContext", ErrNotImplemented)
+	}
+	return f.SetUserLingerContextFunc(ctx, uid, enable, interactive)
+}
+
+func (f *Conn) SetWallMessage(ctx context.Context, message string, enable bool) error {
+	if f.SetWallMessageFunc == nil {
+		return fmt.Errorf("%w: SetWallMessage", ErrNotImplemented)
+	}
+	return f.SetWallMessageFunc(ctx, message, enable)
+}
+
+func (f *Conn) SleepWithFlags(ctx context.Context, flags uint64) error {
+	if f.SleepWithFlagsFunc == nil {
+		return fmt.Errorf("%w: SleepWithFlags", ErrNotImplemented)
+	}
+	return f.SleepWithFlagsFunc(ctx, flags)
+}
+
+func (f *Conn) Subscribe(members ...string) chan *dbus.Signal {
+	if f.SubscribeFunc == nil {
+		var r0 chan *dbus.Signal
+		return r0
+	}
+	return f.SubscribeFunc(members...)
+}
+
+func (f *Conn) SubscribeContext(ctx context.Context, members ...string) (signals <-chan *dbus.Signal, cancel func(), err error) {
+	if f.SubscribeContextFunc == nil {
+		var r0 <-chan *dbus.Signal
+		var r1 func()
+		return r0, r1, fmt.Errorf("%w: SubscribeContext", ErrNotImplemented)
+	}
+	return f.SubscribeContextFunc(ctx, members...)
+}
+
+func (f *Conn) Suspend(ctx context.Context, askForAuth bool) error {
+	if f.SuspendFunc == nil {
+		return fmt.Errorf("%w: Suspend", ErrNotImplemented)
+	}
+	return f.SuspendFunc(ctx, askForAuth)
+}
+
+func (f *Conn) SuspendThenHibernate(ctx context.Context, askForAuth bool) error {
+	if f.SuspendThenHibernateFunc == nil {
+		return fmt.Errorf("%w: SuspendThenHibernate", ErrNotImplemented)
+	}
+	return f.SuspendThenHibernateFunc(ctx, askForAuth)
+}
+
+func (f *Conn) SwitchTo(ctx context.Context, seat string, vtnr uint32) error {
+	if f.SwitchToFunc == nil {
+		return fmt.Errorf("%w: SwitchTo", ErrNotImplemented)
+	}
+	return f.SwitchToFunc(ctx, seat, vtnr)
+}
+
+func (f *Conn) TerminateSession(id string) {
+	if f.TerminateSessionFunc != nil {
+		f.TerminateSessionFunc(id)
+	}
+}
+
+func (f *Conn) TerminateSessionContext(ctx context.Context, id string) error {
+	if f.TerminateSessionContextFunc == nil {
+		return fmt.Errorf("%w: TerminateSessionContext", ErrNotImplemented)
+	}
+	return f.TerminateSessionContextFunc(ctx, id)
+}
+
+func (f *Conn) TerminateUser(uid uint32) {
+	if f.TerminateUserFunc != nil {
+		f.TerminateUserFunc(uid)
+	}
+}
+
+func (f *Conn) TerminateUserContext(ctx context.Context, uid uint32) error {
+	if f.TerminateUserContextFunc == nil {
+		return fmt.Errorf("%w: TerminateUserContext", ErrNotImplemented)
+	}
+	return f.TerminateUserContextFunc(ctx, uid)
+}
+
+func (f *Conn) Unlock(ctx context.Context, sessionPath dbus.ObjectPath) error {
+	if f.UnlockFunc == nil {
+		return fmt.Errorf("%w: Unlock", ErrNotImplemented)
+	}
+	return f.UnlockFunc(ctx, sessionPath)
+}
+
+func (f *Conn) WatchSessionProperties(ctx context.Context, sessionPath dbus.ObjectPath, names ...string) (<-chan login1.PropertiesUpdate, error) {
+	if f.WatchSessionPropertiesFunc == nil {
+		var r0 <-chan login1.PropertiesUpdate
+		return r0, fmt.Errorf("%w: WatchSessionProperties", ErrNotImplemented)
+	}
+	return f.WatchSessionPropertiesFunc(ctx, sessionPath, names...)
+}
+
+func (f *Conn) WatchSessions(ctx context.Context) (<-chan login1.SessionEvent, error) {
+	if f.WatchSessionsFunc == nil {
+		var r0 <-chan login1.SessionEvent
+		return r0, fmt.Errorf("%w: WatchSessions", ErrNotImplemented)
+	}
+	return f.WatchSessionsFunc(ctx)
+}
+
+func (f *Conn) WatchUserProperties(ctx context.Context, userPath dbus.ObjectPath, names ...string) (<-chan login1.PropertiesUpdate, error) {
+	if f.WatchUserPropertiesFunc == nil {
+		var r0 <-chan login1.PropertiesUpdate
+		return r0, fmt.Errorf("%w: WatchUserProperties", ErrNotImplemented)
+	}
+	return f.WatchUserPropertiesFunc(ctx, userPath, names...)
+}
+
+func (f *Conn) WatchUsers(ctx context.Context) (<-chan login1.UserEvent, error) {
+	if f.WatchUsersFunc == nil {
+		var r0 <-chan login1.UserEvent
+		return r0, fmt.Errorf("%w: WatchUsers", ErrNotImplemented)
+	}
+	return f.WatchUsersFunc(ctx)
+}
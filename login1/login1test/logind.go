@@ -0,0 +1,292 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+
+	sd_dbus "github.com/gr-butler/go-systemd/v22/dbus"
+	"github.com/gr-butler/go-systemd/v22/login1"
+)
+
+// Call is a method call recorded by a Fake. Args are the arguments of the
+// call, without the context.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Fake is an in-memory logind, for unit tests of code managing sessions and
+// power states. It serves the sessions and users added to it, applies
+// TerminateSession and TerminateUser to them, and records the calls of the
+// methods changing the state of the system, e.g. Reboot, Suspend and
+// ScheduleShutdown, so that tests can check them with Calls.
+//
+// The other methods behave as in Conn. The function fields of the embedded
+// Conn may be set to override the behavior of any method.
+type Fake struct {
+	*Conn
+
+	// Capability is returned by the CanReboot, CanSuspend and similar
+	// methods. NewFake sets it to login1.CapabilityYes.
+	Capability login1.Capability
+
+	mu       sync.Mutex
+	sessions []login1.Session
+	users    []login1.User
+	calls    []Call
+	// scheduled is whether a shutdown was scheduled and not canceled
+	scheduled bool
+}
+
+// NewFake returns a Fake without sessions and users.
+func NewFake() *Fake {
+	f := &Fake{Conn: new(Conn), Capability: login1.CapabilityYes}
+
+	f.ConnectedFunc = func() bool { return true }
+	f.CloseFunc = func() {}
+
+	f.ListSessionsFunc = f.listSessions
+	f.ListSessionsContextFunc = func(context.Context) ([]login1.Session, error) { return f.listSessions() }
+	f.ListUsersFunc = f.listUsers
+	f.ListUsersContextFunc = func(context.Context) ([]login1.User, error) { return f.listUsers() }
+	f.GetSessionFunc = f.getSession
+	f.GetSessionUserFunc = f.getSessionUser
+	f.GetUserContextFunc = func(_ context.Context, uid uint32) (dbus.ObjectPath, error) { return f.getUser(uid) }
+
+	f.TerminateSessionFunc = func(id string) { f.terminateSession(id) }
+	f.TerminateSessionContextFunc = func(_ context.Context, id string) error { return f.terminateSession(id) }
+	f.TerminateUserFunc = func(uid uint32) { f.terminateUser(uid) }
+	f.TerminateUserContextFunc = func(_ context.Context, uid uint32) error { return f.terminateUser(uid) }
+	f.LockSessionFunc = func(id string) { f.lockSession(id) }
+	f.LockSessionContextFunc = func(_ context.Context, id string) error { return f.lockSession(id) }
+	f.LockSessionsFunc = func() { f.record("LockSessions") }
+	f.LockSessionsContextFunc = func(context.Context) error { f.record("LockSessions"); return nil }
+
+	f.RebootFunc = func(askForAuth bool) { f.record("Reboot", askForAuth) }
+	f.RebootContextFunc = func(_ context.Context, askForAuth bool) error { f.record("Reboot", askForAuth); return nil }
+	f.PowerOffFunc = func(askForAuth bool) { f.record("PowerOff", askForAuth) }
+	f.PowerOffContextFunc = func(_ context.Context, askForAuth bool) error { f.record("PowerOff", askForAuth); return nil }
+	f.SuspendFunc = func(_ context.Context, askForAuth bool) error { f.record("Suspend", askForAuth); return nil }
+	f.HibernateFunc = func(_ context.Context, askForAuth bool) error { f.record("Hibernate", askForAuth); return nil }
+	f.HybridSleepFunc = func(_ context.Context, askForAuth bool) error { f.record("HybridSleep", askForAuth); return nil }
+	f.SuspendThenHibernateFunc = func(_ context.Context, askForAuth bool) error {
+		f.record("SuspendThenHibernate", askForAuth)
+		return nil
+	}
+	f.RebootWithFlagsContextFunc = func(_ context.Context, flags uint64) error { f.record("RebootWithFlags", flags); return nil }
+	f.SleepWithFlagsFunc = func(_ context.Context, flags uint64) error { f.record("SleepWithFlags", flags); return nil }
+	f.ScheduleShutdownFunc = f.scheduleShutdown
+	f.CancelScheduledShutdownFunc = f.cancelScheduledShutdown
+	f.SetWallMessageFunc = func(_ context.Context, message string, enable bool) error {
+		f.record("SetWallMessage", message, enable)
+		return nil
+	}
+	f.SetRebootParameterContextFunc = func(_ context.Context, param string) error {
+		f.record("SetRebootParameter", param)
+		return nil
+	}
+	f.SetRebootToFirmwareSetupFunc = func(_ context.Context, enable bool) error {
+		f.record("SetRebootToFirmwareSetup", enable)
+		return nil
+	}
+	f.SetRebootToBootLoaderEntryFunc = func(_ context.Context, entry string) error {
+		f.record("SetRebootToBootLoaderEntry", entry)
+		return nil
+	}
+
+	f.CanRebootFunc = f.capability
+	f.CanPowerOffFunc = f.capability
+	f.CanSuspendFunc = f.capability
+	f.CanHibernateFunc = f.capability
+	f.CanHybridSleepFunc = f.capability
+	f.CanSuspendThenHibernateFunc = f.capability
+	f.CanSleepFunc = f.capability
+	f.CanRebootParameterFunc = f.capability
+	f.CanRebootToFirmwareSetupFunc = f.capability
+	f.CanRebootToBootLoaderEntryFunc = f.capability
+
+	return f
+}
+
+// AddSession adds a session. If its path is empty, it is set to the path
+// logind uses for the session ID.
+func (f *Fake) AddSession(session login1.Session) {
+	if session.Path == "" {
+		session.Path = dbus.ObjectPath("/org/freedesktop/login1/session/" + sd_dbus.PathBusEscape(session.ID))
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sessions = append(f.sessions, session)
+}
+
+// AddUser adds a user. If its path is empty, it is set to the path logind
+// uses for the UID.
+func (f *Fake) AddUser(user login1.User) {
+	if user.Path == "" {
+		user.Path = dbus.ObjectPath(fmt.Sprintf("/org/freedesktop/login1/user/_%d", user.UID))
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.users = append(f.users, user)
+}
+
+// Calls returns the calls recorded so far, in order.
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+func (f *Fake) record(method string, args ...interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Method: method, Args: args})
+}
+
+func (f *Fake) capability(context.Context) (login1.Capability, error) {
+	return f.Capability, nil
+}
+
+func (f *Fake) scheduleShutdown(_ context.Context, kind string, usec uint64) error {
+	f.record("ScheduleShutdown", kind, usec)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.scheduled = true
+	return nil
+}
+
+func (f *Fake) cancelScheduledShutdown(context.Context) (bool, error) {
+	f.record("CancelScheduledShutdown")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	canceled := f.scheduled
+	f.scheduled = false
+	return canceled, nil
+}
+
+func (f *Fake) listSessions() ([]login1.Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]login1.Session{}, f.sessions...), nil
+}
+
+func (f *Fake) listUsers() ([]login1.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]login1.User{}, f.users...), nil
+}
+
+func (f *Fake) getSession(id string) (dbus.ObjectPath, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, s := range f.sessions {
+		if s.ID == id {
+			return s.Path, nil
+		}
+	}
+	return "", noSuchSession(id)
+}
+
+func (f *Fake) getSessionUser(sessionPath dbus.ObjectPath) (*login1.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, s := range f.sessions {
+		if s.Path != sessionPath {
+			continue
+		}
+		for _, u := range f.users {
+			if u.UID == s.UID {
+				user := u
+				return &user, nil
+			}
+		}
+		return &login1.User{UID: s.UID, Name: s.User}, nil
+	}
+	return nil, fmt.Errorf("unknown session path %s", sessionPath)
+}
+
+func (f *Fake) getUser(uid uint32) (dbus.ObjectPath, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, u := range f.users {
+		if u.UID == uid {
+			return u.Path, nil
+		}
+	}
+	return "", dbus.Error{
+		Name: "org.freedesktop.login1.NoSuchUser",
+		Body: []interface{}{fmt.Sprintf("User ID %d is not logged in or lingering", uid)},
+	}
+}
+
+func (f *Fake) lockSession(id string) error {
+	f.record("LockSession", id)
+	_, err := f.getSession(id)
+	return err
+}
+
+func (f *Fake) terminateSession(id string) error {
+	f.record("TerminateSession", id)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, s := range f.sessions {
+		if s.ID == id {
+			f.sessions = append(f.sessions[:i], f.sessions[i+1:]...)
+			return nil
+		}
+	}
+	return noSuchSession(id)
+}
+
+func (f *Fake) terminateUser(uid uint32) error {
+	f.record("TerminateUser", uid)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sessions := f.sessions[:0]
+	for _, s := range f.sessions {
+		if s.UID != uid {
+			sessions = append(sessions, s)
+		}
+	}
+	f.sessions = sessions
+	for i, u := range f.users {
+		if u.UID == uid {
+			f.users = append(f.users[:i], f.users[i+1:]...)
+			return nil
+		}
+	}
+	return dbus.Error{
+		Name: "org.freedesktop.login1.NoSuchUser",
+		Body: []interface{}{fmt.Sprintf("User ID %d is not logged in or lingering", uid)},
+	}
+}
+
+func noSuchSession(id string) error {
+	return dbus.Error{
+		Name: "org.freedesktop.login1.NoSuchSession",
+		Body: []interface{}{fmt.Sprintf("No session '%s' known", id)},
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/gr-butler/go-systemd/v22/login1"
+)
+
+var _ login1.Connection = NewFake()
+
+func TestFake(t *testing.T) {
+	ctx := context.Background()
+	f := NewFake()
+	f.AddUser(login1.User{UID: 1000, Name: "core"})
+	f.AddSession(login1.Session{ID: "1", UID: 1000, User: "core"})
+	f.AddSession(login1.Session{ID: "c2", UID: 0, User: "root"})
+
+	path, err := f.GetSession("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := dbus.ObjectPath("/org/freedesktop/login1/session/_31"); path != expected {
+		t.Errorf("expected path %s, got %s", expected, path)
+	}
+	user, err := f.GetSessionUser(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := dbus.ObjectPath("/org/freedesktop/login1/user/_1000"); user.Path != expected {
+		t.Errorf("expected user path %s, got %s", expected, user.Path)
+	}
+
+	if err := f.TerminateUserContext(ctx, 1000); err != nil {
+		t.Fatal(err)
+	}
+	sessions, err := f.ListSessionsContext(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != "c2" {
+		t.Errorf("expected only session c2 to remain, got %v", sessions)
+	}
+	if _, err := f.GetUserContext(ctx, 1000); err == nil {
+		t.Error("expected an error for a terminated user")
+	}
+
+	var dbusErr dbus.Error
+	if err := f.TerminateSessionContext(ctx, "1"); !errors.As(err, &dbusErr) || dbusErr.Name != "org.freedesktop.login1.NoSuchSession" {
+		t.Errorf("expected a NoSuchSession error, got %v", err)
+	}
+
+	if can, err := f.CanSuspend(ctx); err != nil || can != login1.CapabilityYes {
+		t.Errorf("expected %s, got %s, %v", login1.CapabilityYes, can, err)
+	}
+	if can, err := f.CanRebootToBootLoaderEntry(ctx); err != nil || can != login1.CapabilityYes {
+		t.Errorf("expected %s, got %s, %v", login1.CapabilityYes, can, err)
+	}
+	if err := f.Suspend(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	f.Reboot(true)
+
+	if err := f.ScheduleShutdown(ctx, "reboot", 1000); err != nil {
+		t.Fatal(err)
+	}
+	if canceled, err := f.CancelScheduledShutdown(ctx); err != nil || !canceled {
+		t.Errorf("expected the scheduled shutdown to be canceled, got %v, %v", canceled, err)
+	}
+	if canceled, err := f.CancelScheduledShutdown(ctx); err != nil || canceled {
+		t.Errorf("expected no scheduled shutdown to cancel, got %v, %v", canceled, err)
+	}
+
+	expected := []Call{
+		{"TerminateUser", []interface{}{uint32(1000)}},
+		{"TerminateSession", []interface{}{"1"}},
+		{"Suspend", []interface{}{false}},
+		{"Reboot", []interface{}{true}},
+		{"ScheduleShutdown", []interface{}{"reboot", uint64(1000)}},
+		{"CancelScheduledShutdown", nil},
+		{"CancelScheduledShutdown", nil},
+	}
+	if calls := f.Calls(); !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+
+	if _, _, err := f.GetScheduledShutdown(ctx); !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("expected ErrNotImplemented, got %v", err)
+	}
+}
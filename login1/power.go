@@ -0,0 +1,286 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Suspend asks logind to suspend the system, optionally asking for auth.
+func (c *Conn) Suspend(ctx context.Context, askForAuth bool) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".Suspend", 0, askForAuth).Err
+}
+
+// Hibernate asks logind to hibernate the system, optionally asking for auth.
+func (c *Conn) Hibernate(ctx context.Context, askForAuth bool) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".Hibernate", 0, askForAuth).Err
+}
+
+// HybridSleep asks logind to hibernate and suspend the system, optionally
+// asking for auth.
+func (c *Conn) HybridSleep(ctx context.Context, askForAuth bool) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".HybridSleep", 0, askForAuth).Err
+}
+
+// SuspendThenHibernate asks logind to suspend the system and hibernate it
+// after HibernateDelaySec= of logind.conf, optionally asking for auth.
+func (c *Conn) SuspendThenHibernate(ctx context.Context, askForAuth bool) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".SuspendThenHibernate", 0, askForAuth).Err
+}
+
+// Flags of RebootWithFlagsContext and SleepWithFlags, named after the
+// SD_LOGIND_* constants of logind.
+const (
+	// FlagRootCheckInhibitors makes the call fail for root as well if the
+	// action is inhibited, instead of ignoring the inhibitors.
+	FlagRootCheckInhibitors uint64 = 1 << iota
+	// FlagKexecReboot reboots into the kernel loaded for kexec, if any.
+	FlagKexecReboot
+	// FlagSoftReboot restarts the userspace only, keeping the kernel
+	// running. Requires systemd 254.
+	FlagSoftReboot
+	// FlagSoftRebootIfNextRootSetUp soft-reboots if /run/nextroot/ is set
+	// up, and reboots normally otherwise. Requires systemd 254.
+	FlagSoftRebootIfNextRootSetUp
+	// FlagSkipInhibitors ignores the inhibitors, if the caller is allowed to.
+	// Requires systemd 256.
+	FlagSkipInhibitors
+)
+
+// RebootWithFlagsContext asks logind to reboot the system, with flags such
+// as FlagSoftReboot. Unlike RebootContext, it doesn't ask for auth. Requires
+// systemd 246, FlagSoftReboot requiring systemd 254, see the flags.
+func (c *Conn) RebootWithFlagsContext(ctx context.Context, flags uint64) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".RebootWithFlags", 0, flags).Err
+}
+
+// Sleep operations, as listed by GetSleepOperation.
+const (
+	SleepSuspend              = "suspend"
+	SleepHibernate            = "hibernate"
+	SleepHybridSleep          = "hybrid-sleep"
+	SleepSuspendThenHibernate = "suspend-then-hibernate"
+)
+
+// SleepWithFlags asks logind to put the system to sleep with the first
+// operation supported of SleepOperation= of logind.conf, see
+// GetSleepOperation. flags may contain FlagRootCheckInhibitors and
+// FlagSkipInhibitors. This requires systemd 256; on older versions, the
+// system is suspended instead.
+func (c *Conn) SleepWithFlags(ctx context.Context, flags uint64) error {
+	err := c.object.CallWithContext(ctx, dbusManagerInterface+".Sleep", 0, flags).Err
+	if !isUnknownMethod(err) {
+		return err
+	}
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".SuspendWithFlags", 0, flags).Err
+}
+
+// GetSleepOperation returns the sleep operations SleepWithFlags tries, in
+// order, e.g. SleepSuspendThenHibernate and SleepSuspend. It returns
+// SleepSuspend alone on systemd versions older than 256, where
+// SleepWithFlags suspends.
+func (c *Conn) GetSleepOperation(ctx context.Context) ([]string, error) {
+	p, err := c.getProperty(ctx, dbusPath, dbusManagerInterface, "SleepOperation")
+	if isUnknownProperty(err) {
+		return []string{SleepSuspend}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	operations, ok := p.Value().([]string)
+	if !ok {
+		return nil, fmt.Errorf("failed to typecast SleepOperation to []string")
+	}
+	return operations, nil
+}
+
+// isUnknownMethod reports whether err was returned because the method is not
+// implemented by the running logind.
+func isUnknownMethod(err error) bool {
+	var dbusErr dbus.Error
+	return errors.As(err, &dbusErr) && dbusErr.Name == "org.freedesktop.DBus.Error.UnknownMethod"
+}
+
+// isUnknownProperty reports whether err was returned because the property is
+// not implemented by the running logind.
+func isUnknownProperty(err error) bool {
+	var dbusErr dbus.Error
+	return errors.As(err, &dbusErr) && dbusErr.Name == "org.freedesktop.DBus.Error.UnknownProperty"
+}
+
+// SetRebootParameterContext sets the parameter passed to the kernel on the
+// next reboot, e.g. to select a boot mode on some Android devices. An empty
+// param unsets it.
+func (c *Conn) SetRebootParameterContext(ctx context.Context, param string) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".SetRebootParameter", 0, param).Err
+}
+
+// SetRebootToFirmwareSetup sets whether the system boots into the firmware
+// setup on the next reboot, if the firmware supports it.
+func (c *Conn) SetRebootToFirmwareSetup(ctx context.Context, enable bool) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".SetRebootToFirmwareSetup", 0, enable).Err
+}
+
+// SetRebootToBootLoaderEntry sets the boot loader entry the system boots
+// into on the next reboot, if the boot loader implements the Boot Loader
+// Interface, like systemd-boot. An empty entry unsets it.
+func (c *Conn) SetRebootToBootLoaderEntry(ctx context.Context, entry string) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".SetRebootToBootLoaderEntry", 0, entry).Err
+}
+
+// Capability tells whether the caller may perform an action, as returned by
+// the Can methods.
+type Capability string
+
+const (
+	// CapabilityYes means the action is supported and allowed.
+	CapabilityYes Capability = "yes"
+	// CapabilityNo means the action is supported but not allowed.
+	CapabilityNo Capability = "no"
+	// CapabilityChallenge means the action is supported and allowed after
+	// authentication, which is asked for if askForAuth is set.
+	CapabilityChallenge Capability = "challenge"
+	// CapabilityNA means the action is not supported, e.g. as the hardware
+	// or the kernel lack support for it.
+	CapabilityNA Capability = "na"
+)
+
+func (c *Conn) can(ctx context.Context, method string) (Capability, error) {
+	var result string
+	err := c.object.CallWithContext(ctx, dbusManagerInterface+"."+method, 0).Store(&result)
+	return Capability(result), err
+}
+
+// CanReboot tells whether the caller may reboot the system.
+func (c *Conn) CanReboot(ctx context.Context) (Capability, error) {
+	return c.can(ctx, "CanReboot")
+}
+
+// CanPowerOff tells whether the caller may power off the system.
+func (c *Conn) CanPowerOff(ctx context.Context) (Capability, error) {
+	return c.can(ctx, "CanPowerOff")
+}
+
+// CanSuspend tells whether the caller may suspend the system.
+func (c *Conn) CanSuspend(ctx context.Context) (Capability, error) {
+	return c.can(ctx, "CanSuspend")
+}
+
+// CanHibernate tells whether the caller may hibernate the system.
+func (c *Conn) CanHibernate(ctx context.Context) (Capability, error) {
+	return c.can(ctx, "CanHibernate")
+}
+
+// CanHybridSleep tells whether the caller may hibernate and suspend the
+// system.
+func (c *Conn) CanHybridSleep(ctx context.Context) (Capability, error) {
+	return c.can(ctx, "CanHybridSleep")
+}
+
+// CanSuspendThenHibernate tells whether the caller may suspend the system and
+// hibernate it later.
+func (c *Conn) CanSuspendThenHibernate(ctx context.Context) (Capability, error) {
+	return c.can(ctx, "CanSuspendThenHibernate")
+}
+
+// CanSleep tells whether the caller may put the system to sleep with
+// SleepWithFlags. On systemd versions older than 256, it tells whether the
+// caller may suspend the system.
+func (c *Conn) CanSleep(ctx context.Context) (Capability, error) {
+	can, err := c.can(ctx, "CanSleep")
+	if !isUnknownMethod(err) {
+		return can, err
+	}
+	return c.can(ctx, "CanSuspend")
+}
+
+// CanRebootParameter tells whether the caller may set the reboot parameter.
+func (c *Conn) CanRebootParameter(ctx context.Context) (Capability, error) {
+	return c.can(ctx, "CanRebootParameter")
+}
+
+// CanRebootToFirmwareSetup tells whether the caller may make the system boot
+// into the firmware setup.
+func (c *Conn) CanRebootToFirmwareSetup(ctx context.Context) (Capability, error) {
+	return c.can(ctx, "CanRebootToFirmwareSetup")
+}
+
+// CanRebootToBootLoaderEntry tells whether the caller may select the boot
+// loader entry of the next boot.
+func (c *Conn) CanRebootToBootLoaderEntry(ctx context.Context) (Capability, error) {
+	return c.can(ctx, "CanRebootToBootLoaderEntry")
+}
+
+// Kinds of scheduled shutdowns. The dry kinds only log the shutdown and
+// notify the users, without shutting down.
+const (
+	ShutdownPowerOff    = "poweroff"
+	ShutdownReboot      = "reboot"
+	ShutdownHalt        = "halt"
+	ShutdownDryPowerOff = "dry-poweroff"
+	ShutdownDryReboot   = "dry-reboot"
+	ShutdownDryHalt     = "dry-halt"
+)
+
+// ScheduleShutdown schedules a shutdown of the given kind, e.g.
+// ShutdownReboot, at usec microseconds since the epoch, like shutdown(8).
+// It replaces any shutdown scheduled before.
+func (c *Conn) ScheduleShutdown(ctx context.Context, kind string, usec uint64) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".ScheduleShutdown", 0, kind, usec).Err
+}
+
+// CancelScheduledShutdown cancels the scheduled shutdown, reporting whether
+// there was one.
+func (c *Conn) CancelScheduledShutdown(ctx context.Context) (bool, error) {
+	var cancelled bool
+	err := c.object.CallWithContext(ctx, dbusManagerInterface+".CancelScheduledShutdown", 0).Store(&cancelled)
+	return cancelled, err
+}
+
+// GetScheduledShutdown returns the kind of the scheduled shutdown and when it
+// is scheduled, in microseconds since the epoch. The kind is empty if no
+// shutdown is scheduled.
+func (c *Conn) GetScheduledShutdown(ctx context.Context) (string, uint64, error) {
+	p, err := c.getProperty(ctx, dbusPath, dbusManagerInterface, "ScheduledShutdown")
+	if err != nil {
+		return "", 0, err
+	}
+	return scheduledShutdown(*p)
+}
+
+// scheduledShutdown converts the (st) ScheduledShutdown property.
+func scheduledShutdown(v dbus.Variant) (string, uint64, error) {
+	fields, ok := v.Value().([]interface{})
+	if !ok {
+		return "", 0, fmt.Errorf("failed to typecast ScheduledShutdown to []interface{}")
+	}
+	var kind string
+	var usec uint64
+	if err := dbus.Store(fields, &kind, &usec); err != nil {
+		return "", 0, fmt.Errorf("failed to convert ScheduledShutdown: %w", err)
+	}
+	return kind, usec, nil
+}
+
+// SetWallMessage sets the message broadcast to the logged-in users on
+// shutdown, like systemctl --message, and whether messages are broadcast at
+// all.
+func (c *Conn) SetWallMessage(ctx context.Context, message string, enable bool) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".SetWallMessage", 0, message, enable).Err
+}
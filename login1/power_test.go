@@ -0,0 +1,51 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestScheduledShutdown(t *testing.T) {
+	kind, usec, err := scheduledShutdown(dbus.MakeVariant([]interface{}{"reboot", uint64(1700000600000000)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != ShutdownReboot || usec != 1700000600000000 {
+		t.Errorf("expected reboot at 1700000600000000, got %s at %d", kind, usec)
+	}
+
+	if _, _, err := scheduledShutdown(dbus.MakeVariant("reboot")); err == nil {
+		t.Error("expected an error for an invalid property")
+	}
+}
+
+func TestIsUnknownMethod(t *testing.T) {
+	unknownMethod := dbus.Error{Name: "org.freedesktop.DBus.Error.UnknownMethod"}
+	unknownProperty := dbus.Error{Name: "org.freedesktop.DBus.Error.UnknownProperty"}
+
+	if !isUnknownMethod(fmt.Errorf("calling Sleep: %w", unknownMethod)) {
+		t.Error("expected a wrapped UnknownMethod error to be detected")
+	}
+	if isUnknownMethod(unknownProperty) || isUnknownMethod(nil) {
+		t.Error("expected only UnknownMethod errors to be detected")
+	}
+	if !isUnknownProperty(unknownProperty) || isUnknownProperty(unknownMethod) {
+		t.Error("expected only UnknownProperty errors to be detected")
+	}
+}
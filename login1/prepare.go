@@ -0,0 +1,149 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// PrepareOptions configures the delay inhibitor lock taken by
+// OnPrepareForSleep and OnPrepareForShutdown.
+type PrepareOptions struct {
+	Who string // Who takes the lock, e.g. the name of the application
+	Why string // Why the lock is taken
+
+	// OnReacquireError, if set, is called with the errors of taking the
+	// lock again after resume, or after a shutdown was cancelled.
+	OnReacquireError func(error)
+}
+
+// OnPrepareForSleep runs prepare before the system is suspended or
+// hibernated. It takes a sleep delay inhibitor lock, so that logind waits
+// for prepare to return, up to InhibitDelayMaxSec of logind.conf, before
+// suspending. The lock is released once prepare returns, and taken again
+// when the system resumes, so that prepare runs before every sleep until ctx
+// is done, when the lock is released.
+func (c *Conn) OnPrepareForSleep(ctx context.Context, opts PrepareOptions, prepare func(context.Context)) error {
+	return c.onPrepare(ctx, "sleep", "PrepareForSleep", opts, prepare)
+}
+
+// OnPrepareForShutdown is like OnPrepareForSleep, but runs prepare before
+// the system is shut down or rebooted. The lock is taken again if the
+// shutdown is cancelled.
+func (c *Conn) OnPrepareForShutdown(ctx context.Context, opts PrepareOptions, prepare func(context.Context)) error {
+	return c.onPrepare(ctx, "shutdown", "PrepareForShutdown", opts, prepare)
+}
+
+// delayLock is the delay inhibitor lock of onPrepare, nil while it is
+// released.
+type delayLock struct {
+	mu     sync.Mutex
+	file   *os.File
+	closed bool
+}
+
+// set replaces the file of the lock, closing the previous one. Once the lock
+// is closed, file is closed right away instead.
+func (l *delayLock) set(file *os.File) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file != nil {
+		l.file.Close()
+	}
+	l.file = nil
+	if l.closed {
+		if file != nil {
+			file.Close()
+		}
+		return
+	}
+	l.file = file
+}
+
+// close releases the lock for good, so that a lock taken after the watcher
+// finished is not leaked.
+func (l *delayLock) close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file != nil {
+		l.file.Close()
+	}
+	l.file = nil
+	l.closed = true
+}
+
+// onPrepare runs prepare on the member signal with a true argument, holding
+// a delay lock on what until then, and takes the lock again on the signal
+// with a false argument.
+func (c *Conn) onPrepare(ctx context.Context, what, member string, opts PrepareOptions, prepare func(context.Context)) error {
+	lock := new(delayLock)
+	acquire := func() error {
+		file, err := c.inhibit(context.Background(), what, opts.Who, opts.Why, "delay")
+		if err != nil {
+			return err
+		}
+		lock.set(file)
+		return nil
+	}
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	// the signals are watched before the lock is taken, so that none is
+	// missed while holding it
+	err := c.watchSignals(watchCtx, []string{member}, func(signal *dbus.Signal) {
+		start, ok := prepareSignal(signal, member)
+		if !ok {
+			return
+		}
+		if start {
+			prepare(ctx)
+			lock.set(nil)
+			return
+		}
+		if err := acquire(); err != nil && opts.OnReacquireError != nil {
+			opts.OnReacquireError(err)
+		}
+	}, func() {
+		lock.close()
+		cancel()
+	})
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	if err := acquire(); err != nil {
+		cancel()
+		return err
+	}
+	return nil
+}
+
+// prepareSignal returns the boolean argument of a PrepareForSleep or
+// PrepareForShutdown signal, true before the operation and false after
+// resume or cancellation.
+func prepareSignal(signal *dbus.Signal, member string) (bool, bool) {
+	if signal.Path != dbusPath || signal.Name != dbusManagerInterface+"."+member {
+		return false, false
+	}
+	var start bool
+	if dbus.Store(signal.Body, &start) != nil {
+		return false, false
+	}
+	return start, true
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestPrepareSignal(t *testing.T) {
+	tests := []struct {
+		signal *dbus.Signal
+		start  bool
+		ok     bool
+	}{
+		{&dbus.Signal{Path: dbusPath, Name: dbusManagerInterface + ".PrepareForSleep", Body: []interface{}{true}}, true, true},
+		{&dbus.Signal{Path: dbusPath, Name: dbusManagerInterface + ".PrepareForSleep", Body: []interface{}{false}}, false, true},
+		{&dbus.Signal{Path: dbusPath, Name: dbusManagerInterface + ".PrepareForShutdown", Body: []interface{}{true}}, false, false},
+		{&dbus.Signal{Path: "/", Name: dbusManagerInterface + ".PrepareForSleep", Body: []interface{}{true}}, false, false},
+		{&dbus.Signal{Path: dbusPath, Name: dbusManagerInterface + ".PrepareForSleep", Body: []interface{}{"true"}}, false, false},
+	}
+
+	for _, tt := range tests {
+		start, ok := prepareSignal(tt.signal, "PrepareForSleep")
+		if start != tt.start || ok != tt.ok {
+			t.Errorf("prepareSignal(%s %v): expected %v, %v, got %v, %v", tt.signal.Name, tt.signal.Body, tt.start, tt.ok, start, ok)
+		}
+	}
+}
+
+func TestDelayLockClose(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	// the watcher may finish before the first lock is taken
+	l := new(delayLock)
+	l.close()
+	l.set(w)
+	if l.file != nil {
+		t.Error("expected no lock to be held after close")
+	}
+
+	// the write end is closed, so reads return EOF
+	if n, err := r.Read(make([]byte, 1)); n != 0 || err == nil {
+		t.Errorf("expected EOF, got %d, %v", n, err)
+	}
+}
+
+func TestOnPrepareForSleepCancelled(t *testing.T) {
+	c, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	who := "go-systemd-test-cancelled"
+	c.OnPrepareForSleep(ctx, PrepareOptions{Who: who, Why: "testing"}, func(context.Context) {})
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		inhibitors, err := c.ListInhibitorsContext(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		held := false
+		for _, i := range inhibitors {
+			if i.Who == who {
+				held = true
+			}
+		}
+		if !held {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the delay lock is still held after ctx was cancelled")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"context"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// PropertiesUpdate reports properties of a session or user object which
+// changed. Changed holds the new values. Invalidated lists the properties
+// which changed without their new value being sent; they have to be fetched
+// if needed.
+type PropertiesUpdate struct {
+	Path        dbus.ObjectPath
+	Changed     map[string]dbus.Variant
+	Invalidated []string
+}
+
+// WatchSessionProperties delivers the changes of the properties of the
+// session at sessionPath, e.g. Active or LockedHint, on the returned channel,
+// until ctx is done, when the channel is closed. If names are given, only
+// the changes of these properties are delivered.
+func (c *Conn) WatchSessionProperties(ctx context.Context, sessionPath dbus.ObjectPath, names ...string) (<-chan PropertiesUpdate, error) {
+	return c.watchProperties(ctx, sessionPath, dbusSessionInterface, names)
+}
+
+// WatchUserProperties is like WatchSessionProperties, but for the user at
+// userPath.
+func (c *Conn) WatchUserProperties(ctx context.Context, userPath dbus.ObjectPath, names ...string) (<-chan PropertiesUpdate, error) {
+	return c.watchProperties(ctx, userPath, dbusUserInterface, names)
+}
+
+func (c *Conn) watchProperties(ctx context.Context, path dbus.ObjectPath, iface string, names []string) (<-chan PropertiesUpdate, error) {
+	match := []dbus.MatchOption{
+		dbus.WithMatchObjectPath(path),
+		dbus.WithMatchInterface("org.freedesktop.DBus.Properties"),
+		dbus.WithMatchMember("PropertiesChanged"),
+		dbus.WithMatchArg(0, iface),
+	}
+
+	out := make(chan PropertiesUpdate, signalBuffer)
+	err := c.watchMatches(ctx, [][]dbus.MatchOption{match}, func(signal *dbus.Signal) {
+		if update, ok := propertiesUpdate(signal, path, iface, names); ok {
+			select {
+			case out <- update:
+			case <-ctx.Done():
+			}
+		}
+	}, func() { close(out) })
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// propertiesUpdate converts a PropertiesChanged signal of the iface
+// interface of the object at path, with a body of the interface, the changed
+// properties and the invalidated ones. If names are given, the other
+// properties are left out, and false is returned if none is left.
+func propertiesUpdate(signal *dbus.Signal, path dbus.ObjectPath, iface string, names []string) (PropertiesUpdate, bool) {
+	update := PropertiesUpdate{Path: path}
+	if signal.Path != path || signal.Name != "org.freedesktop.DBus.Properties.PropertiesChanged" {
+		return update, false
+	}
+	var signalIface string
+	if dbus.Store(signal.Body, &signalIface, &update.Changed, &update.Invalidated) != nil || signalIface != iface {
+		return update, false
+	}
+	if len(names) == 0 {
+		return update, true
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+	for name := range update.Changed {
+		if !wanted[name] {
+			delete(update.Changed, name)
+		}
+	}
+	invalidated := update.Invalidated[:0]
+	for _, name := range update.Invalidated {
+		if wanted[name] {
+			invalidated = append(invalidated, name)
+		}
+	}
+	update.Invalidated = invalidated
+
+	return update, len(update.Changed) > 0 || len(update.Invalidated) > 0
+}
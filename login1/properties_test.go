@@ -0,0 +1,73 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestPropertiesUpdate(t *testing.T) {
+	path := dbus.ObjectPath("/org/freedesktop/login1/session/_31")
+	signal := func() *dbus.Signal {
+		return &dbus.Signal{
+			Path: path,
+			Name: "org.freedesktop.DBus.Properties.PropertiesChanged",
+			Body: []interface{}{
+				dbusSessionInterface,
+				map[string]dbus.Variant{"Active": dbus.MakeVariant(false), "IdleHint": dbus.MakeVariant(true)},
+				[]string{"LockedHint"},
+			},
+		}
+	}
+
+	update, ok := propertiesUpdate(signal(), path, dbusSessionInterface, nil)
+	if !ok {
+		t.Fatal("expected the signal to be converted")
+	}
+	expected := PropertiesUpdate{
+		Path:        path,
+		Changed:     map[string]dbus.Variant{"Active": dbus.MakeVariant(false), "IdleHint": dbus.MakeVariant(true)},
+		Invalidated: []string{"LockedHint"},
+	}
+	if !reflect.DeepEqual(update, expected) {
+		t.Errorf("expected %+v, got %+v", expected, update)
+	}
+
+	update, ok = propertiesUpdate(signal(), path, dbusSessionInterface, []string{"Active"})
+	if !ok {
+		t.Fatal("expected the signal to be converted")
+	}
+	expected = PropertiesUpdate{
+		Path:        path,
+		Changed:     map[string]dbus.Variant{"Active": dbus.MakeVariant(false)},
+		Invalidated: []string{},
+	}
+	if !reflect.DeepEqual(update, expected) {
+		t.Errorf("expected %+v, got %+v", expected, update)
+	}
+
+	if _, ok := propertiesUpdate(signal(), path, dbusSessionInterface, []string{"State"}); ok {
+		t.Error("expected no update without changes of the given properties")
+	}
+	if _, ok := propertiesUpdate(signal(), path, dbusUserInterface, nil); ok {
+		t.Error("expected no update for another interface")
+	}
+	if _, ok := propertiesUpdate(signal(), "/org/freedesktop/login1/session/_32", dbusSessionInterface, nil); ok {
+		t.Error("expected no update for another object")
+	}
+}
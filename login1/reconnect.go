@@ -0,0 +1,285 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/gr-butler/go-systemd/v22/instrument"
+)
+
+const (
+	defaultMinBackoff = 100 * time.Millisecond
+	defaultMaxBackoff = 30 * time.Second
+)
+
+// ConnState is the state of the connection of a ResilientConn.
+type ConnState int
+
+const (
+	// StateConnected is reported when a lost connection was re-established.
+	StateConnected ConnState = iota
+	// StateDisconnected is reported when the connection was lost. Method
+	// calls fail until StateConnected is reported.
+	StateDisconnected
+)
+
+func (s ConnState) String() string {
+	switch s {
+	case StateConnected:
+		return "connected"
+	case StateDisconnected:
+		return "disconnected"
+	}
+	return "unknown"
+}
+
+// ReconnectOptions configures a ResilientConn.
+type ReconnectOptions struct {
+	// Dial establishes a new connection. It defaults to New.
+	Dial func() (*Conn, error)
+	// MinBackoff and MaxBackoff bound the exponential delay between
+	// reconnection attempts.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// OnStateChange, if set, is called when the connection is lost and when
+	// it is re-established.
+	OnStateChange func(ConnState)
+	// Hook and Timeout, if set, are set on every connection established,
+	// see Conn.SetHook and Conn.SetTimeout.
+	Hook    instrument.Hook
+	Timeout time.Duration
+}
+
+// configure sets the hook and the timeout of the options on conn.
+func (opts *ReconnectOptions) configure(conn *Conn) {
+	if opts.Hook != nil {
+		conn.SetHook(opts.Hook)
+	}
+	if opts.Timeout > 0 {
+		conn.SetTimeout(opts.Timeout)
+	}
+}
+
+// ResilientConn wraps a Conn and transparently re-establishes it when the
+// connection to the bus is lost, e.g. because dbus-daemon or dbus-broker was
+// restarted. After reconnecting it re-adds the signal matches of the
+// subscriptions made through Subscribe.
+type ResilientConn struct {
+	opts ReconnectOptions
+
+	mu   sync.Mutex
+	conn *Conn
+	subs []*subscription
+	// connected is false from the loss of conn until a new connection is
+	// established.
+	connected bool
+
+	forwarders sync.WaitGroup
+	done       chan struct{}
+	once       sync.Once
+}
+
+// subscription is a Subscribe call of a ResilientConn, restored after every
+// reconnect.
+type subscription struct {
+	members []string
+	ch      chan *dbus.Signal
+}
+
+// NewResilientConnection establishes a connection using opts.Dial and keeps
+// it alive until Close is called. Callers should call Close() when done with
+// the connection.
+func NewResilientConnection(opts ReconnectOptions) (*ResilientConn, error) {
+	if opts.Dial == nil {
+		opts.Dial = New
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = defaultMinBackoff
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = defaultMaxBackoff
+	}
+
+	conn, err := opts.Dial()
+	if err != nil {
+		return nil, err
+	}
+	opts.configure(conn)
+
+	r := &ResilientConn{
+		opts:      opts,
+		conn:      conn,
+		connected: true,
+		done:      make(chan struct{}),
+	}
+	go r.monitor(conn)
+
+	return r, nil
+}
+
+// Conn returns the current underlying connection. The returned connection
+// may be replaced after a reconnect, so it should not be cached. The
+// subscriptions made directly on it, e.g. with SubscribeContext or
+// WatchSessions, end when the connection is lost and are not restored; use
+// Subscribe to keep receiving signals across reconnects.
+func (r *ResilientConn) Conn() *Conn {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.conn
+}
+
+// Close closes the underlying connection and stops reconnecting. The channels
+// returned by Subscribe are closed.
+func (r *ResilientConn) Close() {
+	r.once.Do(func() {
+		close(r.done)
+		r.mu.Lock()
+		r.conn.Close()
+		r.mu.Unlock()
+
+		r.forwarders.Wait()
+		for _, sub := range r.subs {
+			close(sub.ch)
+		}
+	})
+}
+
+// Subscribe is like Conn.Subscribe, but the returned channel keeps receiving
+// the signals after reconnects, until Close is called.
+func (r *ResilientConn) Subscribe(members ...string) chan *dbus.Signal {
+	sub := &subscription{
+		members: members,
+		ch:      make(chan *dbus.Signal, 10),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	select {
+	case <-r.done:
+		close(sub.ch)
+		return sub.ch
+	default:
+	}
+
+	r.subs = append(r.subs, sub)
+	// while disconnected the subscription is attached by redial, as signals
+	// can't be added to a closed connection
+	if r.connected && r.conn.conn.Context().Err() == nil {
+		r.forward(r.conn, sub)
+	}
+	return sub.ch
+}
+
+// forward subscribes conn to the signals of sub and forwards them to the
+// channel of sub until conn is closed. r.mu must be held.
+func (r *ResilientConn) forward(conn *Conn, sub *subscription) {
+	ch := conn.Subscribe(sub.members...)
+
+	r.forwarders.Add(1)
+	go func() {
+		defer r.forwarders.Done()
+		for {
+			var signal *dbus.Signal
+			select {
+			case s, ok := <-ch:
+				// ch is closed along with conn
+				if !ok {
+					return
+				}
+				signal = s
+			case <-r.done:
+				return
+			}
+
+			select {
+			case sub.ch <- signal:
+			case <-r.done:
+				return
+			}
+		}
+	}()
+}
+
+// monitor waits for conn to be lost and re-establishes the connection.
+func (r *ResilientConn) monitor(conn *Conn) {
+	select {
+	case <-r.done:
+		return
+	case <-conn.conn.Context().Done():
+	}
+
+	r.mu.Lock()
+	r.connected = false
+	r.mu.Unlock()
+
+	r.notify(StateDisconnected)
+	conn.Close()
+	r.redial()
+}
+
+// redial establishes a new connection with exponential backoff and hands
+// over monitoring to it.
+func (r *ResilientConn) redial() {
+	backoff := r.opts.MinBackoff
+
+	for {
+		conn, err := r.opts.Dial()
+		if err == nil {
+			r.opts.configure(conn)
+			r.mu.Lock()
+			select {
+			case <-r.done:
+				r.mu.Unlock()
+				conn.Close()
+				return
+			default:
+			}
+			r.conn = conn
+			r.connected = true
+			for _, sub := range r.subs {
+				r.forward(conn, sub)
+			}
+			r.mu.Unlock()
+
+			go r.monitor(conn)
+			r.notify(StateConnected)
+			return
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-r.done:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if backoff > r.opts.MaxBackoff {
+			backoff = r.opts.MaxBackoff
+		}
+	}
+}
+
+func (r *ResilientConn) notify(state ConnState) {
+	if r.opts.OnStateChange != nil {
+		r.opts.OnStateChange(state)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// Ensure that a ResilientConn re-establishes a lost connection.
+func TestResilientConnReconnect(t *testing.T) {
+	states := make(chan ConnState, 2)
+
+	r, err := NewResilientConnection(ReconnectOptions{
+		OnStateChange: func(state ConnState) { states <- state },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	signals := r.Subscribe("SessionNew")
+
+	old := r.Conn()
+	old.conn.Close()
+
+	for _, expected := range []ConnState{StateDisconnected, StateConnected} {
+		select {
+		case state := <-states:
+			if state != expected {
+				t.Fatalf("expected state %s, got %s", expected, state)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for state %s", expected)
+		}
+	}
+
+	if r.Conn() == old {
+		t.Fatal("connection was not replaced")
+	}
+	if _, err := r.Conn().ListSessions(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the subscription channel is closed by Close
+	r.Close()
+	for range signals {
+	}
+}
+
+// Ensure that a subscription made while disconnected doesn't block Close.
+func TestResilientConnSubscribeDisconnected(t *testing.T) {
+	states := make(chan ConnState, 1)
+	dialed := false
+
+	r, err := NewResilientConnection(ReconnectOptions{
+		Dial: func() (*Conn, error) {
+			if dialed {
+				return nil, errors.New("unavailable")
+			}
+			dialed = true
+			return New()
+		},
+		OnStateChange: func(state ConnState) { states <- state },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r.Conn().conn.Close()
+	select {
+	case <-states:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the connection to be lost")
+	}
+
+	signals := r.Subscribe("SessionNew")
+
+	closed := make(chan struct{})
+	go func() {
+		r.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for Close")
+	}
+	for range signals {
+	}
+}
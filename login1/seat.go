@@ -0,0 +1,105 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const dbusSeatInterface = "org.freedesktop.login1.Seat"
+
+// Seat is a seat of logind.
+type Seat struct {
+	ID   string
+	Path dbus.ObjectPath
+}
+
+// ListSeatsContext returns the seats.
+func (c *Conn) ListSeatsContext(ctx context.Context) ([]Seat, error) {
+	var out [][]interface{}
+	if err := c.object.CallWithContext(ctx, dbusManagerInterface+".ListSeats", 0).Store(&out); err != nil {
+		return nil, err
+	}
+
+	ret := []Seat{}
+	for _, el := range out {
+		seat, err := seatFromInterfaces(el)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, *seat)
+	}
+	return ret, nil
+}
+
+func seatFromInterfaces(seat []interface{}) (*Seat, error) {
+	var ret Seat
+	if err := dbus.Store(seat, &ret.ID, &ret.Path); err != nil {
+		return nil, fmt.Errorf("failed to convert seat: %w", err)
+	}
+	return &ret, nil
+}
+
+// GetSeatContext returns the object path of the seat with the specified ID.
+func (c *Conn) GetSeatContext(ctx context.Context, id string) (dbus.ObjectPath, error) {
+	var path dbus.ObjectPath
+	err := c.object.CallWithContext(ctx, dbusManagerInterface+".GetSeat", 0, id).Store(&path)
+	return path, err
+}
+
+// ActivateSession activates the session on the seat, switching to its
+// virtual terminal if it has one.
+func (c *Conn) ActivateSession(ctx context.Context, seat, session string) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".ActivateSessionOnSeat", 0, session, seat).Err
+}
+
+// SwitchTo switches the seat to the virtual terminal vtnr.
+func (c *Conn) SwitchTo(ctx context.Context, seat string, vtnr uint32) error {
+	path, err := c.GetSeatContext(ctx, seat)
+	if err != nil {
+		return err
+	}
+	return c.pathObject(path).CallWithContext(ctx, dbusSeatInterface+".SwitchTo", 0, vtnr).Err
+}
+
+// CanMultiSession tells whether the seat supports multiple sessions, which
+// is the case if it has virtual terminals.
+func (c *Conn) CanMultiSession(ctx context.Context, seat string) (bool, error) {
+	return c.seatBoolProperty(ctx, seat, "CanMultiSession")
+}
+
+// CanGraphical tells whether the seat is suitable for graphical sessions.
+func (c *Conn) CanGraphical(ctx context.Context, seat string) (bool, error) {
+	return c.seatBoolProperty(ctx, seat, "CanGraphical")
+}
+
+func (c *Conn) seatBoolProperty(ctx context.Context, seat, property string) (bool, error) {
+	path, err := c.GetSeatContext(ctx, seat)
+	if err != nil {
+		return false, err
+	}
+	v, err := c.getProperty(ctx, path, dbusSeatInterface, property)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("failed to typecast %s to bool", property)
+	}
+	return b, nil
+}
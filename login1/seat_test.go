@@ -0,0 +1,35 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestSeatFromInterfaces(t *testing.T) {
+	seat, err := seatFromInterfaces([]interface{}{"seat0", dbus.ObjectPath("/org/freedesktop/login1/seat/seat0")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (Seat{ID: "seat0", Path: "/org/freedesktop/login1/seat/seat0"}); *seat != expected {
+		t.Errorf("expected %+v, got %+v", expected, *seat)
+	}
+
+	if _, err := seatFromInterfaces([]interface{}{"seat0"}); err == nil {
+		t.Error("expected an error for a missing path")
+	}
+}
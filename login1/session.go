@@ -0,0 +1,261 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// SessionProperties are the properties of a session, see
+// https://www.freedesktop.org/software/systemd/man/org.freedesktop.login1.html#Session%20Objects
+type SessionProperties struct {
+	ID       string
+	UID      uint32          // The user of the session
+	UserPath dbus.ObjectPath // The object path of the user
+	Name     string          // The name of the user
+
+	Timestamp time.Time // When the session was created
+	VTNr      uint32    // The virtual terminal number, 0 if none
+	Seat      string    // Empty if the session is not attached to a seat
+	SeatPath  dbus.ObjectPath
+	TTY       string
+	Display   string // The X11 display, if any
+
+	Remote     bool
+	RemoteHost string
+	RemoteUser string
+	Service    string // The PAM service which registered the session
+	Desktop    string
+	Scope      string // The scope unit of the session
+	Leader     uint32 // The PID of the process which registered the session
+	Audit      uint32 // The audit session ID
+
+	Type   string // e.g. tty, x11 or wayland
+	Class  string // e.g. user or greeter
+	Active bool
+	State  string // online, active or closing
+
+	IdleHint      bool
+	IdleSinceHint time.Time // When the session became idle, if it did
+	// IdleSinceHintMonotonic is IdleSinceHint on the CLOCK_MONOTONIC
+	// clock, i.e. the time since boot.
+	IdleSinceHintMonotonic time.Duration
+	LockedHint             bool
+}
+
+// GetSessionTyped returns the properties of the session at sessionPath.
+func (c *Conn) GetSessionTyped(ctx context.Context, sessionPath dbus.ObjectPath) (*SessionProperties, error) {
+	props, err := c.GetSessionPropertiesContext(ctx, sessionPath)
+	if err != nil {
+		return nil, err
+	}
+	return sessionPropertiesFromVariants(props)
+}
+
+// SessionInfo is a summary of the properties of a session, for session
+// monitors.
+type SessionInfo struct {
+	User       User
+	Display    string // The X11 display, if any
+	TTY        string
+	RemoteHost string
+	Seat       string // Empty if the session is not attached to a seat
+	State      string // online, active or closing
+}
+
+// GetSessionInfo returns a summary of the session at sessionPath. Unlike
+// GetSessionUser and GetSessionDisplay, it fetches all the properties in a
+// single call.
+func (c *Conn) GetSessionInfo(ctx context.Context, sessionPath dbus.ObjectPath) (*SessionInfo, error) {
+	s, err := c.GetSessionTyped(ctx, sessionPath)
+	if err != nil {
+		return nil, err
+	}
+	return s.info(), nil
+}
+
+func (s *SessionProperties) info() *SessionInfo {
+	return &SessionInfo{
+		User:       User{UID: s.UID, Name: s.Name, Path: s.UserPath},
+		Display:    s.Display,
+		TTY:        s.TTY,
+		RemoteHost: s.RemoteHost,
+		Seat:       s.Seat,
+		State:      s.State,
+	}
+}
+
+func sessionPropertiesFromVariants(props map[string]dbus.Variant) (*SessionProperties, error) {
+	str := func(name string) string {
+		s, _ := props[name].Value().(string)
+		return s
+	}
+	u32 := func(name string) uint32 {
+		u, _ := props[name].Value().(uint32)
+		return u
+	}
+	boolean := func(name string) bool {
+		b, _ := props[name].Value().(bool)
+		return b
+	}
+	usec := func(name string) time.Time {
+		u, _ := props[name].Value().(uint64)
+		return usecTime(u)
+	}
+
+	s := &SessionProperties{
+		ID:            str("Id"),
+		Name:          str("Name"),
+		Timestamp:     usec("Timestamp"),
+		VTNr:          u32("VTNr"),
+		TTY:           str("TTY"),
+		Display:       str("Display"),
+		Remote:        boolean("Remote"),
+		RemoteHost:    str("RemoteHost"),
+		RemoteUser:    str("RemoteUser"),
+		Service:       str("Service"),
+		Desktop:       str("Desktop"),
+		Scope:         str("Scope"),
+		Leader:        u32("Leader"),
+		Audit:         u32("Audit"),
+		Type:          str("Type"),
+		Class:         str("Class"),
+		Active:        boolean("Active"),
+		State:         str("State"),
+		IdleHint:      boolean("IdleHint"),
+		IdleSinceHint: usec("IdleSinceHint"),
+		LockedHint:    boolean("LockedHint"),
+	}
+	monotonic, _ := props["IdleSinceHintMonotonic"].Value().(uint64)
+	s.IdleSinceHintMonotonic = time.Duration(monotonic) * time.Microsecond
+
+	// User is a (uo) and Seat a (so) struct
+	if err := storeStruct(props, "User", &s.UID, &s.UserPath); err != nil {
+		return nil, err
+	}
+	if err := storeStruct(props, "Seat", &s.Seat, &s.SeatPath); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// SetIdleHint reports whether the session at sessionPath is idle. It may only
+// be called by the owner of the session, e.g. by its screen locker.
+func (c *Conn) SetIdleHint(ctx context.Context, sessionPath dbus.ObjectPath, idle bool) error {
+	return c.sessionCall(ctx, sessionPath, "SetIdleHint", idle)
+}
+
+// GetIdleHint reports whether the session at sessionPath is idle.
+func (c *Conn) GetIdleHint(ctx context.Context, sessionPath dbus.ObjectPath) (bool, error) {
+	var idle bool
+	err := c.storeSessionProperty(ctx, sessionPath, "IdleHint", &idle)
+	return idle, err
+}
+
+// GetIdleSinceHint returns when the session at sessionPath became idle, or
+// the zero time if it isn't idle.
+func (c *Conn) GetIdleSinceHint(ctx context.Context, sessionPath dbus.ObjectPath) (time.Time, error) {
+	var usec uint64
+	err := c.storeSessionProperty(ctx, sessionPath, "IdleSinceHint", &usec)
+	return usecTime(usec), err
+}
+
+// GetIdleSinceHintMonotonic is like GetIdleSinceHint, but returns the time
+// on the CLOCK_MONOTONIC clock, i.e. since boot, and 0 if the session isn't
+// idle.
+func (c *Conn) GetIdleSinceHintMonotonic(ctx context.Context, sessionPath dbus.ObjectPath) (time.Duration, error) {
+	var usec uint64
+	err := c.storeSessionProperty(ctx, sessionPath, "IdleSinceHintMonotonic", &usec)
+	return time.Duration(usec) * time.Microsecond, err
+}
+
+// Lock asks the session at sessionPath to lock its screen, like LockSession
+// but by object path. logind only emits the Lock signal of the session, it is
+// up to the screen locker of the session to act on it and report the result
+// with SetLockedHint.
+func (c *Conn) Lock(ctx context.Context, sessionPath dbus.ObjectPath) error {
+	return c.sessionCall(ctx, sessionPath, "Lock")
+}
+
+// Unlock asks the session at sessionPath to unlock its screen, see Lock.
+func (c *Conn) Unlock(ctx context.Context, sessionPath dbus.ObjectPath) error {
+	return c.sessionCall(ctx, sessionPath, "Unlock")
+}
+
+// SetLockedHint reports whether the screen of the session at sessionPath is
+// locked. It may only be called by the owner of the session.
+func (c *Conn) SetLockedHint(ctx context.Context, sessionPath dbus.ObjectPath, locked bool) error {
+	return c.sessionCall(ctx, sessionPath, "SetLockedHint", locked)
+}
+
+// GetLockedHint reports whether the screen of the session at sessionPath is
+// locked, as reported by its screen locker.
+func (c *Conn) GetLockedHint(ctx context.Context, sessionPath dbus.ObjectPath) (bool, error) {
+	var locked bool
+	err := c.storeSessionProperty(ctx, sessionPath, "LockedHint", &locked)
+	return locked, err
+}
+
+// sessionCall calls a method of the session object at sessionPath.
+func (c *Conn) sessionCall(ctx context.Context, sessionPath dbus.ObjectPath, method string, args ...interface{}) error {
+	if !sessionPath.IsValid() {
+		return fmt.Errorf("invalid object path (%s)", sessionPath)
+	}
+	return c.pathObject(sessionPath).CallWithContext(ctx, dbusSessionInterface+"."+method, 0, args...).Err
+}
+
+// storeSessionProperty stores the property of the session at sessionPath
+// into dest.
+func (c *Conn) storeSessionProperty(ctx context.Context, sessionPath dbus.ObjectPath, property string, dest interface{}) error {
+	v, err := c.GetSessionPropertyContext(ctx, sessionPath, property)
+	if err != nil {
+		return err
+	}
+	if err := dbus.Store([]interface{}{v.Value()}, dest); err != nil {
+		return fmt.Errorf("failed to convert %s: %w", property, err)
+	}
+	return nil
+}
+
+// storeStruct stores the fields of the struct property name into dest. A
+// missing property leaves dest untouched.
+func storeStruct(props map[string]dbus.Variant, name string, dest ...interface{}) error {
+	v, ok := props[name]
+	if !ok {
+		return nil
+	}
+	fields, ok := v.Value().([]interface{})
+	if !ok {
+		return fmt.Errorf("failed to typecast %s to []interface{}", name)
+	}
+	if err := dbus.Store(fields, dest...); err != nil {
+		return fmt.Errorf("failed to convert %s: %w", name, err)
+	}
+	return nil
+}
+
+// usecTime converts microseconds since the epoch to a time, which is zero for
+// 0.
+func usecTime(usec uint64) time.Time {
+	if usec == 0 {
+		return time.Time{}
+	}
+	return time.UnixMicro(int64(usec))
+}
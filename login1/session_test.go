@@ -0,0 +1,91 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestSessionPropertiesFromVariants(t *testing.T) {
+	props := map[string]dbus.Variant{
+		"Id":            dbus.MakeVariant("2"),
+		"User":          dbus.MakeVariant([]interface{}{uint32(1000), dbus.ObjectPath("/org/freedesktop/login1/user/_1000")}),
+		"Name":          dbus.MakeVariant("core"),
+		"Timestamp":     dbus.MakeVariant(uint64(1700000000000000)),
+		"VTNr":          dbus.MakeVariant(uint32(1)),
+		"Seat":          dbus.MakeVariant([]interface{}{"seat0", dbus.ObjectPath("/org/freedesktop/login1/seat/seat0")}),
+		"TTY":           dbus.MakeVariant("tty1"),
+		"Remote":        dbus.MakeVariant(false),
+		"Service":       dbus.MakeVariant("login"),
+		"Scope":         dbus.MakeVariant("session-2.scope"),
+		"Leader":        dbus.MakeVariant(uint32(812)),
+		"Audit":         dbus.MakeVariant(uint32(2)),
+		"Type":          dbus.MakeVariant("tty"),
+		"Class":         dbus.MakeVariant("user"),
+		"Active":        dbus.MakeVariant(true),
+		"State":         dbus.MakeVariant("active"),
+		"IdleHint":      dbus.MakeVariant(true),
+		"IdleSinceHint": dbus.MakeVariant(uint64(1700000060000000)),
+		"LockedHint":    dbus.MakeVariant(false),
+	}
+
+	expected := &SessionProperties{
+		ID:            "2",
+		UID:           1000,
+		UserPath:      "/org/freedesktop/login1/user/_1000",
+		Name:          "core",
+		Timestamp:     time.Unix(1700000000, 0),
+		VTNr:          1,
+		Seat:          "seat0",
+		SeatPath:      "/org/freedesktop/login1/seat/seat0",
+		TTY:           "tty1",
+		Service:       "login",
+		Scope:         "session-2.scope",
+		Leader:        812,
+		Audit:         2,
+		Type:          "tty",
+		Class:         "user",
+		Active:        true,
+		State:         "active",
+		IdleHint:      true,
+		IdleSinceHint: time.Unix(1700000060, 0),
+	}
+	s, err := sessionPropertiesFromVariants(props)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s, expected) {
+		t.Errorf("expected %+v, got %+v", expected, s)
+	}
+
+	expectedInfo := &SessionInfo{
+		User:  User{UID: 1000, Name: "core", Path: "/org/freedesktop/login1/user/_1000"},
+		TTY:   "tty1",
+		Seat:  "seat0",
+		State: "active",
+	}
+	if info := s.info(); !reflect.DeepEqual(info, expectedInfo) {
+		t.Errorf("expected info %+v, got %+v", expectedInfo, info)
+	}
+
+	props["Seat"] = dbus.MakeVariant("seat0")
+	if _, err := sessionPropertiesFromVariants(props); err == nil {
+		t.Error("expected an error for an invalid Seat")
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// GetUserContext returns the object path of the user with the specified
+// UID. It fails with a org.freedesktop.login1.NoSuchUser error for users who
+// are neither logged in nor lingering.
+func (c *Conn) GetUserContext(ctx context.Context, uid uint32) (dbus.ObjectPath, error) {
+	var path dbus.ObjectPath
+	err := c.object.CallWithContext(ctx, dbusManagerInterface+".GetUser", 0, uid).Store(&path)
+	return path, err
+}
+
+// SetUserLingerContext enables or disables lingering of the user with the
+// specified UID, which keeps their user manager running while they are not
+// logged in. If interactive is true, the caller may be asked for
+// authorization by polkit.
+func (c *Conn) SetUserLingerContext(ctx context.Context, uid uint32, enable, interactive bool) error {
+	return c.object.CallWithContext(ctx, dbusManagerInterface+".SetUserLinger", 0, uid, enable, interactive).Err
+}
+
+// GetUserLingerContext reports whether lingering is enabled for the user with
+// the specified UID.
+func (c *Conn) GetUserLingerContext(ctx context.Context, uid uint32) (bool, error) {
+	path, err := c.GetUserContext(ctx, uid)
+	if err != nil {
+		// logind loads all lingering users, so users it doesn't know
+		// don't linger
+		var dbusErr dbus.Error
+		if errors.As(err, &dbusErr) && dbusErr.Name == "org.freedesktop.login1.NoSuchUser" {
+			return false, nil
+		}
+		return false, err
+	}
+
+	v, err := c.getProperty(ctx, path, dbusUserInterface, "Linger")
+	if err != nil {
+		return false, err
+	}
+	linger, ok := v.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("failed to typecast Linger to bool")
+	}
+	return linger, nil
+}
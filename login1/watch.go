@@ -0,0 +1,230 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// EventKind tells whether an object was added or removed.
+type EventKind int
+
+const (
+	// EventNew is the kind of the events of the SessionNew and UserNew
+	// signals.
+	EventNew EventKind = iota
+	// EventRemoved is the kind of the events of the SessionRemoved and
+	// UserRemoved signals.
+	EventRemoved
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventNew:
+		return "new"
+	case EventRemoved:
+		return "removed"
+	}
+	return "unknown"
+}
+
+// SessionEvent reports a session which was created or removed.
+type SessionEvent struct {
+	ID   string
+	Path dbus.ObjectPath
+	Kind EventKind
+}
+
+// UserEvent reports a user who logged in or whose last session was removed.
+type UserEvent struct {
+	UID  uint32
+	Path dbus.ObjectPath
+	Kind EventKind
+}
+
+// signalBuffer is the size of the buffer of the channels receiving signals.
+const signalBuffer = 16
+
+// WatchSessions delivers the SessionNew and SessionRemoved signals of logind
+// as events on the returned channel, until ctx is done, when the channel is
+// closed.
+func (c *Conn) WatchSessions(ctx context.Context) (<-chan SessionEvent, error) {
+	out := make(chan SessionEvent, signalBuffer)
+	err := c.watchSignals(ctx, []string{"SessionNew", "SessionRemoved"}, func(signal *dbus.Signal) {
+		if ev, ok := sessionEvent(signal); ok {
+			select {
+			case out <- ev:
+			case <-ctx.Done():
+			}
+		}
+	}, func() { close(out) })
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WatchUsers delivers the UserNew and UserRemoved signals of logind as events
+// on the returned channel, until ctx is done, when the channel is closed.
+func (c *Conn) WatchUsers(ctx context.Context) (<-chan UserEvent, error) {
+	out := make(chan UserEvent, signalBuffer)
+	err := c.watchSignals(ctx, []string{"UserNew", "UserRemoved"}, func(signal *dbus.Signal) {
+		if ev, ok := userEvent(signal); ok {
+			select {
+			case out <- ev:
+			case <-ctx.Done():
+			}
+		}
+	}, func() { close(out) })
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SubscribeContext delivers the signals of the manager interface of logind
+// with the given member names, e.g. PrepareForSleep, on the returned channel.
+// The subscription ends when ctx is done or cancel is called: the match rules
+// are removed then, and the channel is closed.
+func (c *Conn) SubscribeContext(ctx context.Context, members ...string) (signals <-chan *dbus.Signal, cancel func(), err error) {
+	if len(members) == 0 {
+		return nil, nil, fmt.Errorf("no signal members")
+	}
+
+	ctx, cancel = context.WithCancel(ctx)
+	out := make(chan *dbus.Signal, signalBuffer)
+	err = c.watchSignals(ctx, members, func(signal *dbus.Signal) {
+		if !isManagerSignal(signal, members) {
+			return
+		}
+		select {
+		case out <- signal:
+		case <-ctx.Done():
+		}
+	}, func() { close(out) })
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	return out, cancel, nil
+}
+
+// isManagerSignal reports whether signal is one of the members of the
+// manager interface. The connection receives the signals of all its matches,
+// so they have to be filtered.
+func isManagerSignal(signal *dbus.Signal, members []string) bool {
+	if signal.Path != dbusPath {
+		return false
+	}
+	for _, member := range members {
+		if signal.Name == dbusManagerInterface+"."+member {
+			return true
+		}
+	}
+	return false
+}
+
+// watchSignals adds matches for the members of the manager interface, and
+// calls handle with the signals received until ctx is done. The matches are
+// removed then, and done is called.
+func (c *Conn) watchSignals(ctx context.Context, members []string, handle func(*dbus.Signal), done func()) error {
+	matches := make([][]dbus.MatchOption, len(members))
+	for i, member := range members {
+		matches[i] = []dbus.MatchOption{
+			dbus.WithMatchObjectPath(dbusPath),
+			dbus.WithMatchInterface(dbusManagerInterface),
+			dbus.WithMatchMember(member),
+		}
+	}
+	return c.watchMatches(ctx, matches, handle, done)
+}
+
+// watchMatches adds the matches, and calls handle with the signals received
+// until ctx is done. The matches are removed then, and done is called.
+func (c *Conn) watchMatches(ctx context.Context, matches [][]dbus.MatchOption, handle func(*dbus.Signal), done func()) error {
+	for i, match := range matches {
+		if err := c.conn.AddMatchSignalContext(ctx, match...); err != nil {
+			for _, m := range matches[:i] {
+				c.conn.RemoveMatchSignal(m...)
+			}
+			return err
+		}
+	}
+
+	ch := make(chan *dbus.Signal, signalBuffer)
+	c.conn.Signal(ch)
+
+	go func() {
+		defer done()
+		defer func() {
+			c.conn.RemoveSignal(ch)
+			for _, m := range matches {
+				c.conn.RemoveMatchSignal(m...)
+			}
+		}()
+
+		for {
+			select {
+			case signal, ok := <-ch:
+				if !ok {
+					return
+				}
+				handle(signal)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// sessionEvent converts a SessionNew or SessionRemoved signal, with a body of
+// the session ID and path.
+func sessionEvent(signal *dbus.Signal) (SessionEvent, bool) {
+	var ev SessionEvent
+	switch signal.Name {
+	case dbusManagerInterface + ".SessionNew":
+		ev.Kind = EventNew
+	case dbusManagerInterface + ".SessionRemoved":
+		ev.Kind = EventRemoved
+	default:
+		return ev, false
+	}
+	if signal.Path != dbusPath || dbus.Store(signal.Body, &ev.ID, &ev.Path) != nil {
+		return ev, false
+	}
+	return ev, true
+}
+
+// userEvent converts a UserNew or UserRemoved signal, with a body of the UID
+// and the path of the user.
+func userEvent(signal *dbus.Signal) (UserEvent, bool) {
+	var ev UserEvent
+	switch signal.Name {
+	case dbusManagerInterface + ".UserNew":
+		ev.Kind = EventNew
+	case dbusManagerInterface + ".UserRemoved":
+		ev.Kind = EventRemoved
+	default:
+		return ev, false
+	}
+	if signal.Path != dbusPath || dbus.Store(signal.Body, &ev.UID, &ev.Path) != nil {
+		return ev, false
+	}
+	return ev, true
+}
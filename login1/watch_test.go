@@ -0,0 +1,81 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login1
+
+import (
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestSessionEvent(t *testing.T) {
+	signal := &dbus.Signal{
+		Path: dbusPath,
+		Name: "org.freedesktop.login1.Manager.SessionRemoved",
+		Body: []interface{}{"c1", dbus.ObjectPath("/org/freedesktop/login1/session/c1")},
+	}
+	ev, ok := sessionEvent(signal)
+	if !ok {
+		t.Fatal("expected a session event")
+	}
+	if expected := (SessionEvent{ID: "c1", Path: "/org/freedesktop/login1/session/c1", Kind: EventRemoved}); ev != expected {
+		t.Errorf("expected %+v, got %+v", expected, ev)
+	}
+
+	signal.Name = "org.freedesktop.login1.Manager.UserNew"
+	if _, ok := sessionEvent(signal); ok {
+		t.Error("expected no session event for UserNew")
+	}
+}
+
+func TestUserEvent(t *testing.T) {
+	signal := &dbus.Signal{
+		Path: dbusPath,
+		Name: "org.freedesktop.login1.Manager.UserNew",
+		Body: []interface{}{uint32(1000), dbus.ObjectPath("/org/freedesktop/login1/user/_1000")},
+	}
+	ev, ok := userEvent(signal)
+	if !ok {
+		t.Fatal("expected a user event")
+	}
+	if expected := (UserEvent{UID: 1000, Path: "/org/freedesktop/login1/user/_1000", Kind: EventNew}); ev != expected {
+		t.Errorf("expected %+v, got %+v", expected, ev)
+	}
+
+	signal.Body = []interface{}{"1000", dbus.ObjectPath("/org/freedesktop/login1/user/_1000")}
+	if _, ok := userEvent(signal); ok {
+		t.Error("expected no user event for an invalid body")
+	}
+}
+
+func TestIsManagerSignal(t *testing.T) {
+	members := []string{"PrepareForSleep", "SessionNew"}
+	tests := []struct {
+		signal   *dbus.Signal
+		expected bool
+	}{
+		{&dbus.Signal{Path: dbusPath, Name: dbusManagerInterface + ".PrepareForSleep"}, true},
+		{&dbus.Signal{Path: dbusPath, Name: dbusManagerInterface + ".SessionNew"}, true},
+		{&dbus.Signal{Path: dbusPath, Name: dbusManagerInterface + ".SessionRemoved"}, false},
+		{&dbus.Signal{Path: "/org/freedesktop/login1/session/_31", Name: dbusManagerInterface + ".SessionNew"}, false},
+		{&dbus.Signal{Path: dbusPath, Name: "org.freedesktop.DBus.Properties.PropertiesChanged"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := isManagerSignal(tt.signal, members); got != tt.expected {
+			t.Errorf("isManagerSignal(%s %s): expected %v, got %v", tt.signal.Path, tt.signal.Name, tt.expected, got)
+		}
+	}
+}
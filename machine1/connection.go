@@ -0,0 +1,48 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+package machine1
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	sd_dbus "github.com/gr-butler/go-systemd/v22/dbus"
+	"github.com/gr-butler/go-systemd/v22/instrument"
+)
+
+// Connection is the interface of Conn, which allows for replacing it in tests,
+// e.g. by the fake of the machine1test package. See Conn for the documentation of
+// the methods.
+type Connection interface {
+	Connected() bool
+	CopyFromMachine(name, source, destination string) error
+	CopyToMachine(name, source, destination string) error
+	CreateMachine(name string, id []byte, service string, class string, pid int, root_directory string, scope_properties []sd_dbus.Property) error
+	CreateMachineFromSpec(spec MachineSpec) (dbus.ObjectPath, error)
+	CreateMachineWithNetwork(name string, id []byte, service string, class string, pid int, root_directory string, ifindices []int, scope_properties []sd_dbus.Property) error
+	DescribeMachine(name string) (machineProps map[string]interface{}, err error)
+	ExecInMachine(ctx context.Context, name string, cmd []string, opts ExecOptions) (int, error)
+	GetImage(name string) (dbus.ObjectPath, error)
+	GetMachine(name string) (dbus.ObjectPath, error)
+	GetMachineAddresses(name string) (dbus.ObjectPath, error)
+	GetMachineByPID(pid uint) (dbus.ObjectPath, error)
+	GetMachineInfo(name string) (*Machine, error)
+	GetMachineOSRelease(name string) (map[string]string, error)
+	KillMachine(name, who string, sig syscall.Signal) error
+	ListImages() ([]ImageStatus, error)
+	ListMachineAddresses(name string) ([]MachineAddress, error)
+	ListMachines() ([]MachineStatus, error)
+	OpenMachinePTY(name string) (*os.File, string, error)
+	OpenMachineShell(name, user, path string, args, env []string) (*os.File, string, error)
+	RegisterMachine(name string, id []byte, service string, class string, pid int, root_directory string) error
+	RegisterMachineFromSpec(spec MachineSpec) (dbus.ObjectPath, error)
+	RegisterMachineWithNetwork(name string, id []byte, service string, class string, pid int, root_directory string, ifindices []int) error
+	SetHook(hook instrument.Hook)
+	SetTimeout(timeout time.Duration)
+	TerminateMachine(name string) error
+}
+
+var _ Connection = (*Conn)(nil)
@@ -19,15 +19,21 @@ package machine1
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/godbus/dbus/v5"
 
 	sd_dbus "github.com/gr-butler/go-systemd/v22/dbus"
+	"github.com/gr-butler/go-systemd/v22/instrument"
+	"github.com/gr-butler/go-systemd/v22/internal/calltimeout"
 )
 
+//go:generate go run github.com/gr-butler/go-systemd/v22/internal/fakegen
+
 const (
 	dbusInterface = "org.freedesktop.machine1.Manager"
 	dbusPath      = "/org/freedesktop/machine1"
@@ -37,6 +43,9 @@ const (
 type Conn struct {
 	conn   *dbus.Conn
 	object dbus.BusObject
+
+	hook    instrument.Hook
+	timeout time.Duration
 }
 
 // MachineStatus is a set of necessary info for each machine
@@ -98,6 +107,29 @@ func (c *Conn) initConnection() error {
 	return nil
 }
 
+// SetHook sets the hook observing the method calls made on the connection,
+// e.g. to trace them, see the instrument package. A nil hook removes it.
+// SetHook must not be called concurrently with method calls.
+func (c *Conn) SetHook(hook instrument.Hook) {
+	c.hook = hook
+	c.object = c.pathObject(dbus.ObjectPath(dbusPath))
+}
+
+// SetTimeout sets the default timeout of the method calls made on the
+// connection without a context deadline, including those made by the
+// methods without context, which otherwise block forever if machined hangs.
+// It is disabled by a timeout of zero, the default. SetTimeout must not be
+// called concurrently with method calls.
+func (c *Conn) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+	c.object = c.pathObject(dbus.ObjectPath(dbusPath))
+}
+
+// pathObject returns the object at path on the machine1 service.
+func (c *Conn) pathObject(path dbus.ObjectPath) dbus.BusObject {
+	return instrument.Object(calltimeout.Object(c.conn.Object("org.freedesktop.machine1", path), c.timeout), c.hook)
+}
+
 func (c *Conn) getPath(method string, args ...interface{}) (dbus.ObjectPath, error) {
 	result := c.object.Call(fmt.Sprintf("%s.%s", dbusInterface, method), 0, args...)
 	if result.Err != nil {
@@ -143,10 +175,37 @@ func (c *Conn) GetMachineByPID(pid uint) (dbus.ObjectPath, error) {
 }
 
 // GetMachineAddresses gets a list of IP addresses
+//
+// Deprecated: it doesn't decode the reply of systemd-machined, use
+// ListMachineAddresses instead.
 func (c *Conn) GetMachineAddresses(name string) (dbus.ObjectPath, error) {
 	return c.getPath("GetMachineAddresses", name)
 }
 
+// ListMachineAddresses gets the IP addresses of a machine
+func (c *Conn) ListMachineAddresses(name string) ([]MachineAddress, error) {
+	var result []struct {
+		Family  int32
+		Address []byte
+	}
+	if err := c.object.Call(dbusInterface+".GetMachineAddresses", 0, name).Store(&result); err != nil {
+		return nil, err
+	}
+
+	addrs := make([]MachineAddress, 0, len(result))
+	for _, r := range result {
+		addrs = append(addrs, MachineAddress{Family: int(r.Family), Address: net.IP(r.Address)})
+	}
+	return addrs, nil
+}
+
+// GetMachineOSRelease gets the fields of the os-release file of a machine
+func (c *Conn) GetMachineOSRelease(name string) (map[string]string, error) {
+	var fields map[string]string
+	err := c.object.Call(dbusInterface+".GetMachineOSRelease", 0, name).Store(&fields)
+	return fields, err
+}
+
 // DescribeMachine gets the properties of a machine
 func (c *Conn) DescribeMachine(name string) (machineProps map[string]interface{}, err error) {
 	var dbusProps map[string]dbus.Variant
@@ -154,7 +213,7 @@ func (c *Conn) DescribeMachine(name string) (machineProps map[string]interface{}
 	if pathErr != nil {
 		return nil, pathErr
 	}
-	obj := c.conn.Object("org.freedesktop.machine1", path)
+	obj := c.pathObject(path)
 	err = obj.Call("org.freedesktop.DBus.Properties.GetAll", 0, "").Store(&dbusProps)
 	if err != nil {
 		return nil, err
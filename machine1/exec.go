@@ -0,0 +1,227 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package machine1
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// OpenMachinePTY allocates a pseudo terminal in the machine and returns its
+// master side and the path of the slave side in the machine.
+func (c *Conn) OpenMachinePTY(name string) (*os.File, string, error) {
+	return c.openPTY("OpenMachinePTY", name)
+}
+
+// OpenMachineShell runs the program at path with args, including argv[0],
+// and the environment assignments env in the machine as user, root if empty,
+// attached to a new pseudo terminal. It returns the master side of the
+// terminal and the path of the slave side in the machine. If path is empty, a
+// login shell of user is started.
+func (c *Conn) OpenMachineShell(name, user, path string, args, env []string) (*os.File, string, error) {
+	return c.openPTY("OpenMachineShell", name, user, path, args, env)
+}
+
+func (c *Conn) openPTY(method string, args ...interface{}) (*os.File, string, error) {
+	var fd dbus.UnixFD
+	var path string
+	if err := c.object.Call(dbusInterface+"."+method, 0, args...).Store(&fd, &path); err != nil {
+		return nil, "", err
+	}
+	pty, err := newPTYFile(int(fd), path)
+	if err != nil {
+		return nil, "", err
+	}
+	return pty, path, nil
+}
+
+// CopyFromMachine copies the file or directory source in the machine to
+// destination on the host. Both paths must be absolute.
+func (c *Conn) CopyFromMachine(name, source, destination string) error {
+	return c.object.Call(dbusInterface+".CopyFromMachine", 0, name, source, destination).Err
+}
+
+// CopyToMachine copies the file or directory source on the host to
+// destination in the machine. Both paths must be absolute.
+func (c *Conn) CopyToMachine(name, source, destination string) error {
+	return c.object.Call(dbusInterface+".CopyToMachine", 0, name, source, destination).Err
+}
+
+// ExecOptions configures ExecInMachine.
+type ExecOptions struct {
+	User  string   // The user to run the command as, root if empty
+	Env   []string // Environment assignments in the form KEY=VALUE
+	Stdin io.Reader
+	// Stdout receives the output of the command. As the command runs on a
+	// terminal, this includes what it writes to stderr.
+	Stdout io.Writer
+	// StatusDir is the directory in the machine the exit status of the
+	// command is passed through, /tmp if empty. It must be writable by
+	// User.
+	StatusDir string
+}
+
+// ExecInMachine runs cmd in the machine, like machinectl shell -q, streaming
+// opts.Stdin to it and its output to opts.Stdout, and returns its exit
+// status. cmd[0] is looked up in the PATH of the machine if it doesn't
+// contain a slash. The machine must have /bin/sh.
+//
+// The command runs on a pseudo terminal with echo and output processing
+// disabled, and an end of file is sent once opts.Stdin is exhausted. The
+// command gets a SIGHUP when ctx is done. The exit status is written to a
+// temporary file in the machine by a wrapping shell, and copied from the
+// machine once the command exits.
+func (c *Conn) ExecInMachine(ctx context.Context, name string, cmd []string, opts ExecOptions) (int, error) {
+	if len(cmd) == 0 {
+		return 0, errors.New("no command given")
+	}
+
+	statusDir := opts.StatusDir
+	if statusDir == "" {
+		statusDir = "/tmp"
+	}
+	statusFile, err := randomName(filepath.Join(statusDir, "go-systemd-exec-"))
+	if err != nil {
+		return 0, err
+	}
+
+	// $0 is the status file and "$@" the command
+	args := append([]string{"sh", "-c", `"$@"; echo $? >"$0"`, statusFile}, cmd...)
+	pty, _, err := c.OpenMachineShell(name, opts.User, "/bin/sh", args, opts.Env)
+	if err != nil {
+		return 0, err
+	}
+	defer c.removeInMachine(name, opts.User, statusFile)
+
+	if err := streamPTY(ctx, pty, opts.Stdin, opts.Stdout); err != nil {
+		return 0, err
+	}
+
+	return c.readExitStatus(name, statusFile)
+}
+
+// streamPTY copies stdin to pty and the output of pty to stdout until the
+// slave side of pty is closed, and closes pty.
+func streamPTY(ctx context.Context, pty *os.File, stdin io.Reader, stdout io.Writer) error {
+	defer pty.Close()
+
+	if err := setRaw(pty); err != nil {
+		return err
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		// hangs up the terminal
+		pty.Close()
+	})
+	defer stop()
+
+	if stdin != nil {
+		go copyInput(pty, stdin)
+	}
+	if stdout == nil {
+		stdout = io.Discard
+	}
+
+	_, err := io.Copy(stdout, pty)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	// the master side fails with EIO once the slave side is closed
+	if err != nil && !errors.Is(err, syscall.EIO) {
+		return err
+	}
+	return nil
+}
+
+// copyInput copies stdin to pty, followed by an end of file.
+func copyInput(pty io.Writer, stdin io.Reader) {
+	last := byte('\n')
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := stdin.Read(buf)
+		if n > 0 {
+			if _, err := pty.Write(buf[:n]); err != nil {
+				return
+			}
+			last = buf[n-1]
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	// VEOF only ends the input at the start of a line, and otherwise
+	// terminates the line
+	eof := []byte{4}
+	if last != '\n' {
+		eof = append(eof, 4)
+	}
+	pty.Write(eof)
+}
+
+func (c *Conn) readExitStatus(name, statusFile string) (int, error) {
+	dir, err := os.MkdirTemp("", "go-systemd-exec-")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(dir)
+
+	local := filepath.Join(dir, "status")
+	if err := c.CopyFromMachine(name, statusFile, local); err != nil {
+		return 0, fmt.Errorf("failed to retrieve exit status: %w", err)
+	}
+	b, err := os.ReadFile(local)
+	if err != nil {
+		return 0, err
+	}
+	return parseExitStatus(b)
+}
+
+func parseExitStatus(b []byte) (int, error) {
+	status, err := strconv.Atoi(string(bytes.TrimSpace(b)))
+	if err != nil || status < 0 || status > 255 {
+		return 0, fmt.Errorf("invalid exit status %q", strings.TrimSpace(string(b)))
+	}
+	return status, nil
+}
+
+// removeInMachine removes path in the machine, ignoring errors.
+func (c *Conn) removeInMachine(name, user, path string) {
+	pty, _, err := c.OpenMachineShell(name, user, "/bin/rm", []string{"rm", "-f", path}, nil)
+	if err != nil {
+		return
+	}
+	streamPTY(context.Background(), pty, nil, nil)
+}
+
+func randomName(prefix string) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package machine1
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+// openTestPTY allocates a pseudo terminal and returns its master side, as
+// returned by OpenMachinePTY, and its slave side.
+func openTestPTY(t *testing.T) (*os.File, *os.File) {
+	t.Helper()
+	fd, err := syscall.Open("/dev/ptmx", syscall.O_RDWR|syscall.O_NOCTTY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		t.Skipf("failed to open /dev/ptmx: %v", err)
+	}
+
+	var unlock int32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); errno != 0 {
+		t.Fatal(errno)
+	}
+	var n uint32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.TIOCGPTN, uintptr(unsafe.Pointer(&n))); errno != 0 {
+		t.Fatal(errno)
+	}
+
+	master, err := newPTYFile(fd, "/dev/ptmx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	slave, err := os.OpenFile("/dev/pts/"+strconv.Itoa(int(n)), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		t.Fatal(err)
+	}
+	return master, slave
+}
+
+// startOnPTY starts a command on the slave side of a pseudo terminal, which
+// becomes its controlling terminal if ctty is set.
+func startOnPTY(t *testing.T, slave *os.File, ctty bool, name string, args ...string) *exec.Cmd {
+	t.Helper()
+	cmd := exec.Command(name, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = slave, slave, slave
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: ctty, Setctty: ctty}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	// only the command keeps the slave side open
+	slave.Close()
+	return cmd
+}
+
+func TestStreamPTY(t *testing.T) {
+	master, slave := openTestPTY(t)
+	// setRaw is applied before the command runs here, unlike with machined
+	// where it races with the start of the command
+	if err := setRaw(master); err != nil {
+		t.Fatal(err)
+	}
+	cmd := startOnPTY(t, slave, false, "cat")
+
+	var out bytes.Buffer
+	if err := streamPTY(context.Background(), master, strings.NewReader("hello\nworld"), &out); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Wait(); err != nil {
+		t.Errorf("cat failed: %v", err)
+	}
+	// the input isn't echoed and line endings aren't translated
+	if out.String() != "hello\nworld" {
+		t.Errorf("got output %q", out.String())
+	}
+}
+
+func TestStreamPTYContext(t *testing.T) {
+	master, slave := openTestPTY(t)
+	cmd := startOnPTY(t, slave, true, "sleep", "60")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := streamPTY(ctx, master, nil, nil); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// closing the master side hangs up the command
+	if err := cmd.Wait(); err == nil {
+		t.Error("expected sleep to be killed by SIGHUP")
+	}
+}
+
+func TestParseExitStatus(t *testing.T) {
+	for _, tt := range []struct {
+		in     string
+		status int
+		ok     bool
+	}{
+		{"0\n", 0, true},
+		{"130\n", 130, true},
+		{"255", 255, true},
+		{"", 0, false},
+		{"256\n", 0, false},
+		{"-1\n", 0, false},
+		{"abc\n", 0, false},
+	} {
+		status, err := parseExitStatus([]byte(tt.in))
+		if (err == nil) != tt.ok || status != tt.status {
+			t.Errorf("parseExitStatus(%q) = %d, %v", tt.in, status, err)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+// Package machine1test provides a fake of machine1.Conn for unit tests.
+package machine1test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	sd_dbus "github.com/gr-butler/go-systemd/v22/dbus"
+	"github.com/gr-butler/go-systemd/v22/instrument"
+	"github.com/gr-butler/go-systemd/v22/machine1"
+)
+
+// ErrNotImplemented is returned by the methods whose function field is unset.
+var ErrNotImplemented = errors.New("not implemented")
+
+// Conn is a fake machine1.Connection. Each method calls the function field of
+// the same name with a Func suffix. If it is unset, the method returns zero
+// values and, if it returns an error, ErrNotImplemented.
+type Conn struct {
+	ConnectedFunc                  func() bool
+	CopyFromMachineFunc            func(name, source, destination string) error
+	CopyToMachineFunc              func(name, source, destination string) error
+	CreateMachineFunc              func(name string, id []byte, service string, class string, pid int, root_directory string, scope_properties []sd_dbus.Property) error
+	CreateMachineFromSpecFunc      func(spec machine1.MachineSpec) (dbus.ObjectPath, error)
+	CreateMachineWithNetworkFunc   func(name string, id []byte, service string, class string, pid int, root_directory string, ifindices []int, scope_properties []sd_dbus.Property) error
+	DescribeMachineFunc            func(name string) (machineProps map[string]interface{}, err error)
+	ExecInMachineFunc              func(ctx context.Context, name string, cmd []string, opts machine1.ExecOptions) (int, error)
+	GetImageFunc                   func(name string) (dbus.ObjectPath, error)
+	GetMachineFunc                 func(name string) (dbus.ObjectPath, error)
+	GetMachineAddressesFunc        func(name string) (dbus.ObjectPath, error)
+	GetMachineByPIDFunc            func(pid uint) (dbus.ObjectPath, error)
+	GetMachineInfoFunc             func(name string) (*machine1.Machine, error)
+	GetMachineOSReleaseFunc        func(name string) (map[string]string, error)
+	KillMachineFunc                func(name, who string, sig syscall.Signal) error
+	ListImagesFunc                 func() ([]machine1.ImageStatus, error)
+	ListMachineAddressesFunc       func(name string) ([]machine1.MachineAddress, error)
+	ListMachinesFunc               func() ([]machine1.MachineStatus, error)
+	OpenMachinePTYFunc             func(name string) (*os.File, string, error)
+	OpenMachineShellFunc           func(name, user, path string, args, env []string) (*os.File, string, error)
+	RegisterMachineFunc            func(name string, id []byte, service string, class string, pid int, root_directory string) error
+	RegisterMachineFromSpecFunc    func(spec machine1.MachineSpec) (dbus.ObjectPath, error)
+	RegisterMachineWithNetworkFunc func(name string, id []byte, service string, class string, pid int, root_directory string, ifindices []int) error
+	SetHookFunc                    func(hook instrument.Hook)
+	SetTimeoutFunc                 func(timeout time.Duration)
+	TerminateMachineFunc           func(name string) error
+}
+
+var _ machine1.Connection = (*Conn)(nil)
+
+func (f *Conn) Connected() bool {
+	if f.ConnectedFunc == nil {
+		var r0 bool
+		return r0
+	}
+	return f.ConnectedFunc()
+}
+
+func (f *Conn) CopyFromMachine(name, source, destination string) error {
+	if f.CopyFromMachineFunc == nil {
+		return fmt.Errorf("%w: CopyFromMachine", ErrNotImplemented)
+	}
+	return f.CopyFromMachineFunc(name, source, destination)
+}
+
+func (f *Conn) CopyToMachine(name, source, destination string) error {
+	if f.CopyToMachineFunc == nil {
+		return fmt.Errorf("%w: CopyToMachine", ErrNotImplemented)
+	}
+	return f.CopyToMachineFunc(name, source, destination)
+}
+
+func (f *Conn) CreateMachine(name string, id []byte, service string, class string, pid int, root_directory string, scope_properties []sd_dbus.Property) error {
+	if f.CreateMachineFunc == nil {
+		return fmt.Errorf("%w: CreateMachine", ErrNotImplemented)
+	}
+	return f.CreateMachineFunc(name, id, service, class, pid, root_directory, scope_properties)
+}
+
+func (f *Conn) CreateMachineFromSpec(spec machine1.MachineSpec) (dbus.ObjectPath, error) {
+	if f.CreateMachineFromSpecFunc == nil {
+		var r0 dbus.ObjectPath
+		return r0, fmt.Errorf("%w: CreateMachineFromSpec", ErrNotImplemented)
+	}
+	return f.CreateMachineFromSpecFunc(spec)
+}
+
+func (f *Conn) CreateMachineWithNetwork(name string, id []byte, service string, class string, pid int, root_directory string, ifindices []int, scope_properties []sd_dbus.Property) error {
+	if f.CreateMachineWithNetworkFunc == nil {
+		return fmt.Errorf("%w: CreateMachineWithNetwork", ErrNotImplemented)
+	}
+	return f.CreateMachineWithNetworkFunc(name, id, service, class, pid, root_directory, ifindices, scope_properties)
+}
+
+func (f *Conn) DescribeMachine(name string) (machineProps map[string]interface{}, err error) {
+	if f.DescribeMachineFunc == nil {
+		var r0 map[string]interface{}
+		return r0, fmt.Errorf("%w: DescribeMachine", ErrNotImplemented)
+	}
+	return f.DescribeMachineFunc(name)
+}
+
+func (f *Conn) ExecInMachine(ctx context.Context, name string, cmd []string, opts machine1.ExecOptions) (int, error) {
+	if f.ExecInMachineFunc == nil {
+		var r0 int
+		return r0, fmt.Errorf("%w: ExecInMachine", ErrNotImplemented)
+	}
+	return f.ExecInMachineFunc(ctx, name, cmd, opts)
+}
+
+func (f *Conn) GetImage(name string) (dbus.ObjectPath, error) {
+	if f.GetImageFunc == nil {
+		var r0 dbus.ObjectPath
+		return r0, fmt.Errorf("%w: GetImage", ErrNotImplemented)
+	}
+	return f.GetImageFunc(name)
+}
+
+func (f *Conn) GetMachine(name string) (dbus.ObjectPath, error) {
+	if f.GetMachineFunc == nil {
+		var r0 dbus.ObjectPath
+		return r0, fmt.Errorf("%w: GetMachine", ErrNotImplemented)
+	}
+	return f.GetMachineFunc(name)
+}
+
+func (f *Conn) GetMachineAddresses(name string) (dbus.ObjectPath, error) {
+	if f.GetMachineAddressesFunc == nil {
+		var r0 dbus.ObjectPath
+		return r0, fmt.Errorf("%w: GetMachineAddresses", ErrNotImplemented)
+	}
+	return f.GetMachineAddressesFunc(name)
+}
+
+func (f *Conn) GetMachineByPID(pid uint) (dbus.ObjectPath, error) {
+	if f.GetMachineByPIDFunc == nil {
+		var r0 dbus.ObjectPath
+		return r0, fmt.Errorf("%w: GetMachineByPID", ErrNotImplemented)
+	}
+	return f.GetMachineByPIDFunc(pid)
+}
+
+func (f *Conn) GetMachineInfo(name string) (*machine1.Machine, error) {
+	if f.GetMachineInfoFunc == nil {
+		var r0 *machine1.Machine
+		return r0, fmt.Errorf("%w: GetMachineInfo", ErrNotImplemented)
+	}
+	return f.GetMachineInfoFunc(name)
+}
+
+func (f *Conn) GetMachineOSRelease(name string) (map[string]string, error) {
+	if f.GetMachineOSReleaseFunc == nil {
+		var r0 map[string]string
+		return r0, fmt.Errorf("%w: GetMachineOSRelease", ErrNotImplemented)
+	}
+	return f.GetMachineOSReleaseFunc(name)
+}
+
+func (f *Conn) KillMachine(name, who string, sig syscall.Signal) error {
+	if f.KillMachineFunc == nil {
+		return fmt.Errorf("%w: KillMachine", ErrNotImplemented)
+	}
+	return f.KillMachineFunc(name, who, sig)
+}
+
+func (f *Conn) ListImages() ([]machine1.ImageStatus, error) {
+	if f.ListImagesFunc == nil {
+		var r0 []machine1.ImageStatus
+		return r0, fmt.Errorf("%w: ListImages", ErrNotImplemented)
+	}
+	return f.ListImagesFunc()
+}
+
+func (f *Conn) ListMachineAddresses(name string) ([]machine1.MachineAddress, error) {
+	if f.ListMachineAddressesFunc == nil {
+		var r0 []machine1.MachineAddress
+		return r0, fmt.Errorf("%w: ListMachineAddresses", ErrNotImplemented)
+	}
+	return f.ListMachineAddressesFunc(name)
+}
+
+func (f *Conn) ListMachines() ([]machine1.MachineStatus, error) {
+	if f.ListMachinesFunc == nil {
+		var r0 []machine1.MachineStatus
+		return r0, fmt.Errorf("%w: ListMachines", ErrNotImplemented)
+	}
+	return f.ListMachinesFunc()
+}
+
+func (f *Conn) OpenMachinePTY(name string) (*os.File, string, error) {
+	if f.OpenMachinePTYFunc == nil {
+		var r0 *os.File
+		var r1 string
+		return r0, r1, fmt.Errorf("%w: OpenMachinePTY", ErrNotImplemented)
+	}
+	return f.OpenMachinePTYFunc(name)
+}
+
+func (f *Conn) OpenMachineShell(name, user, path string, args, env []string) (*os.File, string, error) {
+	if f.OpenMachineShellFunc == nil {
+		var r0 *os.File
+		var r1 string
+		return r0, r1, fmt.Errorf("%w: OpenMachineShell", ErrNotImplemented)
+	}
+	return f.OpenMachineShellFunc(name, user, path, args, env)
+}
+
+func (f *Conn) RegisterMachine(name string, id []byte, service string, class string, pid int, root_directory string) error {
+	if f.RegisterMachineFunc == nil {
+		return fmt.Errorf("%w: RegisterMachine", ErrNotImplemented)
+	}
+	return f.RegisterMachineFunc(name, id, service, class, pid, root_directory)
+}
+
+func (f *Conn) RegisterMachineFromSpec(spec machine1.MachineSpec) (dbus.ObjectPath, error) {
+	if f.RegisterMachineFromSpecFunc == nil {
+		var r0 dbus.ObjectPath
+		return r0, fmt.Errorf("%w: RegisterMachineFromSpec", ErrNotImplemented)
+	}
+	return f.RegisterMachineFromSpecFunc(spec)
+}
+
+func (f *Conn) RegisterMachineWithNetwork(name string, id []byte, service string, class string, pid int, root_directory string, ifindices []int) error {
+	if f.RegisterMachineWithNetworkFunc == nil {
+		return fmt.Errorf("%w: RegisterMachineWithNetwork", ErrNotImplemented)
+	}
+	return f.RegisterMachineWithNetworkFunc(name, id, service, class, pid, root_directory, ifindices)
+}
+
+func (f *Conn) SetHook(hook instrument.Hook) {
+	if f.SetHookFunc != nil {
+		f.SetHookFunc(hook)
+	}
+}
+
+func (f *Conn) SetTimeout(timeout time.Duration) {
+	if f.SetTimeoutFunc != nil {
+		f.SetTimeoutFunc(timeout)
+	}
+}
+
+func (f *Conn) TerminateMachine(name string) error {
+	if f.TerminateMachineFunc == nil {
+		return fmt.Errorf("%w: TerminateMachine", ErrNotImplemented)
+	}
+	return f.TerminateMachineFunc(name)
+}
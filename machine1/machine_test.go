@@ -0,0 +1,87 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package machine1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMachineFromProperties(t *testing.T) {
+	id := []byte{0xde, 0xad, 0xbe, 0xef, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
+	props := map[string]interface{}{
+		"Name":              "web",
+		"Id":                id,
+		"Timestamp":         uint64(1700000000000000),
+		"Service":           "nspawn",
+		"Unit":              "machine-web.scope",
+		"Leader":            uint32(1234),
+		"Class":             "container",
+		"RootDirectory":     "/var/lib/machines/web",
+		"NetworkInterfaces": []int32{3},
+		"State":             "running",
+	}
+
+	m, err := machineFromProperties(props)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &Machine{
+		Name:              "web",
+		ID:                id,
+		Timestamp:         time.UnixMicro(1700000000000000),
+		Service:           "nspawn",
+		Unit:              "machine-web.scope",
+		Leader:            1234,
+		Class:             "container",
+		RootDirectory:     "/var/lib/machines/web",
+		NetworkInterfaces: []int32{3},
+		State:             "running",
+	}
+	if !reflect.DeepEqual(m, expected) {
+		t.Errorf("expected %+v, got %+v", expected, m)
+	}
+
+	props["Id"] = make([]byte, 16)
+	if m, err = machineFromProperties(props); err != nil {
+		t.Fatal(err)
+	} else if m.ID != nil {
+		t.Errorf("expected no ID for an all-zero ID, got %x", m.ID)
+	}
+
+	if _, err := machineFromProperties(map[string]interface{}{}); err == nil {
+		t.Error("expected an error without a Name")
+	}
+}
+
+func TestMachineSpecValidate(t *testing.T) {
+	tests := []struct {
+		spec  MachineSpec
+		valid bool
+	}{
+		{MachineSpec{Name: "web", Leader: 1}, true},
+		{MachineSpec{Name: "web", ID: make([]byte, 16), Leader: 1}, true},
+		{MachineSpec{Leader: 1}, false},
+		{MachineSpec{Name: "web", ID: make([]byte, 8), Leader: 1}, false},
+		{MachineSpec{Name: "web"}, false},
+	}
+
+	for i, tt := range tests {
+		if err := tt.spec.validate(); (err == nil) != tt.valid {
+			t.Errorf("#%d: expected valid %v, got %v", i, tt.valid, err)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	sd_dbus "github.com/gr-butler/go-systemd/v22/dbus"
+	"github.com/gr-butler/go-systemd/v22/login1"
+)
+
+// dbusManager is the Manager of BackendDBus. The connection to logind is
+// only established when sessions are listed, as it is not needed otherwise.
+type dbusManager struct {
+	conn *sd_dbus.Conn
+
+	mu    sync.Mutex
+	login *login1.Conn
+}
+
+func newDBusManager(ctx context.Context, user bool) (*dbusManager, error) {
+	var conn *sd_dbus.Conn
+	var err error
+	if user {
+		conn, err = sd_dbus.NewUserConnectionContext(ctx)
+	} else {
+		conn, err = sd_dbus.NewWithContext(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &dbusManager{conn: conn}, nil
+}
+
+type jobFunc func(ctx context.Context, name string, mode sd_dbus.JobMode, ch chan<- string) (int, error)
+
+// runJob starts a job with start and waits for its result.
+func runJob(ctx context.Context, start jobFunc, verb, name string) error {
+	ch := make(chan string, 1)
+	if _, err := start(ctx, name, sd_dbus.JobModeReplace, ch); err != nil {
+		return err
+	}
+
+	select {
+	case result := <-ch:
+		if result != "done" {
+			return fmt.Errorf("%w: %s %s: %s", sd_dbus.ErrJobFailed, verb, name, result)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *dbusManager) StartUnit(ctx context.Context, name string) error {
+	return runJob(ctx, m.conn.StartUnitContext, "start", name)
+}
+
+func (m *dbusManager) StopUnit(ctx context.Context, name string) error {
+	return runJob(ctx, m.conn.StopUnitContext, "stop", name)
+}
+
+func (m *dbusManager) RestartUnit(ctx context.Context, name string) error {
+	return runJob(ctx, m.conn.RestartUnitContext, "restart", name)
+}
+
+func (m *dbusManager) ReloadUnit(ctx context.Context, name string) error {
+	return runJob(ctx, m.conn.ReloadUnitContext, "reload", name)
+}
+
+func (m *dbusManager) EnableUnitFiles(ctx context.Context, files []string, runtime bool) error {
+	if _, _, err := m.conn.EnableUnitFilesContext(ctx, files, runtime, false); err != nil {
+		return err
+	}
+	return m.conn.ReloadContext(ctx)
+}
+
+func (m *dbusManager) DisableUnitFiles(ctx context.Context, files []string, runtime bool) error {
+	if _, err := m.conn.DisableUnitFilesContext(ctx, files, runtime); err != nil {
+		return err
+	}
+	return m.conn.ReloadContext(ctx)
+}
+
+func (m *dbusManager) Reload(ctx context.Context) error {
+	return m.conn.ReloadContext(ctx)
+}
+
+func (m *dbusManager) UnitStatus(ctx context.Context, name string) (*UnitStatus, error) {
+	props, err := m.conn.GetUnitPropertiesContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	str := func(name string) string {
+		s, _ := props[name].(string)
+		return s
+	}
+	status := &UnitStatus{
+		Name:          str("Id"),
+		Description:   str("Description"),
+		LoadState:     str("LoadState"),
+		ActiveState:   str("ActiveState"),
+		SubState:      str("SubState"),
+		UnitFileState: str("UnitFileState"),
+	}
+	if status.Name == "" {
+		status.Name = name
+	}
+
+	if strings.HasSuffix(status.Name, ".service") && status.LoadState == "loaded" {
+		p, err := m.conn.GetServicePropertyContext(ctx, status.Name, "MainPID")
+		if err != nil {
+			return nil, err
+		}
+		status.MainPID, _ = p.Value.Value().(uint32)
+	}
+
+	return status, nil
+}
+
+func (m *dbusManager) ListSessions(ctx context.Context) ([]Session, error) {
+	m.mu.Lock()
+	if m.login == nil {
+		login, err := login1.New()
+		if err != nil {
+			m.mu.Unlock()
+			return nil, err
+		}
+		m.login = login
+	}
+	login := m.login
+	m.mu.Unlock()
+
+	sessions, err := login.ListSessionsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]Session, len(sessions))
+	for i, s := range sessions {
+		ret[i] = Session{ID: s.ID, UID: s.UID, User: s.User, Seat: s.Seat}
+	}
+	return ret, nil
+}
+
+func (m *dbusManager) Close() {
+	m.conn.Close()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.login != nil {
+		m.login.Close()
+	}
+}
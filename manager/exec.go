@@ -0,0 +1,213 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	sd_dbus "github.com/gr-butler/go-systemd/v22/dbus"
+)
+
+// statusProperties are the unit properties queried for UnitStatus.
+var statusProperties = []string{"Id", "Description", "LoadState", "ActiveState", "SubState", "UnitFileState", "MainPID"}
+
+// execManager is the Manager of BackendExec.
+type execManager struct {
+	systemctl string
+	loginctl  string
+	user      bool
+}
+
+func newExecManager(opts Options) (*execManager, error) {
+	systemctl, err := lookPath(opts.Systemctl, "systemctl")
+	if err != nil {
+		return nil, err
+	}
+	// loginctl is only needed to list sessions, so it is looked up then
+	return &execManager{systemctl: systemctl, loginctl: opts.Loginctl, user: opts.User}, nil
+}
+
+// run executes cmd with args and returns its output. If it fails, the
+// error includes the message written by the command to stderr.
+func run(ctx context.Context, cmd string, args ...string) ([]byte, error) {
+	c := exec.CommandContext(ctx, cmd, args...)
+	var stderr bytes.Buffer
+	c.Stderr = &stderr
+
+	out, err := c.Output()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return nil, fmt.Errorf("%s %s: %w", cmd, strings.Join(args, " "), err)
+		}
+		// systemctl reports failed jobs with "Job for <unit> failed ..."
+		if strings.HasPrefix(msg, "Job for ") {
+			return nil, fmt.Errorf("%w: %s", sd_dbus.ErrJobFailed, msg)
+		}
+		return nil, fmt.Errorf("%s %s: %w: %s", cmd, strings.Join(args, " "), err, msg)
+	}
+	return out, nil
+}
+
+// systemctlCmd runs systemctl with args, for the user manager if selected.
+func (m *execManager) systemctlCmd(ctx context.Context, args ...string) ([]byte, error) {
+	flags := []string{"--no-ask-password"}
+	if m.user {
+		flags = append(flags, "--user")
+	}
+	return run(ctx, m.systemctl, append(flags, args...)...)
+}
+
+func (m *execManager) unitCommand(ctx context.Context, verb, name string) error {
+	_, err := m.systemctlCmd(ctx, verb, "--", name)
+	return err
+}
+
+func (m *execManager) StartUnit(ctx context.Context, name string) error {
+	return m.unitCommand(ctx, "start", name)
+}
+
+func (m *execManager) StopUnit(ctx context.Context, name string) error {
+	return m.unitCommand(ctx, "stop", name)
+}
+
+func (m *execManager) RestartUnit(ctx context.Context, name string) error {
+	return m.unitCommand(ctx, "restart", name)
+}
+
+func (m *execManager) ReloadUnit(ctx context.Context, name string) error {
+	return m.unitCommand(ctx, "reload", name)
+}
+
+func (m *execManager) unitFilesCommand(ctx context.Context, verb string, files []string, runtime bool) error {
+	args := []string{verb}
+	if runtime {
+		args = append(args, "--runtime")
+	}
+	_, err := m.systemctlCmd(ctx, append(append(args, "--"), files...)...)
+	return err
+}
+
+func (m *execManager) EnableUnitFiles(ctx context.Context, files []string, runtime bool) error {
+	return m.unitFilesCommand(ctx, "enable", files, runtime)
+}
+
+func (m *execManager) DisableUnitFiles(ctx context.Context, files []string, runtime bool) error {
+	return m.unitFilesCommand(ctx, "disable", files, runtime)
+}
+
+func (m *execManager) Reload(ctx context.Context) error {
+	_, err := m.systemctlCmd(ctx, "daemon-reload")
+	return err
+}
+
+func (m *execManager) UnitStatus(ctx context.Context, name string) (*UnitStatus, error) {
+	out, err := m.systemctlCmd(ctx, "show", "--property="+strings.Join(statusProperties, ","), "--", name)
+	if err != nil {
+		return nil, err
+	}
+	status, err := parseShow(out)
+	if err != nil {
+		return nil, err
+	}
+	if status.Name == "" {
+		status.Name = name
+	}
+	return status, nil
+}
+
+// parseShow parses the output of systemctl show for the statusProperties.
+func parseShow(out []byte) (*UnitStatus, error) {
+	status := &UnitStatus{}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Id":
+			status.Name = value
+		case "Description":
+			status.Description = value
+		case "LoadState":
+			status.LoadState = value
+		case "ActiveState":
+			status.ActiveState = value
+		case "SubState":
+			status.SubState = value
+		case "UnitFileState":
+			status.UnitFileState = value
+		case "MainPID":
+			pid, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid MainPID %q: %w", value, err)
+			}
+			status.MainPID = uint32(pid)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if status.LoadState == "" {
+		return nil, errors.New("no LoadState in the output of systemctl show")
+	}
+	return status, nil
+}
+
+func (m *execManager) ListSessions(ctx context.Context) ([]Session, error) {
+	loginctl, err := lookPath(m.loginctl, "loginctl")
+	if err != nil {
+		return nil, err
+	}
+	out, err := run(ctx, loginctl, "--no-ask-password", "--output=json", "list-sessions")
+	if err != nil {
+		return nil, err
+	}
+	return parseSessions(out)
+}
+
+// parseSessions parses the JSON output of loginctl list-sessions. Columns
+// without value, e.g. the seat, are null, which leaves the fields empty.
+func parseSessions(out []byte) ([]Session, error) {
+	var rows []struct {
+		Session string `json:"session"`
+		UID     uint32 `json:"uid"`
+		User    string `json:"user"`
+		Seat    string `json:"seat"`
+	}
+	if err := json.Unmarshal(out, &rows); err != nil {
+		return nil, fmt.Errorf("invalid output of loginctl list-sessions: %w", err)
+	}
+
+	sessions := make([]Session, len(rows))
+	for i, row := range rows {
+		sessions[i] = Session{ID: row.Session, UID: row.UID, User: row.User, Seat: row.Seat}
+	}
+	return sessions, nil
+}
+
+func (m *execManager) Close() {}
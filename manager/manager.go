@@ -0,0 +1,143 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package manager provides the core operations on the service manager,
+// starting, stopping and enabling units and querying their status, and on
+// the login manager, with a D-Bus backend and a backend executing systemctl
+// and loginctl. The latter allows for running the same code in restricted
+// environments, e.g. containers, where the D-Bus socket is not available but
+// the systemctl and loginctl commands can reach systemd through its private
+// socket.
+package manager
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+)
+
+// Manager starts, stops and enables units and reports their status. The
+// methods changing the state of units wait for their jobs to complete, and
+// fail with an error matching dbus.ErrJobFailed if a job does not succeed.
+type Manager interface {
+	StartUnit(ctx context.Context, name string) error
+	StopUnit(ctx context.Context, name string) error
+	RestartUnit(ctx context.Context, name string) error
+	ReloadUnit(ctx context.Context, name string) error
+
+	// EnableUnitFiles and DisableUnitFiles enable and disable the unit
+	// files, in /run if runtime is true, and reload the manager
+	// configuration, like systemctl enable and disable.
+	EnableUnitFiles(ctx context.Context, files []string, runtime bool) error
+	DisableUnitFiles(ctx context.Context, files []string, runtime bool) error
+	// Reload reloads the manager configuration, like systemctl
+	// daemon-reload.
+	Reload(ctx context.Context) error
+
+	// UnitStatus returns the status of the unit. Units which are not found
+	// are reported with the not-found load state rather than an error.
+	UnitStatus(ctx context.Context, name string) (*UnitStatus, error)
+
+	// ListSessions lists the sessions of the login manager.
+	ListSessions(ctx context.Context) ([]Session, error)
+
+	Close()
+}
+
+// UnitStatus is the status of a unit.
+type UnitStatus struct {
+	Name          string
+	Description   string
+	LoadState     string // e.g. loaded, not-found or masked
+	ActiveState   string // e.g. active, inactive or failed
+	SubState      string // e.g. running or dead
+	UnitFileState string // e.g. enabled or disabled; empty if the unit has no unit file
+	MainPID       uint32 // The main process of a service, 0 if there is none
+}
+
+// Session is a session of the login manager.
+type Session struct {
+	ID   string
+	UID  uint32
+	User string
+	Seat string // empty if the session is not attached to a seat
+}
+
+// Backend selects the implementation of Manager.
+type Backend int
+
+const (
+	// BackendAuto uses D-Bus if a connection can be established, and the
+	// commands otherwise.
+	BackendAuto Backend = iota
+	// BackendDBus uses the dbus and login1 packages.
+	BackendDBus
+	// BackendExec executes systemctl and loginctl.
+	BackendExec
+)
+
+func (b Backend) String() string {
+	switch b {
+	case BackendAuto:
+		return "auto"
+	case BackendDBus:
+		return "dbus"
+	case BackendExec:
+		return "exec"
+	}
+	return fmt.Sprintf("Backend(%d)", int(b))
+}
+
+// Options configures a Manager.
+type Options struct {
+	Backend Backend
+	// User selects the user manager of the calling user rather than the
+	// system manager. Sessions are always listed from the system login
+	// manager.
+	User bool
+
+	// Systemctl and Loginctl are the commands executed by the exec
+	// backend. They default to systemctl and loginctl, looked up in PATH.
+	Systemctl string
+	Loginctl  string
+}
+
+// New returns a Manager using the backend selected by opts. Callers should
+// call Close() when done with it.
+func New(ctx context.Context, opts Options) (Manager, error) {
+	switch opts.Backend {
+	case BackendDBus:
+		return newDBusManager(ctx, opts.User)
+	case BackendExec:
+		return newExecManager(opts)
+	case BackendAuto:
+		m, err := newDBusManager(ctx, opts.User)
+		if err == nil {
+			return m, nil
+		}
+		if em, execErr := newExecManager(opts); execErr == nil {
+			return em, nil
+		}
+		return nil, err
+	}
+	return nil, fmt.Errorf("unknown backend %v", opts.Backend)
+}
+
+// lookPath returns the path of the command cmd, defaulting to name.
+func lookPath(cmd, name string) (string, error) {
+	if cmd == "" {
+		cmd = name
+	}
+	return exec.LookPath(cmd)
+}
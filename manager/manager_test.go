@@ -0,0 +1,184 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	sd_dbus "github.com/gr-butler/go-systemd/v22/dbus"
+)
+
+// fakeCommand writes a shell script to dir which logs its arguments to the
+// file log, writes stdout and stderr, and exits with status.
+func fakeCommand(t *testing.T, dir, name, stdout, stderr string, status int) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	script := "#!/bin/sh\n" +
+		"echo \"$@\" >>" + filepath.Join(dir, "log") + "\n" +
+		"printf '%s' '" + stdout + "'\n" +
+		"printf '%s' '" + stderr + "' >&2\n" +
+		"exit " + strconv.Itoa(status) + "\n"
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readLog(t *testing.T, dir string) []string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, "log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+func TestExecManager(t *testing.T) {
+	dir := t.TempDir()
+	show := "Id=foo.service\nDescription=Foo\nLoadState=loaded\nActiveState=active\nSubState=running\nUnitFileState=enabled\nMainPID=42\n"
+	m, err := New(context.Background(), Options{
+		Backend:   BackendExec,
+		User:      true,
+		Systemctl: fakeCommand(t, dir, "systemctl", show, "", 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+
+	ctx := context.Background()
+	for _, err := range []error{
+		m.StartUnit(ctx, "foo.service"),
+		m.RestartUnit(ctx, "foo.service"),
+		m.EnableUnitFiles(ctx, []string{"foo.service", "bar.socket"}, true),
+		m.DisableUnitFiles(ctx, []string{"foo.service"}, false),
+		m.Reload(ctx),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	status, err := m.UnitStatus(ctx, "foo.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedStatus := &UnitStatus{
+		Name:          "foo.service",
+		Description:   "Foo",
+		LoadState:     "loaded",
+		ActiveState:   "active",
+		SubState:      "running",
+		UnitFileState: "enabled",
+		MainPID:       42,
+	}
+	if !reflect.DeepEqual(status, expectedStatus) {
+		t.Errorf("expected %+v, got %+v", expectedStatus, status)
+	}
+
+	expected := []string{
+		"--no-ask-password --user start -- foo.service",
+		"--no-ask-password --user restart -- foo.service",
+		"--no-ask-password --user enable --runtime -- foo.service bar.socket",
+		"--no-ask-password --user disable -- foo.service",
+		"--no-ask-password --user daemon-reload",
+		"--no-ask-password --user show --property=Id,Description,LoadState,ActiveState,SubState,UnitFileState,MainPID -- foo.service",
+	}
+	if log := readLog(t, dir); !reflect.DeepEqual(log, expected) {
+		t.Errorf("expected commands %q, got %q", expected, log)
+	}
+}
+
+func TestExecManagerErrors(t *testing.T) {
+	dir := t.TempDir()
+	m, err := New(context.Background(), Options{
+		Backend:   BackendExec,
+		Systemctl: fakeCommand(t, dir, "systemctl", "", "Job for foo.service failed because the control process exited with error code.", 1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = m.StartUnit(context.Background(), "foo.service")
+	if !errors.Is(err, sd_dbus.ErrJobFailed) {
+		t.Errorf("expected an error matching ErrJobFailed, got %v", err)
+	}
+
+	m, err = New(context.Background(), Options{
+		Backend:   BackendExec,
+		Systemctl: fakeCommand(t, dir, "systemctl2", "", "Failed to connect to bus", 1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = m.StopUnit(context.Background(), "foo.service")
+	if err == nil || errors.Is(err, sd_dbus.ErrJobFailed) || !strings.Contains(err.Error(), "Failed to connect to bus") {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if _, err := New(context.Background(), Options{Backend: BackendExec, Systemctl: filepath.Join(dir, "missing")}); err == nil {
+		t.Error("expected an error for a missing systemctl")
+	}
+}
+
+func TestExecListSessions(t *testing.T) {
+	dir := t.TempDir()
+	sessions := `[{"session":"2","uid":1000,"user":"core","seat":"seat0","tty":"tty1"},{"session":"c1","uid":0,"user":"root","seat":null,"tty":null}]`
+	m, err := New(context.Background(), Options{
+		Backend:   BackendExec,
+		Systemctl: fakeCommand(t, dir, "systemctl", "", "", 0),
+		Loginctl:  fakeCommand(t, dir, "loginctl", sessions, "", 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := m.ListSessions(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Session{
+		{ID: "2", UID: 1000, User: "core", Seat: "seat0"},
+		{ID: "c1", UID: 0, User: "root"},
+	}
+	if !reflect.DeepEqual(list, expected) {
+		t.Errorf("expected %+v, got %+v", expected, list)
+	}
+}
+
+func TestParseShow(t *testing.T) {
+	status, err := parseShow([]byte("Id=foo.socket\nLoadState=not-found\nActiveState=inactive\nSubState=dead\nUnitFileState=\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &UnitStatus{Name: "foo.socket", LoadState: "not-found", ActiveState: "inactive", SubState: "dead"}
+	if !reflect.DeepEqual(status, expected) {
+		t.Errorf("expected %+v, got %+v", expected, status)
+	}
+
+	if _, err := parseShow([]byte("MainPID=x\nLoadState=loaded\n")); err == nil {
+		t.Error("expected an error for an invalid MainPID")
+	}
+	if _, err := parseShow(nil); err == nil {
+		t.Error("expected an error for missing properties")
+	}
+}
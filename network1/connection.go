@@ -0,0 +1,35 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+package network1
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"time"
+)
+
+// Connection is the interface of Conn, which allows for replacing it in tests,
+// e.g. by the fake of the network1test package. See Conn for the documentation of
+// the methods.
+type Connection interface {
+	Close() error
+	Connected() bool
+	Describe(ctx context.Context) (json.RawMessage, error)
+	DescribeLink(ctx context.Context, ifindex int) (*LinkDescription, error)
+	ForceRenewLink(ctx context.Context, ifindex int) error
+	GetLinkByIndex(ctx context.Context, ifindex int) (*Link, error)
+	GetLinkByName(ctx context.Context, name string) (*Link, error)
+	ListLinks(ctx context.Context) ([]Link, error)
+	ReconfigureLink(ctx context.Context, ifindex int) error
+	Reload(ctx context.Context) error
+	RenewLink(ctx context.Context, ifindex int) error
+	RevertLinkDNS(ctx context.Context, ifindex int) error
+	RevertLinkNTP(ctx context.Context, ifindex int) error
+	SetLinkDNS(ctx context.Context, ifindex int, servers []net.IP) error
+	SetLinkDomains(ctx context.Context, ifindex int, domains []Domain) error
+	SetLinkNTP(ctx context.Context, ifindex int, servers []string) error
+	SetTimeout(timeout time.Duration)
+}
+
+var _ Connection = (*Conn)(nil)
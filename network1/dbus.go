@@ -0,0 +1,317 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package network1 provides integration with the systemd-networkd API. See
+// https://www.freedesktop.org/software/systemd/man/org.freedesktop.network1.html
+package network1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/gr-butler/go-systemd/v22/internal/calltimeout"
+)
+
+//go:generate go run github.com/gr-butler/go-systemd/v22/internal/fakegen
+
+const (
+	dbusDest          = "org.freedesktop.network1"
+	dbusInterface     = "org.freedesktop.network1.Manager"
+	dbusLinkInterface = "org.freedesktop.network1.Link"
+	dbusPath          = "/org/freedesktop/network1"
+)
+
+// Address families, as used by systemd-networkd on Linux.
+const (
+	FamilyIPv4 = 2  // AF_INET
+	FamilyIPv6 = 10 // AF_INET6
+)
+
+// Conn is a connection to systemd-networkd's dbus endpoint.
+type Conn struct {
+	conn   *dbus.Conn
+	object dbus.BusObject
+
+	timeout time.Duration
+}
+
+// Link is a network interface known to systemd-networkd.
+type Link struct {
+	Index int
+	Name  string
+	Path  dbus.ObjectPath
+}
+
+// Domain is a search or routing domain of a link.
+type Domain struct {
+	Name string
+	// RoutingOnly is whether the domain is only used to route lookups to
+	// the link, rather than as a search domain too.
+	RoutingOnly bool
+}
+
+// LinkAddress is an address of a link, as described by DescribeLink.
+type LinkAddress struct {
+	Family       int
+	Address      []byte
+	PrefixLength int
+	Scope        int
+	ConfigSource string // e.g. static, DHCPv4, DHCPv6 or foreign
+	ConfigState  string
+}
+
+// IP returns the address as a net.IP.
+func (a LinkAddress) IP() net.IP {
+	return net.IP(a.Address)
+}
+
+// LinkDescription is the JSON description of a link, as shown by networkctl
+// status --json. Only the most common fields are decoded; all of them are
+// available in Raw.
+type LinkDescription struct {
+	Index               int
+	Name                string
+	AlternativeNames    []string
+	Type                string
+	Kind                string
+	Driver              string
+	MTU                 uint32
+	AdministrativeState string // e.g. configuring, configured, unmanaged or failed
+	OperationalState    string // e.g. off, no-carrier, degraded or routable
+	CarrierState        string
+	AddressState        string
+	OnlineState         string
+	NetworkFile         string
+	Addresses           []LinkAddress
+
+	Raw json.RawMessage `json:"-"`
+}
+
+// New establishes a connection to the system bus and authenticates.
+func New() (*Conn, error) {
+	c := new(Conn)
+
+	if err := c.initConnection(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Conn) initConnection() error {
+	var err error
+	c.conn, err = dbus.SystemBusPrivate()
+	if err != nil {
+		return err
+	}
+
+	// Only use EXTERNAL method, and hardcode the uid (not username)
+	// to avoid a username lookup (which requires a dynamically linked
+	// libc)
+	methods := []dbus.Auth{dbus.AuthExternal(strconv.Itoa(os.Getuid()))}
+
+	err = c.conn.Auth(methods)
+	if err != nil {
+		c.conn.Close()
+		return err
+	}
+
+	err = c.conn.Hello()
+	if err != nil {
+		c.conn.Close()
+		return err
+	}
+
+	c.object = c.conn.Object(dbusDest, dbus.ObjectPath(dbusPath))
+
+	return nil
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// Connected returns whether conn is connected
+func (c *Conn) Connected() bool {
+	return c.conn.Connected()
+}
+
+// SetTimeout sets the default timeout of the method calls made on the
+// connection without a context deadline, including those made by the
+// methods without context, which otherwise block forever if systemd-networkd
+// hangs. It is disabled by a timeout of zero, the default. SetTimeout must
+// not be called concurrently with method calls.
+func (c *Conn) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+	c.object = c.pathObject(dbus.ObjectPath(dbusPath))
+}
+
+// pathObject returns the object at path on the network1 service.
+func (c *Conn) pathObject(path dbus.ObjectPath) dbus.BusObject {
+	return calltimeout.Object(c.conn.Object(dbusDest, path), c.timeout)
+}
+
+// ListLinks returns the network interfaces known to systemd-networkd.
+func (c *Conn) ListLinks(ctx context.Context) ([]Link, error) {
+	var result []struct {
+		Index int32
+		Name  string
+		Path  dbus.ObjectPath
+	}
+	if err := c.object.CallWithContext(ctx, dbusInterface+".ListLinks", 0).Store(&result); err != nil {
+		return nil, err
+	}
+
+	links := make([]Link, 0, len(result))
+	for _, r := range result {
+		links = append(links, Link{Index: int(r.Index), Name: r.Name, Path: r.Path})
+	}
+	return links, nil
+}
+
+// GetLinkByName returns the link with the given interface name.
+func (c *Conn) GetLinkByName(ctx context.Context, name string) (*Link, error) {
+	link := &Link{Name: name}
+	var index int32
+	if err := c.object.CallWithContext(ctx, dbusInterface+".GetLinkByName", 0, name).Store(&index, &link.Path); err != nil {
+		return nil, err
+	}
+	link.Index = int(index)
+	return link, nil
+}
+
+// GetLinkByIndex returns the link with the given interface index.
+func (c *Conn) GetLinkByIndex(ctx context.Context, ifindex int) (*Link, error) {
+	link := &Link{Index: ifindex}
+	if err := c.object.CallWithContext(ctx, dbusInterface+".GetLinkByIndex", 0, int32(ifindex)).Store(&link.Name, &link.Path); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// DescribeLink returns the description of a link.
+func (c *Conn) DescribeLink(ctx context.Context, ifindex int) (*LinkDescription, error) {
+	link, err := c.GetLinkByIndex(ctx, ifindex)
+	if err != nil {
+		return nil, err
+	}
+
+	var description string
+	obj := c.pathObject(link.Path)
+	if err := obj.CallWithContext(ctx, dbusLinkInterface+".Describe", 0).Store(&description); err != nil {
+		return nil, err
+	}
+	return parseLinkDescription([]byte(description))
+}
+
+func parseLinkDescription(data []byte) (*LinkDescription, error) {
+	d := &LinkDescription{}
+	if err := json.Unmarshal(data, d); err != nil {
+		return nil, fmt.Errorf("failed to decode link description: %w", err)
+	}
+	d.Raw = json.RawMessage(data)
+	return d, nil
+}
+
+// Describe returns the JSON description of all the links and of the global
+// configuration of systemd-networkd.
+func (c *Conn) Describe(ctx context.Context) (json.RawMessage, error) {
+	var description string
+	if err := c.object.CallWithContext(ctx, dbusInterface+".Describe", 0).Store(&description); err != nil {
+		return nil, err
+	}
+	if !json.Valid([]byte(description)) {
+		return nil, fmt.Errorf("invalid JSON description %q", description)
+	}
+	return json.RawMessage(description), nil
+}
+
+// RenewLink renews the DHCP leases of a link.
+func (c *Conn) RenewLink(ctx context.Context, ifindex int) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".RenewLink", 0, int32(ifindex)).Err
+}
+
+// ForceRenewLink makes the DHCP server of a link send FORCERENEW messages to
+// its clients.
+func (c *Conn) ForceRenewLink(ctx context.Context, ifindex int) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".ForceRenewLink", 0, int32(ifindex)).Err
+}
+
+// ReconfigureLink reconfigures a link from its .network file, e.g. after it
+// was edited and Reload was called.
+func (c *Conn) ReconfigureLink(ctx context.Context, ifindex int) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".ReconfigureLink", 0, int32(ifindex)).Err
+}
+
+// Reload reloads the .netdev and .network files. Links whose configuration
+// changed are reconfigured.
+func (c *Conn) Reload(ctx context.Context) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".Reload", 0).Err
+}
+
+// SetLinkDNS sets the DNS servers of a link at runtime, overriding its
+// .network file until RevertLinkDNS is called.
+func (c *Conn) SetLinkDNS(ctx context.Context, ifindex int, servers []net.IP) error {
+	type server struct {
+		Family  int32
+		Address []byte
+	}
+	wire := make([]server, 0, len(servers))
+	for _, ip := range servers {
+		if ip4 := ip.To4(); ip4 != nil {
+			wire = append(wire, server{FamilyIPv4, ip4})
+		} else if ip16 := ip.To16(); ip16 != nil {
+			wire = append(wire, server{FamilyIPv6, ip16})
+		} else {
+			return fmt.Errorf("invalid IP address %v", ip)
+		}
+	}
+	return c.object.CallWithContext(ctx, dbusInterface+".SetLinkDNS", 0, int32(ifindex), wire).Err
+}
+
+// SetLinkDomains sets the search and routing domains of a link at runtime.
+func (c *Conn) SetLinkDomains(ctx context.Context, ifindex int, domains []Domain) error {
+	if domains == nil {
+		domains = []Domain{}
+	}
+	return c.object.CallWithContext(ctx, dbusInterface+".SetLinkDomains", 0, int32(ifindex), domains).Err
+}
+
+// SetLinkNTP sets the NTP servers of a link at runtime, overriding its
+// .network file until RevertLinkNTP is called.
+func (c *Conn) SetLinkNTP(ctx context.Context, ifindex int, servers []string) error {
+	if servers == nil {
+		servers = []string{}
+	}
+	return c.object.CallWithContext(ctx, dbusInterface+".SetLinkNTP", 0, int32(ifindex), servers).Err
+}
+
+// RevertLinkDNS reverts the DNS servers and domains of a link set at
+// runtime.
+func (c *Conn) RevertLinkDNS(ctx context.Context, ifindex int) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".RevertLinkDNS", 0, int32(ifindex)).Err
+}
+
+// RevertLinkNTP reverts the NTP servers of a link set at runtime.
+func (c *Conn) RevertLinkNTP(ctx context.Context, ifindex int) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".RevertLinkNTP", 0, int32(ifindex)).Err
+}
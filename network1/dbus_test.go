@@ -0,0 +1,80 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package network1
+
+import (
+	"context"
+	"net"
+	"testing"
+)
+
+func TestParseLinkDescription(t *testing.T) {
+	data := `{
+		"Index": 2,
+		"Name": "eth0",
+		"AlternativeNames": ["enp1s0"],
+		"Type": "ether",
+		"Driver": "virtio_net",
+		"MTU": 1500,
+		"HardwareAddress": [82, 84, 0, 18, 52, 86],
+		"AdministrativeState": "configured",
+		"OperationalState": "routable",
+		"NetworkFile": "/etc/systemd/network/10-eth0.network",
+		"Addresses": [
+			{"Family": 2, "Address": [192, 0, 2, 10], "PrefixLength": 24, "Scope": 0, "ConfigSource": "DHCPv4", "ConfigState": "configured"}
+		]
+	}`
+
+	d, err := parseLinkDescription([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Index != 2 || d.Name != "eth0" || d.MTU != 1500 || d.OperationalState != "routable" || d.NetworkFile == "" {
+		t.Errorf("unexpected description %+v", d)
+	}
+	if len(d.Addresses) != 1 || !d.Addresses[0].IP().Equal(net.IPv4(192, 0, 2, 10)) || d.Addresses[0].ConfigSource != "DHCPv4" {
+		t.Errorf("unexpected addresses %+v", d.Addresses)
+	}
+	if string(d.Raw) != data {
+		t.Error("expected the raw description to be kept")
+	}
+
+	if _, err := parseLinkDescription([]byte("{")); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestListLinks(t *testing.T) {
+	conn, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	links, err := conn.ListLinks(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, l := range links {
+		if l.Name == "lo" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the loopback link in %v", links)
+	}
+}
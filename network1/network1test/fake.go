@@ -0,0 +1,167 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+// Package network1test provides a fake of network1.Conn for unit tests.
+package network1test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/gr-butler/go-systemd/v22/network1"
+)
+
+// ErrNotImplemented is returned by the methods whose function field is unset.
+var ErrNotImplemented = errors.New("not implemented")
+
+// Conn is a fake network1.Connection. Each method calls the function field of
+// the same name with a Func suffix. If it is unset, the method returns zero
+// values and, if it returns an error, ErrNotImplemented.
+type Conn struct {
+	CloseFunc           func() error
+	ConnectedFunc       func() bool
+	DescribeFunc        func(ctx context.Context) (json.RawMessage, error)
+	DescribeLinkFunc    func(ctx context.Context, ifindex int) (*network1.LinkDescription, error)
+	ForceRenewLinkFunc  func(ctx context.Context, ifindex int) error
+	GetLinkByIndexFunc  func(ctx context.Context, ifindex int) (*network1.Link, error)
+	GetLinkByNameFunc   func(ctx context.Context, name string) (*network1.Link, error)
+	ListLinksFunc       func(ctx context.Context) ([]network1.Link, error)
+	ReconfigureLinkFunc func(ctx context.Context, ifindex int) error
+	ReloadFunc          func(ctx context.Context) error
+	RenewLinkFunc       func(ctx context.Context, ifindex int) error
+	RevertLinkDNSFunc   func(ctx context.Context, ifindex int) error
+	RevertLinkNTPFunc   func(ctx context.Context, ifindex int) error
+	SetLinkDNSFunc      func(ctx context.Context, ifindex int, servers []net.IP) error
+	SetLinkDomainsFunc  func(ctx context.Context, ifindex int, domains []network1.Domain) error
+	SetLinkNTPFunc      func(ctx context.Context, ifindex int, servers []string) error
+	SetTimeoutFunc      func(timeout time.Duration)
+}
+
+var _ network1.Connection = (*Conn)(nil)
+
+func (f *Conn) Close() error {
+	if f.CloseFunc == nil {
+		return fmt.Errorf("%w: Close", ErrNotImplemented)
+	}
+	return f.CloseFunc()
+}
+
+func (f *Conn) Connected() bool {
+	if f.ConnectedFunc == nil {
+		var r0 bool
+		return r0
+	}
+	return f.ConnectedFunc()
+}
+
+func (f *Conn) Describe(ctx context.Context) (json.RawMessage, error) {
+	if f.DescribeFunc == nil {
+		var r0 json.RawMessage
+		return r0, fmt.Errorf("%w: Describe", ErrNotImplemented)
+	}
+	return f.DescribeFunc(ctx)
+}
+
+func (f *Conn) DescribeLink(ctx context.Context, ifindex int) (*network1.LinkDescription, error) {
+	if f.DescribeLinkFunc == nil {
+		var r0 *network1.LinkDescription
+		return r0, fmt.Errorf("%w: DescribeLink", ErrNotImplemented)
+	}
+	return f.DescribeLinkFunc(ctx, ifindex)
+}
+
+func (f *Conn) ForceRenewLink(ctx context.Context, ifindex int) error {
+	if f.ForceRenewLinkFunc == nil {
+		return fmt.Errorf("%w: ForceRenewLink", ErrNotImplemented)
+	}
+	return f.ForceRenewLinkFunc(ctx, ifindex)
+}
+
+func (f *Conn) GetLinkByIndex(ctx context.Context, ifindex int) (*network1.Link, error) {
+	if f.GetLinkByIndexFunc == nil {
+		var r0 *network1.Link
+		return r0, fmt.Errorf("%w: GetLinkByIndex", ErrNotImplemented)
+	}
+	return f.GetLinkByIndexFunc(ctx, ifindex)
+}
+
+func (f *Conn) GetLinkByName(ctx context.Context, name string) (*network1.Link, error) {
+	if f.GetLinkByNameFunc == nil {
+		var r0 *network1.Link
+		return r0, fmt.Errorf("%w: GetLinkByName", ErrNotImplemented)
+	}
+	return f.GetLinkByNameFunc(ctx, name)
+}
+
+func (f *Conn) ListLinks(ctx context.Context) ([]network1.Link, error) {
+	if f.ListLinksFunc == nil {
+		var r0 []network1.Link
+		return r0, fmt.Errorf("%w: ListLinks", ErrNotImplemented)
+	}
+	return f.ListLinksFunc(ctx)
+}
+
+func (f *Conn) ReconfigureLink(ctx context.Context, ifindex int) error {
+	if f.ReconfigureLinkFunc == nil {
+		return fmt.Errorf("%w: ReconfigureLink", ErrNotImplemented)
+	}
+	return f.ReconfigureLinkFunc(ctx, ifindex)
+}
+
+func (f *Conn) Reload(ctx context.Context) error {
+	if f.ReloadFunc == nil {
+		return fmt.Errorf("%w: Reload", ErrNotImplemented)
+	}
+	return f.ReloadFunc(ctx)
+}
+
+func (f *Conn) RenewLink(ctx context.Context, ifindex int) error {
+	if f.RenewLinkFunc == nil {
+		return fmt.Errorf("%w: RenewLink", ErrNotImplemented)
+	}
+	return f.RenewLinkFunc(ctx, ifindex)
+}
+
+func (f *Conn) RevertLinkDNS(ctx context.Context, ifindex int) error {
+	if f.RevertLinkDNSFunc == nil {
+		return fmt.Errorf("%w: RevertLinkDNS", ErrNotImplemented)
+	}
+	return f.RevertLinkDNSFunc(ctx, ifindex)
+}
+
+func (f *Conn) RevertLinkNTP(ctx context.Context, ifindex int) error {
+	if f.RevertLinkNTPFunc == nil {
+		return fmt.Errorf("%w: RevertLinkNTP", ErrNotImplemented)
+	}
+	return f.RevertLinkNTPFunc(ctx, ifindex)
+}
+
+func (f *Conn) SetLinkDNS(ctx context.Context, ifindex int, servers []net.IP) error {
+	if f.SetLinkDNSFunc == nil {
+		return fmt.Errorf("%w: SetLinkDNS", ErrNotImplemented)
+	}
+	return f.SetLinkDNSFunc(ctx, ifindex, servers)
+}
+
+func (f *Conn) SetLinkDomains(ctx context.Context, ifindex int, domains []network1.Domain) error {
+	if f.SetLinkDomainsFunc == nil {
+		return fmt.Errorf("%w: SetLinkDomains", ErrNotImplemented)
+	}
+	return f.SetLinkDomainsFunc(ctx, ifindex, domains)
+}
+
+func (f *Conn) SetLinkNTP(ctx context.Context, ifindex int, servers []string) error {
+	if f.SetLinkNTPFunc == nil {
+		return fmt.Errorf("%w: SetLinkNTP", ErrNotImplemented)
+	}
+	return f.SetLinkNTPFunc(ctx, ifindex, servers)
+}
+
+func (f *Conn) SetTimeout(timeout time.Duration) {
+	if f.SetTimeoutFunc != nil {
+		f.SetTimeoutFunc(timeout)
+	}
+}
@@ -0,0 +1,23 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+package oomd1
+
+import (
+	"context"
+	"time"
+)
+
+// Connection is the interface of Conn, which allows for replacing it in tests,
+// e.g. by the fake of the oomd1test package. See Conn for the documentation of
+// the methods.
+type Connection interface {
+	Close() error
+	Connected() bool
+	DumpByFileDescriptor(ctx context.Context) (string, error)
+	GetDump(ctx context.Context) (*Dump, error)
+	ListMonitoredCGroups(ctx context.Context) ([]string, error)
+	SetTimeout(timeout time.Duration)
+	SubscribeKills(ctx context.Context, fn func(Kill)) error
+}
+
+var _ Connection = (*Conn)(nil)
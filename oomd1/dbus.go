@@ -0,0 +1,272 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package oomd1 provides integration with the systemd-oomd API on
+// org.freedesktop.oom1. See
+// https://www.freedesktop.org/software/systemd/man/org.freedesktop.oom1.html
+package oomd1
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/gr-butler/go-systemd/v22/internal/calltimeout"
+)
+
+//go:generate go run github.com/gr-butler/go-systemd/v22/internal/fakegen
+
+const (
+	dbusDest      = "org.freedesktop.oom1"
+	dbusInterface = "org.freedesktop.oom1.Manager"
+	dbusPath      = "/org/freedesktop/oom1"
+)
+
+// Conn is a connection to systemd-oomd's dbus endpoint.
+type Conn struct {
+	conn   *dbus.Conn
+	object dbus.BusObject
+
+	timeout time.Duration
+}
+
+// MonitoredCGroup is a cgroup monitored by systemd-oomd.
+type MonitoredCGroup struct {
+	Path string
+	// Properties holds the reported state of the cgroup, e.g. "Memory
+	// Pressure Limit", "Current Memory Usage" or "Swap Usage".
+	Properties map[string]string
+}
+
+// Dump is the state of systemd-oomd, as shown by oomctl dump.
+type Dump struct {
+	DryRun bool
+	// Settings holds the global settings, e.g. "Swap Used Limit" and
+	// "Default Memory Pressure Limit".
+	Settings map[string]string
+	// SwapMonitored are the cgroups with ManagedOOMSwap=kill.
+	SwapMonitored []MonitoredCGroup
+	// MemoryPressureMonitored are the cgroups with
+	// ManagedOOMMemoryPressure=kill.
+	MemoryPressureMonitored []MonitoredCGroup
+	// Raw is the unparsed dump.
+	Raw string
+}
+
+// Kill is a kill action of systemd-oomd.
+type Kill struct {
+	CGroup string // The cgroup whose processes were killed
+	Reason string // e.g. memory-used or memory-pressure
+}
+
+// New establishes a connection to the system bus and authenticates.
+func New() (*Conn, error) {
+	c := new(Conn)
+
+	if err := c.initConnection(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Conn) initConnection() error {
+	var err error
+	c.conn, err = dbus.SystemBusPrivate()
+	if err != nil {
+		return err
+	}
+
+	// Only use EXTERNAL method, and hardcode the uid (not username)
+	// to avoid a username lookup (which requires a dynamically linked
+	// libc)
+	methods := []dbus.Auth{dbus.AuthExternal(strconv.Itoa(os.Getuid()))}
+
+	err = c.conn.Auth(methods)
+	if err != nil {
+		c.conn.Close()
+		return err
+	}
+
+	err = c.conn.Hello()
+	if err != nil {
+		c.conn.Close()
+		return err
+	}
+
+	c.object = c.conn.Object(dbusDest, dbus.ObjectPath(dbusPath))
+
+	return nil
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// Connected returns whether conn is connected
+func (c *Conn) Connected() bool {
+	return c.conn.Connected()
+}
+
+// SetTimeout sets the default timeout of the method calls made on the
+// connection without a context deadline, including those made by the
+// methods without context, which otherwise block forever if systemd-oomd
+// hangs. It is disabled by a timeout of zero, the default. SetTimeout must
+// not be called concurrently with method calls.
+func (c *Conn) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+	c.object = calltimeout.Object(c.conn.Object(dbusDest, dbus.ObjectPath(dbusPath)), timeout)
+}
+
+// DumpByFileDescriptor returns the raw state dump of systemd-oomd, read from
+// the file descriptor it passes.
+func (c *Conn) DumpByFileDescriptor(ctx context.Context) (string, error) {
+	var fd dbus.UnixFD
+	if err := c.object.CallWithContext(ctx, dbusInterface+".DumpByFileDescriptor", 0).Store(&fd); err != nil {
+		return "", err
+	}
+
+	f := os.NewFile(uintptr(fd), "oomd-dump")
+	defer f.Close()
+
+	dump, err := io.ReadAll(f)
+	return string(dump), err
+}
+
+// GetDump returns the parsed state of systemd-oomd, including the cgroups it
+// monitors.
+func (c *Conn) GetDump(ctx context.Context) (*Dump, error) {
+	raw, err := c.DumpByFileDescriptor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDump(raw)
+}
+
+// ListMonitoredCGroups returns the paths of the cgroups monitored by
+// systemd-oomd, for swap or memory pressure.
+func (c *Conn) ListMonitoredCGroups(ctx context.Context) ([]string, error) {
+	d, err := c.GetDump(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	seen := make(map[string]bool)
+	for _, cg := range append(d.SwapMonitored, d.MemoryPressureMonitored...) {
+		if !seen[cg.Path] {
+			seen[cg.Path] = true
+			paths = append(paths, cg.Path)
+		}
+	}
+	return paths, nil
+}
+
+// ParseDump parses the output of oomctl dump or DumpByFileDescriptor.
+func ParseDump(raw string) (*Dump, error) {
+	d := &Dump{Settings: make(map[string]string), Raw: raw}
+
+	var list *[]MonitoredCGroup
+	var current *MonitoredCGroup
+	scanner := bufio.NewScanner(strings.NewReader(raw))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		indented := line != strings.TrimLeft(line, " \t")
+
+		switch {
+		case trimmed == "Swap Monitored CGroups:":
+			list, current = &d.SwapMonitored, nil
+		case trimmed == "Memory Pressure Monitored CGroups:":
+			list, current = &d.MemoryPressureMonitored, nil
+		case !indented:
+			list, current = nil, nil
+			key, value, ok := strings.Cut(trimmed, ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid line in oomd dump: %q", line)
+			}
+			value = strings.TrimSpace(value)
+			if key == "Dry Run" {
+				d.DryRun = value == "yes"
+			} else if value != "" {
+				d.Settings[key] = value
+			}
+		case list != nil:
+			key, value, _ := strings.Cut(trimmed, ":")
+			value = strings.TrimSpace(value)
+			if key == "Path" {
+				*list = append(*list, MonitoredCGroup{Path: value, Properties: make(map[string]string)})
+				current = &(*list)[len(*list)-1]
+			} else if current != nil {
+				current.Properties[key] = value
+			}
+		}
+		// other indented lines, e.g. of the System Context, are skipped
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// SubscribeKills calls fn for each kill action of systemd-oomd until ctx is
+// done. It requires systemd v256 or higher, which emits the Killed signal.
+func (c *Conn) SubscribeKills(ctx context.Context, fn func(Kill)) error {
+	match := []dbus.MatchOption{
+		dbus.WithMatchSender(dbusDest),
+		dbus.WithMatchObjectPath(dbusPath),
+		dbus.WithMatchInterface(dbusInterface),
+		dbus.WithMatchMember("Killed"),
+	}
+	if err := c.conn.AddMatchSignalContext(ctx, match...); err != nil {
+		return err
+	}
+	defer c.conn.RemoveMatchSignal(match...)
+
+	ch := make(chan *dbus.Signal, 16)
+	c.conn.Signal(ch)
+	defer c.conn.RemoveSignal(ch)
+
+	for {
+		select {
+		case s := <-ch:
+			if k, ok := killFromSignal(s); ok {
+				fn(k)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func killFromSignal(s *dbus.Signal) (Kill, bool) {
+	if s.Name != dbusInterface+".Killed" || len(s.Body) < 2 {
+		return Kill{}, false
+	}
+	cgroup, ok1 := s.Body[0].(string)
+	reason, ok2 := s.Body[1].(string)
+	return Kill{CGroup: cgroup, Reason: reason}, ok1 && ok2
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oomd1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const testDump = `Dry Run: no
+Swap Used Limit: 90.00%
+Default Memory Pressure Limit: 60.00%
+Default Memory Pressure Duration: 20s
+System Context:
+	Memory: Used: 1.4G Total: 3.8G
+	Swap: Used: 0B Total: 0B
+Swap Monitored CGroups:
+	Path: /
+		Swap Usage: (see System Context)
+Memory Pressure Monitored CGroups:
+	Path: /user.slice/user-1000.slice/user@1000.service
+		Memory Pressure Limit: 50.00%
+		Pressure: Avg10: 0.00 Avg60: 0.00 Avg300: 0.00 Total: 0
+		Current Memory Usage: 1.1G
+	Path: /system.slice
+		Memory Pressure Limit: 60.00%
+`
+
+func TestParseDump(t *testing.T) {
+	d, err := ParseDump(testDump)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Dump{
+		Settings: map[string]string{
+			"Swap Used Limit":                  "90.00%",
+			"Default Memory Pressure Limit":    "60.00%",
+			"Default Memory Pressure Duration": "20s",
+		},
+		SwapMonitored: []MonitoredCGroup{
+			{Path: "/", Properties: map[string]string{"Swap Usage": "(see System Context)"}},
+		},
+		MemoryPressureMonitored: []MonitoredCGroup{
+			{
+				Path: "/user.slice/user-1000.slice/user@1000.service",
+				Properties: map[string]string{
+					"Memory Pressure Limit": "50.00%",
+					"Pressure":              "Avg10: 0.00 Avg60: 0.00 Avg300: 0.00 Total: 0",
+					"Current Memory Usage":  "1.1G",
+				},
+			},
+			{Path: "/system.slice", Properties: map[string]string{"Memory Pressure Limit": "60.00%"}},
+		},
+		Raw: testDump,
+	}
+	if !reflect.DeepEqual(d, expected) {
+		t.Errorf("expected %+v, got %+v", expected, d)
+	}
+}
+
+func TestKillFromSignal(t *testing.T) {
+	s := &dbus.Signal{Name: dbusInterface + ".Killed", Body: []interface{}{"/system.slice/foo.service", "memory-pressure"}}
+	k, ok := killFromSignal(s)
+	if !ok || k != (Kill{CGroup: "/system.slice/foo.service", Reason: "memory-pressure"}) {
+		t.Errorf("unexpected kill %+v, %v", k, ok)
+	}
+
+	if _, ok := killFromSignal(&dbus.Signal{Name: dbusInterface + ".Killed"}); ok {
+		t.Error("expected a Killed signal without body to be ignored")
+	}
+}
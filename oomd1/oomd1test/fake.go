@@ -0,0 +1,83 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+// Package oomd1test provides a fake of oomd1.Conn for unit tests.
+package oomd1test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gr-butler/go-systemd/v22/oomd1"
+)
+
+// ErrNotImplemented is returned by the methods whose function field is unset.
+var ErrNotImplemented = errors.New("not implemented")
+
+// Conn is a fake oomd1.Connection. Each method calls the function field of
+// the same name with a Func suffix. If it is unset, the method returns zero
+// values and, if it returns an error, ErrNotImplemented.
+type Conn struct {
+	CloseFunc                func() error
+	ConnectedFunc            func() bool
+	DumpByFileDescriptorFunc func(ctx context.Context) (string, error)
+	GetDumpFunc              func(ctx context.Context) (*oomd1.Dump, error)
+	ListMonitoredCGroupsFunc func(ctx context.Context) ([]string, error)
+	SetTimeoutFunc           func(timeout time.Duration)
+	SubscribeKillsFunc       func(ctx context.Context, fn func(oomd1.Kill)) error
+}
+
+var _ oomd1.Connection = (*Conn)(nil)
+
+func (f *Conn) Close() error {
+	if f.CloseFunc == nil {
+		return fmt.Errorf("%w: Close", ErrNotImplemented)
+	}
+	return f.CloseFunc()
+}
+
+func (f *Conn) Connected() bool {
+	if f.ConnectedFunc == nil {
+		var r0 bool
+		return r0
+	}
+	return f.ConnectedFunc()
+}
+
+func (f *Conn) DumpByFileDescriptor(ctx context.Context) (string, error) {
+	if f.DumpByFileDescriptorFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: DumpByFileDescriptor", ErrNotImplemented)
+	}
+	return f.DumpByFileDescriptorFunc(ctx)
+}
+
+func (f *Conn) GetDump(ctx context.Context) (*oomd1.Dump, error) {
+	if f.GetDumpFunc == nil {
+		var r0 *oomd1.Dump
+		return r0, fmt.Errorf("%w: GetDump", ErrNotImplemented)
+	}
+	return f.GetDumpFunc(ctx)
+}
+
+func (f *Conn) ListMonitoredCGroups(ctx context.Context) ([]string, error) {
+	if f.ListMonitoredCGroupsFunc == nil {
+		var r0 []string
+		return r0, fmt.Errorf("%w: ListMonitoredCGroups", ErrNotImplemented)
+	}
+	return f.ListMonitoredCGroupsFunc(ctx)
+}
+
+func (f *Conn) SetTimeout(timeout time.Duration) {
+	if f.SetTimeoutFunc != nil {
+		f.SetTimeoutFunc(timeout)
+	}
+}
+
+func (f *Conn) SubscribeKills(ctx context.Context, fn func(oomd1.Kill)) error {
+	if f.SubscribeKillsFunc == nil {
+		return fmt.Errorf("%w: SubscribeKills", ErrNotImplemented)
+	}
+	return f.SubscribeKillsFunc(ctx, fn)
+}
@@ -0,0 +1,37 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+package resolve1
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// Connection is the interface of Conn, which allows for replacing it in tests,
+// e.g. by the fake of the resolve1test package. See Conn for the documentation of
+// the methods.
+type Connection interface {
+	Close() error
+	Connected() bool
+	FlushCaches(ctx context.Context) error
+	GetStatistics(ctx context.Context) (*Statistics, error)
+	ResetServerFeatures(ctx context.Context) error
+	ResetStatistics(ctx context.Context) error
+	ResolveAddress(ctx context.Context, ifindex int, ip net.IP, flags Flags) ([]Name, Flags, error)
+	ResolveHostname(ctx context.Context, ifindex int, name string, family int, flags Flags) (*HostnameResult, error)
+	ResolveRecord(ctx context.Context, ifindex int, name string, class, rrtype uint16, flags Flags) ([]Record, Flags, error)
+	ResolveService(ctx context.Context, ifindex int, name, service, domain string, family int, flags Flags) (*ServiceResult, error)
+	RevertLink(ctx context.Context, ifindex int) error
+	SetLinkDNS(ctx context.Context, ifindex int, servers []net.IP) error
+	SetLinkDNSOverTLS(ctx context.Context, ifindex int, mode string) error
+	SetLinkDNSSEC(ctx context.Context, ifindex int, mode DNSSECMode) error
+	SetLinkDNSSECNegativeTrustAnchors(ctx context.Context, ifindex int, domains []string) error
+	SetLinkDefaultRoute(ctx context.Context, ifindex int, enable bool) error
+	SetLinkDomains(ctx context.Context, ifindex int, domains []Domain) error
+	SetLinkLLMNR(ctx context.Context, ifindex int, mode string) error
+	SetLinkMulticastDNS(ctx context.Context, ifindex int, mode string) error
+	SetTimeout(timeout time.Duration)
+}
+
+var _ Connection = (*Conn)(nil)
@@ -0,0 +1,416 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resolve1 provides integration with the systemd-resolved API. See
+// https://www.freedesktop.org/software/systemd/man/org.freedesktop.resolve1.html
+package resolve1
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/gr-butler/go-systemd/v22/internal/calltimeout"
+)
+
+//go:generate go run github.com/gr-butler/go-systemd/v22/internal/fakegen
+
+const (
+	dbusDest      = "org.freedesktop.resolve1"
+	dbusInterface = "org.freedesktop.resolve1.Manager"
+	dbusPath      = "/org/freedesktop/resolve1"
+)
+
+// Address families, as used by systemd-resolved on Linux.
+const (
+	FamilyUnspec = 0  // AF_UNSPEC, for both IPv4 and IPv6
+	FamilyIPv4   = 2  // AF_INET
+	FamilyIPv6   = 10 // AF_INET6
+)
+
+// Flags are the flags of lookups, selecting the protocols used and
+// reporting how the result was obtained.
+type Flags uint64
+
+const (
+	FlagDNS             Flags = 1 << 0  // Use unicast DNS
+	FlagLLMNRIPv4       Flags = 1 << 1  // Use LLMNR over IPv4
+	FlagLLMNRIPv6       Flags = 1 << 2  // Use LLMNR over IPv6
+	FlagMDNSIPv4        Flags = 1 << 3  // Use multicast DNS over IPv4
+	FlagMDNSIPv6        Flags = 1 << 4  // Use multicast DNS over IPv6
+	FlagNoCNAME         Flags = 1 << 5  // Don't follow CNAME redirections
+	FlagNoTXT           Flags = 1 << 6  // Don't resolve the TXT records of services
+	FlagNoAddress       Flags = 1 << 7  // Don't resolve the addresses of services
+	FlagNoSearch        Flags = 1 << 8  // Don't apply search domains
+	FlagAuthenticated   Flags = 1 << 9  // The result is authenticated with DNSSEC
+	FlagNoValidate      Flags = 1 << 10 // Don't validate with DNSSEC
+	FlagNoSynthesize    Flags = 1 << 11 // Don't synthesize local records
+	FlagNoCache         Flags = 1 << 12 // Don't use the cache
+	FlagNoZone          Flags = 1 << 13 // Don't use the local zone
+	FlagNoTrustAnchor   Flags = 1 << 14 // Don't use the trust anchor
+	FlagNoNetwork       Flags = 1 << 15 // Don't use the network
+	FlagRequirePrimary  Flags = 1 << 16 // Only return the primary answer
+	FlagClampTTL        Flags = 1 << 17 // Clamp the TTLs of cached records
+	FlagConfidential    Flags = 1 << 18 // The result was obtained over an encrypted channel
+	FlagSynthetic       Flags = 1 << 19 // The result was synthesized locally
+	FlagFromCache       Flags = 1 << 20 // The result was served from the cache
+	FlagFromZone        Flags = 1 << 21 // The result was served from the local zone
+	FlagFromTrustAnchor Flags = 1 << 22 // The result was served from the trust anchor
+	FlagFromNetwork     Flags = 1 << 23 // The result was obtained from the network
+)
+
+// Conn is a connection to systemd-resolved's dbus endpoint.
+type Conn struct {
+	conn   *dbus.Conn
+	object dbus.BusObject
+
+	timeout time.Duration
+}
+
+// Address is an IP address, with the index of the network interface it
+// belongs to, or 0.
+type Address struct {
+	IfIndex int
+	IP      net.IP
+}
+
+// Name is a host name, with the index of the network interface it was
+// resolved on.
+type Name struct {
+	IfIndex int
+	Name    string
+}
+
+// Record is a resource record in DNS wire format.
+type Record struct {
+	IfIndex int
+	Class   uint16
+	Type    uint16
+	Data    []byte // The full record, including its header
+}
+
+// HostnameResult is the result of ResolveHostname.
+type HostnameResult struct {
+	Addresses []Address
+	Canonical string // The canonical name, after following CNAMEs
+	Flags     Flags
+}
+
+// Service is an SRV record of a service, with the addresses of its host.
+type Service struct {
+	Priority          uint16
+	Weight            uint16
+	Port              uint16
+	Hostname          string
+	Addresses         []Address
+	CanonicalHostname string
+}
+
+// ServiceResult is the result of ResolveService.
+type ServiceResult struct {
+	Services        []Service
+	TXT             [][]byte // The TXT strings of the service
+	CanonicalName   string
+	CanonicalType   string
+	CanonicalDomain string
+	Flags           Flags
+}
+
+// Statistics are the statistics of systemd-resolved.
+type Statistics struct {
+	CurrentTransactions uint64
+	TotalTransactions   uint64
+	CacheSize           uint64
+	CacheHits           uint64
+	CacheMisses         uint64
+	DNSSECSecure        uint64
+	DNSSECInsecure      uint64
+	DNSSECBogus         uint64
+	DNSSECIndeterminate uint64
+}
+
+// New establishes a connection to the system bus and authenticates.
+func New() (*Conn, error) {
+	c := new(Conn)
+
+	if err := c.initConnection(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Conn) initConnection() error {
+	var err error
+	c.conn, err = dbus.SystemBusPrivate()
+	if err != nil {
+		return err
+	}
+
+	// Only use EXTERNAL method, and hardcode the uid (not username)
+	// to avoid a username lookup (which requires a dynamically linked
+	// libc)
+	methods := []dbus.Auth{dbus.AuthExternal(strconv.Itoa(os.Getuid()))}
+
+	err = c.conn.Auth(methods)
+	if err != nil {
+		c.conn.Close()
+		return err
+	}
+
+	err = c.conn.Hello()
+	if err != nil {
+		c.conn.Close()
+		return err
+	}
+
+	c.object = c.conn.Object(dbusDest, dbus.ObjectPath(dbusPath))
+
+	return nil
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// Connected returns whether conn is connected
+func (c *Conn) Connected() bool {
+	return c.conn.Connected()
+}
+
+// SetTimeout sets the default timeout of the method calls made on the
+// connection without a context deadline, including those made by the
+// methods without context, which otherwise block forever if systemd-resolved
+// hangs. It is disabled by a timeout of zero, the default. SetTimeout must
+// not be called concurrently with method calls.
+func (c *Conn) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+	c.object = calltimeout.Object(c.conn.Object(dbusDest, dbus.ObjectPath(dbusPath)), timeout)
+}
+
+// wireAddress is the (iay) or (iiay) representation of addresses.
+type wireAddress struct {
+	IfIndex int32
+	Family  int32
+	Address []byte
+}
+
+func (a wireAddress) address() (Address, error) {
+	if (a.Family == FamilyIPv4 && len(a.Address) != net.IPv4len) ||
+		(a.Family == FamilyIPv6 && len(a.Address) != net.IPv6len) ||
+		(a.Family != FamilyIPv4 && a.Family != FamilyIPv6) {
+		return Address{}, fmt.Errorf("invalid address of family %d: %x", a.Family, a.Address)
+	}
+	return Address{IfIndex: int(a.IfIndex), IP: net.IP(a.Address)}, nil
+}
+
+func addressesFromWire(wire []wireAddress) ([]Address, error) {
+	addrs := make([]Address, 0, len(wire))
+	for _, w := range wire {
+		a, err := w.address()
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, a)
+	}
+	return addrs, nil
+}
+
+// addressToWire returns the family and bytes of ip.
+func addressToWire(ip net.IP) (int32, []byte, error) {
+	if ip4 := ip.To4(); ip4 != nil {
+		return FamilyIPv4, []byte(ip4), nil
+	}
+	if ip16 := ip.To16(); ip16 != nil {
+		return FamilyIPv6, []byte(ip16), nil
+	}
+	return 0, nil, fmt.Errorf("invalid IP address %v", ip)
+}
+
+// ResolveHostname resolves a host name to its addresses. If ifindex is not
+// 0, only the network interface with that index is used. family is
+// FamilyUnspec, FamilyIPv4 or FamilyIPv6, and flags is 0 for the default
+// lookup.
+func (c *Conn) ResolveHostname(ctx context.Context, ifindex int, name string, family int, flags Flags) (*HostnameResult, error) {
+	var wire []wireAddress
+	var result HostnameResult
+	err := c.object.CallWithContext(ctx, dbusInterface+".ResolveHostname", 0, int32(ifindex), name, int32(family), uint64(flags)).
+		Store(&wire, &result.Canonical, &result.Flags)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Addresses, err = addressesFromWire(wire); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ResolveAddress resolves an IP address to its host names.
+func (c *Conn) ResolveAddress(ctx context.Context, ifindex int, ip net.IP, flags Flags) ([]Name, Flags, error) {
+	family, address, err := addressToWire(ip)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var wire []struct {
+		IfIndex int32
+		Name    string
+	}
+	var out Flags
+	err = c.object.CallWithContext(ctx, dbusInterface+".ResolveAddress", 0, int32(ifindex), family, address, uint64(flags)).
+		Store(&wire, &out)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	names := make([]Name, 0, len(wire))
+	for _, w := range wire {
+		names = append(names, Name{IfIndex: int(w.IfIndex), Name: w.Name})
+	}
+	return names, out, nil
+}
+
+// ResolveRecord looks up the resource records of the given class, e.g. 1 for
+// IN, and type, e.g. 16 for TXT, of a name.
+func (c *Conn) ResolveRecord(ctx context.Context, ifindex int, name string, class, rrtype uint16, flags Flags) ([]Record, Flags, error) {
+	var wire []struct {
+		IfIndex int32
+		Class   uint16
+		Type    uint16
+		Data    []byte
+	}
+	var out Flags
+	err := c.object.CallWithContext(ctx, dbusInterface+".ResolveRecord", 0, int32(ifindex), name, class, rrtype, uint64(flags)).
+		Store(&wire, &out)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	records := make([]Record, 0, len(wire))
+	for _, w := range wire {
+		records = append(records, Record{IfIndex: int(w.IfIndex), Class: w.Class, Type: w.Type, Data: w.Data})
+	}
+	return records, out, nil
+}
+
+// ResolveService resolves a DNS-SD or SRV service. For DNS-SD, name is the
+// instance name, service the service type, e.g. _http._tcp, and domain the
+// domain; for plain SRV lookups, name is empty.
+func (c *Conn) ResolveService(ctx context.Context, ifindex int, name, service, domain string, family int, flags Flags) (*ServiceResult, error) {
+	var wire []struct {
+		Priority          uint16
+		Weight            uint16
+		Port              uint16
+		Hostname          string
+		Addresses         []wireAddress
+		CanonicalHostname string
+	}
+	var result ServiceResult
+	err := c.object.CallWithContext(ctx, dbusInterface+".ResolveService", 0, int32(ifindex), name, service, domain, int32(family), uint64(flags)).
+		Store(&wire, &result.TXT, &result.CanonicalName, &result.CanonicalType, &result.CanonicalDomain, &result.Flags)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range wire {
+		addrs, err := addressesFromWire(w.Addresses)
+		if err != nil {
+			return nil, err
+		}
+		result.Services = append(result.Services, Service{
+			Priority:          w.Priority,
+			Weight:            w.Weight,
+			Port:              w.Port,
+			Hostname:          w.Hostname,
+			Addresses:         addrs,
+			CanonicalHostname: w.CanonicalHostname,
+		})
+	}
+	return &result, nil
+}
+
+// FlushCaches flushes the local caches of systemd-resolved.
+func (c *Conn) FlushCaches(ctx context.Context) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".FlushCaches", 0).Err
+}
+
+// ResetServerFeatures forgets the features learnt about the DNS servers, so
+// that they are probed again, e.g. after a network change.
+func (c *Conn) ResetServerFeatures(ctx context.Context) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".ResetServerFeatures", 0).Err
+}
+
+// ResetStatistics resets the statistics returned by GetStatistics.
+func (c *Conn) ResetStatistics(ctx context.Context) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".ResetStatistics", 0).Err
+}
+
+// GetStatistics returns the transaction, cache and DNSSEC statistics of
+// systemd-resolved.
+func (c *Conn) GetStatistics(ctx context.Context) (*Statistics, error) {
+	var props map[string]dbus.Variant
+	err := c.object.CallWithContext(ctx, "org.freedesktop.DBus.Properties.GetAll", 0, dbusInterface).Store(&props)
+	if err != nil {
+		return nil, err
+	}
+	return statisticsFromVariants(props)
+}
+
+func statisticsFromVariants(props map[string]dbus.Variant) (*Statistics, error) {
+	counters := func(name string, n int) ([]uint64, error) {
+		values, ok := props[name].Value().([]interface{})
+		if !ok || len(values) != n {
+			return nil, fmt.Errorf("failed to typecast %s to a struct of %d uint64", name, n)
+		}
+		counters := make([]uint64, n)
+		for i, v := range values {
+			if counters[i], ok = v.(uint64); !ok {
+				return nil, fmt.Errorf("failed to typecast %s field %d to uint64", name, i)
+			}
+		}
+		return counters, nil
+	}
+
+	transactions, err := counters("TransactionStatistics", 2)
+	if err != nil {
+		return nil, err
+	}
+	cache, err := counters("CacheStatistics", 3)
+	if err != nil {
+		return nil, err
+	}
+	dnssec, err := counters("DNSSECStatistics", 4)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Statistics{
+		CurrentTransactions: transactions[0],
+		TotalTransactions:   transactions[1],
+		CacheSize:           cache[0],
+		CacheHits:           cache[1],
+		CacheMisses:         cache[2],
+		DNSSECSecure:        dnssec[0],
+		DNSSECInsecure:      dnssec[1],
+		DNSSECBogus:         dnssec[2],
+		DNSSECIndeterminate: dnssec[3],
+	}, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolve1
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestAddressesFromWire(t *testing.T) {
+	wire := []wireAddress{
+		{IfIndex: 2, Family: FamilyIPv4, Address: []byte{192, 0, 2, 1}},
+		{Family: FamilyIPv6, Address: net.ParseIP("2001:db8::1")},
+	}
+	expected := []Address{
+		{IfIndex: 2, IP: net.IPv4(192, 0, 2, 1).To4()},
+		{IP: net.ParseIP("2001:db8::1")},
+	}
+
+	addrs, err := addressesFromWire(wire)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(addrs, expected) {
+		t.Errorf("expected %v, got %v", expected, addrs)
+	}
+
+	if _, err := addressesFromWire([]wireAddress{{Family: FamilyIPv6, Address: []byte{1, 2, 3, 4}}}); err == nil {
+		t.Error("expected an error for an IPv6 address of 4 bytes")
+	}
+}
+
+func TestDNSServers(t *testing.T) {
+	wire, err := dnsServers([]net.IP{net.ParseIP("192.0.2.53"), net.ParseIP("2001:db8::53")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(wire) != 2 || wire[0].Family != FamilyIPv4 || len(wire[0].Address) != 4 ||
+		wire[1].Family != FamilyIPv6 || len(wire[1].Address) != 16 {
+		t.Errorf("unexpected servers %v", wire)
+	}
+
+	if _, err := dnsServers([]net.IP{nil}); err == nil {
+		t.Error("expected an error for an invalid address")
+	}
+}
+
+func TestStatisticsFromVariants(t *testing.T) {
+	props := map[string]dbus.Variant{
+		"TransactionStatistics": dbus.MakeVariant([]interface{}{uint64(1), uint64(100)}),
+		"CacheStatistics":       dbus.MakeVariant([]interface{}{uint64(20), uint64(60), uint64(40)}),
+		"DNSSECStatistics":      dbus.MakeVariant([]interface{}{uint64(5), uint64(6), uint64(0), uint64(1)}),
+	}
+	expected := &Statistics{
+		CurrentTransactions: 1,
+		TotalTransactions:   100,
+		CacheSize:           20,
+		CacheHits:           60,
+		CacheMisses:         40,
+		DNSSECSecure:        5,
+		DNSSECInsecure:      6,
+		DNSSECIndeterminate: 1,
+	}
+
+	stats, err := statisticsFromVariants(props)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+
+	delete(props, "DNSSECStatistics")
+	if _, err := statisticsFromVariants(props); err == nil {
+		t.Error("expected an error without DNSSECStatistics")
+	}
+}
+
+func TestResolveHostname(t *testing.T) {
+	conn, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	result, err := conn.ResolveHostname(context.Background(), 0, "localhost", FamilyUnspec, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Addresses) == 0 {
+		t.Fatal("expected addresses for localhost")
+	}
+	for _, a := range result.Addresses {
+		if !a.IP.IsLoopback() {
+			t.Errorf("expected a loopback address for localhost, got %v", a.IP)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolve1
+
+import (
+	"context"
+	"net"
+)
+
+// DNSSECMode is the DNSSEC mode of a link. The empty mode resets the link to
+// the global setting.
+type DNSSECMode string
+
+const (
+	DNSSECYes            DNSSECMode = "yes"
+	DNSSECNo             DNSSECMode = "no"
+	DNSSECAllowDowngrade DNSSECMode = "allow-downgrade"
+)
+
+// Domain is a search or routing domain of a link.
+type Domain struct {
+	Name string
+	// RoutingOnly is whether the domain is only used to route lookups to
+	// the link, rather than as a search domain too (~ prefix in
+	// resolvectl).
+	RoutingOnly bool
+}
+
+// SetLinkDNS sets the DNS servers of the network interface with index
+// ifindex, replacing the servers configured so far.
+func (c *Conn) SetLinkDNS(ctx context.Context, ifindex int, servers []net.IP) error {
+	wire, err := dnsServers(servers)
+	if err != nil {
+		return err
+	}
+	return c.object.CallWithContext(ctx, dbusInterface+".SetLinkDNS", 0, int32(ifindex), wire).Err
+}
+
+// dnsServers formats servers as the a(iay) argument of SetLinkDNS.
+func dnsServers(servers []net.IP) ([]struct {
+	Family  int32
+	Address []byte
+}, error) {
+	wire := make([]struct {
+		Family  int32
+		Address []byte
+	}, len(servers))
+	for i, ip := range servers {
+		family, address, err := addressToWire(ip)
+		if err != nil {
+			return nil, err
+		}
+		wire[i].Family, wire[i].Address = family, address
+	}
+	return wire, nil
+}
+
+// SetLinkDomains sets the search and routing domains of a link.
+func (c *Conn) SetLinkDomains(ctx context.Context, ifindex int, domains []Domain) error {
+	if domains == nil {
+		domains = []Domain{}
+	}
+	return c.object.CallWithContext(ctx, dbusInterface+".SetLinkDomains", 0, int32(ifindex), domains).Err
+}
+
+// SetLinkDefaultRoute sets whether the link is used for lookups of names
+// which don't match the routing domain of any link.
+func (c *Conn) SetLinkDefaultRoute(ctx context.Context, ifindex int, enable bool) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".SetLinkDefaultRoute", 0, int32(ifindex), enable).Err
+}
+
+// SetLinkDNSSEC sets the DNSSEC mode of a link.
+func (c *Conn) SetLinkDNSSEC(ctx context.Context, ifindex int, mode DNSSECMode) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".SetLinkDNSSEC", 0, int32(ifindex), string(mode)).Err
+}
+
+// SetLinkDNSSECNegativeTrustAnchors sets the domains of a link for which
+// DNSSEC validation is turned off.
+func (c *Conn) SetLinkDNSSECNegativeTrustAnchors(ctx context.Context, ifindex int, domains []string) error {
+	if domains == nil {
+		domains = []string{}
+	}
+	return c.object.CallWithContext(ctx, dbusInterface+".SetLinkDNSSECNegativeTrustAnchors", 0, int32(ifindex), domains).Err
+}
+
+// SetLinkDNSOverTLS sets the DNS-over-TLS mode of a link: yes, no or
+// opportunistic, or empty for the global setting.
+func (c *Conn) SetLinkDNSOverTLS(ctx context.Context, ifindex int, mode string) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".SetLinkDNSOverTLS", 0, int32(ifindex), mode).Err
+}
+
+// SetLinkLLMNR sets the LLMNR mode of a link: yes, no or resolve, or empty
+// for the global setting.
+func (c *Conn) SetLinkLLMNR(ctx context.Context, ifindex int, mode string) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".SetLinkLLMNR", 0, int32(ifindex), mode).Err
+}
+
+// SetLinkMulticastDNS sets the multicast DNS mode of a link: yes, no or
+// resolve, or empty for the global setting.
+func (c *Conn) SetLinkMulticastDNS(ctx context.Context, ifindex int, mode string) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".SetLinkMulticastDNS", 0, int32(ifindex), mode).Err
+}
+
+// RevertLink reverts the DNS configuration of a link set with the SetLink*
+// methods.
+func (c *Conn) RevertLink(ctx context.Context, ifindex int) error {
+	return c.object.CallWithContext(ctx, dbusInterface+".RevertLink", 0, int32(ifindex)).Err
+}
@@ -0,0 +1,192 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+// Package resolve1test provides a fake of resolve1.Conn for unit tests.
+package resolve1test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/gr-butler/go-systemd/v22/resolve1"
+)
+
+// ErrNotImplemented is returned by the methods whose function field is unset.
+var ErrNotImplemented = errors.New("not implemented")
+
+// Conn is a fake resolve1.Connection. Each method calls the function field of
+// the same name with a Func suffix. If it is unset, the method returns zero
+// values and, if it returns an error, ErrNotImplemented.
+type Conn struct {
+	CloseFunc                             func() error
+	ConnectedFunc                         func() bool
+	FlushCachesFunc                       func(ctx context.Context) error
+	GetStatisticsFunc                     func(ctx context.Context) (*resolve1.Statistics, error)
+	ResetServerFeaturesFunc               func(ctx context.Context) error
+	ResetStatisticsFunc                   func(ctx context.Context) error
+	ResolveAddressFunc                    func(ctx context.Context, ifindex int, ip net.IP, flags resolve1.Flags) ([]resolve1.Name, resolve1.Flags, error)
+	ResolveHostnameFunc                   func(ctx context.Context, ifindex int, name string, family int, flags resolve1.Flags) (*resolve1.HostnameResult, error)
+	ResolveRecordFunc                     func(ctx context.Context, ifindex int, name string, class, rrtype uint16, flags resolve1.Flags) ([]resolve1.Record, resolve1.Flags, error)
+	ResolveServiceFunc                    func(ctx context.Context, ifindex int, name, service, domain string, family int, flags resolve1.Flags) (*resolve1.ServiceResult, error)
+	RevertLinkFunc                        func(ctx context.Context, ifindex int) error
+	SetLinkDNSFunc                        func(ctx context.Context, ifindex int, servers []net.IP) error
+	SetLinkDNSOverTLSFunc                 func(ctx context.Context, ifindex int, mode string) error
+	SetLinkDNSSECFunc                     func(ctx context.Context, ifindex int, mode resolve1.DNSSECMode) error
+	SetLinkDNSSECNegativeTrustAnchorsFunc func(ctx context.Context, ifindex int, domains []string) error
+	SetLinkDefaultRouteFunc               func(ctx context.Context, ifindex int, enable bool) error
+	SetLinkDomainsFunc                    func(ctx context.Context, ifindex int, domains []resolve1.Domain) error
+	SetLinkLLMNRFunc                      func(ctx context.Context, ifindex int, mode string) error
+	SetLinkMulticastDNSFunc               func(ctx context.Context, ifindex int, mode string) error
+	SetTimeoutFunc                        func(timeout time.Duration)
+}
+
+var _ resolve1.Connection = (*Conn)(nil)
+
+func (f *Conn) Close() error {
+	if f.CloseFunc == nil {
+		return fmt.Errorf("%w: Close", ErrNotImplemented)
+	}
+	return f.CloseFunc()
+}
+
+func (f *Conn) Connected() bool {
+	if f.ConnectedFunc == nil {
+		var r0 bool
+		return r0
+	}
+	return f.ConnectedFunc()
+}
+
+func (f *Conn) FlushCaches(ctx context.Context) error {
+	if f.FlushCachesFunc == nil {
+		return fmt.Errorf("%w: FlushCaches", ErrNotImplemented)
+	}
+	return f.FlushCachesFunc(ctx)
+}
+
+func (f *Conn) GetStatistics(ctx context.Context) (*resolve1.Statistics, error) {
+	if f.GetStatisticsFunc == nil {
+		var r0 *resolve1.Statistics
+		return r0, fmt.Errorf("%w: GetStatistics", ErrNotImplemented)
+	}
+	return f.GetStatisticsFunc(ctx)
+}
+
+func (f *Conn) ResetServerFeatures(ctx context.Context) error {
+	if f.ResetServerFeaturesFunc == nil {
+		return fmt.Errorf("%w: ResetServerFeatures", ErrNotImplemented)
+	}
+	return f.ResetServerFeaturesFunc(ctx)
+}
+
+func (f *Conn) ResetStatistics(ctx context.Context) error {
+	if f.ResetStatisticsFunc == nil {
+		return fmt.Errorf("%w: ResetStatistics", ErrNotImplemented)
+	}
+	return f.ResetStatisticsFunc(ctx)
+}
+
+func (f *Conn) ResolveAddress(ctx context.Context, ifindex int, ip net.IP, flags resolve1.Flags) ([]resolve1.Name, resolve1.Flags, error) {
+	if f.ResolveAddressFunc == nil {
+		var r0 []resolve1.Name
+		var r1 resolve1.Flags
+		return r0, r1, fmt.Errorf("%w: ResolveAddress", ErrNotImplemented)
+	}
+	return f.ResolveAddressFunc(ctx, ifindex, ip, flags)
+}
+
+func (f *Conn) ResolveHostname(ctx context.Context, ifindex int, name string, family int, flags resolve1.Flags) (*resolve1.HostnameResult, error) {
+	if f.ResolveHostnameFunc == nil {
+		var r0 *resolve1.HostnameResult
+		return r0, fmt.Errorf("%w: ResolveHostname", ErrNotImplemented)
+	}
+	return f.ResolveHostnameFunc(ctx, ifindex, name, family, flags)
+}
+
+func (f *Conn) ResolveRecord(ctx context.Context, ifindex int, name string, class, rrtype uint16, flags resolve1.Flags) ([]resolve1.Record, resolve1.Flags, error) {
+	if f.ResolveRecordFunc == nil {
+		var r0 []resolve1.Record
+		var r1 resolve1.Flags
+		return r0, r1, fmt.Errorf("%w: ResolveRecord", ErrNotImplemented)
+	}
+	return f.ResolveRecordFunc(ctx, ifindex, name, class, rrtype, flags)
+}
+
+func (f *Conn) ResolveService(ctx context.Context, ifindex int, name, service, domain string, family int, flags resolve1.Flags) (*resolve1.ServiceResult, error) {
+	if f.ResolveServiceFunc == nil {
+		var r0 *resolve1.ServiceResult
+		return r0, fmt.Errorf("%w: ResolveService", ErrNotImplemented)
+	}
+	return f.ResolveServiceFunc(ctx, ifindex, name, service, domain, family, flags)
+}
+
+func (f *Conn) RevertLink(ctx context.Context, ifindex int) error {
+	if f.RevertLinkFunc == nil {
+		return fmt.Errorf("%w: RevertLink", ErrNotImplemented)
+	}
+	return f.RevertLinkFunc(ctx, ifindex)
+}
+
+func (f *Conn) SetLinkDNS(ctx context.Context, ifindex int, servers []net.IP) error {
+	if f.SetLinkDNSFunc == nil {
+		return fmt.Errorf("%w: SetLinkDNS", ErrNotImplemented)
+	}
+	return f.SetLinkDNSFunc(ctx, ifindex, servers)
+}
+
+func (f *Conn) SetLinkDNSOverTLS(ctx context.Context, ifindex int, mode string) error {
+	if f.SetLinkDNSOverTLSFunc == nil {
+		return fmt.Errorf("%w: SetLinkDNSOverTLS", ErrNotImplemented)
+	}
+	return f.SetLinkDNSOverTLSFunc(ctx, ifindex, mode)
+}
+
+func (f *Conn) SetLinkDNSSEC(ctx context.Context, ifindex int, mode resolve1.DNSSECMode) error {
+	if f.SetLinkDNSSECFunc == nil {
+		return fmt.Errorf("%w: SetLinkDNSSEC", ErrNotImplemented)
+	}
+	return f.SetLinkDNSSECFunc(ctx, ifindex, mode)
+}
+
+func (f *Conn) SetLinkDNSSECNegativeTrustAnchors(ctx context.Context, ifindex int, domains []string) error {
+	if f.SetLinkDNSSECNegativeTrustAnchorsFunc == nil {
+		return fmt.Errorf("%w: SetLinkDNSSECNegativeTrustAnchors", ErrNotImplemented)
+	}
+	return f.SetLinkDNSSECNegativeTrustAnchorsFunc(ctx, ifindex, domains)
+}
+
+func (f *Conn) SetLinkDefaultRoute(ctx context.Context, ifindex int, enable bool) error {
+	if f.SetLinkDefaultRouteFunc == nil {
+		return fmt.Errorf("%w: SetLinkDefaultRoute", ErrNotImplemented)
+	}
+	return f.SetLinkDefaultRouteFunc(ctx, ifindex, enable)
+}
+
+func (f *Conn) SetLinkDomains(ctx context.Context, ifindex int, domains []resolve1.Domain) error {
+	if f.SetLinkDomainsFunc == nil {
+		return fmt.Errorf("%w: SetLinkDomains", ErrNotImplemented)
+	}
+	return f.SetLinkDomainsFunc(ctx, ifindex, domains)
+}
+
+func (f *Conn) SetLinkLLMNR(ctx context.Context, ifindex int, mode string) error {
+	if f.SetLinkLLMNRFunc == nil {
+		return fmt.Errorf("%w: SetLinkLLMNR", ErrNotImplemented)
+	}
+	return f.SetLinkLLMNRFunc(ctx, ifindex, mode)
+}
+
+func (f *Conn) SetLinkMulticastDNS(ctx context.Context, ifindex int, mode string) error {
+	if f.SetLinkMulticastDNSFunc == nil {
+		return fmt.Errorf("%w: SetLinkMulticastDNS", ErrNotImplemented)
+	}
+	return f.SetLinkMulticastDNSFunc(ctx, ifindex, mode)
+}
+
+func (f *Conn) SetTimeout(timeout time.Duration) {
+	if f.SetTimeoutFunc != nil {
+		f.SetTimeoutFunc(timeout)
+	}
+}
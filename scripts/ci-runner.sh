@@ -6,7 +6,7 @@ PROJ="go-systemd"
 ORG_PATH="github.com/coreos"
 REPO_PATH="${ORG_PATH}/${PROJ}"
 
-PACKAGES="activation daemon dbus internal/dlopen journal login1 machine1 sdjournal unit util import1"
+PACKAGES="activation daemon dbus internal/dlopen journal login1 login1/login1test machine1 sdjournal unit util import1 hostname1 locale1 resolve1 network1 unit/networkd oomd1 device cgroups unit/sysusers unit/tmpfiles creds varlink boot timesync1 unit/nspawn coredump id128 internal/words internal/unitcodec internal/fakegen instrument internal/calltimeout manager systemdtest"
 EXAMPLES="activation listen udpconn"
 
 function build_source {
@@ -0,0 +1,135 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdjournal
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// BootInfo describes a boot recorded in the journal.
+type BootInfo struct {
+	// Offset is the position of the boot relative to the last boot in the
+	// journal, as listed by journalctl --list-boots: 0 for the last boot,
+	// -1 for the one before it, and so on.
+	Offset int
+	ID     string
+	First  time.Time // when the first entry of the boot was written
+	Last   time.Time // when the last entry of the boot was written
+}
+
+// ListBoots returns the boots recorded in the journal, ordered from the
+// oldest to the newest, like journalctl --list-boots. As it finds the entries
+// of each boot by matching them, it flushes the matches of the journal, which
+// have to be added again afterwards.
+func (j *Journal) ListBoots() ([]BootInfo, error) {
+	ids, err := j.GetUniqueValues(SD_JOURNAL_FIELD_BOOT_ID)
+	if err != nil {
+		return nil, err
+	}
+	defer j.FlushMatches()
+
+	boots := make([]BootInfo, 0, len(ids))
+	for _, id := range ids {
+		j.FlushMatches()
+		if err := j.AddMatch(SD_JOURNAL_FIELD_BOOT_ID + "=" + id); err != nil {
+			return nil, err
+		}
+
+		first, ok, err := j.boundaryUsec(true)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		last, _, err := j.boundaryUsec(false)
+		if err != nil {
+			return nil, err
+		}
+
+		boots = append(boots, BootInfo{
+			ID:    id,
+			First: time.UnixMicro(int64(first)),
+			Last:  time.UnixMicro(int64(last)),
+		})
+	}
+
+	sort.Slice(boots, func(a, b int) bool { return boots[a].First.Before(boots[b].First) })
+	for i := range boots {
+		boots[i].Offset = i - (len(boots) - 1)
+	}
+	return boots, nil
+}
+
+// boundaryUsec returns the realtime timestamp of the first or last entry
+// matching the current matches, and whether there is such an entry.
+func (j *Journal) boundaryUsec(first bool) (uint64, bool, error) {
+	var n uint64
+	var err error
+	if first {
+		if err = j.SeekHead(); err == nil {
+			n, err = j.Next()
+		}
+	} else {
+		if err = j.SeekTail(); err == nil {
+			n, err = j.Previous()
+		}
+	}
+	if err != nil || n == 0 {
+		return 0, false, err
+	}
+
+	usec, err := j.GetRealtimeUsec()
+	return usec, err == nil, err
+}
+
+// SeekBoot seeks to the first entry of a boot, like journalctl --boot: 0 is
+// the current boot, negative offsets count back from the last boot in the
+// journal and positive offsets count forward from the first boot, starting
+// at 1. This call must be followed by a call to Next before any call to Get*
+// will return data about the first entry of the boot. Like ListBoots, it
+// flushes the matches of the journal. To read only the entries of the boot,
+// add a match for its _BOOT_ID, or use a Filter with Boot.
+func (j *Journal) SeekBoot(offset int) error {
+	id, err := j.bootID(offset)
+	if err != nil {
+		return err
+	}
+	defer j.FlushMatches()
+
+	j.FlushMatches()
+	if err := j.AddMatch(SD_JOURNAL_FIELD_BOOT_ID + "=" + id); err != nil {
+		return err
+	}
+	if err := j.SeekHead(); err != nil {
+		return err
+	}
+	n, err := j.Next()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("no entries of boot %s in the journal", id)
+	}
+
+	cursor, err := j.GetCursor()
+	if err != nil {
+		return err
+	}
+	j.FlushMatches()
+	return j.SeekCursor(cursor)
+}
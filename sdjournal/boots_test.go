@@ -0,0 +1,80 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdjournal
+
+import (
+	"testing"
+)
+
+func TestListBoots(t *testing.T) {
+	j, err := NewJournal()
+	if err != nil {
+		t.Fatalf("Error opening journal: %s", err)
+	}
+	defer j.Close()
+
+	boots, err := j.ListBoots()
+	if err != nil {
+		t.Fatalf("Error listing boots: %s", err)
+	}
+	if len(boots) == 0 {
+		t.Skip("no boots in the journal")
+	}
+
+	for i, b := range boots {
+		if b.Offset != i-(len(boots)-1) {
+			t.Errorf("boot %s: unexpected offset %d", b.ID, b.Offset)
+		}
+		if b.Last.Before(b.First) {
+			t.Errorf("boot %s: last entry %s before first entry %s", b.ID, b.Last, b.First)
+		}
+		if i > 0 && b.First.Before(boots[i-1].First) {
+			t.Errorf("boots not ordered by time: %+v", boots)
+		}
+	}
+
+	if err := j.SeekBoot(1); err != nil {
+		t.Fatalf("Error seeking to the first boot: %s", err)
+	}
+	if n, err := j.Next(); err != nil || n == 0 {
+		t.Fatalf("Error reading the first entry of the boot: %v", err)
+	}
+	entry, err := j.GetEntry()
+	if err != nil {
+		t.Fatalf("Error getting entry: %s", err)
+	}
+	if entry.Fields[SD_JOURNAL_FIELD_BOOT_ID] != boots[0].ID {
+		t.Errorf("expected an entry of boot %s, got %s", boots[0].ID, entry.Fields[SD_JOURNAL_FIELD_BOOT_ID])
+	}
+}
+
+func TestSeekBootEmptyJournal(t *testing.T) {
+	j, err := NewJournalFromDir(t.TempDir())
+	if err != nil {
+		t.Fatalf("Error opening journal: %s", err)
+	}
+	defer j.Close()
+
+	boots, err := j.ListBoots()
+	if err != nil {
+		t.Fatalf("Error listing boots: %s", err)
+	}
+	if len(boots) != 0 {
+		t.Errorf("expected no boots, got %+v", boots)
+	}
+	if err := j.SeekBoot(-1); err == nil {
+		t.Error("expected seeking to a missing boot to fail")
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdjournal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CursorStore persists the cursor of the last processed journal entry, so
+// that reading can resume after it, e.g. after a restart.
+type CursorStore interface {
+	// Load returns the saved cursor, or "" if none was saved yet.
+	Load() (string, error)
+	// Save saves the cursor, replacing the previous one.
+	Save(cursor string) error
+}
+
+// FileCursorStore is a CursorStore saving the cursor in a file.
+type FileCursorStore struct {
+	Path string
+}
+
+// Load returns the cursor saved in the file, or "" if the file doesn't exist.
+func (s *FileCursorStore) Load() (string, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	cursor := strings.TrimSpace(string(data))
+	if cursor != "" && !validCursor(cursor) {
+		return "", fmt.Errorf("invalid cursor %q in %s", cursor, s.Path)
+	}
+	return cursor, nil
+}
+
+// Save writes the cursor to the file atomically, so that a crash leaves
+// either the previous or the new cursor.
+func (s *FileCursorStore) Save(cursor string) error {
+	if !validCursor(cursor) {
+		return fmt.Errorf("invalid cursor %q", cursor)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), "."+filepath.Base(s.Path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.WriteString(cursor + "\n")
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// validCursor reports whether cursor looks like a journal cursor, a list of
+// key=value pairs separated by semicolons.
+func validCursor(cursor string) bool {
+	if cursor == "" || strings.ContainsAny(cursor, "\n\x00") {
+		return false
+	}
+	for _, part := range strings.Split(cursor, ";") {
+		if k, _, ok := strings.Cut(part, "="); !ok || k == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// seekAfterCursor positions the journal so that the next call to Next returns
+// the entry following the one at cursor. If that entry no longer exists,
+// e.g. because the journal was rotated, Next returns the closest entry after
+// it.
+func (j *Journal) seekAfterCursor(cursor string) error {
+	if err := j.SeekCursor(cursor); err != nil {
+		return err
+	}
+
+	n, err := j.Next()
+	if err != nil || n == 0 {
+		return err
+	}
+
+	if err := j.TestCursor(cursor); err == ErrNoTestCursor {
+		// the entry at cursor is gone, so the current one wasn't read yet
+		_, err = j.Previous()
+		return err
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
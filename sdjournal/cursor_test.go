@@ -0,0 +1,51 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdjournal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileCursorStore(t *testing.T) {
+	s := &FileCursorStore{Path: filepath.Join(t.TempDir(), "cursor")}
+
+	cursor, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cursor != "" {
+		t.Errorf("expected no cursor, got %q", cursor)
+	}
+
+	const saved = "s=739ad463348b4ceca5a9e69c95a3c93f;i=4ece7;b=6c7c6013a8674ec7b064f50dbd7a7d8d;m=cd4b2f1b6;t=5536f1d4f2c2e;x=f9fa9e2607b8e2bf"
+	if err := s.Save(saved); err != nil {
+		t.Fatal(err)
+	}
+	if cursor, err = s.Load(); err != nil || cursor != saved {
+		t.Errorf("expected %q, got %q (%v)", saved, cursor, err)
+	}
+
+	if err := s.Save("not a cursor"); err == nil {
+		t.Error("expected saving an invalid cursor to fail")
+	}
+	if err := os.WriteFile(s.Path, []byte("garbage\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Load(); err == nil {
+		t.Error("expected loading an invalid cursor to fail")
+	}
+}
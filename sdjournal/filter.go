@@ -0,0 +1,199 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdjournal
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gr-butler/go-systemd/v22/journal"
+)
+
+// Filter builds the matches selecting journal entries, similar to the
+// filtering options of journalctl. All conditions added to a Filter must
+// hold for an entry to be selected, e.g.
+//
+//	NewFilter().Unit("nginx.service").MaxPriority(journal.PriWarning).Boot(0).Since(t)
+//
+// selects the warnings and errors of nginx.service logged during the current
+// boot since t. Use Or to select entries matching any of several filters.
+type Filter struct {
+	matches []Match
+	or      [][]*Filter
+	boot    *int
+	since   time.Time
+	err     error
+}
+
+// NewFilter returns an empty Filter, which selects all entries.
+func NewFilter() *Filter {
+	return &Filter{}
+}
+
+// Match adds the condition that field has the given value. Several values
+// for the same field select entries with any of them.
+func (f *Filter) Match(field, value string) *Filter {
+	f.matches = append(f.matches, Match{Field: field, Value: value})
+	return f
+}
+
+// Unit selects the entries logged by the given system unit.
+func (f *Filter) Unit(name string) *Filter {
+	return f.Match(SD_JOURNAL_FIELD_SYSTEMD_UNIT, name)
+}
+
+// UserUnit selects the entries logged by the given user unit.
+func (f *Filter) UserUnit(name string) *Filter {
+	return f.Match(SD_JOURNAL_FIELD_SYSTEMD_USER_UNIT, name)
+}
+
+// Identifier selects the entries with the given syslog identifier.
+func (f *Filter) Identifier(identifier string) *Filter {
+	return f.Match(SD_JOURNAL_FIELD_SYSLOG_IDENTIFIER, identifier)
+}
+
+// MaxPriority selects the entries with priority p or a more important one,
+// e.g. journal.PriWarning selects warnings, errors and more severe entries.
+func (f *Filter) MaxPriority(p journal.Priority) *Filter {
+	for i := journal.PriEmerg; i <= p; i++ {
+		f.Match(SD_JOURNAL_FIELD_PRIORITY, strconv.Itoa(int(i)))
+	}
+	return f
+}
+
+// Boot selects the entries of a boot, like journalctl --boot: 0 is the
+// current boot, negative offsets count back from it and positive offsets
+// count forward from the first boot in the journal, starting at 1.
+func (f *Filter) Boot(offset int) *Filter {
+	f.boot = &offset
+	return f
+}
+
+// Since selects the entries logged at or after t, by seeking to t when the
+// Filter is applied.
+func (f *Filter) Since(t time.Time) *Filter {
+	f.since = t
+	return f
+}
+
+// Or adds the condition that any of the alternatives holds. The alternatives
+// may only contain matches, not Boot, Since or conditions added with Or.
+func (f *Filter) Or(alternatives ...*Filter) *Filter {
+	for _, alt := range alternatives {
+		if alt.err != nil {
+			f.err = alt.err
+		} else if len(alt.or) > 0 || alt.boot != nil || !alt.since.IsZero() {
+			f.err = errors.New("alternatives of an Or filter may only contain matches")
+		}
+	}
+	f.or = append(f.or, alternatives)
+	return f
+}
+
+// levels compiles the Filter into the match levels of sd-journal, given the
+// ID of the selected boot, if any: the levels are combined with AND, the
+// groups of a level with OR and the matches of a group with AND, except for
+// matches of the same field, which are combined with OR.
+func (f *Filter) levels(bootID string) [][][]Match {
+	group := f.matches
+	if bootID != "" {
+		group = append(group[:len(group):len(group)], Match{Field: SD_JOURNAL_FIELD_BOOT_ID, Value: bootID})
+	}
+
+	var levels [][][]Match
+	if len(group) > 0 {
+		levels = append(levels, [][]Match{group})
+	}
+	for _, alternatives := range f.or {
+		var level [][]Match
+		for _, alt := range alternatives {
+			level = append(level, alt.matches)
+		}
+		levels = append(levels, level)
+	}
+	return levels
+}
+
+// ApplyFilter replaces the matches of the journal with those of the filter
+// and, if the filter has a Since condition, seeks to it.
+func (j *Journal) ApplyFilter(f *Filter) error {
+	j.FlushMatches()
+	return j.addFilter(f)
+}
+
+// addFilter adds the matches of the filter to the journal, which must not
+// have any matches yet as they may be flushed to resolve the boot of the
+// filter, and seeks to its Since condition, if any.
+func (j *Journal) addFilter(f *Filter) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	var bootID string
+	if f.boot != nil {
+		var err error
+		if bootID, err = j.bootID(*f.boot); err != nil {
+			return err
+		}
+	}
+
+	for _, level := range f.levels(bootID) {
+		if err := j.AddConjunction(); err != nil {
+			return err
+		}
+		for i, group := range level {
+			if i > 0 {
+				if err := j.AddDisjunction(); err != nil {
+					return err
+				}
+			}
+			for _, m := range group {
+				if err := j.AddMatch(m.String()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if !f.since.IsZero() {
+		return j.SeekRealtimeUsec(uint64(f.since.UnixMicro()))
+	}
+	return nil
+}
+
+// bootID returns the ID of the boot at offset, as described by Filter.Boot.
+// For offsets other than 0, it flushes the matches of the journal, see
+// ListBoots.
+func (j *Journal) bootID(offset int) (string, error) {
+	if offset == 0 {
+		return j.GetBootID()
+	}
+
+	boots, err := j.ListBoots()
+	if err != nil {
+		return "", err
+	}
+
+	i := offset - 1
+	if offset < 0 {
+		i = len(boots) - 1 + offset
+	}
+	if i < 0 || i >= len(boots) {
+		return "", fmt.Errorf("no boot at offset %d in the journal", offset)
+	}
+	return boots[i].ID, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdjournal
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/gr-butler/go-systemd/v22/journal"
+)
+
+func TestFilterLevels(t *testing.T) {
+	f := NewFilter().
+		Unit("nginx.service").
+		MaxPriority(journal.PriErr).
+		Or(NewFilter().Identifier("nginx"), NewFilter().Match("CODE_FILE", "main.go").Match("CODE_LINE", "42"))
+
+	expected := [][][]Match{
+		{{
+			{SD_JOURNAL_FIELD_SYSTEMD_UNIT, "nginx.service"},
+			{SD_JOURNAL_FIELD_PRIORITY, "0"},
+			{SD_JOURNAL_FIELD_PRIORITY, "1"},
+			{SD_JOURNAL_FIELD_PRIORITY, "2"},
+			{SD_JOURNAL_FIELD_PRIORITY, "3"},
+			{SD_JOURNAL_FIELD_BOOT_ID, "0123456789abcdef0123456789abcdef"},
+		}},
+		{
+			{{SD_JOURNAL_FIELD_SYSLOG_IDENTIFIER, "nginx"}},
+			{{"CODE_FILE", "main.go"}, {"CODE_LINE", "42"}},
+		},
+	}
+
+	if levels := f.levels("0123456789abcdef0123456789abcdef"); !reflect.DeepEqual(levels, expected) {
+		t.Errorf("expected levels %v, got %v", expected, levels)
+	}
+	if len(f.matches) != 5 {
+		t.Errorf("compiling changed the filter matches: %v", f.matches)
+	}
+}
+
+func TestFilterInvalidOr(t *testing.T) {
+	f := NewFilter().Or(NewFilter().Unit("a.service"), NewFilter().Boot(-1))
+	if f.err == nil {
+		t.Fatal("expected an error for an alternative with a Boot condition")
+	}
+
+	j, err := NewJournal()
+	if err != nil {
+		t.Fatalf("Error opening journal: %s", err)
+	}
+	defer j.Close()
+
+	if err := j.ApplyFilter(f); err == nil {
+		t.Fatal("expected ApplyFilter to fail")
+	}
+}
+
+// Ensure that resolving the boot of a filter doesn't drop the matches added
+// along with it.
+func TestFilterBootKeepsMatches(t *testing.T) {
+	const unit = "go-systemd-no-such-unit.service"
+
+	j, err := NewJournal()
+	if err != nil {
+		t.Fatalf("Error opening journal: %s", err)
+	}
+	defer j.Close()
+
+	if err := j.ApplyFilter(NewFilter().Unit(unit).Boot(-1)); err != nil {
+		t.Skipf("no previous boot in the journal: %s", err)
+	}
+	if n, err := j.Next(); err != nil || n != 0 {
+		t.Errorf("expected no entries of %s, got %d, %v", unit, n, err)
+	}
+
+	r, err := NewJournalReader(JournalReaderConfig{
+		Matches: []Match{{Field: SD_JOURNAL_FIELD_SYSTEMD_UNIT, Value: unit}},
+		Filter:  NewFilter().Boot(-1),
+	})
+	if err != nil {
+		t.Fatalf("Error opening journal reader: %s", err)
+	}
+	defer r.Close()
+
+	if _, err := r.Read(make([]byte, 64)); err != io.EOF {
+		t.Errorf("expected no entries of %s, got %v", unit, err)
+	}
+}
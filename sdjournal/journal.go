@@ -28,6 +28,9 @@ package sdjournal
 // #include <systemd/sd-id128.h>
 // #include <stdlib.h>
 // #include <syslog.h>
+// #include <poll.h>
+// #include <errno.h>
+// #include <time.h>
 //
 // int
 // my_sd_journal_open(void *f, sd_journal **ret, int flags)
@@ -318,9 +321,59 @@ package sdjournal
 //   return sd_id128_to_string(boot_id, s);
 // }
 //
+// int
+// my_sd_journal_get_fd(void *f, sd_journal *j)
+// {
+//   int (*sd_journal_get_fd)(sd_journal *);
+//
+//   sd_journal_get_fd = f;
+//   return sd_journal_get_fd(j);
+// }
+//
+// int
+// my_sd_journal_get_timeout(void *f, sd_journal *j, uint64_t *timeout_usec)
+// {
+//   int (*sd_journal_get_timeout)(sd_journal *, uint64_t *);
+//
+//   sd_journal_get_timeout = f;
+//   return sd_journal_get_timeout(j, timeout_usec);
+// }
+//
+// int
+// my_sd_journal_process(void *f, sd_journal *j)
+// {
+//   int (*sd_journal_process)(sd_journal *);
+//
+//   sd_journal_process = f;
+//   return sd_journal_process(j);
+// }
+//
+// // my_poll_journal polls fd until it becomes readable, cancel_fd becomes
+// // readable or the CLOCK_MONOTONIC timeout_usec passes. It returns 1 if
+// // cancel_fd became readable, 0 otherwise, or a negative errno.
+// int
+// my_poll_journal(int fd, int cancel_fd, uint64_t timeout_usec)
+// {
+//   struct pollfd fds[2] = {{.fd = fd, .events = POLLIN}, {.fd = cancel_fd, .events = POLLIN}};
+//   struct timespec ts;
+//   uint64_t now;
+//   int msec = -1;
+//
+//   if (timeout_usec != (uint64_t) -1) {
+//     clock_gettime(CLOCK_MONOTONIC, &ts);
+//     now = (uint64_t) ts.tv_sec * 1000000 + ts.tv_nsec / 1000;
+//     msec = timeout_usec > now ? (int) ((timeout_usec - now + 999) / 1000) : 0;
+//   }
+//
+//   if (poll(fds, 2, msec) < 0)
+//     return -errno;
+//   return fds[1].revents ? 1 : 0;
+// }
+//
 import "C"
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -407,6 +460,10 @@ type JournalEntry struct {
 	Cursor             string
 	RealtimeTimestamp  uint64
 	MonotonicTimestamp uint64
+
+	// MultiFields holds all values, in order, of the fields which have more
+	// than one value in the entry, for which Fields holds only the last one.
+	MultiFields map[string][]string
 }
 
 // Match is a convenience wrapper to describe filters supplied to AddMatch.
@@ -800,6 +857,15 @@ func (j *Journal) GetEntry() (*JournalEntry, error) {
 			return nil, fmt.Errorf("failed to parse field")
 		}
 
+		if prev, ok := entry.Fields[kv[0]]; ok {
+			if entry.MultiFields == nil {
+				entry.MultiFields = make(map[string][]string)
+			}
+			if _, ok := entry.MultiFields[kv[0]]; !ok {
+				entry.MultiFields[kv[0]] = []string{prev}
+			}
+			entry.MultiFields[kv[0]] = append(entry.MultiFields[kv[0]], kv[1])
+		}
 		entry.Fields[kv[0]] = kv[1]
 	}
 
@@ -1035,6 +1101,128 @@ func (j *Journal) Wait(timeout time.Duration) int {
 	return int(r)
 }
 
+// WaitContext is like Wait with IndefiniteWait, but returns ctx.Err() when ctx
+// is done before the journal gets changed. Unlike Wait, it doesn't block other
+// calls on the Journal while waiting. It returns SD_JOURNAL_APPEND or
+// SD_JOURNAL_INVALIDATE.
+//
+// WaitContext sleeps on the inotify watches sd-journal keeps on the journal
+// directories and files, so it wakes up as soon as they change, without
+// periodic wakeups.
+func (j *Journal) WaitContext(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	sd_journal_get_fd, err := getFunction("sd_journal_get_fd")
+	if err != nil {
+		return 0, err
+	}
+	sd_journal_get_timeout, err := getFunction("sd_journal_get_timeout")
+	if err != nil {
+		return 0, err
+	}
+	sd_journal_process, err := getFunction("sd_journal_process")
+	if err != nil {
+		return 0, err
+	}
+
+	j.mu.Lock()
+	fd := C.my_sd_journal_get_fd(sd_journal_get_fd, j.cjournal)
+	j.mu.Unlock()
+
+	if fd < 0 {
+		return 0, fmt.Errorf("failed to get journal file descriptor: %s", syscall.Errno(-fd).Error())
+	}
+
+	// the pipe wakes up the poll when ctx is done
+	var p [2]int
+	if err := syscall.Pipe2(p[:], syscall.O_CLOEXEC); err != nil {
+		return 0, err
+	}
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case <-ctx.Done():
+			syscall.Write(p[1], []byte{0})
+		case <-done:
+		}
+	}()
+	defer func() {
+		close(done)
+		<-exited
+		syscall.Close(p[0])
+		syscall.Close(p[1])
+	}()
+
+	for {
+		var timeout C.uint64_t
+		j.mu.Lock()
+		r := C.my_sd_journal_get_timeout(sd_journal_get_timeout, j.cjournal, &timeout)
+		j.mu.Unlock()
+
+		if r < 0 {
+			return 0, fmt.Errorf("failed to get journal timeout: %s", syscall.Errno(-r).Error())
+		}
+
+		r = C.my_poll_journal(fd, C.int(p[0]), timeout)
+		if r == -C.int(syscall.EINTR) {
+			continue
+		}
+		if r < 0 {
+			return 0, fmt.Errorf("failed to wait for journal: %s", syscall.Errno(-r).Error())
+		}
+		if r == 1 {
+			return 0, ctx.Err()
+		}
+
+		j.mu.Lock()
+		r = C.my_sd_journal_process(sd_journal_process, j.cjournal)
+		j.mu.Unlock()
+
+		if r < 0 {
+			return 0, fmt.Errorf("failed to process journal events: %s", syscall.Errno(-r).Error())
+		}
+		if int(r) != SD_JOURNAL_NOP {
+			return int(r), nil
+		}
+	}
+}
+
+// Follow calls fn for each entry from the current position of the journal on,
+// waiting for new entries when the end of the journal is reached, until ctx is
+// done or fn returns an error. To follow only new entries, call SeekTail and
+// Previous first. Follow returns the error of fn, or ctx.Err().
+func (j *Journal) Follow(ctx context.Context, fn func(entry *JournalEntry) error) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		n, err := j.Next()
+		if err != nil {
+			return err
+		}
+
+		if n == 0 {
+			if _, err := j.WaitContext(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+
+		entry, err := j.GetEntry()
+		if err != nil {
+			return err
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+}
+
 // GetUsage returns the journal disk space usage, in bytes.
 func (j *Journal) GetUsage() (uint64, error) {
 	var out C.uint64_t
@@ -17,6 +17,7 @@ package sdjournal
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -84,6 +85,114 @@ func TestJournalFollow(t *testing.T) {
 	}
 }
 
+func TestJournalFollowContext(t *testing.T) {
+	id := time.Now().String()
+	j, err := NewJournal()
+	if err != nil {
+		t.Fatalf("Error opening journal: %s", err)
+	}
+	defer j.Close()
+
+	if err := j.AddMatch("TEST=TestJournalFollowContext " + id); err != nil {
+		t.Fatalf("Error adding match: %s", err)
+	}
+	if err := j.SeekTail(); err != nil {
+		t.Fatalf("Error seeking to tail: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		journal.Send("test message", journal.PriInfo, map[string]string{"TEST": "TestJournalFollowContext " + id})
+	}()
+
+	var entry *JournalEntry
+	err = j.Follow(ctx, func(e *JournalEntry) error {
+		entry = e
+		cancel()
+		return nil
+	})
+	if err != context.Canceled {
+		t.Fatalf("Error during follow: %s", err)
+	}
+	if entry == nil || entry.Fields["MESSAGE"] != "test message" {
+		t.Fatalf("Expected the test message, got %v", entry)
+	}
+
+	// once cancelled, waiting returns immediately
+	if _, err := j.WaitContext(ctx); err != context.Canceled {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestJournalFollowExpires(t *testing.T) {
+	r, err := NewJournalReader(JournalReaderConfig{Path: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Error opening journal: %s", err)
+	}
+	defer r.Close()
+
+	start := time.Now()
+	if err := r.Follow(time.After(200*time.Millisecond), io.Discard); err != ErrExpired {
+		t.Fatalf("Expected ErrExpired, got %v", err)
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Errorf("Follow returned %s after the deadline", d)
+	}
+}
+
+func TestJournalReaderEntries(t *testing.T) {
+	id := time.Now().String()
+	if err := journal.Send("test message", journal.PriInfo, map[string]string{"TEST": "TestJournalReaderEntries " + id}); err != nil {
+		t.Fatalf("Error writing to journal: %s", err)
+	}
+
+	r, err := NewJournalReader(JournalReaderConfig{
+		Matches: []Match{{Field: "TEST", Value: "TestJournalReaderEntries " + id}},
+	})
+	if err != nil {
+		t.Fatalf("Error opening journal: %s", err)
+	}
+	defer r.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for entry, err := range r.Entries(ctx, true) {
+		if err != nil {
+			t.Fatalf("Error reading entries: %s", err)
+		}
+		if entry.Fields["MESSAGE"] != "test message" || entry.Cursor == "" || entry.RealtimeTimestamp == 0 {
+			t.Errorf("Unexpected entry %+v", entry)
+		}
+		break
+	}
+}
+
+func TestJournalReaderEntriesEmpty(t *testing.T) {
+	r, err := NewJournalReader(JournalReaderConfig{Path: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Error opening journal: %s", err)
+	}
+	defer r.Close()
+
+	for entry, err := range r.Entries(context.Background(), false) {
+		t.Fatalf("Unexpected entry %v (%v)", entry, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	var errs []error
+	for _, err := range r.Entries(ctx, true) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || errs[0] != context.DeadlineExceeded {
+		t.Fatalf("Expected a single context.DeadlineExceeded, got %v", errs)
+	}
+}
+
 func TestJournalWait(t *testing.T) {
 	id := time.Now().String()
 	j, err := NewJournal()
@@ -211,7 +320,7 @@ func TestNewJournalFromDir(t *testing.T) {
 		t.Fatalf("Error expected when opening dummy path (%s)", dir)
 	}
 	// test for main code path
-	dir, err = ioutil.TempDir("", "go-systemd-test")
+	dir, err = os.MkdirTemp("", "go-systemd-test")
 	if err != nil {
 		t.Fatalf("Error creating tempdir: %s", err)
 	}
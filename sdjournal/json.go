@@ -0,0 +1,224 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdjournal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// MarshalJournalJSON returns the entry as a JSON object in the format of
+// journalctl -o json --all: the cursor and timestamps are included as
+// __CURSOR, __REALTIME_TIMESTAMP and __MONOTONIC_TIMESTAMP, all values are
+// strings, values which aren't printable text are arrays of their bytes, and
+// fields with several values are arrays of these values.
+func (e *JournalEntry) MarshalJournalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	buf.WriteByte('{')
+	first := true
+	writeField := func(name string, value interface{}) error {
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		if err := enc.Encode(name); err != nil {
+			return err
+		}
+		buf.Truncate(buf.Len() - 1) // the newline added by Encode
+		buf.WriteByte(':')
+		if err := enc.Encode(value); err != nil {
+			return err
+		}
+		buf.Truncate(buf.Len() - 1)
+		return nil
+	}
+
+	if err := writeField(SD_JOURNAL_FIELD_CURSOR, e.Cursor); err != nil {
+		return nil, err
+	}
+	if err := writeField(SD_JOURNAL_FIELD_REALTIME_TIMESTAMP, strconv.FormatUint(e.RealtimeTimestamp, 10)); err != nil {
+		return nil, err
+	}
+	if err := writeField(SD_JOURNAL_FIELD_MONOTONIC_TIMESTAMP, strconv.FormatUint(e.MonotonicTimestamp, 10)); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var value interface{}
+		if values, ok := e.MultiFields[name]; ok {
+			array := make([]interface{}, len(values))
+			for i, v := range values {
+				array[i] = jsonValue(v)
+			}
+			value = array
+		} else {
+			value = jsonValue(e.Fields[name])
+		}
+
+		if err := writeField(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonValue returns the JSON representation of a field value: a string if it
+// is printable text, otherwise an array of its bytes.
+func jsonValue(value string) interface{} {
+	if isPrintable(value) {
+		return value
+	}
+
+	array := make([]int, len(value))
+	for i := 0; i < len(value); i++ {
+		array[i] = int(value[i])
+	}
+	return array
+}
+
+// isPrintable reports whether value is valid UTF-8 without control characters
+// other than tabs and newlines.
+func isPrintable(value string) bool {
+	if !utf8.ValidString(value) {
+		return false
+	}
+	for _, c := range value {
+		if (c < ' ' && c != '\t' && c != '\n') || (0x7f <= c && c <= 0x9f) {
+			return false
+		}
+	}
+	return true
+}
+
+// JSONFormatter formats entries for a JournalReader like journalctl -o json
+// --all, one JSON object per line.
+func JSONFormatter(entry *JournalEntry) (string, error) {
+	b, err := entry.MarshalJournalJSON()
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
+
+// ParseJournalJSON parses an entry in the format of journalctl -o json, as
+// produced by MarshalJournalJSON. Fields with a null value, which journalctl
+// outputs for large values unless --all is given, are skipped.
+func ParseJournalJSON(data []byte) (*JournalEntry, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	entry := &JournalEntry{Fields: make(map[string]string)}
+	for name, msg := range raw {
+		values, err := parseJSONValues(msg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse field %s: %w", name, err)
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		switch name {
+		case SD_JOURNAL_FIELD_CURSOR:
+			entry.Cursor = values[0]
+			continue
+		case SD_JOURNAL_FIELD_REALTIME_TIMESTAMP:
+			entry.RealtimeTimestamp, err = strconv.ParseUint(values[0], 10, 64)
+		case SD_JOURNAL_FIELD_MONOTONIC_TIMESTAMP:
+			entry.MonotonicTimestamp, err = strconv.ParseUint(values[0], 10, 64)
+		default:
+			entry.Fields[name] = values[len(values)-1]
+			if len(values) > 1 {
+				if entry.MultiFields == nil {
+					entry.MultiFields = make(map[string][]string)
+				}
+				entry.MultiFields[name] = values
+			}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse field %s: %w", name, err)
+		}
+	}
+
+	return entry, nil
+}
+
+// parseJSONValues parses a field value, which is null, a string, an array of
+// bytes, or an array of strings and arrays of bytes.
+func parseJSONValues(msg json.RawMessage) ([]string, error) {
+	var value interface{}
+	if err := json.Unmarshal(msg, &value); err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		if b, ok := jsonBytes(v); ok {
+			return []string{b}, nil
+		}
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			switch item := item.(type) {
+			case string:
+				values = append(values, item)
+			case []interface{}:
+				b, ok := jsonBytes(item)
+				if !ok {
+					return nil, fmt.Errorf("invalid binary value")
+				}
+				values = append(values, b)
+			default:
+				return nil, fmt.Errorf("invalid value %v", item)
+			}
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("invalid value %v", v)
+	}
+}
+
+// jsonBytes converts an array of byte values to the string of these bytes.
+func jsonBytes(array []interface{}) (string, bool) {
+	b := make([]byte, len(array))
+	for i, item := range array {
+		n, ok := item.(float64)
+		if !ok || n < 0 || n > 255 || n != float64(int(n)) {
+			return "", false
+		}
+		b[i] = byte(n)
+	}
+	return string(b), true
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdjournal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarshalJournalJSON(t *testing.T) {
+	entry := &JournalEntry{
+		Cursor:             "s=abc;i=1",
+		RealtimeTimestamp:  1700000000000000,
+		MonotonicTimestamp: 42,
+		Fields: map[string]string{
+			"MESSAGE":  "a <b>\nc",
+			"BLOB":     "\x00\x01",
+			"TAG":      "y",
+			"PRIORITY": "6",
+		},
+		MultiFields: map[string][]string{
+			"TAG": {"x", "\x02", "y"},
+		},
+	}
+
+	b, err := entry.MarshalJournalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"__CURSOR":"s=abc;i=1","__REALTIME_TIMESTAMP":"1700000000000000","__MONOTONIC_TIMESTAMP":"42",` +
+		`"BLOB":[0,1],"MESSAGE":"a <b>\nc","PRIORITY":"6","TAG":["x",[2],"y"]}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, b)
+	}
+
+	parsed, err := ParseJournalJSON(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, entry) {
+		t.Errorf("expected %+v, got %+v", entry, parsed)
+	}
+}
+
+func TestParseJournalJSON(t *testing.T) {
+	// as output by journalctl without --all
+	entry, err := ParseJournalJSON([]byte(`{"__CURSOR":"c","__REALTIME_TIMESTAMP":"1","MESSAGE":null,"_PID":"1"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := entry.Fields["MESSAGE"]; ok || entry.Fields["_PID"] != "1" || entry.RealtimeTimestamp != 1 {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+
+	if _, err := ParseJournalJSON([]byte(`{"MESSAGE":[{}]}`)); err == nil {
+		t.Error("expected an error for an invalid value")
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdjournal
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MetricKey identifies the entries counted together by an Aggregator. Its
+// fields are empty for entries without the corresponding journal field.
+type MetricKey struct {
+	Unit      string // _SYSTEMD_UNIT
+	Priority  string // PRIORITY, e.g. "3" for errors
+	MessageID string // MESSAGE_ID
+}
+
+// MetricsSnapshot holds the entry counts of an Aggregator.
+type MetricsSnapshot struct {
+	// Total counts all entries added, suitable for Prometheus counters.
+	Total map[MetricKey]uint64
+	// Window counts the entries written during the sliding window ending
+	// at the time of the snapshot, suitable for gauges of recent rates.
+	Window map[MetricKey]uint64
+}
+
+// Aggregator counts journal entries by unit, priority and message ID, in
+// total and over a sliding window, so that metrics such as error rates per
+// unit can be exported without shipping the entries. It is safe for
+// concurrent use.
+type Aggregator struct {
+	resolution time.Duration
+	now        func() time.Time
+
+	mu      sync.Mutex
+	total   map[MetricKey]uint64
+	buckets []metricsBucket
+}
+
+// metricsBucket counts the entries written during one resolution interval.
+type metricsBucket struct {
+	index  int64 // the interval, as the time divided by the resolution
+	counts map[MetricKey]uint64
+}
+
+// NewAggregator returns an Aggregator with a sliding window of the given
+// length, tracked with the given resolution, e.g. a window of 5 minutes with a
+// resolution of 10 seconds. If resolution is zero, a sixtieth of the window
+// is used.
+func NewAggregator(window, resolution time.Duration) *Aggregator {
+	if resolution <= 0 {
+		resolution = window / 60
+	}
+	if resolution <= 0 {
+		resolution = time.Second
+	}
+	n := int(window / resolution)
+	if n < 1 {
+		n = 1
+	}
+
+	return &Aggregator{
+		resolution: resolution,
+		now:        time.Now,
+		total:      make(map[MetricKey]uint64),
+		buckets:    make([]metricsBucket, n),
+	}
+}
+
+// Add counts the entry. Entries are assigned to the window by their realtime
+// timestamp, so entries older than the window are only counted in the total.
+func (a *Aggregator) Add(entry *JournalEntry) {
+	key := MetricKey{
+		Unit:      entry.Fields[SD_JOURNAL_FIELD_SYSTEMD_UNIT],
+		Priority:  entry.Fields[SD_JOURNAL_FIELD_PRIORITY],
+		MessageID: entry.Fields[SD_JOURNAL_FIELD_MESSAGE_ID],
+	}
+	index := time.UnixMicro(int64(entry.RealtimeTimestamp)).UnixNano() / int64(a.resolution)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.total[key]++
+
+	current := a.now().UnixNano() / int64(a.resolution)
+	if index <= current-int64(len(a.buckets)) || index > current {
+		return
+	}
+	b := &a.buckets[index%int64(len(a.buckets))]
+	if b.index != index || b.counts == nil {
+		*b = metricsBucket{index: index, counts: make(map[MetricKey]uint64)}
+	}
+	b.counts[key]++
+}
+
+// Snapshot returns the current counts.
+func (a *Aggregator) Snapshot() MetricsSnapshot {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	s := MetricsSnapshot{
+		Total:  make(map[MetricKey]uint64, len(a.total)),
+		Window: make(map[MetricKey]uint64),
+	}
+	for key, n := range a.total {
+		s.Total[key] = n
+	}
+
+	current := a.now().UnixNano() / int64(a.resolution)
+	for _, b := range a.buckets {
+		if b.counts == nil || b.index <= current-int64(len(a.buckets)) || b.index > current {
+			continue
+		}
+		for key, n := range b.counts {
+			s.Window[key] += n
+		}
+	}
+	return s
+}
+
+// Run adds the entries of the JournalReader, following it until ctx is done
+// or reading fails. It returns ctx.Err() when ctx is done. To count only new
+// entries, create the reader with NumFromTail set to 1, or Since set to a
+// negative duration.
+func (a *Aggregator) Run(ctx context.Context, r *JournalReader) error {
+	for entry, err := range r.Entries(ctx, true) {
+		if err != nil {
+			return err
+		}
+		a.Add(entry)
+	}
+	return nil
+}
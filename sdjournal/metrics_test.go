@@ -0,0 +1,59 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdjournal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAggregator(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	a := NewAggregator(time.Minute, 10*time.Second)
+	a.now = func() time.Time { return now }
+
+	entry := func(unit, priority string, age time.Duration) *JournalEntry {
+		return &JournalEntry{
+			Fields:            map[string]string{SD_JOURNAL_FIELD_SYSTEMD_UNIT: unit, SD_JOURNAL_FIELD_PRIORITY: priority},
+			RealtimeTimestamp: uint64(now.Add(-age).UnixMicro()),
+		}
+	}
+
+	a.Add(entry("nginx.service", "3", time.Second))
+	a.Add(entry("nginx.service", "3", 30*time.Second))
+	a.Add(entry("nginx.service", "3", 2*time.Minute))
+	a.Add(entry("sshd.service", "6", time.Second))
+
+	errors := MetricKey{Unit: "nginx.service", Priority: "3"}
+	info := MetricKey{Unit: "sshd.service", Priority: "6"}
+
+	s := a.Snapshot()
+	if s.Total[errors] != 3 || s.Window[errors] != 2 || s.Window[info] != 1 {
+		t.Errorf("unexpected snapshot %+v", s)
+	}
+
+	// the window slides, the totals stay
+	now = now.Add(45 * time.Second)
+	s = a.Snapshot()
+	if s.Total[errors] != 3 || s.Window[errors] != 1 || s.Window[info] != 1 {
+		t.Errorf("unexpected snapshot after 45s %+v", s)
+	}
+
+	now = now.Add(time.Minute)
+	s = a.Snapshot()
+	if len(s.Window) != 0 || s.Total[info] != 1 {
+		t.Errorf("unexpected snapshot after the window %+v", s)
+	}
+}
@@ -16,12 +16,12 @@
 package sdjournal
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"iter"
 	"strings"
-	"sync"
 	"time"
 )
 
@@ -44,6 +44,11 @@ type JournalReaderConfig struct {
 	// the array is empty, entries will not be filtered.
 	Matches []Match
 
+	// If not nil, Filter additionally restricts the entries to those it
+	// selects. A Since condition of the Filter determines where the reading
+	// begins, unless one of the options above is set.
+	Filter *Filter
+
 	// If not empty, the journal instance will point to a journal residing
 	// in this directory. The supplied path may be relative or absolute.
 	Path string
@@ -52,6 +57,11 @@ type JournalReaderConfig struct {
 	// into strings. If not set, the default format (timestamp and message field)
 	// will be used. If Formatter returns an error, Read will stop and return the error.
 	Formatter func(entry *JournalEntry) (string, error)
+
+	// If not nil, CursorStore holds the cursor of the last entry committed
+	// with Commit, and reading resumes after it unless one of Since,
+	// NumFromTail or Cursor is set.
+	CursorStore CursorStore
 }
 
 // JournalReader is an io.ReadCloser which provides a simple interface for iterating through the
@@ -60,6 +70,9 @@ type JournalReader struct {
 	journal   *Journal
 	msgReader *strings.Reader
 	formatter func(entry *JournalEntry) (string, error)
+
+	cursorStore CursorStore
+	lastCursor  string
 }
 
 // NewJournalReader creates a new JournalReader with configuration options that are similar to the
@@ -71,7 +84,8 @@ func NewJournalReader(config JournalReaderConfig) (*JournalReader, error) {
 	}
 
 	r := &JournalReader{
-		formatter: config.Formatter,
+		formatter:   config.Formatter,
+		cursorStore: config.CursorStore,
 	}
 
 	// Open the journal
@@ -85,6 +99,18 @@ func NewJournalReader(config JournalReaderConfig) (*JournalReader, error) {
 		return nil, err
 	}
 
+	// Add the filter first, as resolving its boot flushes the matches
+	if config.Filter != nil {
+		if err = r.journal.addFilter(config.Filter); err != nil {
+			return nil, err
+		}
+		if len(config.Matches) > 0 {
+			if err = r.journal.AddConjunction(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Add any supplied matches
 	for _, m := range config.Matches {
 		if err = r.journal.AddMatch(m.String()); err != nil {
@@ -124,6 +150,17 @@ func NewJournalReader(config JournalReaderConfig) (*JournalReader, error) {
 		if err := r.journal.SeekCursor(config.Cursor); err != nil {
 			return nil, err
 		}
+	} else if config.CursorStore != nil {
+		// Resume after the last committed entry
+		cursor, err := config.CursorStore.Load()
+		if err != nil {
+			return nil, err
+		}
+		if cursor != "" {
+			if err := r.journal.seekAfterCursor(cursor); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return r, nil
@@ -162,6 +199,7 @@ func (r *JournalReader) Read(b []byte) (int, error) {
 			return 0, err
 		}
 		r.msgReader = strings.NewReader(msg)
+		r.lastCursor = entry.Cursor
 	}
 
 	// Copy and return the message
@@ -183,79 +221,144 @@ func (r *JournalReader) Read(b []byte) (int, error) {
 	return sz, nil
 }
 
+// NextEntry advances the JournalReader and returns the next entry with all
+// its fields, cursor and timestamps, instead of its formatted message. It
+// returns io.EOF when the end of the journal is reached. Any formatted
+// message partially returned by Read is discarded.
+func (r *JournalReader) NextEntry() (*JournalEntry, error) {
+	r.msgReader = nil
+
+	c, err := r.journal.Next()
+	if err != nil {
+		return nil, err
+	}
+	if c == 0 {
+		return nil, io.EOF
+	}
+
+	entry, err := r.journal.GetEntry()
+	if err != nil {
+		return nil, err
+	}
+	r.lastCursor = entry.Cursor
+	return entry, nil
+}
+
+// Entries returns an iterator over the entries of the JournalReader, as
+// returned by NextEntry. It stops at the end of the journal, or, if follow is
+// set, waits for new entries until ctx is done. Errors, including ctx.Err(),
+// are yielded with a nil entry and end the iteration. Calling Commit while
+// iterating saves the cursor of the last yielded entry.
+func (r *JournalReader) Entries(ctx context.Context, follow bool) iter.Seq2[*JournalEntry, error] {
+	return func(yield func(*JournalEntry, error) bool) {
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			entry, err := r.NextEntry()
+			if err == io.EOF {
+				if !follow {
+					return
+				}
+				if _, err := r.journal.WaitContext(ctx); err != nil {
+					yield(nil, err)
+					return
+				}
+				continue
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(entry, nil) {
+				return
+			}
+		}
+	}
+}
+
 // Close closes the JournalReader's handle to the journal.
 func (r *JournalReader) Close() error {
 	return r.journal.Close()
 }
 
+// Commit saves the cursor of the last entry read, i.e. the last entry whose
+// formatted message Read started to return, to the CursorStore of the
+// JournalReader. Call it once the entries read so far have been processed,
+// so that they aren't read again when resuming, for at-least-once delivery.
+func (r *JournalReader) Commit() error {
+	if r.cursorStore == nil {
+		return errors.New("no CursorStore configured")
+	}
+	if r.lastCursor == "" {
+		return nil
+	}
+	return r.cursorStore.Save(r.lastCursor)
+}
+
 // Rewind attempts to rewind the JournalReader to the first entry.
 func (r *JournalReader) Rewind() error {
 	r.msgReader = nil
 	return r.journal.SeekHead()
 }
 
-// Follow synchronously follows the JournalReader, writing each new journal entry to writer. The
-// follow will continue until a single time.Time is received on the until channel.
-func (r *JournalReader) Follow(until <-chan time.Time, writer io.Writer) error {
-
-	// Process journal entries and events. Entries are flushed until the tail or
-	// timeout is reached, and then we wait for new events or the timeout.
+// FollowContext follows the JournalReader, writing each new journal entry to
+// writer, until ctx is done. It waits for journal changes with WaitContext,
+// which wakes up as soon as journal files change rather than polling the
+// journal, and returns ctx.Err() when ctx is done.
+func (r *JournalReader) FollowContext(ctx context.Context, writer io.Writer) error {
 	var msg = make([]byte, 64*1<<(10))
-	var waitCh = make(chan int, 1)
-	var waitGroup sync.WaitGroup
-	defer waitGroup.Wait()
 
-process:
 	for {
-		c, err := r.Read(msg)
-		if err != nil && err != io.EOF {
+		if err := ctx.Err(); err != nil {
 			return err
 		}
 
-		select {
-		case <-until:
-			return ErrExpired
-		default:
+		c, err := r.Read(msg)
+		if err != nil && err != io.EOF {
+			return err
 		}
 		if c > 0 {
 			if _, err = writer.Write(msg[:c]); err != nil {
 				return err
 			}
-			continue process
+			continue
 		}
 
-		// We're at the tail, so wait for new events or time out.
-		// Holds journal events to process. Tightly bounded for now unless there's a
-		// reason to unblock the journal watch routine more quickly.
-		for {
-			waitGroup.Add(1)
-			go func() {
-				status := r.journal.Wait(100 * time.Millisecond)
-				waitCh <- status
-				waitGroup.Done()
-			}()
-
-			select {
-			case <-until:
-				return ErrExpired
-			case e := <-waitCh:
-				switch e {
-				case SD_JOURNAL_NOP:
-					// the journal did not change since the last invocation
-				case SD_JOURNAL_APPEND, SD_JOURNAL_INVALIDATE:
-					continue process
-				default:
-					if e < 0 {
-						return fmt.Errorf("received error event: %d", e)
-					}
-
-					log.Printf("received unknown event: %d\n", e)
-				}
-			}
+		// We're at the tail, so wait for new events.
+		if _, err := r.journal.WaitContext(ctx); err != nil {
+			return err
 		}
 	}
 }
 
+// Follow synchronously follows the JournalReader, writing each new journal entry to writer. The
+// follow will continue until a single time.Time is received on the until channel, and then
+// returns ErrExpired.
+//
+// Deprecated: use FollowContext instead.
+func (r *JournalReader) Follow(until <-chan time.Time, writer io.Writer) error {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	go func() {
+		select {
+		case <-until:
+			cancel(ErrExpired)
+		case <-ctx.Done():
+		}
+	}()
+
+	err := r.FollowContext(ctx, writer)
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	return err
+}
+
 // simpleMessageFormatter is the default formatter.
 // It returns a string representing the current journal entry in a simple format which
 // includes the entry timestamp and MESSAGE field.
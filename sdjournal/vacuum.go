@@ -0,0 +1,206 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdjournal
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// DefaultJournalDirs are the directories of the persistent and the volatile
+// journal.
+var DefaultJournalDirs = []string{"/var/log/journal", "/run/log/journal"}
+
+// JournalFile is a journal file on disk.
+type JournalFile struct {
+	Path string
+	// Size is the disk space used by the file, in bytes.
+	Size int64
+	// Archived is whether journald finished writing to the file, so that it
+	// may be removed. Active files are never removed by Vacuum.
+	Archived bool
+	// Time is when the first entry of an archived file was written, as
+	// encoded in its name, or the modification time of the file.
+	Time time.Time
+}
+
+// ListJournalFiles returns the journal files in the given directories and
+// their subdirectories, e.g. those named by machine IDs, or in
+// DefaultJournalDirs if none are given, ordered from the oldest to the newest.
+// Directories which don't exist are skipped.
+func ListJournalFiles(dirs ...string) ([]JournalFile, error) {
+	if len(dirs) == 0 {
+		dirs = DefaultJournalDirs
+	}
+
+	var files []JournalFile
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if path == dir && errors.Is(err, fs.ErrNotExist) {
+					return filepath.SkipDir
+				}
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+
+			name := d.Name()
+			if !strings.HasSuffix(name, ".journal") && !strings.HasSuffix(name, ".journal~") {
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			files = append(files, newJournalFile(path, info))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(files, func(i, j int) bool { return files[i].Time.Before(files[j].Time) })
+	return files, nil
+}
+
+func newJournalFile(path string, info fs.FileInfo) JournalFile {
+	f := JournalFile{Path: path, Size: info.Size(), Time: info.ModTime()}
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		f.Size = int64(st.Blocks) * 512
+	}
+
+	name := filepath.Base(path)
+	if strings.HasSuffix(name, ".journal~") {
+		// a file disposed after it was found corrupted:
+		// system@<realtime>-<random>.journal~
+		f.Archived = true
+		if _, fields, ok := strings.Cut(strings.TrimSuffix(name, ".journal~"), "@"); ok {
+			if realtime, _, ok := strings.Cut(fields, "-"); ok {
+				f.Time = parseRealtimeHex(realtime, f.Time)
+			}
+		}
+	} else if _, fields, ok := strings.Cut(strings.TrimSuffix(name, ".journal"), "@"); ok {
+		// an archived file: system@<seqnum ID>-<head seqnum>-<head realtime>.journal
+		f.Archived = true
+		if parts := strings.Split(fields, "-"); len(parts) == 3 {
+			f.Time = parseRealtimeHex(parts[2], f.Time)
+		}
+	}
+	return f
+}
+
+func parseRealtimeHex(s string, fallback time.Time) time.Time {
+	usec, err := strconv.ParseUint(s, 16, 64)
+	if err != nil || usec == 0 {
+		return fallback
+	}
+	return time.UnixMicro(int64(usec))
+}
+
+// DiskUsage returns the disk space used by the journal files in the given
+// directories, or in DefaultJournalDirs if none are given, like journalctl
+// --disk-usage.
+func DiskUsage(dirs ...string) (int64, error) {
+	files, err := ListJournalFiles(dirs...)
+	if err != nil {
+		return 0, err
+	}
+
+	var usage int64
+	for _, f := range files {
+		usage += f.Size
+	}
+	return usage, nil
+}
+
+// VacuumOptions are the limits enforced by Vacuum. Zero values mean no
+// limit.
+type VacuumOptions struct {
+	// MaxSize is the maximum disk space used by all journal files, like
+	// journalctl --vacuum-size.
+	MaxSize int64
+	// MaxAge is the maximum age of the archived files, like journalctl
+	// --vacuum-time.
+	MaxAge time.Duration
+	// MaxFiles is the maximum number of archived files, like journalctl
+	// --vacuum-files.
+	MaxFiles int
+	// DryRun reports the files which would be removed without removing
+	// them.
+	DryRun bool
+}
+
+// Vacuum removes the oldest archived journal files in the given directories,
+// or in DefaultJournalDirs if none are given, until the limits are met, and
+// returns the removed files. Active journal files are never removed, so the
+// limits may still be exceeded afterwards.
+func Vacuum(opts VacuumOptions, dirs ...string) ([]JournalFile, error) {
+	files, err := ListJournalFiles(dirs...)
+	if err != nil {
+		return nil, err
+	}
+
+	var usage int64
+	archived := 0
+	for _, f := range files {
+		usage += f.Size
+		if f.Archived {
+			archived++
+		}
+	}
+
+	cutoff := time.Time{}
+	if opts.MaxAge > 0 {
+		cutoff = time.Now().Add(-opts.MaxAge)
+	}
+
+	var removed []JournalFile
+	for _, f := range files {
+		if !f.Archived {
+			continue
+		}
+
+		tooLarge := opts.MaxSize > 0 && usage > opts.MaxSize
+		tooOld := !cutoff.IsZero() && f.Time.Before(cutoff)
+		tooMany := opts.MaxFiles > 0 && archived > opts.MaxFiles
+		if !tooLarge && !tooOld && !tooMany {
+			// the files are ordered from the oldest, so no limit
+			// applies to the remaining ones either
+			break
+		}
+
+		if !opts.DryRun {
+			if err := os.Remove(f.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return removed, err
+			}
+		}
+		removed = append(removed, f)
+		usage -= f.Size
+		archived--
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdjournal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func createJournalFiles(t *testing.T, dir string, ages ...time.Duration) []string {
+	machineDir := filepath.Join(dir, "0123456789abcdef0123456789abcdef")
+	if err := os.Mkdir(machineDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for i, age := range ages {
+		realtime := time.Now().Add(-age).UnixMicro()
+		names = append(names, fmt.Sprintf("system@739ad463348b4ceca5a9e69c95a3c93f-%016x-%016x.journal", i+1, realtime))
+	}
+	names = append(names, "system.journal")
+
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(machineDir, name), make([]byte, 8192), 0640); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return names
+}
+
+func TestListJournalFiles(t *testing.T) {
+	dir := t.TempDir()
+	names := createJournalFiles(t, dir, 48*time.Hour, 72*time.Hour)
+
+	files, err := ListJournalFiles(dir, filepath.Join(dir, "missing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("expected 3 files, got %+v", files)
+	}
+	if filepath.Base(files[0].Path) != names[1] || filepath.Base(files[1].Path) != names[0] {
+		t.Errorf("files not ordered by age: %+v", files)
+	}
+	if !files[0].Archived || files[2].Archived {
+		t.Errorf("unexpected archived state: %+v", files)
+	}
+
+	usage, err := DiskUsage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage != files[0].Size+files[1].Size+files[2].Size || usage == 0 {
+		t.Errorf("unexpected disk usage %d", usage)
+	}
+}
+
+func TestVacuum(t *testing.T) {
+	dir := t.TempDir()
+	createJournalFiles(t, dir, 24*time.Hour, 48*time.Hour, 72*time.Hour)
+
+	removed, err := Vacuum(VacuumOptions{MaxAge: 36 * time.Hour, DryRun: true}, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 2 {
+		t.Errorf("expected 2 files older than 36h, got %+v", removed)
+	}
+
+	removed, err = Vacuum(VacuumOptions{MaxFiles: 1}, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 2 {
+		t.Fatalf("expected 2 files to be removed, got %+v", removed)
+	}
+
+	// the active file is kept even if the size limit is exceeded
+	removed, err = Vacuum(VacuumOptions{MaxSize: 1}, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 1 {
+		t.Fatalf("expected the last archived file to be removed, got %+v", removed)
+	}
+
+	files, err := ListJournalFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Archived {
+		t.Errorf("expected only the active file to remain, got %+v", files)
+	}
+}
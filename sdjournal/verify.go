@@ -0,0 +1,195 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdjournal
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Journal file header flags, see
+// https://systemd.io/JOURNAL_FILE_FORMAT/
+const (
+	headerCompatibleSealed           = 1 << 0
+	headerCompatibleSealedContinuous = 1 << 2
+
+	headerIncompatibleCompressedXZ   = 1 << 0
+	headerIncompatibleCompressedLZ4  = 1 << 1
+	headerIncompatibleKeyedHash      = 1 << 2
+	headerIncompatibleCompressedZSTD = 1 << 3
+	headerIncompatibleCompact        = 1 << 4
+)
+
+// JournalFileState is the state recorded in the header of a journal file.
+type JournalFileState int
+
+const (
+	JournalFileOffline JournalFileState = iota
+	JournalFileOnline
+	JournalFileArchived
+)
+
+func (s JournalFileState) String() string {
+	switch s {
+	case JournalFileOffline:
+		return "offline"
+	case JournalFileOnline:
+		return "online"
+	case JournalFileArchived:
+		return "archived"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// JournalFileHeader describes the header of a journal file.
+type JournalFileHeader struct {
+	State JournalFileState
+	// Sealed is whether the file is protected with Forward Secure Sealing,
+	// so that it can be verified with a verification key.
+	Sealed      bool
+	Compression string // xz, lz4 or zstd, or empty if not compressed
+	KeyedHash   bool
+	Compact     bool
+}
+
+// ReadJournalFileHeader reads the header of the journal file at path, e.g. to
+// check that it is sealed before verifying it.
+func ReadJournalFileHeader(path string) (*JournalFileHeader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var raw struct {
+		Signature         [8]byte
+		CompatibleFlags   uint32
+		IncompatibleFlags uint32
+		State             uint8
+	}
+	if err := binary.Read(f, binary.LittleEndian, &raw); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("%s: not a journal file", path)
+		}
+		return nil, err
+	}
+	if string(raw.Signature[:]) != "LPKSHHRH" {
+		return nil, fmt.Errorf("%s: not a journal file", path)
+	}
+
+	h := &JournalFileHeader{
+		State:     JournalFileState(raw.State),
+		Sealed:    raw.CompatibleFlags&(headerCompatibleSealed|headerCompatibleSealedContinuous) != 0,
+		KeyedHash: raw.IncompatibleFlags&headerIncompatibleKeyedHash != 0,
+		Compact:   raw.IncompatibleFlags&headerIncompatibleCompact != 0,
+	}
+	switch {
+	case raw.IncompatibleFlags&headerIncompatibleCompressedXZ != 0:
+		h.Compression = "xz"
+	case raw.IncompatibleFlags&headerIncompatibleCompressedLZ4 != 0:
+		h.Compression = "lz4"
+	case raw.IncompatibleFlags&headerIncompatibleCompressedZSTD != 0:
+		h.Compression = "zstd"
+	}
+	return h, nil
+}
+
+// VerifyOptions selects the journal files verified by VerifyJournal.
+type VerifyOptions struct {
+	// Files are the journal files to verify. If empty, the files in
+	// Directory, or the local journal if Directory is empty as well, are
+	// verified.
+	Files     []string
+	Directory string
+	// VerifyKey is the verification key generated by journalctl
+	// --setup-keys, to check the seals of sealed files. Without it, only the
+	// consistency of the files is checked.
+	VerifyKey string
+}
+
+// VerifyResult is the outcome of verifying a journal file.
+type VerifyResult struct {
+	Path   string
+	Passed bool
+	// Message is the reason the verification failed, or, for sealed files
+	// verified with a key, the range of entries validated by seals.
+	Message string
+}
+
+// VerifyJournal checks the consistency of journal files and, if a
+// verification key is given, their Forward Secure Sealing, like journalctl
+// --verify. As the sd-journal API doesn't provide verification, journalctl is
+// run to do it, so it must be installed. The returned error is nil if
+// journalctl ran, even if files failed verification, which is reported in
+// their results.
+func VerifyJournal(ctx context.Context, opts VerifyOptions) ([]VerifyResult, error) {
+	args := []string{"--verify", "--no-pager"}
+	for _, f := range opts.Files {
+		args = append(args, "--file="+f)
+	}
+	if len(opts.Files) == 0 && opts.Directory != "" {
+		args = append(args, "--directory="+opts.Directory)
+	}
+	if opts.VerifyKey != "" {
+		args = append(args, "--verify-key="+opts.VerifyKey)
+	}
+
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, "journalctl", args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+
+	results := parseVerifyOutput(&out)
+	var exitErr *exec.ExitError
+	if err != nil && !(errors.As(err, &exitErr) && len(results) > 0) {
+		return nil, fmt.Errorf("failed to run journalctl --verify: %w: %s", err, strings.TrimSpace(out.String()))
+	}
+	return results, nil
+}
+
+// parseVerifyOutput parses the PASS: and FAIL: lines of journalctl --verify,
+// and the => lines detailing the sealing of the file before them.
+func parseVerifyOutput(r io.Reader) []VerifyResult {
+	var results []VerifyResult
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if path, ok := strings.CutPrefix(line, "PASS: "); ok {
+			results = append(results, VerifyResult{Path: path, Passed: true})
+		} else if rest, ok := strings.CutPrefix(line, "FAIL: "); ok {
+			result := VerifyResult{Path: rest}
+			if i := strings.LastIndex(rest, " ("); i >= 0 && strings.HasSuffix(rest, ")") {
+				result.Path, result.Message = rest[:i], rest[i+2:len(rest)-1]
+			}
+			results = append(results, result)
+		} else if detail, ok := strings.CutPrefix(line, "=> "); ok && len(results) > 0 {
+			results[len(results)-1].Message = detail
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdjournal
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseVerifyOutput(t *testing.T) {
+	out := `PASS: /var/log/journal/m/system@a-1-2.journal
+=> Validated from Mon 2026-01-05 10:00:00 UTC to Mon 2026-01-05 11:00:00 UTC, final 15min of entries not sealed.
+File corruption detected at /var/log/journal/m/user-1000.journal:1234 (of 8388608 bytes, 0%).
+FAIL: /var/log/journal/m/user-1000.journal (Bad message)
+`
+
+	expected := []VerifyResult{
+		{
+			Path:    "/var/log/journal/m/system@a-1-2.journal",
+			Passed:  true,
+			Message: "Validated from Mon 2026-01-05 10:00:00 UTC to Mon 2026-01-05 11:00:00 UTC, final 15min of entries not sealed.",
+		},
+		{
+			Path:    "/var/log/journal/m/user-1000.journal",
+			Message: "Bad message",
+		},
+	}
+
+	if results := parseVerifyOutput(strings.NewReader(out)); !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected %+v, got %+v", expected, results)
+	}
+}
+
+func TestReadJournalFileHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "system.journal")
+
+	header := make([]byte, 256)
+	copy(header, "LPKSHHRH")
+	binary.LittleEndian.PutUint32(header[8:], headerCompatibleSealed)
+	binary.LittleEndian.PutUint32(header[12:], headerIncompatibleCompressedZSTD|headerIncompatibleKeyedHash)
+	header[16] = byte(JournalFileArchived)
+	if err := os.WriteFile(path, header, 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := ReadJournalFileHeader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &JournalFileHeader{State: JournalFileArchived, Sealed: true, Compression: "zstd", KeyedHash: true}
+	if !reflect.DeepEqual(h, expected) {
+		t.Errorf("expected %+v, got %+v", expected, h)
+	}
+
+	if err := os.WriteFile(path, []byte("not a journal"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadJournalFileHeader(path); err == nil {
+		t.Error("expected an error for a file which isn't a journal")
+	}
+}
@@ -0,0 +1,416 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package systemdtest runs throwaway systemd instances for integration tests,
+// either a user manager or a container booted with systemd-nspawn, and
+// provides helpers to connect the dbus package to them and to manage and
+// check the units of the instance.
+//
+// Tests are skipped if the instance can't be started, e.g. because systemd is
+// not installed or the environment doesn't support running it, so that test
+// suites using the package still pass in such environments:
+//
+//	func TestService(t *testing.T) {
+//		inst := systemdtest.StartUserManager(t)
+//		inst.WriteUnit(t, "hello.service", "[Service]\nExecStart=/bin/sleep infinity\n")
+//		inst.StartUnit(t, "hello.service")
+//		systemdtest.AssertUnitState(t, inst.Conn(t), "hello.service", dbus.StateActive)
+//	}
+package systemdtest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	godbus "github.com/godbus/dbus/v5"
+
+	"github.com/gr-butler/go-systemd/v22/dbus"
+)
+
+var (
+	// StartTimeout bounds the time waited for an instance to be up.
+	StartTimeout = 90 * time.Second
+	// StopTimeout bounds the time waited for an instance to shut down
+	// before it is killed.
+	StopTimeout = 30 * time.Second
+)
+
+// systemdPaths are the locations of the systemd binary, unless it is set with
+// the SYSTEMDTEST_SYSTEMD environment variable.
+var systemdPaths = []string{"/usr/lib/systemd/systemd", "/lib/systemd/systemd"}
+
+// Instance is a running systemd instance, stopped when the test ends.
+type Instance struct {
+	// UnitDir is the directory from which the instance loads unit files.
+	UnitDir string
+
+	address string // of the private socket of the manager
+	cmd     *exec.Cmd
+	output  syncBuffer
+	exited  chan struct{}
+	conn    *dbus.Conn
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use, collecting the output
+// of an instance.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// StartUserManager starts a user manager of the calling user, with private
+// runtime and configuration directories, and returns it once it is up. If the
+// tests run in a session of a user manager, the new manager is started in a
+// scope with delegated cgroups, using systemd-run, as it can't manage the
+// cgroup of the test otherwise. The test is skipped if the manager can't be
+// started.
+func StartUserManager(t testing.TB) *Instance {
+	t.Helper()
+
+	systemd := findSystemd(t)
+	dir := t.TempDir()
+	runtimeDir := filepath.Join(dir, "runtime")
+	configDir := filepath.Join(dir, "config")
+	inst := &Instance{
+		UnitDir: filepath.Join(configDir, "systemd", "user"),
+		address: "unix:path=" + filepath.Join(runtimeDir, "systemd", "private"),
+	}
+	if err := os.Mkdir(runtimeDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(inst.UnitDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	args := []string{systemd, "--user"}
+	if run, err := exec.LookPath("systemd-run"); err == nil && hasUserManager() {
+		args = append([]string{run, "--user", "--scope", "--quiet", "--property=Delegate=yes", "--"}, args...)
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Env = append(filterEnv(os.Environ(), "XDG_RUNTIME_DIR", "XDG_CONFIG_HOME", "DBUS_SESSION_BUS_ADDRESS", "NOTIFY_SOCKET"),
+		"XDG_RUNTIME_DIR="+runtimeDir,
+		"XDG_CONFIG_HOME="+configDir,
+		"SYSTEMD_LOG_TARGET=console",
+	)
+	inst.start(t, cmd)
+	return inst
+}
+
+// StartContainer boots the OS tree in directory with systemd-nspawn and
+// returns it once the system is up. The units of the instance are written to
+// /run/systemd/system in the container. Booting a container requires root;
+// the test is skipped otherwise, or if directory is empty, e.g. because it is
+// taken from an unset environment variable, or if the container can't be
+// started.
+func StartContainer(t testing.TB, directory string) *Instance {
+	t.Helper()
+
+	if directory == "" {
+		t.Skip("systemdtest: no container image")
+	}
+	if os.Geteuid() != 0 {
+		t.Skip("systemdtest: booting a container requires root")
+	}
+	nspawn, err := exec.LookPath("systemd-nspawn")
+	if err != nil {
+		t.Skipf("systemdtest: %v", err)
+	}
+
+	// /run/systemd of the container is bound to a directory of the test,
+	// for access to the private socket of the manager and the runtime unit
+	// directory
+	runDir := filepath.Join(t.TempDir(), "systemd")
+	inst := &Instance{
+		UnitDir: filepath.Join(runDir, "system"),
+		address: "unix:path=" + filepath.Join(runDir, "private"),
+	}
+	if err := os.MkdirAll(inst.UnitDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(nspawn,
+		"--quiet",
+		"--boot",
+		"--register=no",
+		"--console=pipe",
+		"--directory="+directory,
+		"--bind="+runDir+":/run/systemd",
+	)
+	inst.start(t, cmd)
+	return inst
+}
+
+func findSystemd(t testing.TB) string {
+	if path := os.Getenv("SYSTEMDTEST_SYSTEMD"); path != "" {
+		return path
+	}
+	for _, path := range systemdPaths {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	t.Skip("systemdtest: systemd not found")
+	return ""
+}
+
+// hasUserManager reports whether the calling user has a user manager
+// running, which systemd-run can reach.
+func hasUserManager() bool {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(dir, "systemd", "private"))
+	return err == nil
+}
+
+func filterEnv(env []string, names ...string) []string {
+	var filtered []string
+	for _, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		keep := true
+		for _, n := range names {
+			if name == n {
+				keep = false
+			}
+		}
+		if keep {
+			filtered = append(filtered, kv)
+		}
+	}
+	return filtered
+}
+
+// start runs cmd and waits until the manager accepts connections and has
+// finished starting up. Stopping the instance is registered as cleanup of t.
+func (inst *Instance) start(t testing.TB, cmd *exec.Cmd) {
+	t.Helper()
+
+	inst.cmd = cmd
+	cmd.Stdout = &inst.output
+	cmd.Stderr = &inst.output
+	if err := cmd.Start(); err != nil {
+		t.Skipf("systemdtest: %v", err)
+	}
+	inst.exited = make(chan struct{})
+	go func() {
+		cmd.Wait()
+		close(inst.exited)
+	}()
+	t.Cleanup(inst.stop)
+
+	ctx, cancel := context.WithTimeout(context.Background(), StartTimeout)
+	defer cancel()
+	conn, err := inst.waitForManager(ctx)
+	if err != nil {
+		inst.stop()
+		t.Skipf("systemdtest: %s did not start: %v\n%s", cmd.Path, err, inst.output.String())
+	}
+	inst.conn = conn
+}
+
+// waitForManager connects to the manager once its socket is available, and
+// waits until it is running.
+func (inst *Instance) waitForManager(ctx context.Context) (*dbus.Conn, error) {
+	tick := time.NewTicker(100 * time.Millisecond)
+	defer tick.Stop()
+
+	var conn *dbus.Conn
+	for {
+		if conn == nil {
+			conn, _ = inst.dial()
+		}
+		if conn != nil {
+			state, err := conn.GetSystemState(ctx)
+			switch {
+			case err != nil:
+				conn.Close()
+				conn = nil
+			case state == "running" || state == "degraded":
+				return conn, nil
+			}
+		}
+
+		select {
+		case <-inst.exited:
+			if conn != nil {
+				conn.Close()
+			}
+			return nil, fmt.Errorf("exited with %v", inst.cmd.ProcessState)
+		case <-ctx.Done():
+			if conn != nil {
+				conn.Close()
+			}
+			return nil, ctx.Err()
+		case <-tick.C:
+		}
+	}
+}
+
+// dial connects to the private socket of the manager. Connections to the
+// private socket are direct, so Hello is not called.
+func (inst *Instance) dial() (*dbus.Conn, error) {
+	return dbus.NewConnection(func() (*godbus.Conn, error) {
+		conn, err := godbus.Dial(inst.address)
+		if err != nil {
+			return nil, err
+		}
+		if err := conn.Auth([]godbus.Auth{godbus.AuthExternal(strconv.Itoa(os.Getuid()))}); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return conn, nil
+	})
+}
+
+// stop shuts the instance down, killing it if it doesn't exit in time.
+// SIGTERM makes user managers exit and systemd-nspawn shut the container
+// down.
+func (inst *Instance) stop() {
+	if inst.conn != nil {
+		inst.conn.Close()
+		inst.conn = nil
+	}
+	select {
+	case <-inst.exited:
+		return
+	default:
+	}
+
+	inst.cmd.Process.Signal(syscall.SIGTERM)
+	select {
+	case <-inst.exited:
+	case <-time.After(StopTimeout):
+		inst.cmd.Process.Kill()
+		<-inst.exited
+	}
+}
+
+// Output returns the output of the instance so far, e.g. to log it on
+// failures.
+func (inst *Instance) Output() string {
+	return inst.output.String()
+}
+
+// Conn returns a new connection to the manager of the instance, closed when
+// the test ends.
+func (inst *Instance) Conn(t testing.TB) *dbus.Conn {
+	t.Helper()
+	conn, err := inst.dial()
+	if err != nil {
+		t.Fatalf("systemdtest: connecting to the manager: %v", err)
+	}
+	t.Cleanup(conn.Close)
+	return conn
+}
+
+// WriteUnit writes the unit file name with content to the unit directory of
+// the instance and reloads the manager.
+func (inst *Instance) WriteUnit(t testing.TB, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(inst.UnitDir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.conn.ReloadContext(context.Background()); err != nil {
+		t.Fatalf("systemdtest: reloading the manager: %v", err)
+	}
+}
+
+// StartUnit starts the unit and waits for the job to complete, failing the test
+// if it doesn't succeed.
+func (inst *Instance) StartUnit(t testing.TB, name string) {
+	t.Helper()
+	inst.runJob(t, inst.conn.StartUnitContext, name)
+}
+
+// StopUnit stops the unit and waits for the job to complete, failing the test
+// if it doesn't succeed.
+func (inst *Instance) StopUnit(t testing.TB, name string) {
+	t.Helper()
+	inst.runJob(t, inst.conn.StopUnitContext, name)
+}
+
+func (inst *Instance) runJob(t testing.TB, start func(context.Context, string, dbus.JobMode, chan<- string) (int, error), name string) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), StartTimeout)
+	defer cancel()
+
+	ch := make(chan string, 1)
+	if _, err := start(ctx, name, dbus.JobModeReplace, ch); err != nil {
+		t.Fatalf("systemdtest: %s: %v", name, err)
+	}
+	select {
+	case result := <-ch:
+		if result != "done" {
+			t.Fatalf("systemdtest: job for %s finished with result %s", name, result)
+		}
+	case <-ctx.Done():
+		t.Fatalf("systemdtest: job for %s: %v", name, ctx.Err())
+	}
+}
+
+// UnitState returns the active state of the unit.
+func UnitState(t testing.TB, conn *dbus.Conn, unit string) dbus.ActiveState {
+	t.Helper()
+	p, err := conn.GetUnitPropertyContext(context.Background(), unit, "ActiveState")
+	if err != nil {
+		t.Fatalf("systemdtest: %s: %v", unit, err)
+	}
+	state, _ := p.Value.Value().(string)
+	return dbus.ActiveState(state)
+}
+
+// AssertUnitState fails the test if the active state of the unit is not
+// state.
+func AssertUnitState(t testing.TB, conn *dbus.Conn, unit string, state dbus.ActiveState) {
+	t.Helper()
+	if actual := UnitState(t, conn, unit); actual != state {
+		t.Errorf("systemdtest: expected %s to be %s, got %s", unit, state, actual)
+	}
+}
+
+// WaitForUnitState waits until the active state of the unit is state, within
+// timeout, and fails the test otherwise, e.g. if the unit fails, see
+// dbus.Conn.WaitForUnitState.
+func WaitForUnitState(t testing.TB, conn *dbus.Conn, unit string, state dbus.ActiveState, timeout time.Duration) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := conn.WaitForUnitState(ctx, unit, state); err != nil {
+		t.Fatalf("systemdtest: waiting for %s to be %s: %v", unit, state, err)
+	}
+}
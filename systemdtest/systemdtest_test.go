@@ -0,0 +1,52 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systemdtest
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gr-butler/go-systemd/v22/dbus"
+)
+
+func TestFilterEnv(t *testing.T) {
+	env := filterEnv([]string{"HOME=/root", "XDG_RUNTIME_DIR=/run/user/0", "XDG_RUNTIME_DIR_X=1", "NOTIFY_SOCKET"}, "XDG_RUNTIME_DIR", "NOTIFY_SOCKET")
+	if expected := []string{"HOME=/root", "XDG_RUNTIME_DIR_X=1"}; !reflect.DeepEqual(env, expected) {
+		t.Errorf("expected %q, got %q", expected, env)
+	}
+}
+
+func testInstance(t *testing.T, inst *Instance) {
+	inst.WriteUnit(t, "systemdtest.service", "[Service]\nExecStart=/bin/sleep infinity\n")
+	conn := inst.Conn(t)
+
+	AssertUnitState(t, conn, "systemdtest.service", dbus.StateInactive)
+	inst.StartUnit(t, "systemdtest.service")
+	WaitForUnitState(t, conn, "systemdtest.service", dbus.StateActive, 10*time.Second)
+	inst.StopUnit(t, "systemdtest.service")
+	AssertUnitState(t, conn, "systemdtest.service", dbus.StateInactive)
+}
+
+func TestUserManager(t *testing.T) {
+	testInstance(t, StartUserManager(t))
+}
+
+// TestContainer boots the OS tree given by the SYSTEMDTEST_IMAGE environment
+// variable.
+func TestContainer(t *testing.T) {
+	testInstance(t, StartContainer(t, os.Getenv("SYSTEMDTEST_IMAGE")))
+}
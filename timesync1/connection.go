@@ -0,0 +1,27 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+package timesync1
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// Connection is the interface of Conn, which allows for replacing it in tests,
+// e.g. by the fake of the timesync1test package. See Conn for the documentation of
+// the methods.
+type Connection interface {
+	Close() error
+	Connected() bool
+	GetNTPMessage(ctx context.Context) (*NTPMessage, error)
+	GetPollInterval(ctx context.Context) (time.Duration, error)
+	GetRootDistanceMax(ctx context.Context) (time.Duration, error)
+	GetServerAddress(ctx context.Context) (net.IP, error)
+	GetServerName(ctx context.Context) (string, error)
+	GetStatus(ctx context.Context) (*Status, error)
+	SetRuntimeNTPServers(ctx context.Context, servers []string) error
+	SetTimeout(timeout time.Duration)
+}
+
+var _ Connection = (*Conn)(nil)
@@ -0,0 +1,339 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package timesync1 provides integration with the systemd-timesyncd API. See
+// https://www.freedesktop.org/software/systemd/man/org.freedesktop.timesync1.html
+package timesync1
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/gr-butler/go-systemd/v22/internal/calltimeout"
+)
+
+//go:generate go run github.com/gr-butler/go-systemd/v22/internal/fakegen
+
+const (
+	dbusDest      = "org.freedesktop.timesync1"
+	dbusInterface = "org.freedesktop.timesync1.Manager"
+	dbusPath      = "/org/freedesktop/timesync1"
+)
+
+// Conn is a connection to systemd-timesyncd's dbus endpoint.
+type Conn struct {
+	conn   *dbus.Conn
+	object dbus.BusObject
+
+	timeout time.Duration
+}
+
+// NTPMessage is the last NTP message received from the server.
+type NTPMessage struct {
+	Leap           uint32
+	Version        uint32
+	Mode           uint32
+	Stratum        uint32
+	Precision      int32 // log2 of the precision of the server clock in seconds
+	RootDelay      time.Duration
+	RootDispersion time.Duration
+	// Reference identifies the reference clock of the server, as a four
+	// character code for stratum 1 servers and as the IPv4 address of the
+	// upstream server otherwise.
+	Reference            []byte
+	OriginateTimestamp   time.Time
+	ReceiveTimestamp     time.Time
+	TransmitTimestamp    time.Time
+	DestinationTimestamp time.Time
+	Ignored              bool // Whether the message was ignored, e.g. for failing sanity checks
+	PacketCount          uint64
+	Jitter               time.Duration
+}
+
+// Offset returns the offset of the local clock to the server, as computed
+// from the timestamps of the message.
+func (m *NTPMessage) Offset() time.Duration {
+	return (m.ReceiveTimestamp.Sub(m.OriginateTimestamp) + m.TransmitTimestamp.Sub(m.DestinationTimestamp)) / 2
+}
+
+// Delay returns the round-trip delay to the server, as computed from the
+// timestamps of the message.
+func (m *NTPMessage) Delay() time.Duration {
+	return m.DestinationTimestamp.Sub(m.OriginateTimestamp) - m.TransmitTimestamp.Sub(m.ReceiveTimestamp)
+}
+
+// RootDistance returns the estimated maximum error to the reference clock of
+// the server, which is compared against RootDistanceMax.
+func (m *NTPMessage) RootDistance() time.Duration {
+	return m.RootDelay/2 + m.RootDispersion
+}
+
+// Status is the state of systemd-timesyncd.
+type Status struct {
+	ServerName      string // The server currently used, empty if none
+	ServerAddress   net.IP
+	RootDistanceMax time.Duration
+	PollIntervalMin time.Duration
+	PollIntervalMax time.Duration
+	PollInterval    time.Duration
+	NTPMessage      NTPMessage
+	Frequency       int64 // The frequency adjustment of the kernel clock, see adjtimex(2)
+
+	LinkNTPServers     []string
+	SystemNTPServers   []string
+	RuntimeNTPServers  []string
+	FallbackNTPServers []string
+}
+
+// New establishes a connection to the system bus and authenticates.
+func New() (*Conn, error) {
+	c := new(Conn)
+
+	if err := c.initConnection(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Conn) initConnection() error {
+	var err error
+	c.conn, err = dbus.SystemBusPrivate()
+	if err != nil {
+		return err
+	}
+
+	// Only use EXTERNAL method, and hardcode the uid (not username)
+	// to avoid a username lookup (which requires a dynamically linked
+	// libc)
+	methods := []dbus.Auth{dbus.AuthExternal(strconv.Itoa(os.Getuid()))}
+
+	err = c.conn.Auth(methods)
+	if err != nil {
+		c.conn.Close()
+		return err
+	}
+
+	err = c.conn.Hello()
+	if err != nil {
+		c.conn.Close()
+		return err
+	}
+
+	c.object = c.conn.Object(dbusDest, dbus.ObjectPath(dbusPath))
+
+	return nil
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// Connected returns whether conn is connected
+func (c *Conn) Connected() bool {
+	return c.conn.Connected()
+}
+
+// SetTimeout sets the default timeout of the method calls made on the
+// connection without a context deadline, including those made by the
+// methods without context, which otherwise block forever if systemd-timesyncd
+// hangs. It is disabled by a timeout of zero, the default. SetTimeout must
+// not be called concurrently with method calls.
+func (c *Conn) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+	c.object = calltimeout.Object(c.conn.Object(dbusDest, dbus.ObjectPath(dbusPath)), timeout)
+}
+
+func (c *Conn) getProperty(ctx context.Context, name string) (dbus.Variant, error) {
+	var v dbus.Variant
+	err := c.object.CallWithContext(ctx, "org.freedesktop.DBus.Properties.Get", 0, dbusInterface, name).Store(&v)
+	return v, err
+}
+
+func (c *Conn) getUSec(ctx context.Context, name string) (time.Duration, error) {
+	v, err := c.getProperty(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	usec, ok := v.Value().(uint64)
+	if !ok {
+		return 0, fmt.Errorf("failed to typecast %s to uint64", name)
+	}
+	return usecDuration(usec), nil
+}
+
+// GetServerName returns the name of the NTP server currently used, or an
+// empty string if none is.
+func (c *Conn) GetServerName(ctx context.Context) (string, error) {
+	v, err := c.getProperty(ctx, "ServerName")
+	if err != nil {
+		return "", err
+	}
+	name, ok := v.Value().(string)
+	if !ok {
+		return "", fmt.Errorf("failed to typecast ServerName to string")
+	}
+	return name, nil
+}
+
+// GetServerAddress returns the address of the NTP server currently used, or
+// nil if none is.
+func (c *Conn) GetServerAddress(ctx context.Context) (net.IP, error) {
+	v, err := c.getProperty(ctx, "ServerAddress")
+	if err != nil {
+		return nil, err
+	}
+	return serverAddress(v)
+}
+
+// GetRootDistanceMax returns the maximum root distance for a server to be
+// used, see RootDistanceMaxSec= in timesyncd.conf(5).
+func (c *Conn) GetRootDistanceMax(ctx context.Context) (time.Duration, error) {
+	return c.getUSec(ctx, "RootDistanceMaxUSec")
+}
+
+// GetPollInterval returns the current interval between NTP requests.
+func (c *Conn) GetPollInterval(ctx context.Context) (time.Duration, error) {
+	return c.getUSec(ctx, "PollIntervalUSec")
+}
+
+// GetNTPMessage returns the last NTP message received from the server.
+func (c *Conn) GetNTPMessage(ctx context.Context) (*NTPMessage, error) {
+	v, err := c.getProperty(ctx, "NTPMessage")
+	if err != nil {
+		return nil, err
+	}
+	return ntpMessage(v)
+}
+
+// GetStatus returns all properties of systemd-timesyncd, like
+// timedatectl show-timesync.
+func (c *Conn) GetStatus(ctx context.Context) (*Status, error) {
+	var props map[string]dbus.Variant
+	err := c.object.CallWithContext(ctx, "org.freedesktop.DBus.Properties.GetAll", 0, dbusInterface).Store(&props)
+	if err != nil {
+		return nil, err
+	}
+	return statusFromVariants(props)
+}
+
+// SetRuntimeNTPServers sets the NTP servers to use until the next reboot,
+// taking precedence over the configured servers. An empty list resets this.
+func (c *Conn) SetRuntimeNTPServers(ctx context.Context, servers []string) error {
+	if servers == nil {
+		servers = []string{}
+	}
+	return c.object.CallWithContext(ctx, dbusInterface+".SetRuntimeNTPServers", 0, servers).Err
+}
+
+func statusFromVariants(props map[string]dbus.Variant) (*Status, error) {
+	usec := func(name string) time.Duration {
+		u, _ := props[name].Value().(uint64)
+		return usecDuration(u)
+	}
+	strs := func(name string) []string {
+		s, _ := props[name].Value().([]string)
+		return s
+	}
+
+	s := &Status{
+		RootDistanceMax:    usec("RootDistanceMaxUSec"),
+		PollIntervalMin:    usec("PollIntervalMinUSec"),
+		PollIntervalMax:    usec("PollIntervalMaxUSec"),
+		PollInterval:       usec("PollIntervalUSec"),
+		LinkNTPServers:     strs("LinkNTPServers"),
+		SystemNTPServers:   strs("SystemNTPServers"),
+		RuntimeNTPServers:  strs("RuntimeNTPServers"),
+		FallbackNTPServers: strs("FallbackNTPServers"),
+	}
+	s.ServerName, _ = props["ServerName"].Value().(string)
+	s.Frequency, _ = props["Frequency"].Value().(int64)
+
+	var err error
+	if s.ServerAddress, err = serverAddress(props["ServerAddress"]); err != nil {
+		return nil, err
+	}
+	m, err := ntpMessage(props["NTPMessage"])
+	if err != nil {
+		return nil, err
+	}
+	s.NTPMessage = *m
+
+	return s, nil
+}
+
+// serverAddress converts the (iay) ServerAddress property, which has an
+// empty address if no server is used.
+func serverAddress(v dbus.Variant) (net.IP, error) {
+	fields, ok := v.Value().([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to typecast ServerAddress to []interface{}")
+	}
+
+	var family int32
+	var address []byte
+	if err := dbus.Store(fields, &family, &address); err != nil {
+		return nil, fmt.Errorf("failed to convert ServerAddress: %w", err)
+	}
+	if len(address) == 0 {
+		return nil, nil
+	}
+	return net.IP(address), nil
+}
+
+// ntpMessage converts the (uuuuittayttttbtt) NTPMessage property.
+func ntpMessage(v dbus.Variant) (*NTPMessage, error) {
+	fields, ok := v.Value().([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to typecast NTPMessage to []interface{}")
+	}
+
+	var m NTPMessage
+	var rootDelay, rootDispersion, origin, receive, transmit, destination, jitter uint64
+	err := dbus.Store(fields, &m.Leap, &m.Version, &m.Mode, &m.Stratum, &m.Precision,
+		&rootDelay, &rootDispersion, &m.Reference, &origin, &receive, &transmit,
+		&destination, &m.Ignored, &m.PacketCount, &jitter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert NTPMessage: %w", err)
+	}
+
+	m.RootDelay = usecDuration(rootDelay)
+	m.RootDispersion = usecDuration(rootDispersion)
+	m.OriginateTimestamp = usecTime(origin)
+	m.ReceiveTimestamp = usecTime(receive)
+	m.TransmitTimestamp = usecTime(transmit)
+	m.DestinationTimestamp = usecTime(destination)
+	m.Jitter = usecDuration(jitter)
+	return &m, nil
+}
+
+func usecDuration(usec uint64) time.Duration {
+	return time.Duration(usec) * time.Microsecond
+}
+
+// usecTime converts microseconds since the epoch to a time, which is zero for
+// 0.
+func usecTime(usec uint64) time.Time {
+	if usec == 0 {
+		return time.Time{}
+	}
+	return time.UnixMicro(int64(usec))
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timesync1
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+func TestStatusFromVariants(t *testing.T) {
+	props := map[string]dbus.Variant{
+		"ServerName":          dbus.MakeVariant("0.debian.pool.ntp.org"),
+		"ServerAddress":       dbus.MakeVariant([]interface{}{int32(2), []byte{192, 0, 2, 1}}),
+		"RootDistanceMaxUSec": dbus.MakeVariant(uint64(5000000)),
+		"PollIntervalMinUSec": dbus.MakeVariant(uint64(32000000)),
+		"PollIntervalMaxUSec": dbus.MakeVariant(uint64(2048000000)),
+		"PollIntervalUSec":    dbus.MakeVariant(uint64(256000000)),
+		"NTPMessage": dbus.MakeVariant([]interface{}{
+			uint32(0), uint32(4), uint32(4), uint32(2), int32(-23),
+			uint64(1500), uint64(2000), []byte{192, 0, 2, 100},
+			uint64(1700000000000000), uint64(1700000000010500), uint64(1700000000010600), uint64(1700000000001100),
+			false, uint64(42), uint64(300),
+		}),
+		"Frequency":          dbus.MakeVariant(int64(-1234567)),
+		"SystemNTPServers":   dbus.MakeVariant([]string{}),
+		"FallbackNTPServers": dbus.MakeVariant([]string{"0.debian.pool.ntp.org", "1.debian.pool.ntp.org"}),
+	}
+
+	s, err := statusFromVariants(props)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Status{
+		ServerName:      "0.debian.pool.ntp.org",
+		ServerAddress:   net.IP{192, 0, 2, 1},
+		RootDistanceMax: 5 * time.Second,
+		PollIntervalMin: 32 * time.Second,
+		PollIntervalMax: 2048 * time.Second,
+		PollInterval:    256 * time.Second,
+		NTPMessage: NTPMessage{
+			Version:              4,
+			Mode:                 4,
+			Stratum:              2,
+			Precision:            -23,
+			RootDelay:            1500 * time.Microsecond,
+			RootDispersion:       2 * time.Millisecond,
+			Reference:            []byte{192, 0, 2, 100},
+			OriginateTimestamp:   time.UnixMicro(1700000000000000),
+			ReceiveTimestamp:     time.UnixMicro(1700000000010500),
+			TransmitTimestamp:    time.UnixMicro(1700000000010600),
+			DestinationTimestamp: time.UnixMicro(1700000000001100),
+			PacketCount:          42,
+			Jitter:               300 * time.Microsecond,
+		},
+		Frequency:          -1234567,
+		SystemNTPServers:   []string{},
+		FallbackNTPServers: []string{"0.debian.pool.ntp.org", "1.debian.pool.ntp.org"},
+	}
+	if !reflect.DeepEqual(s, expected) {
+		t.Errorf("expected %+v, got %+v", expected, s)
+	}
+
+	// the local clock is 10ms behind, with a delay of 1ms
+	if offset := s.NTPMessage.Offset(); offset != 10*time.Millisecond {
+		t.Errorf("got offset %v", offset)
+	}
+	if delay := s.NTPMessage.Delay(); delay != time.Millisecond {
+		t.Errorf("got delay %v", delay)
+	}
+	if distance := s.NTPMessage.RootDistance(); distance != 2750*time.Microsecond {
+		t.Errorf("got root distance %v", distance)
+	}
+
+	// no server in use yet
+	props["ServerAddress"] = dbus.MakeVariant([]interface{}{int32(0), []byte{}})
+	if s, err = statusFromVariants(props); err != nil {
+		t.Fatal(err)
+	}
+	if s.ServerAddress != nil {
+		t.Errorf("expected no server address, got %v", s.ServerAddress)
+	}
+
+	props["NTPMessage"] = dbus.MakeVariant([]interface{}{uint32(0)})
+	if _, err := statusFromVariants(props); err == nil {
+		t.Error("expected an error for an invalid NTPMessage")
+	}
+}
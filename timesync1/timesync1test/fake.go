@@ -0,0 +1,111 @@
+// Code generated by fakegen. DO NOT EDIT.
+
+// Package timesync1test provides a fake of timesync1.Conn for unit tests.
+package timesync1test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/gr-butler/go-systemd/v22/timesync1"
+)
+
+// ErrNotImplemented is returned by the methods whose function field is unset.
+var ErrNotImplemented = errors.New("not implemented")
+
+// Conn is a fake timesync1.Connection. Each method calls the function field of
+// the same name with a Func suffix. If it is unset, the method returns zero
+// values and, if it returns an error, ErrNotImplemented.
+type Conn struct {
+	CloseFunc                func() error
+	ConnectedFunc            func() bool
+	GetNTPMessageFunc        func(ctx context.Context) (*timesync1.NTPMessage, error)
+	GetPollIntervalFunc      func(ctx context.Context) (time.Duration, error)
+	GetRootDistanceMaxFunc   func(ctx context.Context) (time.Duration, error)
+	GetServerAddressFunc     func(ctx context.Context) (net.IP, error)
+	GetServerNameFunc        func(ctx context.Context) (string, error)
+	GetStatusFunc            func(ctx context.Context) (*timesync1.Status, error)
+	SetRuntimeNTPServersFunc func(ctx context.Context, servers []string) error
+	SetTimeoutFunc           func(timeout time.Duration)
+}
+
+var _ timesync1.Connection = (*Conn)(nil)
+
+func (f *Conn) Close() error {
+	if f.CloseFunc == nil {
+		return fmt.Errorf("%w: Close", ErrNotImplemented)
+	}
+	return f.CloseFunc()
+}
+
+func (f *Conn) Connected() bool {
+	if f.ConnectedFunc == nil {
+		var r0 bool
+		return r0
+	}
+	return f.ConnectedFunc()
+}
+
+func (f *Conn) GetNTPMessage(ctx context.Context) (*timesync1.NTPMessage, error) {
+	if f.GetNTPMessageFunc == nil {
+		var r0 *timesync1.NTPMessage
+		return r0, fmt.Errorf("%w: GetNTPMessage", ErrNotImplemented)
+	}
+	return f.GetNTPMessageFunc(ctx)
+}
+
+func (f *Conn) GetPollInterval(ctx context.Context) (time.Duration, error) {
+	if f.GetPollIntervalFunc == nil {
+		var r0 time.Duration
+		return r0, fmt.Errorf("%w: GetPollInterval", ErrNotImplemented)
+	}
+	return f.GetPollIntervalFunc(ctx)
+}
+
+func (f *Conn) GetRootDistanceMax(ctx context.Context) (time.Duration, error) {
+	if f.GetRootDistanceMaxFunc == nil {
+		var r0 time.Duration
+		return r0, fmt.Errorf("%w: GetRootDistanceMax", ErrNotImplemented)
+	}
+	return f.GetRootDistanceMaxFunc(ctx)
+}
+
+func (f *Conn) GetServerAddress(ctx context.Context) (net.IP, error) {
+	if f.GetServerAddressFunc == nil {
+		var r0 net.IP
+		return r0, fmt.Errorf("%w: GetServerAddress", ErrNotImplemented)
+	}
+	return f.GetServerAddressFunc(ctx)
+}
+
+func (f *Conn) GetServerName(ctx context.Context) (string, error) {
+	if f.GetServerNameFunc == nil {
+		var r0 string
+		return r0, fmt.Errorf("%w: GetServerName", ErrNotImplemented)
+	}
+	return f.GetServerNameFunc(ctx)
+}
+
+func (f *Conn) GetStatus(ctx context.Context) (*timesync1.Status, error) {
+	if f.GetStatusFunc == nil {
+		var r0 *timesync1.Status
+		return r0, fmt.Errorf("%w: GetStatus", ErrNotImplemented)
+	}
+	return f.GetStatusFunc(ctx)
+}
+
+func (f *Conn) SetRuntimeNTPServers(ctx context.Context, servers []string) error {
+	if f.SetRuntimeNTPServersFunc == nil {
+		return fmt.Errorf("%w: SetRuntimeNTPServers", ErrNotImplemented)
+	}
+	return f.SetRuntimeNTPServersFunc(ctx, servers)
+}
+
+func (f *Conn) SetTimeout(timeout time.Duration) {
+	if f.SetTimeoutFunc != nil {
+		f.SetTimeoutFunc(timeout)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkd
+
+import (
+	"io"
+
+	"github.com/gr-butler/go-systemd/v22/internal/unitcodec"
+)
+
+// LinkSection is the [Link] section of .link files, configuring the
+// physical device.
+type LinkSection struct {
+	Description            string
+	MACAddressPolicy       string // persistent, random or none
+	MACAddress             string
+	NamePolicy             []string `unit:",join"` // e.g. kernel, database, onboard, slot or path
+	Name                   string
+	AlternativeNamesPolicy []string `unit:",join"`
+	AlternativeName        []string `unit:",join"`
+	MTUBytes               string
+	BitsPerSecond          string
+	Duplex                 string
+	AutoNegotiation        string
+	WakeOnLan              string
+}
+
+// Link is a .link file, applied by systemd-udevd to the devices it matches
+// when they appear.
+type Link struct {
+	Match MatchSection `section:"Match"`
+	Link  LinkSection  `section:"Link"`
+
+	// Extra holds the options without a field above.
+	Extra []ExtraOption
+}
+
+// Serialize encodes the .link file. Sections without options are omitted.
+func (l *Link) Serialize() io.Reader {
+	return unitcodec.Serialize(l)
+}
+
+// ParseLink parses a .link file.
+func ParseLink(r io.Reader) (*Link, error) {
+	l := &Link{}
+	if err := unitcodec.Deserialize(r, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gr-butler/go-systemd/v22/internal/unitcodec"
+)
+
+// NetDevSection is the [NetDev] section of .netdev files.
+type NetDevSection struct {
+	Name        string
+	Kind        string // e.g. vlan, bond, bridge or wireguard
+	Description string
+	MTUBytes    string
+	MACAddress  string
+}
+
+// VLANSection is the [VLAN] section of .netdev files of kind vlan.
+type VLANSection struct {
+	Id            uint16 // The VLAN ID, between 1 and 4094
+	Protocol      string // 802.1q or 802.1ad
+	GVRP          string
+	MVRP          string
+	LooseBinding  string
+	ReorderHeader string
+}
+
+// BondSection is the [Bond] section of .netdev files of kind bond.
+type BondSection struct {
+	Mode                  string // e.g. active-backup or 802.3ad
+	TransmitHashPolicy    string
+	LACPTransmitRate      string
+	MIIMonitorSec         string
+	UpDelaySec            string
+	DownDelaySec          string
+	PrimaryReselectPolicy string
+	MinLinks              uint
+}
+
+// WireGuardSection is the [WireGuard] section of .netdev files of kind
+// wireguard.
+type WireGuardSection struct {
+	PrivateKey     string
+	PrivateKeyFile string
+	ListenPort     string // A port number or auto
+	FirewallMark   uint32
+	RouteTable     string
+}
+
+// WireGuardPeerSection is a [WireGuardPeer] section of .netdev files of kind
+// wireguard.
+type WireGuardPeerSection struct {
+	PublicKey           string
+	PresharedKey        string
+	PresharedKeyFile    string
+	AllowedIPs          []string `unit:",join"`
+	Endpoint            string   // host:port
+	PersistentKeepalive string   // Seconds, or off
+}
+
+// NetDev is a .netdev file, creating a virtual network device.
+type NetDev struct {
+	Match          MatchSection           `section:"Match"`
+	NetDev         NetDevSection          `section:"NetDev"`
+	VLAN           *VLANSection           `section:"VLAN"`
+	Bond           *BondSection           `section:"Bond"`
+	WireGuard      *WireGuardSection      `section:"WireGuard"`
+	WireGuardPeers []WireGuardPeerSection `section:"WireGuardPeer"`
+
+	// Extra holds the options without a field above, e.g. those of
+	// [Bridge] sections.
+	Extra []ExtraOption
+}
+
+// AddWireGuardPeer appends a [WireGuardPeer] section and returns d, so that
+// calls can be chained.
+func (d *NetDev) AddWireGuardPeer(p WireGuardPeerSection) *NetDev {
+	d.WireGuardPeers = append(d.WireGuardPeers, p)
+	return d
+}
+
+// Validate checks that the netdev has a name and a kind, and that its
+// kind-specific sections match the kind.
+func (d *NetDev) Validate() error {
+	if d.NetDev.Name == "" {
+		return fmt.Errorf("netdev has no Name")
+	}
+	if d.NetDev.Kind == "" {
+		return fmt.Errorf("netdev %s has no Kind", d.NetDev.Name)
+	}
+
+	kindSections := []struct {
+		kind string
+		set  bool
+	}{
+		{"vlan", d.VLAN != nil},
+		{"bond", d.Bond != nil},
+		{"wireguard", d.WireGuard != nil || len(d.WireGuardPeers) > 0},
+	}
+	for _, s := range kindSections {
+		if s.set && d.NetDev.Kind != s.kind {
+			return fmt.Errorf("netdev %s of kind %s has %s settings", d.NetDev.Name, d.NetDev.Kind, s.kind)
+		}
+	}
+
+	if d.VLAN != nil && (d.VLAN.Id == 0 || d.VLAN.Id > 4094) {
+		return fmt.Errorf("netdev %s has invalid VLAN Id %d", d.NetDev.Name, d.VLAN.Id)
+	}
+	for _, p := range d.WireGuardPeers {
+		if p.PublicKey == "" {
+			return fmt.Errorf("netdev %s has a WireGuard peer without PublicKey", d.NetDev.Name)
+		}
+	}
+
+	return nil
+}
+
+// Serialize encodes the .netdev file. Sections without options are
+// omitted.
+func (d *NetDev) Serialize() io.Reader {
+	return unitcodec.Serialize(d)
+}
+
+// ParseNetDev parses a .netdev file.
+func ParseNetDev(r io.Reader) (*NetDev, error) {
+	d := &NetDev{}
+	if err := unitcodec.Deserialize(r, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
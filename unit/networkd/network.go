@@ -0,0 +1,156 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package networkd provides typed representations of the .network, .netdev
+// and .link configuration files of systemd-networkd and systemd-udevd, which
+// can be built programmatically, serialized and parsed. See
+// https://www.freedesktop.org/software/systemd/man/systemd.network.html
+package networkd
+
+import (
+	"io"
+
+	"github.com/gr-butler/go-systemd/v22/internal/unitcodec"
+)
+
+// MatchSection is the [Match] section of .network, .netdev and .link files,
+// selecting the devices the file applies to. Lists match if any of their
+// patterns match, and all the non-empty options must match.
+type MatchSection struct {
+	Name                []string `unit:",join"` // Interface name globs
+	MACAddress          []string `unit:",join"`
+	PermanentMACAddress []string `unit:",join"`
+	Path                []string `unit:",join"` // Persistent path globs, as in ID_PATH
+	Driver              []string `unit:",join"`
+	Type                []string `unit:",join"` // Device type globs, e.g. ether or wlan
+	Kind                []string `unit:",join"` // Netdev kind globs, e.g. vlan or bond
+	Property            []string // udev property matches, as NAME=VALUE
+	Host                string
+	Virtualization      string
+	KernelCommandLine   string
+	Architecture        string
+}
+
+// NetworkLinkSection is the [Link] section of .network files.
+type NetworkLinkSection struct {
+	MACAddress        string
+	MTUBytes          string
+	ARP               string
+	Multicast         string
+	Unmanaged         string
+	RequiredForOnline string // e.g. yes, no or an operational state
+	ActivationPolicy  string
+}
+
+// NetworkSection is the [Network] section of .network files.
+type NetworkSection struct {
+	Description         string
+	DHCP                string // yes, no, ipv4 or ipv6
+	DHCPServer          string
+	LinkLocalAddressing string
+	IPv6AcceptRA        string
+	LLDP                string
+	EmitLLDP            string
+	DNSSEC              string
+	DNSOverTLS          string
+	IPForward           string
+	IPMasquerade        string
+	Address             []string // Static addresses with prefix length, e.g. 192.0.2.10/24
+	Gateway             []string
+	DNS                 []string
+	Domains             []string `unit:",join"`
+	NTP                 []string `unit:",join"`
+	VLAN                []string // Names of VLANs to create on the link
+	MACVLAN             []string
+	VXLAN               []string
+	Tunnel              []string
+	Bond                string // Name of the bond the link is added to
+	Bridge              string // Name of the bridge the link is added to
+	VRF                 string
+}
+
+// AddressSection is an [Address] section of .network files.
+type AddressSection struct {
+	Address                   string // The address with prefix length, e.g. 192.0.2.10/24
+	Peer                      string
+	Broadcast                 string
+	Label                     string
+	PreferredLifetime         string
+	Scope                     string
+	RouteMetric               uint32
+	DuplicateAddressDetection string
+}
+
+// RouteSection is a [Route] section of .network files.
+type RouteSection struct {
+	Gateway         string
+	GatewayOnLink   string
+	Destination     string // The destination prefix, e.g. 10.0.0.0/8
+	Source          string
+	PreferredSource string
+	Metric          uint32
+	Scope           string
+	Table           string // A table name or number
+	Type            string // e.g. unicast, blackhole or unreachable
+	Protocol        string
+	MTUBytes        string
+}
+
+// ExtraOption is an option of a .network, .netdev or .link file without a
+// field in the file types. Occurrence tells which of the sections named
+// Section the option belongs to, 0 for the first, so that repeated sections
+// like [Route] are kept apart.
+type ExtraOption = unitcodec.Option
+
+// Network is a .network file, configuring the links it matches.
+type Network struct {
+	Match     MatchSection       `section:"Match"`
+	Link      NetworkLinkSection `section:"Link"`
+	Network   NetworkSection     `section:"Network"`
+	Addresses []AddressSection   `section:"Address"`
+	Routes    []RouteSection     `section:"Route"`
+
+	// Extra holds the options without a field above, e.g. those of
+	// [DHCPv4] sections.
+	Extra []ExtraOption
+}
+
+// AddAddress appends an [Address] section and returns n, so that calls can
+// be chained.
+func (n *Network) AddAddress(a AddressSection) *Network {
+	n.Addresses = append(n.Addresses, a)
+	return n
+}
+
+// AddRoute appends a [Route] section and returns n, so that calls can be
+// chained.
+func (n *Network) AddRoute(r RouteSection) *Network {
+	n.Routes = append(n.Routes, r)
+	return n
+}
+
+// Serialize encodes the .network file. Sections without options are
+// omitted.
+func (n *Network) Serialize() io.Reader {
+	return unitcodec.Serialize(n)
+}
+
+// ParseNetwork parses a .network file.
+func ParseNetwork(r io.Reader) (*Network, error) {
+	n := &Network{}
+	if err := unitcodec.Deserialize(r, n); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
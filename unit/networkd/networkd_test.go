@@ -0,0 +1,232 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkd
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readAll(t *testing.T, r io.Reader) string {
+	t.Helper()
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestNetworkSerialize(t *testing.T) {
+	n := &Network{
+		Match: MatchSection{Name: []string{"eth0", "en*"}},
+		Network: NetworkSection{
+			DHCP: "ipv6",
+			DNS:  []string{"192.0.2.53", "2001:db8::53"},
+			VLAN: []string{"vlan10"},
+		},
+	}
+	n.AddAddress(AddressSection{Address: "192.0.2.10/24"}).
+		AddAddress(AddressSection{Address: "2001:db8::10/64"}).
+		AddRoute(RouteSection{Gateway: "192.0.2.1", Metric: 100})
+
+	expected := `[Match]
+Name=eth0 en*
+
+[Network]
+DHCP=ipv6
+DNS=192.0.2.53
+DNS=2001:db8::53
+VLAN=vlan10
+
+[Address]
+Address=192.0.2.10/24
+
+[Address]
+Address=2001:db8::10/64
+
+[Route]
+Gateway=192.0.2.1
+Metric=100
+`
+	if out := readAll(t, n.Serialize()); out != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out)
+	}
+
+	parsed, err := ParseNetwork(strings.NewReader(expected))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, n) {
+		t.Errorf("expected %+v, got %+v", n, parsed)
+	}
+}
+
+// Ensure that the extra options of repeated sections stay in their section.
+func TestNetworkRepeatedSectionsRoundTrip(t *testing.T) {
+	in := `[Route]
+Gateway=10.0.0.1
+InitialCongestionWindow=10
+
+[Route]
+Gateway=10.0.0.2
+
+[BridgeVLAN]
+VLAN=10
+
+[BridgeVLAN]
+VLAN=20
+`
+	n, err := ParseNetwork(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedExtra := []ExtraOption{
+		{Section: "Route", Occurrence: 0, Name: "InitialCongestionWindow", Value: "10"},
+		{Section: "BridgeVLAN", Occurrence: 0, Name: "VLAN", Value: "10"},
+		{Section: "BridgeVLAN", Occurrence: 1, Name: "VLAN", Value: "20"},
+	}
+	if !reflect.DeepEqual(n.Extra, expectedExtra) {
+		t.Errorf("expected extra options %v, got %v", expectedExtra, n.Extra)
+	}
+
+	if out := readAll(t, n.Serialize()); out != in {
+		t.Errorf("expected the file to be kept on round trip, got:\n%s", out)
+	}
+}
+
+func TestParseNetworkExtra(t *testing.T) {
+	in := `[Match]
+Name=eth0
+
+[Network]
+DNS=192.0.2.53
+DNS=
+DNS=192.0.2.54
+DHCP=yes
+
+[DHCPv4]
+UseDNS=no
+`
+	n, err := ParseNetwork(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(n.Network.DNS, []string{"192.0.2.54"}) {
+		t.Errorf("expected the empty assignment to reset DNS, got %v", n.Network.DNS)
+	}
+	expectedExtra := []ExtraOption{{Section: "DHCPv4", Name: "UseDNS", Value: "no"}}
+	if !reflect.DeepEqual(n.Extra, expectedExtra) {
+		t.Errorf("expected extra options %v, got %v", expectedExtra, n.Extra)
+	}
+	if out := readAll(t, n.Serialize()); !strings.HasSuffix(out, "[DHCPv4]\nUseDNS=no\n") {
+		t.Errorf("expected the extra options to be serialized, got:\n%s", out)
+	}
+
+	if _, err := ParseNetwork(strings.NewReader("[Route]\nMetric=high\n")); err == nil {
+		t.Error("expected an error for an invalid Metric")
+	}
+}
+
+func TestNetDev(t *testing.T) {
+	d := &NetDev{
+		NetDev:    NetDevSection{Name: "wg0", Kind: "wireguard"},
+		WireGuard: &WireGuardSection{PrivateKeyFile: "/etc/systemd/network/wg0.key", ListenPort: "51820"},
+	}
+	d.AddWireGuardPeer(WireGuardPeerSection{
+		PublicKey:  "xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg=",
+		AllowedIPs: []string{"10.0.0.0/24", "fd00::/64"},
+		Endpoint:   "vpn.example.com:51820",
+	})
+	if err := d.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `[NetDev]
+Name=wg0
+Kind=wireguard
+
+[WireGuard]
+PrivateKeyFile=/etc/systemd/network/wg0.key
+ListenPort=51820
+
+[WireGuardPeer]
+PublicKey=xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg=
+AllowedIPs=10.0.0.0/24 fd00::/64
+Endpoint=vpn.example.com:51820
+`
+	if out := readAll(t, d.Serialize()); out != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, out)
+	}
+
+	parsed, err := ParseNetDev(strings.NewReader(expected))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, d) {
+		t.Errorf("expected %+v, got %+v", d, parsed)
+	}
+}
+
+func TestNetDevValidate(t *testing.T) {
+	tests := []struct {
+		netdev NetDev
+		valid  bool
+	}{
+		{NetDev{NetDev: NetDevSection{Name: "vlan10", Kind: "vlan"}, VLAN: &VLANSection{Id: 10}}, true},
+		{NetDev{NetDev: NetDevSection{Name: "bond0", Kind: "bond"}, Bond: &BondSection{Mode: "802.3ad"}}, true},
+		{NetDev{NetDev: NetDevSection{Kind: "vlan"}}, false},
+		{NetDev{NetDev: NetDevSection{Name: "vlan10"}}, false},
+		{NetDev{NetDev: NetDevSection{Name: "vlan10", Kind: "vlan"}, VLAN: &VLANSection{Id: 4095}}, false},
+		{NetDev{NetDev: NetDevSection{Name: "bond0", Kind: "bond"}, VLAN: &VLANSection{Id: 10}}, false},
+		{NetDev{NetDev: NetDevSection{Name: "wg0", Kind: "wireguard"}, WireGuardPeers: []WireGuardPeerSection{{}}}, false},
+	}
+
+	for i, tt := range tests {
+		if err := tt.netdev.Validate(); (err == nil) != tt.valid {
+			t.Errorf("#%d: expected valid %v, got %v", i, tt.valid, err)
+		}
+	}
+}
+
+func TestLink(t *testing.T) {
+	in := `[Match]
+MACAddress=52:54:00:12:34:56
+
+[Link]
+NamePolicy=kernel database onboard
+Name=lan0
+MTUBytes=9000
+`
+	l, err := ParseLink(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &Link{
+		Match: MatchSection{MACAddress: []string{"52:54:00:12:34:56"}},
+		Link: LinkSection{
+			NamePolicy: []string{"kernel", "database", "onboard"},
+			Name:       "lan0",
+			MTUBytes:   "9000",
+		},
+	}
+	if !reflect.DeepEqual(l, expected) {
+		t.Errorf("expected %+v, got %+v", expected, l)
+	}
+	if out := readAll(t, l.Serialize()); out != in {
+		t.Errorf("expected:\n%s\ngot:\n%s", in, out)
+	}
+}
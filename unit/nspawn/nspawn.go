@@ -0,0 +1,146 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nspawn provides a typed representation of the .nspawn settings
+// files of systemd-nspawn, which can be built programmatically, serialized
+// and parsed. The settings of a container named NAME are read from
+// NAME.nspawn in /etc/systemd/nspawn, /run/systemd/nspawn, or next to the
+// container image. See
+// https://www.freedesktop.org/software/systemd/man/systemd.nspawn.html
+package nspawn
+
+import (
+	"io"
+	"strings"
+
+	"github.com/gr-butler/go-systemd/v22/internal/unitcodec"
+)
+
+// ExecSection is the [Exec] section, configuring the process run in the
+// container. Booleans are nil if unset.
+type ExecSection struct {
+	Boot              *bool // Whether to run an init system, as with --boot
+	Ephemeral         *bool
+	ProcessTwo        *bool
+	Parameters        string   // The command line of the process, or the arguments passed to init
+	Environment       []string `unit:",line"` // Assignments in the form KEY=VALUE
+	User              string
+	WorkingDirectory  string
+	PivotRoot         string
+	Capability        []string `unit:",join"`
+	DropCapability    []string `unit:",join"`
+	AmbientCapability []string `unit:",join"`
+	NoNewPrivileges   *bool
+	KillSignal        string
+	Personality       string
+	MachineID         string
+	PrivateUsers      string // yes, no, pick, identity, or a UID range
+	NotifyReady       *bool
+	SystemCallFilter  []string `unit:",join"`
+	OOMScoreAdjust    int
+	CPUAffinity       string
+	Hostname          string
+	ResolvConf        string
+	Timezone          string
+	LinkJournal       string
+	SuppressSync      *bool
+}
+
+// FilesSection is the [Files] section, configuring the file system of the
+// container.
+type FilesSection struct {
+	ReadOnly              *bool
+	Volatile              string   // yes, no, state or overlay
+	Bind                  []string `unit:",line"` // Bind mounts as SOURCE[:DESTINATION[:OPTIONS]]
+	BindReadOnly          []string `unit:",line"`
+	BindUser              []string `unit:",join"`
+	TemporaryFileSystem   []string `unit:",line"`
+	Inaccessible          []string `unit:",line"`
+	Overlay               []string `unit:",line"`
+	OverlayReadOnly       []string `unit:",line"`
+	PrivateUsersOwnership string   // off, chown, map or auto
+}
+
+// NetworkSection is the [Network] section, configuring the network of the
+// container.
+type NetworkSection struct {
+	Private              *bool // Whether the container gets its own network namespace
+	VirtualEthernet      *bool
+	VirtualEthernetExtra []string `unit:",line"`
+	Interface            []string `unit:",join"`
+	MACVLAN              []string `unit:",join"`
+	IPVLAN               []string `unit:",join"`
+	Bridge               string
+	Zone                 string
+	Port                 []string `unit:",line"` // Port forwards as [PROTOCOL:]HOSTPORT[:CONTAINERPORT]
+}
+
+// ExtraOption is an option of a .nspawn file without a field in Settings.
+// Occurrence tells which of the sections named Section the option belongs
+// to, 0 for the first.
+type ExtraOption = unitcodec.Option
+
+// Settings is a .nspawn file.
+type Settings struct {
+	Exec    ExecSection    `section:"Exec"`
+	Files   FilesSection   `section:"Files"`
+	Network NetworkSection `section:"Network"`
+
+	// Extra holds the options without a field.
+	Extra []ExtraOption
+}
+
+// Bool returns a pointer to b, for setting the boolean options.
+func Bool(b bool) *bool {
+	return &b
+}
+
+// Serialize encodes the settings in the .nspawn file format.
+func (s *Settings) Serialize() io.Reader {
+	return unitcodec.Serialize(s)
+}
+
+// ParseSettings parses a .nspawn file.
+func ParseSettings(r io.Reader) (*Settings, error) {
+	s := &Settings{}
+	if err := unitcodec.Deserialize(r, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Env returns the value of the environment variable name set with
+// Environment=, and whether it is set. Later assignments override earlier
+// ones.
+func (e *ExecSection) Env(name string) (string, bool) {
+	value, found := "", false
+	for _, assignment := range e.Environment {
+		if k, v, ok := strings.Cut(assignment, "="); ok && k == name {
+			value, found = v, true
+		}
+	}
+	return value, found
+}
+
+// SetEnv sets the environment variable name to value, replacing previous
+// assignments of it.
+func (e *ExecSection) SetEnv(name, value string) {
+	env := e.Environment[:0:0]
+	for _, assignment := range e.Environment {
+		if k, _, _ := strings.Cut(assignment, "="); k != name {
+			env = append(env, assignment)
+		}
+	}
+	e.Environment = append(env, name+"="+value)
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nspawn
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testSettings = `[Exec]
+Boot=yes
+Parameters=--log-level=debug
+Environment=LANG=C.UTF-8
+Environment=GREETING=hello world
+PrivateUsers=pick
+Capability=CAP_NET_ADMIN CAP_SYS_TIME
+NotifyReady=no
+
+[Files]
+Bind=/srv/data:/data
+Bind=/srv/cache:/var/cache:rbind
+PrivateUsersOwnership=auto
+Volatile=state
+
+[Network]
+VirtualEthernet=yes
+Zone=web
+Port=tcp:8080:80
+Port=443
+Unknown=kept
+`
+
+func TestParseSettings(t *testing.T) {
+	s, err := ParseSettings(strings.NewReader(testSettings))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Settings{
+		Exec: ExecSection{
+			Boot:         Bool(true),
+			Parameters:   "--log-level=debug",
+			Environment:  []string{"LANG=C.UTF-8", "GREETING=hello world"},
+			PrivateUsers: "pick",
+			Capability:   []string{"CAP_NET_ADMIN", "CAP_SYS_TIME"},
+			NotifyReady:  Bool(false),
+		},
+		Files: FilesSection{
+			Bind:                  []string{"/srv/data:/data", "/srv/cache:/var/cache:rbind"},
+			PrivateUsersOwnership: "auto",
+			Volatile:              "state",
+		},
+		Network: NetworkSection{
+			VirtualEthernet: Bool(true),
+			Zone:            "web",
+			Port:            []string{"tcp:8080:80", "443"},
+		},
+		Extra: []ExtraOption{{Section: "Network", Name: "Unknown", Value: "kept"}},
+	}
+	if !reflect.DeepEqual(s, expected) {
+		t.Errorf("expected %+v, got %+v", expected, s)
+	}
+
+	// serializing and parsing again keeps all settings
+	again, err := ParseSettings(s.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(again, expected) {
+		t.Errorf("expected %+v after serializing, got %+v", expected, again)
+	}
+
+	if _, err := ParseSettings(strings.NewReader("[Exec]\nBoot=maybe\n")); err == nil {
+		t.Error("expected an error for an invalid boolean")
+	}
+}
+
+func TestSerialize(t *testing.T) {
+	s := &Settings{}
+	s.Exec.Boot = Bool(true)
+	s.Exec.SetEnv("A", "1")
+	s.Files.ReadOnly = Bool(false)
+	s.Files.Bind = []string{"/a", "/b:/c"}
+	s.Network.Private = Bool(true)
+
+	b, err := io.ReadAll(s.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[Exec]
+Boot=yes
+Environment=A=1
+
+[Files]
+ReadOnly=no
+Bind=/a
+Bind=/b:/c
+
+[Network]
+Private=yes
+`
+	if string(b) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	e := &ExecSection{Environment: []string{"A=1", "B=2", "A=3"}}
+	if v, ok := e.Env("A"); !ok || v != "3" {
+		t.Errorf("got %q, %v for A", v, ok)
+	}
+	if _, ok := e.Env("C"); ok {
+		t.Error("C should not be set")
+	}
+
+	e.SetEnv("A", "4")
+	if expected := []string{"B=2", "A=4"}; !reflect.DeepEqual(e.Environment, expected) {
+		t.Errorf("expected %v, got %v", expected, e.Environment)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Specifiers maps specifier characters to their values, e.g. 'm' to the
+// machine ID for %m. "%%" always expands to "%".
+type Specifiers map[byte]string
+
+// Expand replaces the specifiers in s with their values. It fails for
+// specifiers without a value, as systemd does.
+func (spec Specifiers) Expand(s string) (string, error) {
+	if !strings.Contains(s, "%") {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+1 == len(s) {
+			return "", fmt.Errorf("incomplete specifier at the end of %q", s)
+		}
+
+		i++
+		c := s[i]
+		if c == '%' {
+			b.WriteByte('%')
+			continue
+		}
+		value, ok := spec[c]
+		if !ok {
+			return "", fmt.Errorf("unknown specifier %%%c in %q", c, s)
+		}
+		b.WriteString(value)
+	}
+	return b.String(), nil
+}
+
+// EscapeSpecifiers returns s with "%" escaped as "%%", so that Expand returns
+// s.
+func EscapeSpecifiers(s string) string {
+	return strings.ReplaceAll(s, "%", "%%")
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unit
+
+import "testing"
+
+func TestSpecifiersExpand(t *testing.T) {
+	spec := Specifiers{'m': "0123456789abcdef0123456789abcdef", 'H': "host"}
+
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{"/var/lib/%H", "/var/lib/host"},
+		{"%m-%H", "0123456789abcdef0123456789abcdef-host"},
+		{"100%%", "100%"},
+		{"plain", "plain"},
+	}
+	for _, tt := range tests {
+		out, err := spec.Expand(tt.in)
+		if err != nil {
+			t.Errorf("%q: %v", tt.in, err)
+		} else if out != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.in, tt.expected, out)
+		}
+	}
+
+	for _, in := range []string{"%x", "trailing%"} {
+		if _, err := spec.Expand(in); err == nil {
+			t.Errorf("expected an error for %q", in)
+		}
+	}
+
+	if out, _ := spec.Expand(EscapeSpecifiers("50%H")); out != "50%H" {
+		t.Errorf("expected escaped specifiers to be kept, got %q", out)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sysusers parses and serializes sysusers.d configuration files. See
+// https://www.freedesktop.org/software/systemd/man/sysusers.d.html
+package sysusers
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gr-butler/go-systemd/v22/internal/words"
+	"github.com/gr-butler/go-systemd/v22/unit"
+)
+
+// Line types.
+const (
+	TypeUser       = "u"  // Create a user and its group
+	TypeLockedUser = "u!" // Create a user with a locked password
+	TypeGroup      = "g"  // Create a group
+	TypeMember     = "m"  // Add a user to a group
+	TypeRange      = "r"  // Add a range of IDs for allocation
+)
+
+// Entry is a line of a sysusers.d file. Empty fields are written as "-", for
+// the default.
+type Entry struct {
+	Type string
+	// Name is the name of the user or group, or empty for TypeRange.
+	Name string
+	// ID is the UID or GID, e.g. "42", "42:43" for a user with a GID, or a
+	// path whose owner determines the ID. It is the group name for
+	// TypeMember, and the range, e.g. "500-900", for TypeRange.
+	ID    string
+	GECOS string // The description of a user
+	Home  string // The home directory of a user
+	Shell string // The login shell of a user
+}
+
+// Validate checks that the entry is valid for its type.
+func (e *Entry) Validate() error {
+	switch e.Type {
+	case TypeUser, TypeLockedUser, TypeGroup:
+		if err := validName(e.Name); err != nil {
+			return err
+		}
+	case TypeMember:
+		if err := validName(e.Name); err != nil {
+			return err
+		}
+		if err := validName(e.ID); err != nil {
+			return fmt.Errorf("invalid group: %w", err)
+		}
+	case TypeRange:
+		if e.Name != "" {
+			return fmt.Errorf("range lines must not have a name")
+		}
+		first, last, ok := strings.Cut(e.ID, "-")
+		if !ok {
+			last = first
+		}
+		f, err1 := strconv.ParseUint(first, 10, 32)
+		l, err2 := strconv.ParseUint(last, 10, 32)
+		if err1 != nil || err2 != nil || f > l {
+			return fmt.Errorf("invalid range %q", e.ID)
+		}
+	default:
+		return fmt.Errorf("invalid type %q", e.Type)
+	}
+
+	if e.Type != TypeUser && e.Type != TypeLockedUser && (e.GECOS != "" || e.Home != "" || e.Shell != "") {
+		return fmt.Errorf("only user lines may have a description, home or shell")
+	}
+	for _, path := range []string{e.Home, e.Shell} {
+		if path != "" && !strings.HasPrefix(path, "/") && !strings.HasPrefix(path, "%") {
+			return fmt.Errorf("path %q is not absolute", path)
+		}
+	}
+	return nil
+}
+
+// validName checks a user or group name, allowing specifiers and the
+// relaxed names accepted by systemd-sysusers.
+func validName(name string) error {
+	if name == "" {
+		return fmt.Errorf("missing name")
+	}
+	if len(name) > 31 {
+		return fmt.Errorf("name %q is longer than 31 characters", name)
+	}
+	for i, c := range name {
+		switch {
+		case c == '_' || c == '%' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		case i > 0 && (c == '-' || c == '.' || (c >= '0' && c <= '9')):
+		case i > 0 && i == len(name)-1 && c == '$':
+		default:
+			return fmt.Errorf("invalid name %q", name)
+		}
+	}
+	return nil
+}
+
+// Expand replaces the specifiers in the fields of the entry.
+func (e *Entry) Expand(spec unit.Specifiers) error {
+	for _, f := range []*string{&e.Name, &e.ID, &e.GECOS, &e.Home, &e.Shell} {
+		v, err := spec.Expand(*f)
+		if err != nil {
+			return err
+		}
+		*f = v
+	}
+	return nil
+}
+
+// String returns the entry as a sysusers.d line. Trailing default fields are
+// omitted.
+func (e *Entry) String() string {
+	fields := []string{e.Type, e.Name, e.ID, e.GECOS, e.Home, e.Shell}
+	for len(fields) > 2 && fields[len(fields)-1] == "" {
+		fields = fields[:len(fields)-1]
+	}
+	for i, f := range fields[1:] {
+		if f == "" {
+			fields[i+1] = "-"
+		} else {
+			fields[i+1] = words.Quote(f)
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// ParseLine parses a sysusers.d line.
+func ParseLine(line string) (*Entry, error) {
+	fields, rest, err := words.Split(line, 6)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("trailing garbage %q", rest)
+	}
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("missing name in line %q", line)
+	}
+
+	e := &Entry{Type: fields[0]}
+	dest := []*string{&e.Name, &e.ID, &e.GECOS, &e.Home, &e.Shell}
+	for i, f := range fields[1:] {
+		if f != "-" {
+			*dest[i] = f
+		}
+	}
+
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Parse parses a sysusers.d file, skipping empty lines and comments.
+func Parse(r io.Reader) ([]*Entry, error) {
+	var entries []*Entry
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		e, err := ParseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// Serialize encodes the entries as a sysusers.d file.
+func Serialize(entries []*Entry) io.Reader {
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString(e.String())
+		b.WriteByte('\n')
+	}
+	return strings.NewReader(b.String())
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sysusers
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gr-butler/go-systemd/v22/unit"
+)
+
+func TestParse(t *testing.T) {
+	in := `# comment
+g input -
+u httpd 404 "HTTP User" /var/www /usr/sbin/nologin
+u! locked - -
+m httpd input
+r - 500-900
+u app 1234:input 'App (%H)'
+`
+	entries, err := Parse(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*Entry{
+		{Type: TypeGroup, Name: "input"},
+		{Type: TypeUser, Name: "httpd", ID: "404", GECOS: "HTTP User", Home: "/var/www", Shell: "/usr/sbin/nologin"},
+		{Type: TypeLockedUser, Name: "locked"},
+		{Type: TypeMember, Name: "httpd", ID: "input"},
+		{Type: TypeRange, ID: "500-900"},
+		{Type: TypeUser, Name: "app", ID: "1234:input", GECOS: "App (%H)"},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, entries)
+	}
+
+	b, err := io.ReadAll(Serialize(entries))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedOut := `g input
+u httpd 404 "HTTP User" /var/www /usr/sbin/nologin
+u! locked
+m httpd input
+r - 500-900
+u app 1234:input "App (%H)"
+`
+	if string(b) != expectedOut {
+		t.Errorf("expected:\n%s\ngot:\n%s", expectedOut, b)
+	}
+}
+
+func TestParseLineInvalid(t *testing.T) {
+	for _, line := range []string{
+		"u",
+		"x foo",
+		"u 0foo",
+		"u foo - - relative",
+		"g foo - Description",
+		"m foo",
+		"r foo 1-2",
+		"r - 900-500",
+		"u foo - - /home /bin/sh extra",
+		"u waytoolongnamewaytoolongnamewaytoolong",
+	} {
+		if _, err := ParseLine(line); err == nil {
+			t.Errorf("expected an error for %q", line)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	e := &Entry{Type: TypeUser, Name: "app", GECOS: "App on %H"}
+	if err := e.Expand(unit.Specifiers{'H': "web-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if e.GECOS != "App on web-1" {
+		t.Errorf("unexpected description %q", e.GECOS)
+	}
+}
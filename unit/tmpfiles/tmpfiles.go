@@ -0,0 +1,198 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tmpfiles parses and serializes tmpfiles.d configuration files. See
+// https://www.freedesktop.org/software/systemd/man/tmpfiles.d.html
+package tmpfiles
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gr-butler/go-systemd/v22/internal/words"
+	"github.com/gr-butler/go-systemd/v22/unit"
+)
+
+// types are the line types, with whether they may be suffixed with "+".
+var types = map[byte]bool{
+	'f': true, 'F': false, 'w': true, 'd': false, 'D': false, 'e': false,
+	'v': false, 'q': false, 'Q': false, 'p': true, 'L': true, 'c': true,
+	'b': true, 'C': true, 'x': false, 'X': false, 'r': false, 'R': false,
+	'z': false, 'Z': false, 't': false, 'T': false, 'h': false, 'H': false,
+	'a': true, 'A': true,
+}
+
+// modifiers are the characters which may follow the line type.
+const modifiers = "!-=~^?"
+
+// Entry is a line of a tmpfiles.d file. Empty fields are written as "-", for
+// the default.
+type Entry struct {
+	// Type is the line type, e.g. "d" or "L+".
+	Type string
+	// Modifiers are the modifiers of the line type: "!" for boot-only
+	// lines, "-" to ignore errors, "=" to remove mismatching files, "~" for
+	// base64-encoded arguments, "^" for arguments read from credentials,
+	// and "?" for lines ignored if the path is missing.
+	Modifiers string
+	Path      string
+	Mode      string // Octal mode, optionally prefixed with ~ or :
+	User      string
+	Group     string
+	Age       string
+	// Argument is the argument of the line, e.g. the target of symlinks or
+	// the content of files. C escapes are resolved in the arguments of f, F
+	// and w lines.
+	Argument string
+}
+
+// unescapesArgument reports whether C escapes are resolved in the argument
+// of lines of type t, which are written to files.
+func unescapesArgument(t string) bool {
+	switch strings.TrimSuffix(t, "+") {
+	case "f", "F", "w":
+		return true
+	}
+	return false
+}
+
+// Validate checks that the type and the path of the entry are valid.
+func (e *Entry) Validate() error {
+	if e.Type == "" || len(e.Type) > 2 {
+		return fmt.Errorf("invalid type %q", e.Type)
+	}
+	plus, ok := types[e.Type[0]]
+	if !ok || (len(e.Type) == 2 && (e.Type[1] != '+' || !plus)) {
+		return fmt.Errorf("invalid type %q", e.Type)
+	}
+	for _, m := range e.Modifiers {
+		if !strings.ContainsRune(modifiers, m) {
+			return fmt.Errorf("invalid modifier %q", m)
+		}
+	}
+	if !strings.HasPrefix(e.Path, "/") && !strings.HasPrefix(e.Path, "%") {
+		return fmt.Errorf("path %q is not absolute", e.Path)
+	}
+	return nil
+}
+
+// Expand replaces the specifiers in the path and the argument of the entry.
+func (e *Entry) Expand(spec unit.Specifiers) error {
+	var err error
+	if e.Path, err = spec.Expand(e.Path); err != nil {
+		return err
+	}
+	if e.Argument, err = spec.Expand(e.Argument); err != nil {
+		return err
+	}
+	return nil
+}
+
+// String returns the entry as a tmpfiles.d line.
+func (e *Entry) String() string {
+	field := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return words.Quote(s)
+	}
+
+	line := strings.Join([]string{
+		e.Type + e.Modifiers,
+		field(e.Path),
+		field(e.Mode),
+		field(e.User),
+		field(e.Group),
+		field(e.Age),
+	}, " ")
+	if e.Argument != "" {
+		arg := e.Argument
+		if unescapesArgument(e.Type) {
+			arg = words.Escape(arg)
+			if arg == "-" || strings.HasPrefix(arg, " ") {
+				// would be read as the default, or trimmed
+				arg = fmt.Sprintf(`\x%02x`, arg[0]) + arg[1:]
+			}
+		}
+		line += " " + arg
+	}
+	return line
+}
+
+// ParseLine parses a tmpfiles.d line.
+func ParseLine(line string) (*Entry, error) {
+	fields, rest, err := words.Split(line, 6)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("missing path in line %q", line)
+	}
+
+	e := &Entry{Type: fields[0]}
+	if i := strings.IndexAny(e.Type, modifiers); i > 0 {
+		e.Type, e.Modifiers = e.Type[:i], e.Type[i:]
+	}
+
+	dest := []*string{&e.Path, &e.Mode, &e.User, &e.Group, &e.Age}
+	for i, f := range fields[1:] {
+		if f != "-" {
+			*dest[i] = f
+		}
+	}
+
+	if rest != "" && rest != "-" {
+		e.Argument = rest
+		if unescapesArgument(e.Type) {
+			if e.Argument, err = words.Unescape(rest); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Parse parses a tmpfiles.d file, skipping empty lines and comments.
+func Parse(r io.Reader) ([]*Entry, error) {
+	var entries []*Entry
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		e, err := ParseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// Serialize encodes the entries as a tmpfiles.d file.
+func Serialize(entries []*Entry) io.Reader {
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString(e.String())
+		b.WriteByte('\n')
+	}
+	return strings.NewReader(b.String())
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tmpfiles
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gr-butler/go-systemd/v22/unit"
+)
+
+func TestParse(t *testing.T) {
+	in := `# comment
+d /run/foo 0755 foo foo 10d -
+L+ /etc/foo.conf - - - - ../usr/share/foo/foo.conf
+f /etc/motd 0644 root root - Hello\nWorld
+d- "/var/lib/with space" ~0750
+r! /tmp/stale
+`
+	entries, err := Parse(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*Entry{
+		{Type: "d", Path: "/run/foo", Mode: "0755", User: "foo", Group: "foo", Age: "10d"},
+		{Type: "L+", Path: "/etc/foo.conf", Argument: "../usr/share/foo/foo.conf"},
+		{Type: "f", Path: "/etc/motd", Mode: "0644", User: "root", Group: "root", Argument: "Hello\nWorld"},
+		{Type: "d", Modifiers: "-", Path: "/var/lib/with space", Mode: "~0750"},
+		{Type: "r", Modifiers: "!", Path: "/tmp/stale"},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, entries)
+	}
+
+	b, err := io.ReadAll(Serialize(entries))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reparsed, err := Parse(strings.NewReader(string(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(reparsed, entries) {
+		t.Errorf("round trip of\n%s\nexpected %+v, got %+v", b, entries, reparsed)
+	}
+}
+
+func TestEntryString(t *testing.T) {
+	tests := []struct {
+		entry    Entry
+		expected string
+	}{
+		{Entry{Type: "d", Path: "/run/foo", Mode: "0755"}, "d /run/foo 0755 - - -"},
+		{Entry{Type: "w", Path: "/proc/sys/x", Argument: " 1"}, `w /proc/sys/x - - - - \x201`},
+		{Entry{Type: "f", Path: "/etc/x", Argument: "-"}, `f /etc/x - - - - \x2d`},
+		{Entry{Type: "L", Path: "/etc/a b", Argument: "/usr/a b"}, `L "/etc/a b" - - - - /usr/a b`},
+	}
+	for i, tt := range tests {
+		if s := tt.entry.String(); s != tt.expected {
+			t.Errorf("#%d: expected %q, got %q", i, tt.expected, s)
+		}
+		e, err := ParseLine(tt.expected)
+		if err != nil {
+			t.Errorf("#%d: %v", i, err)
+		} else if !reflect.DeepEqual(*e, tt.entry) {
+			t.Errorf("#%d: expected %+v, got %+v", i, tt.entry, *e)
+		}
+	}
+}
+
+func TestParseLineInvalid(t *testing.T) {
+	for _, line := range []string{"d", "y /tmp", "d+ /tmp", "d relative", "d% /tmp", `f /tmp "unterminated`} {
+		if _, err := ParseLine(line); err == nil {
+			t.Errorf("expected an error for %q", line)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	e := &Entry{Type: "L", Path: "/run/%u/link", Argument: "/home/%u"}
+	if err := e.Expand(unit.Specifiers{'u': "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if e.Path != "/run/alice/link" || e.Argument != "/home/alice" {
+		t.Errorf("unexpected expansion %+v", e)
+	}
+}
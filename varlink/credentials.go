@@ -0,0 +1,133 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package varlink
+
+import (
+	"context"
+	"time"
+)
+
+// CredentialsSocket is the socket of the io.systemd.Credentials interface of
+// systemd-creds.
+const CredentialsSocket = "/run/systemd/io.systemd.Credentials"
+
+// Errors of the io.systemd.Credentials interface, see IsError.
+const (
+	ErrCredentialsNoSuchUser   = "io.systemd.Credentials.NoSuchUser"
+	ErrCredentialsBadFormat    = "io.systemd.Credentials.BadFormat"
+	ErrCredentialsNameMismatch = "io.systemd.Credentials.NameMismatch"
+	ErrCredentialsTimeMismatch = "io.systemd.Credentials.TimeMismatch"
+	ErrCredentialsBadScope     = "io.systemd.Credentials.BadScope"
+)
+
+// Credentials is a client of the io.systemd.Credentials interface, which
+// encrypts and decrypts credentials like systemd-creds encrypt and decrypt.
+type Credentials struct {
+	conn *Conn
+}
+
+// DialCredentials connects to the credentials service.
+func DialCredentials(ctx context.Context) (*Credentials, error) {
+	conn, err := Dial(ctx, CredentialsSocket)
+	if err != nil {
+		return nil, err
+	}
+	return &Credentials{conn: conn}, nil
+}
+
+// Close closes the connection.
+func (c *Credentials) Close() error {
+	return c.conn.Close()
+}
+
+// CredentialOptions configures EncryptCredential and DecryptCredential.
+type CredentialOptions struct {
+	// Timestamp is the creation time recorded when encrypting, and the
+	// time to check the validity against when decrypting. The current time
+	// if zero.
+	Timestamp time.Time
+	// NotAfter, if set, is the time after which the credential can't be
+	// decrypted anymore. Only used when encrypting.
+	NotAfter time.Time
+	// Scope is "system" or "user", in which case the credential is bound
+	// to UID. Empty for the default of the service.
+	Scope string
+	UID   *uint32
+	// AllowInteractiveAuthentication allows polkit to ask for authentication
+	// if the caller isn't privileged.
+	AllowInteractiveAuthentication bool
+}
+
+type credentialParams struct {
+	Name                           string  `json:"name,omitempty"`
+	Data                           *[]byte `json:"data,omitempty"`
+	Blob                           []byte  `json:"blob,omitempty"`
+	Timestamp                      uint64  `json:"timestamp,omitempty"`
+	NotAfter                       uint64  `json:"notAfter,omitempty"`
+	Scope                          string  `json:"scope,omitempty"`
+	UID                            *uint32 `json:"uid,omitempty"`
+	AllowInteractiveAuthentication bool    `json:"allowInteractiveAuthentication,omitempty"`
+}
+
+func newCredentialParams(name string, opts CredentialOptions) credentialParams {
+	p := credentialParams{
+		Name:                           name,
+		Scope:                          opts.Scope,
+		UID:                            opts.UID,
+		AllowInteractiveAuthentication: opts.AllowInteractiveAuthentication,
+	}
+	if !opts.Timestamp.IsZero() {
+		p.Timestamp = uint64(opts.Timestamp.UnixMicro())
+	}
+	if !opts.NotAfter.IsZero() {
+		p.NotAfter = uint64(opts.NotAfter.UnixMicro())
+	}
+	return p
+}
+
+// EncryptCredential encrypts data for the credential name, which may be empty
+// to allow any name, and returns the encrypted credential in the binary format
+// of systemd-creds.
+func (c *Credentials) EncryptCredential(ctx context.Context, name string, data []byte, opts CredentialOptions) ([]byte, error) {
+	params := newCredentialParams(name, opts)
+	// sent even if empty, as either data or text is required
+	if data == nil {
+		data = []byte{}
+	}
+	params.Data = &data
+
+	var reply struct {
+		Blob []byte `json:"blob"`
+	}
+	if err := c.conn.Call(ctx, "io.systemd.Credentials.Encrypt", params, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Blob, nil
+}
+
+// DecryptCredential decrypts an encrypted credential in the binary format of
+// systemd-creds for the credential name.
+func (c *Credentials) DecryptCredential(ctx context.Context, name string, blob []byte, opts CredentialOptions) ([]byte, error) {
+	params := newCredentialParams(name, opts)
+	params.Blob = blob
+
+	var reply struct {
+		Data []byte `json:"data"`
+	}
+	if err := c.conn.Call(ctx, "io.systemd.Credentials.Decrypt", params, &reply); err != nil {
+		return nil, err
+	}
+	return reply.Data, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package varlink
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// HostnameSocket is the socket of the io.systemd.Hostname interface of
+// systemd-hostnamed.
+const HostnameSocket = "/run/systemd/io.systemd.Hostname"
+
+// Hostname is a client of the io.systemd.Hostname interface of
+// systemd-hostnamed.
+type Hostname struct {
+	conn *Conn
+}
+
+// DialHostname connects to systemd-hostnamed.
+func DialHostname(ctx context.Context) (*Hostname, error) {
+	conn, err := Dial(ctx, HostnameSocket)
+	if err != nil {
+		return nil, err
+	}
+	return &Hostname{conn: conn}, nil
+}
+
+// Close closes the connection.
+func (h *Hostname) Close() error {
+	return h.conn.Close()
+}
+
+// HostnameDescription describes the host, as returned by Describe. The fields
+// correspond to the properties of org.freedesktop.hostname1.
+type HostnameDescription struct {
+	Hostname                  string
+	StaticHostname            string
+	PrettyHostname            string
+	DefaultHostname           string
+	HostnameSource            string
+	IconName                  string
+	Chassis                   string
+	Deployment                string
+	Location                  string
+	KernelName                string
+	KernelRelease             string
+	KernelVersion             string
+	OperatingSystemPrettyName string
+	OperatingSystemCPEName    string
+	OperatingSystemHomeURL    string
+	OperatingSystemSupportEnd time.Time // Zero if unknown
+	HardwareVendor            string
+	HardwareModel             string
+	HardwareSerial            string // Only set for privileged callers
+	FirmwareVersion           string
+	FirmwareVendor            string
+	FirmwareDate              time.Time // Zero if unknown
+	MachineID                 string
+	BootID                    string
+	ProductUUID               string // Only set for privileged callers
+
+	// Raw is the complete reply, including fields not covered above.
+	Raw json.RawMessage
+}
+
+// Describe returns a description of the host, like hostnamectl.
+func (h *Hostname) Describe(ctx context.Context) (*HostnameDescription, error) {
+	var raw json.RawMessage
+	if err := h.conn.Call(ctx, "io.systemd.Hostname.Describe", nil, &raw); err != nil {
+		return nil, err
+	}
+	return parseHostnameDescription(raw)
+}
+
+func parseHostnameDescription(raw json.RawMessage) (*HostnameDescription, error) {
+	var d struct {
+		HostnameDescription
+		OperatingSystemSupportEnd uint64
+		FirmwareDate              uint64
+	}
+	if err := json.Unmarshal(raw, &d); err != nil {
+		return nil, err
+	}
+
+	desc := d.HostnameDescription
+	desc.OperatingSystemSupportEnd = usecTime(d.OperatingSystemSupportEnd)
+	desc.FirmwareDate = usecTime(d.FirmwareDate)
+	desc.Raw = raw
+	return &desc, nil
+}
+
+// usecTime converts microseconds since the epoch to a time, which is zero for
+// 0 and USEC_INFINITY.
+func usecTime(usec uint64) time.Time {
+	if usec == 0 || usec == ^uint64(0) {
+		return time.Time{}
+	}
+	return time.UnixMicro(int64(usec))
+}
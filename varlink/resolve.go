@@ -0,0 +1,142 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package varlink
+
+import (
+	"context"
+	"net"
+)
+
+// ResolveSocket is the socket of the io.systemd.Resolve interface of
+// systemd-resolved.
+const ResolveSocket = "/run/systemd/resolve/io.systemd.Resolve"
+
+// Errors of the io.systemd.Resolve interface, see IsError.
+const (
+	ErrResolveNoNameServers          = "io.systemd.Resolve.NoNameServers"
+	ErrResolveNoSuchResourceRecord   = "io.systemd.Resolve.NoSuchResourceRecord"
+	ErrResolveQueryTimedOut          = "io.systemd.Resolve.QueryTimedOut"
+	ErrResolveDNSSECValidationFailed = "io.systemd.Resolve.DNSSECValidationFailed"
+	ErrResolveNetworkDown            = "io.systemd.Resolve.NetworkDown"
+	ErrResolveDNSError               = "io.systemd.Resolve.DNSError"
+)
+
+// Resolve is a client of the io.systemd.Resolve interface of
+// systemd-resolved. See
+// https://www.freedesktop.org/software/systemd/man/latest/org.freedesktop.resolve1.html
+// for the semantics of the methods, which mirror those on D-Bus.
+type Resolve struct {
+	conn *Conn
+}
+
+// DialResolve connects to systemd-resolved.
+func DialResolve(ctx context.Context) (*Resolve, error) {
+	conn, err := Dial(ctx, ResolveSocket)
+	if err != nil {
+		return nil, err
+	}
+	return &Resolve{conn: conn}, nil
+}
+
+// Close closes the connection.
+func (r *Resolve) Close() error {
+	return r.conn.Close()
+}
+
+// ResolvedAddress is an address a hostname resolved to.
+type ResolvedAddress struct {
+	Ifindex int // The interface the address was resolved on, 0 if none
+	Family  int // AF_INET or AF_INET6
+	Address net.IP
+}
+
+// ResolvedName is a name an address resolved to.
+type ResolvedName struct {
+	Ifindex int // The interface the name was resolved on, 0 if none
+	Name    string
+}
+
+type wireAddress struct {
+	Ifindex int   `json:"ifindex,omitempty"`
+	Family  int   `json:"family"`
+	Address []int `json:"address"`
+}
+
+// ResolveHostname resolves name to its addresses, on the interface ifindex
+// only if not 0, and of the address family only if not 0 (AF_UNSPEC). flags
+// are SD_RESOLVED_* flags as in resolve1.Flags. It returns the addresses and
+// the canonical name, and the flags of the reply.
+func (r *Resolve) ResolveHostname(ctx context.Context, ifindex int, name string, family int, flags uint64) ([]ResolvedAddress, string, uint64, error) {
+	params := struct {
+		Ifindex int    `json:"ifindex,omitempty"`
+		Name    string `json:"name"`
+		Family  int    `json:"family,omitempty"`
+		Flags   uint64 `json:"flags,omitempty"`
+	}{ifindex, name, family, flags}
+
+	var reply struct {
+		Addresses []wireAddress `json:"addresses"`
+		Name      string        `json:"name"`
+		Flags     uint64        `json:"flags"`
+	}
+	if err := r.conn.Call(ctx, "io.systemd.Resolve.ResolveHostname", params, &reply); err != nil {
+		return nil, "", 0, err
+	}
+
+	addresses := make([]ResolvedAddress, 0, len(reply.Addresses))
+	for _, a := range reply.Addresses {
+		ip := make(net.IP, len(a.Address))
+		for i, b := range a.Address {
+			ip[i] = byte(b)
+		}
+		addresses = append(addresses, ResolvedAddress{Ifindex: a.Ifindex, Family: a.Family, Address: ip})
+	}
+	return addresses, reply.Name, reply.Flags, nil
+}
+
+// ResolveAddress resolves the address ip to its names, on the interface
+// ifindex only if not 0. flags are as for ResolveHostname.
+func (r *Resolve) ResolveAddress(ctx context.Context, ifindex int, ip net.IP, flags uint64) ([]ResolvedName, uint64, error) {
+	family, b := 2, []byte(ip.To4()) // AF_INET
+	if b == nil {
+		family, b = 10, []byte(ip.To16()) // AF_INET6
+	}
+	address := make([]int, len(b))
+	for i := range b {
+		address[i] = int(b[i])
+	}
+
+	params := struct {
+		wireAddress
+		Flags uint64 `json:"flags,omitempty"`
+	}{wireAddress{ifindex, family, address}, flags}
+
+	var reply struct {
+		Names []struct {
+			Ifindex int    `json:"ifindex"`
+			Name    string `json:"name"`
+		} `json:"names"`
+		Flags uint64 `json:"flags"`
+	}
+	if err := r.conn.Call(ctx, "io.systemd.Resolve.ResolveAddress", params, &reply); err != nil {
+		return nil, 0, err
+	}
+
+	names := make([]ResolvedName, 0, len(reply.Names))
+	for _, n := range reply.Names {
+		names = append(names, ResolvedName{Ifindex: n.Ifindex, Name: n.Name})
+	}
+	return names, reply.Flags, nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package varlink is a minimal client of the varlink protocol, and provides
+// typed bindings for the varlink interfaces of systemd services. Unlike D-Bus,
+// varlink doesn't need a bus, so these are available in early boot and in
+// containers without dbus. See https://varlink.org and
+// https://systemd.io/VARLINK/ for details.
+package varlink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// Error is an error reply of a varlink service.
+type Error struct {
+	Name       string          // The qualified error name, e.g. org.varlink.service.MethodNotFound
+	Parameters json.RawMessage // The error parameters, if any
+}
+
+func (e *Error) Error() string {
+	if len(e.Parameters) == 0 || string(e.Parameters) == "{}" {
+		return e.Name
+	}
+	return e.Name + ": " + string(e.Parameters)
+}
+
+// IsError reports whether err is a varlink error reply with the given name.
+func IsError(err error, name string) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Name == name
+}
+
+// Conn is a connection to a varlink service. Calls on a connection are
+// serialized.
+type Conn struct {
+	mu   sync.Mutex
+	conn net.Conn
+	r    *bufio.Reader
+	err  error // set when the connection is broken
+}
+
+type request struct {
+	Method     string      `json:"method"`
+	Parameters interface{} `json:"parameters"`
+	More       bool        `json:"more,omitempty"`
+	Oneway     bool        `json:"oneway,omitempty"`
+}
+
+type reply struct {
+	Parameters json.RawMessage `json:"parameters"`
+	Continues  bool            `json:"continues"`
+	Error      string          `json:"error"`
+}
+
+// Dial connects to the varlink service listening on the unix socket at path.
+func Dial(ctx context.Context, path string) (*Conn, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "unix", path)
+	if err != nil {
+		return nil, err
+	}
+	return NewConn(conn), nil
+}
+
+// NewConn returns a varlink connection using conn, e.g. an inherited socket.
+func NewConn(conn net.Conn) *Conn {
+	return &Conn{conn: conn, r: bufio.NewReader(conn)}
+}
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+// Call calls method with params, which must marshal to a JSON object or be
+// nil, and unmarshals the parameters of the reply into reply, unless it is
+// nil. Error replies are returned as *Error.
+func (c *Conn) Call(ctx context.Context, method string, params, reply interface{}) error {
+	return c.call(ctx, request{Method: method, Parameters: params}, func(p json.RawMessage) error {
+		if reply == nil {
+			return nil
+		}
+		return json.Unmarshal(p, reply)
+	})
+}
+
+// CallMore calls method with params, requesting multiple replies, and calls
+// fn with the parameters of each reply until the service indicates the last
+// reply, or fn returns an error.
+func (c *Conn) CallMore(ctx context.Context, method string, params interface{}, fn func(json.RawMessage) error) error {
+	return c.call(ctx, request{Method: method, Parameters: params, More: true}, fn)
+}
+
+// Oneway calls method with params without waiting for a reply.
+func (c *Conn) Oneway(ctx context.Context, method string, params interface{}) error {
+	return c.call(ctx, request{Method: method, Parameters: params, Oneway: true}, nil)
+}
+
+func (c *Conn) call(ctx context.Context, req request, fn func(json.RawMessage) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+	if req.Parameters == nil {
+		req.Parameters = struct{}{}
+	}
+
+	deadline, _ := ctx.Deadline()
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() {
+		// unblocks reads and writes
+		c.conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	err := c.roundTrip(req, fn)
+	if err != nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	return err
+}
+
+func (c *Conn) roundTrip(req request, fn func(json.RawMessage) error) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	if _, err := c.conn.Write(append(b, 0)); err != nil {
+		return c.broken(err)
+	}
+	if req.Oneway {
+		return nil
+	}
+
+	for {
+		b, err := c.r.ReadBytes(0)
+		if err != nil {
+			return c.broken(err)
+		}
+
+		var r reply
+		if err := json.Unmarshal(b[:len(b)-1], &r); err != nil {
+			return c.broken(fmt.Errorf("invalid varlink reply: %w", err))
+		}
+		if r.Error != "" {
+			if r.Continues {
+				return c.broken(fmt.Errorf("invalid varlink reply: error %s continues", r.Error))
+			}
+			return &Error{Name: r.Error, Parameters: r.Parameters}
+		}
+		if len(r.Parameters) == 0 {
+			r.Parameters = json.RawMessage("{}")
+		}
+
+		if err := fn(r.Parameters); err != nil {
+			if r.Continues {
+				// the remaining replies can't be skipped reliably
+				c.broken(err)
+			}
+			return err
+		}
+		if !r.Continues {
+			return nil
+		}
+		if !req.More {
+			return c.broken(errors.New("invalid varlink reply: continues without more"))
+		}
+	}
+}
+
+// broken marks the connection as unusable after err, as the state of the
+// protocol is unknown, and returns err.
+func (c *Conn) broken(err error) error {
+	c.err = fmt.Errorf("varlink connection broken: %w", err)
+	return err
+}
+
+// ServiceInfo describes a varlink service, see GetInfo.
+type ServiceInfo struct {
+	Vendor     string   `json:"vendor"`
+	Product    string   `json:"product"`
+	Version    string   `json:"version"`
+	URL        string   `json:"url"`
+	Interfaces []string `json:"interfaces"`
+}
+
+// GetInfo returns information about the service, including the interfaces it
+// implements, with the org.varlink.service.GetInfo method.
+func (c *Conn) GetInfo(ctx context.Context) (*ServiceInfo, error) {
+	var info ServiceInfo
+	if err := c.Call(ctx, "org.varlink.service.GetInfo", nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
@@ -0,0 +1,266 @@
+// Copyright 2026 CoreOS, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package varlink
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testRequest struct {
+	Method     string          `json:"method"`
+	Parameters json.RawMessage `json:"parameters"`
+	More       bool            `json:"more"`
+	Oneway     bool            `json:"oneway"`
+}
+
+// serve runs a varlink service on a socket in a temporary directory, which
+// answers each request with the replies returned by handle, and returns a
+// connection to it.
+func serve(t *testing.T, handle func(req testRequest) []string) *Conn {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "socket")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		for {
+			b, err := r.ReadBytes(0)
+			if err != nil {
+				return
+			}
+			var req testRequest
+			if err := json.Unmarshal(b[:len(b)-1], &req); err != nil {
+				t.Errorf("invalid request %q: %v", b, err)
+				return
+			}
+			for _, reply := range handle(req) {
+				if _, err := conn.Write(append([]byte(reply), 0)); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	conn, err := Dial(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestCall(t *testing.T) {
+	conn := serve(t, func(req testRequest) []string {
+		switch req.Method {
+		case "org.varlink.service.GetInfo":
+			if string(req.Parameters) != "{}" {
+				t.Errorf("unexpected parameters %s", req.Parameters)
+			}
+			return []string{`{"parameters":{"vendor":"The systemd Project","product":"systemd (systemd-resolved)","version":"256","url":"https://systemd.io/","interfaces":["io.systemd.Resolve","org.varlink.service"]}}`}
+		case "com.example.Echo":
+			return []string{`{"parameters":` + string(req.Parameters) + `}`}
+		}
+		return []string{`{"error":"org.varlink.service.MethodNotFound","parameters":{"method":"` + req.Method + `"}}`}
+	})
+
+	info, err := conn.GetInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Version != "256" || len(info.Interfaces) != 2 || info.Interfaces[0] != "io.systemd.Resolve" {
+		t.Errorf("unexpected info %+v", info)
+	}
+
+	var reply struct{ Value int }
+	if err := conn.Call(context.Background(), "com.example.Echo", map[string]int{"Value": 42}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Value != 42 {
+		t.Errorf("got %d", reply.Value)
+	}
+
+	err = conn.Call(context.Background(), "com.example.Missing", nil, nil)
+	var e *Error
+	if !errors.As(err, &e) || e.Name != "org.varlink.service.MethodNotFound" || string(e.Parameters) != `{"method":"com.example.Missing"}` {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !IsError(err, "org.varlink.service.MethodNotFound") || IsError(err, "org.varlink.service.InvalidParameter") {
+		t.Error("IsError doesn't match error name")
+	}
+
+	// the connection is still usable after an error reply
+	if err := conn.Call(context.Background(), "com.example.Echo", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCallMore(t *testing.T) {
+	conn := serve(t, func(req testRequest) []string {
+		if !req.More {
+			t.Errorf("more not set in request")
+		}
+		return []string{
+			`{"parameters":{"n":1},"continues":true}`,
+			`{"parameters":{"n":2},"continues":true}`,
+			`{"parameters":{"n":3}}`,
+		}
+	})
+
+	var ns []int
+	err := conn.CallMore(context.Background(), "com.example.Count", nil, func(p json.RawMessage) error {
+		var r struct{ N int }
+		if err := json.Unmarshal(p, &r); err != nil {
+			return err
+		}
+		ns = append(ns, r.N)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ns) != 3 || ns[0] != 1 || ns[2] != 3 {
+		t.Errorf("got replies %v", ns)
+	}
+
+	// stopping early breaks the connection, as the remaining replies are
+	// still pending
+	stop := errors.New("stop")
+	err = conn.CallMore(context.Background(), "com.example.Count", nil, func(json.RawMessage) error { return stop })
+	if err != stop {
+		t.Errorf("expected error of fn, got %v", err)
+	}
+	if err := conn.Call(context.Background(), "com.example.Count", nil, nil); err == nil {
+		t.Error("expected error on broken connection")
+	}
+}
+
+func TestCallContext(t *testing.T) {
+	conn := serve(t, func(req testRequest) []string {
+		return nil // never reply
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := conn.Call(ctx, "com.example.Hang", nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	conn := serve(t, func(req testRequest) []string {
+		switch req.Method {
+		case "io.systemd.Resolve.ResolveHostname":
+			if string(req.Parameters) != `{"name":"example.com","family":2}` {
+				t.Errorf("unexpected parameters %s", req.Parameters)
+			}
+			return []string{`{"parameters":{"addresses":[{"ifindex":2,"family":2,"address":[93,184,215,14]}],"name":"example.com","flags":1048577}}`}
+		case "io.systemd.Resolve.ResolveAddress":
+			if string(req.Parameters) != `{"family":10,"address":[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,1]}` {
+				t.Errorf("unexpected parameters %s", req.Parameters)
+			}
+			return []string{`{"parameters":{"names":[{"ifindex":1,"name":"localhost"}],"flags":1048577}}`}
+		}
+		return []string{`{"error":"org.varlink.service.MethodNotFound"}`}
+	})
+	r := &Resolve{conn: conn}
+
+	addresses, name, flags, err := r.ResolveHostname(context.Background(), 0, "example.com", 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addresses) != 1 || addresses[0].Ifindex != 2 || addresses[0].Family != 2 || !addresses[0].Address.Equal(net.IPv4(93, 184, 215, 14)) {
+		t.Errorf("unexpected addresses %+v", addresses)
+	}
+	if name != "example.com" || flags != 1048577 {
+		t.Errorf("unexpected name %q or flags %d", name, flags)
+	}
+
+	names, _, err := r.ResolveAddress(context.Background(), 0, net.IPv6loopback, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0].Name != "localhost" || names[0].Ifindex != 1 {
+		t.Errorf("unexpected names %+v", names)
+	}
+}
+
+func TestParseHostnameDescription(t *testing.T) {
+	raw := json.RawMessage(`{"Hostname":"host","StaticHostname":"host","PrettyHostname":null,"DefaultHostname":"localhost","HostnameSource":"static","IconName":"computer-vm","Chassis":"vm","KernelName":"Linux","OperatingSystemPrettyName":"Debian GNU/Linux 12 (bookworm)","OperatingSystemSupportEnd":1782864000000000,"FirmwareDate":1396569600000000,"MachineID":"fed6b2924c424cf1b9a322f606b4de6d","VSockCID":3}`)
+
+	d, err := parseHostnameDescription(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Hostname != "host" || d.PrettyHostname != "" || d.Chassis != "vm" || d.MachineID != "fed6b2924c424cf1b9a322f606b4de6d" {
+		t.Errorf("unexpected description %+v", d)
+	}
+	if !d.OperatingSystemSupportEnd.Equal(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)) || !d.FirmwareDate.Equal(time.Date(2014, 4, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected times %v and %v", d.OperatingSystemSupportEnd, d.FirmwareDate)
+	}
+	if !bytes.Equal(d.Raw, raw) {
+		t.Error("raw reply not kept")
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	conn := serve(t, func(req testRequest) []string {
+		switch req.Method {
+		case "io.systemd.Credentials.Encrypt":
+			if string(req.Parameters) != `{"name":"token","data":"c2VjcmV0","notAfter":1700000000000000}` {
+				t.Errorf("unexpected parameters %s", req.Parameters)
+			}
+			return []string{`{"parameters":{"blob":"YmxvYg=="}}`}
+		case "io.systemd.Credentials.Decrypt":
+			if string(req.Parameters) != `{"name":"other","blob":"YmxvYg==","scope":"user","uid":1000}` {
+				t.Errorf("unexpected parameters %s", req.Parameters)
+			}
+			return []string{`{"error":"io.systemd.Credentials.NameMismatch"}`}
+		}
+		return []string{`{"error":"org.varlink.service.MethodNotFound"}`}
+	})
+	c := &Credentials{conn: conn}
+
+	blob, err := c.EncryptCredential(context.Background(), "token", []byte("secret"), CredentialOptions{NotAfter: time.Unix(1700000000, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(blob) != "blob" {
+		t.Errorf("got blob %q", blob)
+	}
+
+	uid := uint32(1000)
+	_, err = c.DecryptCredential(context.Background(), "other", blob, CredentialOptions{Scope: "user", UID: &uid})
+	if !IsError(err, ErrCredentialsNameMismatch) {
+		t.Errorf("expected %s, got %v", ErrCredentialsNameMismatch, err)
+	}
+}